@@ -1,17 +1,38 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
+	"path"
+	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/audit"
+	"github.com/somaz94/github-action-analyzer/internal/config"
 	"github.com/somaz94/github-action-analyzer/internal/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/server"
+	"github.com/somaz94/github-action-analyzer/internal/storage"
+	"github.com/somaz94/github-action-analyzer/internal/store"
+	"github.com/somaz94/github-action-analyzer/internal/telemetry"
+	"github.com/somaz94/github-action-analyzer/internal/tui"
 )
 
+// tuiLogFile collects the debug and log messages of the terminal UI in the temporary directory
+const tuiLogFile = "github-action-analyzer-tui.log"
+
 func main() {
 	// Create cancellable context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -21,44 +42,541 @@ func main() {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
 	go func() {
-		<-sigCh
+		sig := <-sigCh
+		log.Printf("Received %v, cancelling the analysis", sig)
 		cancel()
+		// A second signal terminates right away
+		signal.Stop(sigCh)
 	}()
 
-	// Get inputs from environment variables
-	token := os.Getenv("INPUT_GITHUB_TOKEN")
-	workflowFile := os.Getenv("INPUT_WORKFLOW_FILE")
-	repository := os.Getenv("INPUT_REPOSITORY")
+	// "analyzer serve" runs the HTTP API instead of a single analysis
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		serve(ctx, os.Args[2:])
+		return
+	}
 
-	if token == "" || workflowFile == "" || repository == "" {
-		log.Fatal("Required inputs are missing")
+	// "analyzer audit" analyzes the same workflows across many repositories
+	if len(os.Args) > 1 && os.Args[1] == "audit" {
+		runAudit(ctx, os.Args[2:])
+		return
 	}
 
-	// Parse repository owner and name
-	parts := strings.Split(repository, "/")
-	if len(parts) != 2 {
-		log.Fatal("Invalid repository format. Expected: owner/repo")
+	// "analyzer diff" compares two saved JSON reports
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
 	}
-	owner, repo := parts[0], parts[1]
 
-	// Initialize GitHub client
-	client := github.NewClient(token)
+	// "analyzer generate" writes a workflow rewritten with the suggested fixes
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		runGenerate(ctx, os.Args[2:])
+		return
+	}
+
+	// "analyzer tui" browses the analysis interactively instead of printing the report
+	args, interactive := os.Args[1:], false
+	if len(args) > 0 && args[0] == "tui" {
+		args, interactive = args[1:], true
+	}
+
+	// Load and validate inputs from INPUT_* environment variables and flags
+	cfg, err := config.Load(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid inputs:\n%v", err)
+	}
+	owner, repo, workflowFile := cfg.Owner, cfg.Repo, cfg.WorkflowFile
+	if interactive && len(cfg.WorkflowFiles) > 1 {
+		log.Fatalf("The terminal UI analyzes one workflow at a time, got %q", workflowFile)
+	}
+	if cfg.Token == "" {
+		log.Printf("Warning: github_token is not set, so the analysis is anonymous: it only reaches public repositories, skips job logs and gets 60 API requests an hour; stages past the limit are skipped and the report is marked partial")
+	}
+
+	// Initialize GitHub client, optionally backed by a response cache kept between runs
+	hc := httpClient(cfg.CABundle)
+	ghClient := github.NewClient(cfg.Token, github.WithHTTPClient(hc), github.WithBaseURL(cfg.APIURL),
+		github.WithLogCache(cfg.LogCacheDir))
+	var client analyzer.GithubClient = ghClient
+	var cache *github.CachedClient
+	if cfg.CacheDir != "" {
+		if cache, err = github.NewCachedClient(ghClient, cfg.CacheDir); err != nil {
+			log.Printf("Warning: API cache disabled: %v", err)
+		} else {
+			client = cache
+		}
+	}
+
+	// Reports are kept next to the API cache when they are reused, and so are the
+	// previous analysis a digest is compared against and the previous findings
+	resultCache, digest, adoption := "", "", ""
+	if cfg.CacheResults {
+		resultCache = cfg.CacheDir
+	}
+	if cfg.Digest {
+		digest = cfg.CacheDir
+	}
+	if cfg.TrackAdoption {
+		adoption = cfg.CacheDir
+	}
 
 	// Create analyzer
-	debug := os.Getenv("DEBUG") == "true"
-	analyzer := analyzer.NewAnalyzer(client, debug)
+	analyzerOpts := []analyzer.Option{
+		analyzer.WithMode(cfg.Mode),
+		analyzer.WithSampleSize(cfg.AnalysisDepth),
+		analyzer.WithAnalyzeDepth(cfg.AnalyzeDepth),
+		analyzer.WithSampling(cfg.Sample),
+		analyzer.WithTimeout(cfg.Timeout),
+		analyzer.WithStageBudgets(cfg.StageTimeouts),
+		analyzer.WithLang(cfg.Lang),
+		analyzer.WithSustainability(cfg.Sustainability, cfg.CarbonIntensity),
+		analyzer.WithDeployWorkflows(cfg.DeployWorkflows),
+		analyzer.WithStyleChecks(cfg.StyleChecks),
+		analyzer.WithMinConfidence(cfg.MinConfidence),
+		analyzer.WithMaskedFailureRate(cfg.MaskedRate),
+		analyzer.WithVersionChannel(cfg.VersionChannel),
+		analyzer.WithPolicy(cfg.Policy),
+		analyzer.WithRegoPolicies(cfg.RegoPolicies),
+		analyzer.WithPricing(cfg.Pricing),
+		analyzer.WithResultCache(resultCache),
+		analyzer.WithDigest(digest),
+		analyzer.WithAdoptionTracking(adoption),
+	}
+	if len(cfg.Exporters) > 0 {
+		analyzerOpts = append(analyzerOpts, analyzer.WithExporters(exporters(cfg, hc)...))
+	}
+	// endoflife.date is reached through the same proxy and CAs as the API
+	if cfg.VersionSource == analyzer.SourceEndOfLife {
+		analyzerOpts = append(analyzerOpts, analyzer.WithVersionChecker(analyzer.NewEndOfLifeChecker(hc, cfg.VersionChannel)))
+	} else {
+		analyzerOpts = append(analyzerOpts, analyzer.WithVersionChecker(analyzer.NewGitHubVersionChecker(client, hc, cfg.VersionChannel)))
+	}
+	// The terminal UI owns the screen, so progress is dropped and debug and log
+	// messages go to a file
+	var tuiLog *os.File
+	if interactive {
+		tuiLog, err = os.Create(filepath.Join(os.TempDir(), tuiLogFile))
+		if err != nil {
+			log.Fatalf("Failed to create the terminal UI log: %v", err)
+		}
+		defer tuiLog.Close()
+		log.SetOutput(tuiLog)
+		analyzerOpts = append(analyzerOpts, analyzer.WithProgress(io.Discard), analyzer.WithDebugOutput(tuiLog))
+	}
+	analyzer := analyzer.NewAnalyzer(client, cfg.Debug, analyzerOpts...)
+
+	if interactive {
+		err := tui.Run(ctx, client, analyzer, owner, repo, workflowFile)
+		saveCache(cache)
+		log.SetOutput(os.Stderr)
+		if err != nil {
+			// log.Fatalf would skip the deferred close of the log file
+			tuiLog.Close()
+			fmt.Fprintf(os.Stderr, "%v (log: %s)\n", err, tuiLog.Name())
+			os.Exit(1)
+		}
+		if cfg.Debug {
+			log.Printf("Debug log: %s", tuiLog.Name())
+		}
+		return
+	}
+
+	plain := cfg.PlainOutput
 
-	// Run analysis with context
-	report, err := analyzer.Analyze(ctx, owner, repo, workflowFile)
+	// Dry run only prints the planned API calls and quota estimate
+	if cfg.DryRun {
+		plan, err := analyzer.Plan(ctx, owner, repo, workflowFile)
+		if err != nil {
+			log.Fatalf("Dry run failed: %v", err)
+		}
+		plan.Plain = plain
+		if err := plan.Output(); err != nil {
+			log.Fatalf("Failed to output plan: %v", err)
+		}
+		return
+	}
+
+	// Diff mode reviews only the workflow files changed by the triggering pull request
+	if cfg.DiffMode {
+		number, err := pullRequestNumber()
+		if err != nil {
+			log.Fatalf("Diff mode requires a pull_request event: %v", err)
+		}
+		report, err := analyzer.AnalyzePullRequest(ctx, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Pull request analysis failed: %v", err)
+		}
+		if err := analyzer.PostReview(ctx, owner, repo, report); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+		saveCache(cache)
+		report.Plain, report.Audience, report.OutputEncoding = plain, cfg.Audience, cfg.OutputEncoding
+		if err := report.Output(cfg.Outputs...); err != nil {
+			log.Fatalf("Failed to output report: %v", err)
+		}
+		uploadReport(ctx, hc, cfg.Upload, report)
+		enforcePolicy(report, cfg.FailOnPolicy)
+		return
+	}
+
+	// Run analysis with context; several workflows are combined into one report
+	report, err := analyzer.AnalyzeWorkflows(ctx, owner, repo, cfg.WorkflowFiles)
 	if err != nil {
 		if ctx.Err() != nil {
+			// Keep the responses fetched so far for the next run
+			saveCache(cache)
 			log.Fatal("Analysis cancelled")
 		}
 		log.Fatalf("Analysis failed: %v", err)
 	}
+	saveCache(cache)
 
 	// Output report
-	if err := report.Output(); err != nil {
+	report.Plain, report.Audience, report.OutputEncoding = plain, cfg.Audience, cfg.OutputEncoding
+	if err := report.Output(cfg.Outputs...); err != nil {
 		log.Fatalf("Failed to output report: %v", err)
 	}
+	// Output calculates the report's metrics, so the upload comes after it
+	uploadReport(ctx, hc, cfg.Upload, report)
+	enforcePolicy(report, cfg.FailOnPolicy)
+}
+
+// serve runs the analyzer as an HTTP service until ctx is cancelled
+func serve(ctx context.Context, args []string) {
+	cfg, err := config.LoadServer(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid inputs:\n%v", err)
+	}
+
+	hc := httpClient(cfg.CABundle)
+	var client analyzer.GithubClient = github.NewClient(cfg.Token, github.WithHTTPClient(hc), github.WithBaseURL(cfg.APIURL))
+	opts := []server.Option{server.WithAPIToken(cfg.APIToken), server.WithWebhookSecret(cfg.WebhookSecret)}
+	if cfg.Database != "" {
+		st, err := store.Open(cfg.Database)
+		if err != nil {
+			log.Fatalf("Failed to open database: %v", err)
+		}
+		defer st.Close()
+		client = store.NewClient(client, st)
+		opts = append(opts, server.WithStore(st))
+	}
+
+	a := analyzer.NewAnalyzer(client, cfg.Debug,
+		analyzer.WithMode(cfg.Mode),
+		analyzer.WithSampleSize(cfg.AnalysisDepth),
+		analyzer.WithTimeout(cfg.Timeout),
+		analyzer.WithLang(cfg.Lang),
+	)
+	if cfg.WebhookSecret == "" {
+		log.Printf("webhook_secret is not set, so POST /webhook is disabled")
+	}
+	srv := server.New(a, cfg.Workers, opts...)
+	if err := srv.ListenAndServe(ctx, cfg.Addr); err != nil {
+		log.Fatalf("Server failed: %v", err)
+	}
+}
+
+// runAudit analyzes workflows across repositories, sharing one API rate limit, and
+// resumes from the checkpoint of an earlier, interrupted audit
+func runAudit(ctx context.Context, args []string) {
+	cfg, err := config.LoadAudit(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid inputs:\n%v", err)
+	}
+
+	// A small burst lets the workers start together without front-loading the hour's budget
+	limiter := github.NewRateLimiter(cfg.RateLimit, cfg.Workers*2)
+	hc := httpClient(cfg.CABundle)
+	client := github.NewClient(cfg.Token, github.WithHTTPClient(hc), github.WithBaseURL(cfg.APIURL), github.WithRateLimiter(limiter))
+
+	repos := cfg.Repositories
+	if cfg.Organization != "" {
+		orgRepos, err := client.ListOrgRepositories(ctx, cfg.Organization)
+		if err != nil {
+			log.Fatalf("Failed to list repositories: %v", err)
+		}
+		repos = append(repos, orgRepos...)
+	}
+
+	checkpoint, err := audit.LoadCheckpoint(cfg.Checkpoint)
+	if err != nil {
+		log.Fatalf("Failed to load checkpoint: %v", err)
+	}
+
+	a := analyzer.NewAnalyzer(client, cfg.Debug,
+		analyzer.WithMode(cfg.Mode),
+		analyzer.WithSampleSize(cfg.AnalysisDepth),
+		analyzer.WithTimeout(cfg.Timeout),
+		analyzer.WithLang(cfg.Lang),
+	)
+	var opts []audit.Option
+	if cfg.Upload != nil {
+		opts = append(opts, audit.WithReportHook(func(ctx context.Context, report *models.PerformanceReport) {
+			uploadReport(ctx, hc, cfg.Upload, report)
+		}))
+	}
+	auditor := audit.New(a, cfg.Workers, cfg.WorkflowFiles, checkpoint, cfg.OutputDir, opts...)
+
+	log.Printf("Auditing %d repositories with %d workers at up to %d requests/hour", len(repos), cfg.Workers, cfg.RateLimit)
+	summary, err := auditor.Run(ctx, repos)
+	log.Printf("Audit finished: %d succeeded, %d failed, %d already done; progress is kept in %s",
+		summary.Succeeded, summary.Failed, summary.Skipped, cfg.Checkpoint)
+	if err != nil {
+		log.Fatalf("Audit interrupted: %v; run it again to resume", err)
+	}
+	if path, err := auditor.WriteTeams(); err != nil {
+		log.Printf("Warning: %v", err)
+	} else if path != "" {
+		log.Printf("Findings and cost by team written to %s", path)
+	}
+	if path, err := auditor.WriteDuplicates(); err != nil {
+		log.Printf("Warning: %v", err)
+	} else if path != "" {
+		log.Printf("Workflows duplicated across repositories written to %s", path)
+	}
+}
+
+// runDiff compares two saved JSON reports: the findings fixed and introduced
+// and the metrics that moved. It exits non-zero on the regressions fail_on and
+// max_slowdown ask to gate on.
+func runDiff(args []string) {
+	cfg, err := config.LoadDiff(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid inputs:\n%v", err)
+	}
+	before, saved, err := readReport(cfg.Before)
+	if err != nil {
+		log.Fatal(err)
+	}
+	after, _, err := readReport(cfg.After)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The comparison is rendered as the digest of the later report
+	after.Lang, after.Plain = cfg.Lang, cfg.PlainOutput
+	after.Digest = models.NewDigest(before, saved, after)
+	if cfg.Format == models.FormatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(after.Digest)
+	} else {
+		var renderer models.Renderer
+		if renderer, err = models.NewRenderer(cfg.Format); err == nil {
+			err = renderer.Render(os.Stdout, after)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to write comparison: %v", err)
+	}
+
+	var regressions []string
+	if cfg.FailOn != "" {
+		// New findings are keyed without measured values, so a finding that only
+		// got slower or more frequent doesn't fail the comparison
+		count := 0
+		for _, finding := range after.Digest.New {
+			if finding.AtLeast(cfg.FailOn) {
+				count++
+			}
+		}
+		if count > 0 {
+			regressions = append(regressions, fmt.Sprintf("%d new findings of %s severity or higher", count, cfg.FailOn))
+		}
+	}
+	if b, a := before.RunStats, after.RunStats; cfg.MaxSlowdown > 0 && b != nil && a != nil && b.Successful.Average > 0 {
+		slowdown := (float64(a.Successful.Average)/float64(b.Successful.Average) - 1) * 100
+		if slowdown > cfg.MaxSlowdown {
+			regressions = append(regressions, fmt.Sprintf("the average successful run got %.0f%% slower (%v → %v)",
+				slowdown, b.Successful.Average.Round(time.Second), a.Successful.Average.Round(time.Second)))
+		}
+	}
+	if len(regressions) > 0 {
+		log.Fatalf("Regressed: %s", strings.Join(regressions, "; "))
+	}
+}
+
+// runGenerate writes the workflow file rewritten with the suggested fixes next to
+// the original, and prints the changes for review
+func runGenerate(ctx context.Context, args []string) {
+	cfg, err := config.LoadGenerate(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatalf("Invalid inputs:\n%v", err)
+	}
+
+	hc := httpClient(cfg.CABundle)
+	client := github.NewClient(cfg.Token, github.WithHTTPClient(hc), github.WithBaseURL(cfg.APIURL))
+	a := analyzer.NewAnalyzer(client, cfg.Debug)
+
+	owner, repo, _ := strings.Cut(cfg.Repository, "/")
+	generated, err := a.Generate(ctx, owner, repo, cfg.WorkflowFile, cfg.Fixes)
+	if err != nil {
+		log.Fatalf("Generating the workflow failed: %v", err)
+	}
+	if generated.Changes == 0 {
+		log.Printf("%s needs none of the fixes, so nothing was written", generated.Path)
+		return
+	}
+	if err := os.WriteFile(cfg.Output, []byte(generated.Content), 0644); err != nil {
+		log.Fatalf("Failed to write %s: %v", cfg.Output, err)
+	}
+	fmt.Print(generated.Diff)
+	log.Printf("Wrote %s with %d changes to %s; review it before replacing the original", cfg.Output, generated.Changes, generated.Path)
+}
+
+// readReport reads a JSON report saved by the json format and when it was saved
+func readReport(path string) (*models.PerformanceReport, time.Time, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to read report: %v", err)
+	}
+	var report models.PerformanceReport
+	if err := json.Unmarshal(raw, &report); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to parse report %s: %v", path, err)
+	}
+	saved := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		saved = info.ModTime()
+	}
+	if report.CachedAt != nil {
+		saved = *report.CachedAt
+	}
+	return &report, saved, nil
+}
+
+// httpClient returns the HTTP client for GitHub and uploads, using the proxy from
+// the environment and trusting the extra CA certificates in caBundle
+func httpClient(caBundle string) *http.Client {
+	transport, err := github.NewTransport(caBundle)
+	if err != nil {
+		log.Fatalf("Failed to set up HTTP client: %v", err)
+	}
+	return &http.Client{Transport: transport}
+}
+
+// exporters returns the backends the exporters input selects, sending through hc
+func exporters(cfg *config.Config, hc *http.Client) []telemetry.Exporter {
+	var list []telemetry.Exporter
+	for _, name := range cfg.Exporters {
+		switch name {
+		case "otlp":
+			list = append(list, telemetry.NewOTLPExporter(cfg.OTLP, hc))
+		case "datadog":
+			list = append(list, telemetry.NewDatadogExporter(cfg.DatadogAPIKey, cfg.DatadogSite, hc))
+		case "honeycomb":
+			list = append(list, telemetry.NewHoneycombExporter(cfg.HoneycombAPIKey, cfg.HoneycombDataset, cfg.CacheDir, hc))
+		}
+	}
+	return list
+}
+
+// saveCache persists the API response cache; failures only cost API calls next time
+func saveCache(cache *github.CachedClient) {
+	if cache == nil {
+		return
+	}
+	hits, misses := cache.Stats()
+	log.Printf("API cache: %d hits, %d misses", hits, misses)
+	if err := cache.Save(); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+}
+
+// uploadReport stores the JSON, text and HTML reports under <owner>/<repo>/<workflow>/ in the
+// bucket so results from many repositories can be collected in one place. A report
+// over several workflows goes under "combined". Failures only cost the upload.
+func uploadReport(ctx context.Context, hc *http.Client, loc *storage.Location, report *models.PerformanceReport) {
+	if loc == nil {
+		return
+	}
+	workflow := strings.TrimSuffix(path.Base(report.WorkflowFile), path.Ext(report.WorkflowFile))
+	switch {
+	case report.PullRequest > 0:
+		workflow = fmt.Sprintf("pull-%d", report.PullRequest)
+	case strings.Contains(report.WorkflowFile, ", "):
+		workflow = "combined"
+	}
+	base := path.Join(report.Repository, workflow, time.Now().UTC().Format("20060102T150405Z"))
+
+	objects := []struct {
+		name, contentType, format string
+	}{
+		{base + ".json", "application/json", models.FormatJSON},
+		{base + ".txt", "text/plain; charset=utf-8", models.FormatConsole},
+		{base + ".html", "text/html; charset=utf-8", models.FormatHTML},
+	}
+	for _, object := range objects {
+		renderer, err := models.NewRenderer(object.format)
+		if err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		var buf bytes.Buffer
+		if err := renderer.Render(&buf, report); err != nil {
+			log.Printf("Warning: failed to render %s report: %v", object.format, err)
+			continue
+		}
+		if err := storage.Put(ctx, hc, loc, object.name, object.contentType, buf.Bytes()); err != nil {
+			log.Printf("Warning: %v", err)
+			continue
+		}
+		log.Printf("Uploaded report to %s", loc.String(object.name))
+	}
+}
+
+// enforcePolicy exits non-zero when fail is set and the report has policy
+// violations. Warnings of custom policies don't count.
+func enforcePolicy(report *models.PerformanceReport, fail bool) {
+	if !fail {
+		return
+	}
+	violations := 0
+	for _, finding := range report.Findings {
+		if finding.Category == analyzer.CategoryPolicy && finding.Severity == models.SeverityCritical {
+			violations++
+		}
+	}
+	if violations > 0 {
+		log.Fatalf("Found %d policy violations", violations)
+	}
+}
+
+// pullRequestNumber reads the pull request number from the triggering event payload
+func pullRequestNumber() (int, error) {
+	eventPath := os.Getenv("GITHUB_EVENT_PATH")
+	if eventPath == "" {
+		return 0, fmt.Errorf("GITHUB_EVENT_PATH is not set")
+	}
+
+	data, err := os.ReadFile(eventPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read event payload: %v", err)
+	}
+
+	var event struct {
+		PullRequest struct {
+			Number int `json:"number"`
+		} `json:"pull_request"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return 0, fmt.Errorf("failed to parse event payload: %v", err)
+	}
+	if event.PullRequest.Number == 0 {
+		return 0, fmt.Errorf("event payload has no pull request")
+	}
+	return event.PullRequest.Number, nil
 }
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// adoptionDir is the directory of adoption records inside the cache directory
+const adoptionDir = "adoption"
+
+// adoptionRecord holds the findings of the last analysis and the totals the next
+// analysis adds to
+type adoptionRecord struct {
+	TrackedSince time.Time        `json:"tracked_since"`
+	AnalyzedAt   time.Time        `json:"analyzed_at"`
+	Findings     []models.Finding `json:"findings"`
+	TotalAdopted int              `json:"total_adopted"`
+}
+
+// WithAdoptionTracking compares each analysis's findings with the previous ones
+// recorded in dir and reports the recommendations adopted since, then records
+// the new findings
+func WithAdoptionTracking(dir string) Option {
+	return func(a *Analyzer) {
+		a.adoption = dir
+	}
+}
+
+// trackAdoption sets the report's adoption against the recorded findings of the
+// same workflows. Partial reports may miss findings that are still there, so
+// they're neither compared nor recorded.
+func (a *Analyzer) trackAdoption(owner, repo string, files []string, report *models.PerformanceReport) error {
+	if report.Partial {
+		return nil
+	}
+	path := filepath.Join(a.adoption, adoptionDir, filepath.Base(a.digestPath(owner, repo, files)))
+
+	now := time.Now().UTC()
+	record := adoptionRecord{TrackedSince: now}
+	if raw, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(raw, &record); err != nil {
+			a.debugLog("Warning: ignoring unreadable adoption record %s: %v", path, err)
+			record = adoptionRecord{TrackedSince: now}
+		}
+	}
+
+	adoption := &models.Adoption{TrackedSince: record.TrackedSince, Adopted: []models.Finding{}, Open: len(report.Findings)}
+	if !record.AnalyzedAt.IsZero() {
+		since := record.AnalyzedAt
+		adoption.Since = &since
+		adoption.Adopted = models.AdoptedFindings(record.Findings, report.Findings)
+	}
+	adoption.TotalAdopted = record.TotalAdopted + len(adoption.Adopted)
+	report.Adoption = adoption
+
+	raw, err := json.Marshal(adoptionRecord{
+		TrackedSince: record.TrackedSince,
+		AnalyzedAt:   now,
+		Findings:     report.Findings,
+		TotalAdopted: adoption.TotalAdopted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode adoption record: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create adoption directory: %v", err)
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write adoption record: %v", err)
+	}
+	return nil
+}
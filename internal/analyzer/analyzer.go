@@ -3,135 +3,184 @@ package analyzer
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
 	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
 	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/policy"
+	"github.com/somaz94/github-action-analyzer/internal/pricing"
+	"github.com/somaz94/github-action-analyzer/internal/telemetry"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+	"golang.org/x/sync/errgroup"
 )
 
+// Mode selects how much data is fetched for each workflow run
+type Mode string
+
+const (
+	// ModeSurvey analyzes many runs using only API metadata (fast, low quota)
+	ModeSurvey Mode = "survey"
+	// ModeDeep downloads job logs for a small sample of recent runs
+	ModeDeep Mode = "deep"
+)
+
+// defaultSampleSize is the number of runs whose logs are downloaded in deep mode
+const defaultSampleSize = 10
+
+// defaultTimeout bounds a whole analysis
+const defaultTimeout = 60 * time.Minute
+
+// partialResultsGrace is how long Analyze waits for in-flight stages to stop after
+// the deadline before giving up on partial results
+const partialResultsGrace = 30 * time.Second
+
+// ParseMode converts an input string into a Mode
+func ParseMode(s string) (Mode, error) {
+	switch Mode(strings.ToLower(strings.TrimSpace(s))) {
+	case "", ModeDeep:
+		return ModeDeep, nil
+	case ModeSurvey:
+		return ModeSurvey, nil
+	default:
+		return "", fmt.Errorf("invalid mode %q: expected survey or deep", s)
+	}
+}
+
 // Analyzer handles workflow analysis
 type Analyzer struct {
-	client         GithubClient
-	versionChecker VersionChecker
-	debug          bool
+	client          GithubClient
+	versionChecker  VersionChecker
+	versionChannel  VersionChannel
+	debug           bool
+	mode            Mode
+	sampleSize      int
+	timeout         time.Duration
+	budgets         StageBudgets
+	sampling        Sampling
+	lang            i18n.Lang
+	sustainability  bool
+	gridCarbon      float64
+	deployWorkflows []string
+	styleChecks     bool
+	policy          *policy.Policy
+	rego            *policy.Rego
+	pricing         *pricing.Pricing
+	exporters       []telemetry.Exporter
+	resultCache     string
+	digest          string
+	adoption        string
+	analyzeDepth    int
+	minConfidence   float64
+	maskedRate      float64 // share of successful runs a continue-on-error step must fail in
+	progress        io.Writer
+	debugOut        io.Writer
 }
 
-// GithubClient interface defines methods for interacting with GitHub API
-type GithubClient interface {
-	GetWorkflowRuns(ctx context.Context, owner, repo, workflowFile string) ([]*gh.WorkflowRun, error)
-	GetWorkflowJobLogs(ctx context.Context, owner, repo string, runID int64) (string, error)
-	GetFileContent(ctx context.Context, owner, repo, path string) (string, error)
-	GetLatestRelease(ctx context.Context, owner, repo string) (*gh.RepositoryRelease, error)
-}
+// Option configures optional Analyzer behaviour
+type Option func(*Analyzer)
 
-// VersionChecker interface for getting latest language versions
-type VersionChecker interface {
-	GetLatestVersion(lang string) (string, error)
+// WithMode sets the analysis mode
+func WithMode(mode Mode) Option {
+	return func(a *Analyzer) {
+		a.mode = mode
+	}
 }
 
-// GitHubVersionChecker implements VersionChecker using GitHub API
-type GitHubVersionChecker struct {
-	client GithubClient
+// WithLang sets the locale used for generated recommendations
+func WithLang(lang i18n.Lang) Option {
+	return func(a *Analyzer) {
+		a.lang = lang
+	}
 }
 
-// GetLatestVersion retrieves the latest version for a given language
-func (g *GitHubVersionChecker) GetLatestVersion(lang string) (string, error) {
-	ctx := context.Background()
-	switch lang {
-	case "go":
-		release, err := g.client.GetLatestRelease(ctx, "golang", "go")
-		if err != nil {
-			return "1.24", nil
-		}
-		version := strings.TrimPrefix(release.GetTagName(), "go")
-		parts := strings.Split(version, ".")
-		if len(parts) >= 2 {
-			return parts[0] + "." + parts[1], nil
-		}
-		return "1.24", nil
-
-	case "node":
-		release, err := g.client.GetLatestRelease(ctx, "nodejs", "node")
-		if err != nil {
-			return "20.11", nil
+// WithSampleSize sets how many runs are analyzed in depth
+func WithSampleSize(n int) Option {
+	return func(a *Analyzer) {
+		if n > 0 {
+			a.sampleSize = n
 		}
-		version := strings.TrimPrefix(release.GetTagName(), "v")
-		if strings.Contains(version, "nightly") || strings.Contains(version, "test") {
-			return "20.11", nil
-		}
-		parts := strings.Split(version, ".")
-		if len(parts) >= 2 {
-			majorVer, _ := strconv.Atoi(parts[0])
-			if majorVer > 20 {
-				return "20.11", nil
-			}
-			return parts[0] + "." + parts[1], nil
-		}
-		return "20.11", nil
+	}
+}
 
-	case "python":
-		release, err := g.client.GetLatestRelease(ctx, "python", "cpython")
-		if err != nil {
-			return "3.12", nil
+// WithProgress sets where the stage progress groups are printed, os.Stdout by default
+func WithProgress(w io.Writer) Option {
+	return func(a *Analyzer) {
+		if w != nil {
+			a.progress = w
 		}
-		version := strings.TrimPrefix(release.GetTagName(), "v")
-		if strings.Contains(version, "a") || strings.Contains(version, "b") || strings.Contains(version, "rc") {
-			return "3.12", nil
-		}
-		parts := strings.Split(version, ".")
-		if len(parts) >= 2 {
-			return parts[0] + "." + parts[1], nil
-		}
-		return "3.12", nil
+	}
+}
 
-	case "java":
-		release, err := g.client.GetLatestRelease(ctx, "adoptium", "temurin")
-		if err != nil {
-			return "17", nil
-		}
-		version := strings.TrimPrefix(release.GetTagName(), "jdk-")
-		parts := strings.Split(version, ".")
-		if len(parts) >= 1 {
-			return parts[0], nil
-		}
-		return "17", nil
+// progressKey is the context key of a per-analysis progress writer
+type progressKey struct{}
 
-	case "ruby":
-		release, err := g.client.GetLatestRelease(ctx, "ruby", "ruby")
-		if err != nil {
-			return "3.2", nil
-		}
-		version := strings.TrimPrefix(release.GetTagName(), "v")
-		parts := strings.Split(version, ".")
-		if len(parts) >= 2 {
-			return parts[0] + "." + parts[1], nil
-		}
-		return "3.2", nil
+// ContextWithProgress makes the analysis run with ctx print its progress to w
+// instead of the analyzer's writer, e.g. to keep concurrent analyses apart
+func ContextWithProgress(ctx context.Context, w io.Writer) context.Context {
+	return context.WithValue(ctx, progressKey{}, w)
+}
 
-	case "rust":
-		// Rust uses a different versioning system, returning latest stable
-		return "stable", nil
+// progressOf returns where the progress of the analysis run with ctx is printed
+func (a *Analyzer) progressOf(ctx context.Context) io.Writer {
+	if w, ok := ctx.Value(progressKey{}).(io.Writer); ok {
+		return w
+	}
+	return a.progress
+}
 
-	case "dotnet":
-		release, err := g.client.GetLatestRelease(ctx, "dotnet", "core")
-		if err != nil {
-			return "7.0", nil
-		}
-		version := strings.TrimPrefix(release.GetTagName(), "v")
-		parts := strings.Split(version, ".")
-		if len(parts) >= 2 {
-			return parts[0] + "." + parts[1], nil
+// WithDebugOutput sets where debug messages are printed, os.Stdout by default,
+// e.g. a log file while a terminal UI owns the screen
+func WithDebugOutput(w io.Writer) Option {
+	return func(a *Analyzer) {
+		if w != nil {
+			a.debugOut = w
 		}
-		return "7.0", nil
+	}
+}
 
-	default:
-		return "", fmt.Errorf("unsupported language: %s", lang)
+// WithTimeout sets the deadline for a whole analysis
+func WithTimeout(d time.Duration) Option {
+	return func(a *Analyzer) {
+		if d > 0 {
+			a.timeout = d
+		}
 	}
 }
 
+// GithubClient interface defines methods for interacting with GitHub API
+type GithubClient interface {
+	GetWorkflowRuns(ctx context.Context, owner, repo, workflowFile string) ([]*gh.WorkflowRun, error)
+	GetWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*gh.WorkflowJob, error)
+	GetWorkflowJobLogs(ctx context.Context, owner, repo string, runID int64) (string, error)
+	GetFileContent(ctx context.Context, owner, repo, path string) (string, error)
+	FileExists(ctx context.Context, owner, repo, path string) (bool, error)
+	GetTree(ctx context.Context, owner, repo string) ([]string, error)
+	GetLatestRelease(ctx context.Context, owner, repo string) (*gh.RepositoryRelease, error)
+	ListReleases(ctx context.Context, owner, repo string, limit int) ([]*gh.RepositoryRelease, error)
+	ListTagNames(ctx context.Context, owner, repo, prefix string) ([]string, error)
+	GetRepository(ctx context.Context, owner, repo string) (*gh.Repository, error)
+	ListContributors(ctx context.Context, owner, repo string, limit int) ([]*gh.Contributor, error)
+	GetCacheUsage(ctx context.Context, owner, repo string) (int64, int, error)
+	ListCaches(ctx context.Context, owner, repo string, limit int) ([]models.ActionsCache, error)
+	GetRateLimit(ctx context.Context) (*gh.Rate, error)
+	GetFileContentAtRef(ctx context.Context, owner, repo, path, ref string) (string, error)
+	GetPullRequest(ctx context.Context, owner, repo string, number int) (*gh.PullRequest, error)
+	ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*gh.CommitFile, error)
+	CreateReview(ctx context.Context, owner, repo string, number int, review *gh.PullRequestReviewRequest) error
+	ListWorkflows(ctx context.Context, owner, repo string) ([]*gh.Workflow, error)
+	CountWorkflowRuns(ctx context.Context, owner, repo, workflowFile string, since time.Time) (int, error)
+	GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) ([]*gh.RequiredStatusCheck, error)
+}
+
+// VersionChecker interface for getting latest language versions
+type VersionChecker interface {
+	GetLatestVersion(ctx context.Context, lang string) (string, error)
+}
+
 // Language-specific cache strategies
 var cacheStrategies = map[string][]models.CacheRecommendation{
 	"go": {
@@ -155,105 +204,6 @@ var cacheStrategies = map[string][]models.CacheRecommendation{
             ${{ runner.os }}-go-`,
 		},
 	},
-	"node": {
-		{
-			Path:        "~/.npm",
-			Description: "Cache npm dependencies",
-			Impact:      "Can reduce npm install time by up to 50%",
-			Example: `      - name: Get npm cache directory
-        id: npm-cache-dir
-        shell: bash
-        run: echo "dir=$(npm config get cache)" >> ${GITHUB_OUTPUT}
-
-      - name: Setup Node.js
-        uses: actions/setup-node@v4
-        with:
-          node-version: '%s'
-          cache: 'npm'  # This enables npm cache
-
-      - uses: actions/cache@v4
-        id: npm-cache
-        with:
-          path: ${{ steps.npm-cache-dir.outputs.dir }}
-          key: ${{ runner.os }}-node-${{ hashFiles('**/package-lock.json') }}
-          restore-keys: |
-            ${{ runner.os }}-node-`,
-		},
-		{
-			Path:        "node_modules",
-			Description: "Cache node_modules directory",
-			Impact:      "Can significantly reduce installation time for large projects",
-			Example: `      - uses: actions/cache@v4
-        with:
-          path: '**/node_modules'
-          key: ${{ runner.os }}-modules-${{ hashFiles('**/package-lock.json') }}`,
-		},
-	},
-	"python": {
-		{
-			Path:        "~/.cache/pip",
-			Description: "Cache pip dependencies",
-			Impact:      "Can reduce pip install time significantly",
-			Example: `      - name: Set up Python
-        id: setup-python
-        uses: actions/setup-python@v5
-        with:
-          python-version: '%s'
-          cache: 'pip'
-          cache-dependency-path: |
-            **/requirements.txt
-            **/requirements-dev.txt
-
-      - uses: actions/cache@v4
-        with:
-          path: |
-            ~/.cache/pip
-            ~/.local/share/virtualenvs
-          key: ${{ runner.os }}-python-${{ hashFiles('**/requirements.txt') }}
-          restore-keys: |
-            ${{ runner.os }}-python-`,
-		},
-	},
-	"java": {
-		{
-			Path:        "~/.m2/repository",
-			Description: "Cache Maven dependencies",
-			Impact:      "Can significantly reduce build time by caching Maven dependencies",
-			Example: `      - name: Set up Java
-        uses: actions/setup-java@v4
-        with:
-          java-version: '%s'
-          distribution: 'temurin'
-          cache: 'maven'
-
-      - uses: actions/cache@v4
-        with:
-          path: ~/.m2/repository
-          key: ${{ runner.os }}-maven-${{ hashFiles('**/pom.xml') }}
-          restore-keys: |
-            ${{ runner.os }}-maven-`,
-		},
-		{
-			Path:        "~/.gradle",
-			Description: "Cache Gradle dependencies and wrapper",
-			Impact:      "Can significantly reduce build time by caching Gradle dependencies",
-			Example: `      - name: Set up Java
-        uses: actions/setup-java@v4
-        with:
-          java-version: '%s'
-          distribution: 'temurin'
-          cache: 'gradle'
-
-      - uses: actions/cache@v4
-        with:
-          path: |
-            ~/.gradle/caches
-            ~/.gradle/wrapper
-          key: ${{ runner.os }}-gradle-${{ hashFiles('**/*.gradle*', '**/gradle-wrapper.properties') }}
-          restore-keys: |
-            ${{ runner.os }}-gradle-`,
-		},
-	},
 	"ruby": {
 		{
 			Path:        "vendor/bundle",
@@ -318,114 +268,313 @@ var cacheStrategies = map[string][]models.CacheRecommendation{
 }
 
 // NewAnalyzer creates a new instance of Analyzer
-func NewAnalyzer(client GithubClient, debug bool) *Analyzer {
-	return &Analyzer{
+func NewAnalyzer(client GithubClient, debug bool, opts ...Option) *Analyzer {
+	a := &Analyzer{
 		client:         client,
-		versionChecker: &GitHubVersionChecker{client: client},
 		debug:          debug,
+		mode:           ModeDeep,
+		sampleSize:     defaultSampleSize,
+		timeout:        defaultTimeout,
+		sampling:       Sampling{Strategy: SampleLatest},
+		lang:           i18n.English,
+		gridCarbon:     defaultGridCarbon,
+		analyzeDepth:   DefaultAnalyzeDepth,
+		progress:       os.Stdout,
+		debugOut:       os.Stdout,
+		versionChannel: ChannelLTS,
+		maskedRate:     DefaultMaskedFailureRate,
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
+	if a.versionChecker == nil {
+		a.versionChecker = NewGitHubVersionChecker(client, nil, a.versionChannel)
+	}
+	return a
 }
 
 // debugLog prints debug information if debug mode is enabled
 func (a *Analyzer) debugLog(format string, args ...interface{}) {
 	if a.debug {
-		fmt.Printf(format+"\n", args...)
+		fmt.Fprintf(a.debugOut, format+"\n", args...)
 	}
 }
 
 // Analyze performs the workflow analysis
 func (a *Analyzer) Analyze(ctx context.Context, owner, repo, workflowFile string) (*models.PerformanceReport, error) {
-	// Parse timeout from env
-	timeoutStr := os.Getenv("TIMEOUT")
-	timeout := 60 * time.Minute // default timeout changed to 60 minutes
-	if t, err := strconv.Atoi(timeoutStr); err == nil && t > 0 {
-		timeout = time.Duration(t) * time.Minute
-	}
-
 	// Create timeout context
-	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx, cancel := context.WithTimeout(ctx, a.timeout)
 	defer cancel()
 
+	// An unchanged workflow and run history give the same report as last time
+	resultKey := ""
+	if a.resultCache != "" {
+		key, err := a.resultKey(ctx, owner, repo, workflowFile)
+		if err != nil {
+			a.debugLog("Warning: not using cached reports: %v", err)
+		} else if cached := a.cachedReport(owner, repo, workflowFile, key); cached != nil {
+			a.debugLog("No changes since the last analysis of %s, reusing its report", workflowFile)
+			a.exportListedRuns(ctx, owner, repo, workflowFile, cached)
+			return cached, nil
+		}
+		resultKey = key
+	}
+
 	report := &models.PerformanceReport{
 		Repository:   fmt.Sprintf("%s/%s", owner, repo),
 		WorkflowFile: workflowFile,
+		Lang:         a.lang,
+	}
+
+	// Local stages only use data already collected and still run after a
+	// timeout so partial results get as much analysis as possible
+	var samples []runSample
+	var workflows []*repoWorkflow
+	loaded := false
+	repoWorkflows := func(ctx context.Context) []*repoWorkflow {
+		if !loaded {
+			workflows, loaded = a.loadWorkflows(ctx, owner, repo), true
+		}
+		return workflows
 	}
+	stages := []stage{
+		{name: "workflow_runs", budget: BudgetRuns, run: func(ctx context.Context) (err error) {
+			samples, err = a.analyzeWorkflowRuns(ctx, owner, repo, workflowFile, report)
+			return err
+		}},
+	}
+	if a.mode == ModeDeep {
+		stages = append(stages, stage{name: "job_logs", budget: BudgetLogs, run: func(ctx context.Context) error {
+			return a.analyzeWorkflowLogs(ctx, owner, repo, samples, report)
+		}})
+	}
+	stages = append(stages,
+		stage{name: "docker", budget: BudgetFiles, run: func(ctx context.Context) error {
+			return a.analyzeDockerConfigs(ctx, owner, repo, report, samples)
+		}},
+		stage{name: "caching", budget: BudgetFiles, run: func(ctx context.Context) error {
+			return a.analyzeCaching(ctx, owner, repo, report, samples)
+		}},
+		stage{name: "workflow_chain", budget: BudgetRuns, run: func(ctx context.Context) error {
+			a.analyzeWorkflowChain(ctx, owner, repo, report, repoWorkflows(ctx), samples)
+			return nil
+		}},
+		stage{name: "deployments", budget: BudgetRuns, run: func(ctx context.Context) error {
+			a.analyzeDeployments(ctx, owner, repo, report, repoWorkflows(ctx))
+			return nil
+		}},
+		stage{name: "unused_workflows", budget: BudgetRuns, run: func(ctx context.Context) error {
+			a.analyzeUnusedWorkflows(ctx, owner, repo, report, repoWorkflows(ctx))
+			return nil
+		}},
+		stage{name: "fork_exposure", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeForkExposure(report, repoWorkflows(ctx))
+			return nil
+		}},
+		stage{name: "required_checks", budget: BudgetRuns, run: func(ctx context.Context) error {
+			a.analyzeRequiredChecks(ctx, owner, repo, report, repoWorkflows(ctx), samples)
+			return nil
+		}},
+		stage{name: "merge_queue", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeMergeQueue(report, repoWorkflows(ctx), samples)
+			return nil
+		}},
+		stage{name: "cache_usage", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeCacheUsage(ctx, owner, repo, report)
+			return nil
+		}},
+		stage{name: "workflow_structure", budget: BudgetFiles, run: func(ctx context.Context) error {
+			return a.analyzeWorkflowFile(ctx, owner, repo, report, samples)
+		}},
+		stage{name: "supply_chain", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeSupplyChain(ctx, owner, repo, report)
+			return nil
+		}},
+		stage{name: "custom_actions", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeCustomActions(ctx, owner, repo, report)
+			return nil
+		}},
+		stage{name: "migrations", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeMigrations(ctx, owner, repo, report)
+			return nil
+		}},
+		stage{name: "starter_workflows", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeStarterWorkflows(ctx, owner, repo, report)
+			return nil
+		}},
+		// After the stages adding findings, so each finding gets its owners
+		stage{name: "codeowners", budget: BudgetFiles, run: func(ctx context.Context) error {
+			a.analyzeOwners(ctx, owner, repo, report)
+			return nil
+		}},
+		stage{name: "sustainability", run: func(ctx context.Context) error {
+			if a.sustainability {
+				report.Sustainability = a.estimateSustainability(samples)
+			}
+			return nil
+		}},
+		stage{name: "cost_estimate", run: func(ctx context.Context) error {
+			report.Cost = a.estimateCost(samples)
+			return nil
+		}},
+		stage{name: "cost_tips", run: func(ctx context.Context) error {
+			a.generateCostSavingTips(report)
+			return nil
+		}},
+		stage{name: "run_export", run: func(ctx context.Context) error {
+			a.exportRuns(ctx, report, samples)
+			return nil
+		}},
+	)
 
 	// Run analysis tasks with timeout context
 	errCh := make(chan error, 1)
 	go func() {
-		var err error
-		defer func() {
-			errCh <- err
-		}()
+		errCh <- a.runStages(ctx, stages, report)
+	}()
 
-		if err = a.analyzeWorkflowRuns(ctx, owner, repo, workflowFile, report); err != nil {
-			return
-		}
-		if err = a.analyzeDockerConfigs(ctx, owner, repo, report); err != nil {
-			return
-		}
-		if err = a.analyzeCaching(ctx, owner, repo, report); err != nil {
-			return
+	result := func(err error) (*models.PerformanceReport, error) {
+		if err != nil {
+			return nil, fmt.Errorf("analysis failed: %v", err)
 		}
-
-		// Get workflow content for structure analysis
-		workflowPath := report.WorkflowFile
-		if !strings.HasPrefix(workflowPath, ".github/workflows/") {
-			workflowPath = fmt.Sprintf(".github/workflows/%s", workflowPath)
+		if report.Partial {
+			a.debugLog("Returning partial results (skipped: %s)", strings.Join(report.SkippedStages, ", "))
 		}
-
-		if content, err := a.client.GetFileContent(ctx, owner, repo, workflowPath); err == nil {
-			if err = a.analyzeWorkflowStructure(content, report); err != nil {
-				a.debugLog("Warning: workflow structure analysis failed: %v", err)
+		report.Findings = a.confidentFindings(report.Findings)
+		linkFindings(owner, repo, report)
+		if resultKey != "" && !report.Partial {
+			if err := a.storeReport(owner, repo, workflowFile, resultKey, report); err != nil {
+				a.debugLog("Warning: %v", err)
 			}
 		}
-
-		a.generateCostSavingTips(report)
-	}()
+		return report, nil
+	}
 
 	// Wait for either completion or timeout
 	select {
 	case err := <-errCh:
-		if err != nil {
-			return nil, fmt.Errorf("analysis failed: %v", err)
-		}
-		return report, nil
+		return result(err)
 	case <-ctx.Done():
-		if ctx.Err() == context.DeadlineExceeded {
-			return nil, fmt.Errorf("analysis timed out after %v minutes", timeout.Minutes())
+		if ctx.Err() != context.DeadlineExceeded {
+			return nil, ctx.Err()
+		}
+		// API calls honour the context, so the remaining stages wind down quickly
+		select {
+		case err := <-errCh:
+			return result(err)
+		case <-time.After(partialResultsGrace):
+			return nil, fmt.Errorf("analysis timed out after %v minutes", a.timeout.Minutes())
 		}
-		return nil, ctx.Err()
 	}
 }
 
-// analyzeWorkflowRuns analyzes workflow execution history
-func (a *Analyzer) analyzeWorkflowRuns(ctx context.Context, owner, repo, workflowFile string, report *models.PerformanceReport) error {
-	var totalTime time.Duration
+// analyzeWorkflowFile runs the structure analysis and line-level checks on the workflow file
+// and proposes patches for what can be fixed mechanically
+func (a *Analyzer) analyzeWorkflowFile(ctx context.Context, owner, repo string, report *models.PerformanceReport, samples []runSample) error {
+	workflowPath := report.WorkflowFile
+	if !strings.HasPrefix(workflowPath, ".github/workflows/") {
+		workflowPath = fmt.Sprintf(".github/workflows/%s", workflowPath)
+	}
+
+	content, err := a.client.GetFileContent(ctx, owner, repo, workflowPath)
+	if err != nil {
+		return ctx.Err() // a missing workflow file only skips this stage
+	}
+	if err = a.analyzeWorkflowStructure(content, report); err != nil {
+		a.debugLog("Warning: workflow structure analysis failed: %v", err)
+	}
+	report.Findings = append(report.Findings, a.inspectWorkflow(ctx, workflowPath, content, samples)...)
+	if wf, err := workflow.Parse(content); err == nil {
+		report.Secrets = secretsInventory(workflowPath, wf, owner)
+		report.Jobs = jobGraph(wf, samples)
+		report.Fingerprints = []models.WorkflowFingerprint{workflowFingerprint(workflowPath, wf)}
+		report.Findings = append(report.Findings, a.inspectCallees(ctx, owner, repo, wf)...)
+		if findings, hardening := a.checkRelease(workflowPath, wf); hardening != nil {
+			report.Findings = append(report.Findings, findings...)
+			report.ReleaseHardening = []models.ReleaseHardening{*hardening}
+		}
+	}
+	report.Patches = a.workflowPatches(ctx, workflowPath, content)
+	return nil
+}
+
+// analyzeWorkflowRuns analyzes workflow execution history and returns the
+// collected run samples for evidence-based checks
+func (a *Analyzer) analyzeWorkflowRuns(ctx context.Context, owner, repo, workflowFile string, report *models.PerformanceReport) ([]runSample, error) {
+	var samples []runSample
 
 	runs, err := a.client.GetWorkflowRuns(ctx, owner, repo, workflowFile)
 	if err != nil {
-		return fmt.Errorf("failed to get workflow runs: %v", err)
+		return nil, fmt.Errorf("failed to get workflow runs: %v", err)
 	}
 
-	for _, githubRun := range runs {
-		// Calculate actual workflow run time
-		if githubRun.CreatedAt != nil && githubRun.UpdatedAt != nil {
-			runDuration := githubRun.UpdatedAt.Sub(githubRun.CreatedAt.Time)
-			totalTime += runDuration
-		}
+	a.debugLog("Analyzing %d runs in %s mode", len(runs), a.mode)
+
+	selected := a.selectRuns(runs)
+	report.Sampling = a.describeSampling(runs, selected)
+
+	// Only finished runs that succeeded or failed count towards the timings
+	report.RunStats = summarizeRuns(runs)
+	report.Trend = runTrend(runs)
+	report.MergeQueue = summarizeMergeQueue(runs)
+	report.Heatmap = runHeatmap(runs)
+	report.TotalExecutionTime = report.RunStats.Successful.Total + report.RunStats.Failed.Total
 
+	for i, githubRun := range runs {
 		run := models.NewWorkflowRunFromGitHub(githubRun)
 
-		// Get job logs
-		logs, err := a.client.GetWorkflowJobLogs(ctx, owner, repo, run.ID)
+		// Deep mode only inspects the sampled runs
+		if a.mode == ModeDeep && !selected[i] {
+			continue
+		}
+
+		// Job metadata is a single cheap call per run and carries step timings
+		jobs, err := a.client.GetWorkflowJobs(ctx, owner, repo, run.ID, githubRun.GetRunAttempt())
 		if err != nil {
+			if ctx.Err() != nil {
+				return samples, ctx.Err()
+			}
+			return nil, fmt.Errorf("failed to get workflow jobs: %v", err)
+		}
+		samples = append(samples, runSample{Run: githubRun, Jobs: jobs, Listed: runs})
+
+		// Survey mode relies on job metadata only
+		if a.mode == ModeSurvey {
+			for _, step := range analyzeJobSteps(jobs) {
+				if step.IsSlowStep {
+					report.SlowSteps = append(report.SlowSteps, step)
+				}
+			}
+		}
+	}
+
+	return samples, nil
+}
+
+// analyzeWorkflowLogs downloads job logs for each sampled run (deep mode) and
+// reports slow steps found in them
+func (a *Analyzer) analyzeWorkflowLogs(ctx context.Context, owner, repo string, samples []runSample, report *models.PerformanceReport) error {
+	report.Timeline = runTimeline(samples)
+	// The run timings already sum the runs up, so log timings only stand in without them
+	timed := report.TotalExecutionTime > 0
+	for i := range samples {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		logs, err := a.client.GetWorkflowJobLogs(ctx, owner, repo, samples[i].Run.GetID())
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
 			return fmt.Errorf("failed to get job logs: %v", err)
 		}
+		samples[i].Logs = logs
 
 		// Analyze steps
 		steps, duration := analyzeSteps(logs)
-		totalTime += duration
+		if !timed && timingClass(samples[i].Run) != timingExcluded {
+			report.TotalExecutionTime += duration
+		}
 
 		// Identify slow steps
 		for _, step := range steps {
@@ -434,13 +583,11 @@ func (a *Analyzer) analyzeWorkflowRuns(ctx context.Context, owner, repo, workflo
 			}
 		}
 	}
-
-	report.TotalExecutionTime = totalTime
 	return nil
 }
 
 // analyzeDockerConfigs analyzes Dockerfile configurations
-func (a *Analyzer) analyzeDockerConfigs(ctx context.Context, owner, repo string, report *models.PerformanceReport) error {
+func (a *Analyzer) analyzeDockerConfigs(ctx context.Context, owner, repo string, report *models.PerformanceReport, samples []runSample) error {
 	// Analyze Dockerfile if exists
 	dockerFile, err := a.client.GetFileContent(ctx, owner, repo, "Dockerfile")
 	if err != nil {
@@ -448,12 +595,21 @@ func (a *Analyzer) analyzeDockerConfigs(ctx context.Context, owner, repo string,
 	}
 
 	optimizations := analyzeDockerfile(dockerFile)
+	for i, opt := range optimizations {
+		if opt.Issue == "No layer caching strategy detected" {
+			opt.EstimatedSavings = a.estimateSavings(samples, dockerBuildSteps, dockerLayerCacheRatio)
+		}
+		opt.Issue = a.lang.T(opt.Issue)
+		opt.Suggestion = a.lang.T(opt.Suggestion)
+		opt.Improvement = a.lang.T(opt.Improvement)
+		optimizations[i] = opt
+	}
 	report.DockerOptimizations = optimizations
 	return nil
 }
 
 // analyzeCaching analyzes and suggests caching strategies
-func (a *Analyzer) analyzeCaching(ctx context.Context, owner, repo string, report *models.PerformanceReport) error {
+func (a *Analyzer) analyzeCaching(ctx context.Context, owner, repo string, report *models.PerformanceReport, samples []runSample) error {
 	workflowPath := report.WorkflowFile
 	if !strings.HasPrefix(workflowPath, ".github/workflows/") {
 		workflowPath = fmt.Sprintf(".github/workflows/%s", workflowPath)
@@ -467,17 +623,51 @@ func (a *Analyzer) analyzeCaching(ctx context.Context, owner, repo string, repor
 		detectedLangs := detectLanguagesFromWorkflow(workflowContent)
 		a.debugLog("Detected languages: %v", detectedLangs)
 
-		for _, lang := range detectedLangs {
-			latestVersion, err := a.versionChecker.GetLatestVersion(lang)
-			if err != nil {
-				a.debugLog("Error getting latest version for %s: %v", lang, err)
+		// Manifests per directory find subprojects, and languages the workflow doesn't mention
+		tree, err := a.client.GetTree(ctx, owner, repo)
+		if err != nil {
+			a.debugLog("Error listing repository tree: %v", err)
+		}
+		projects := detectProjects(tree)
+		languages := append(detectedLangs, treeLanguages(projects, detectedLangs)...)
+		texts := toolingTexts(workflowContent, samples)
+
+		versions, err := a.latestVersions(ctx, languages)
+		if err != nil {
+			return err
+		}
+		for _, lang := range languages {
+			latestVersion, found := versions[lang]
+			if !found {
 				continue
 			}
 			a.debugLog("Latest version for %s: %s", lang, latestVersion)
 
-			if strategies, ok := cacheStrategies[lang]; ok {
+			var strategies []models.CacheRecommendation
+			var ok bool
+			if scoped := projects[lang]; isMonorepo(scoped) {
+				if len(scoped) > maxSubprojects {
+					a.debugLog("Found %d %s subprojects, scoping recommendations to the first %d", len(scoped), lang, maxSubprojects)
+					scoped = scoped[:maxSubprojects]
+				}
+				for _, project := range scoped {
+					a.debugLog("Detected %s subproject: %s", lang, project.dir)
+					recs, found := a.cacheStrategiesFor(ctx, owner, repo, lang, &project, tree, workflowContent, texts)
+					for _, rec := range recs {
+						strategies = append(strategies, scopeCacheStrategy(rec, lang, project))
+					}
+					ok = ok || found
+				}
+			} else {
+				strategies, ok = a.cacheStrategiesFor(ctx, owner, repo, lang, nil, tree, workflowContent, texts)
+			}
+			if ok {
+				savings := a.estimateSavings(samples, installSteps[lang], cacheSavingRatio[lang])
 				for _, strategy := range strategies {
 					updatedStrategy := strategy
+					updatedStrategy.Description = a.lang.T(strategy.Description)
+					updatedStrategy.Impact = a.lang.T(strategy.Impact)
+					updatedStrategy.EstimatedSavings = savings
 					if strings.Contains(strategy.Example, "%s") {
 						updatedStrategy.Example = fmt.Sprintf(strategy.Example, latestVersion)
 					} else {
@@ -487,6 +677,8 @@ func (a *Analyzer) analyzeCaching(ctx context.Context, owner, repo string, repor
 				}
 			}
 		}
+		report.CacheRecommendations = append(report.CacheRecommendations, a.buildSystemCaches(tree, texts)...)
+		report.Findings = append(report.Findings, a.checkLockfiles(ctx, owner, repo, workflowPath, workflowContent, projects, samples)...)
 	} else {
 		a.debugLog("Error getting workflow content: %v", err)
 	}
@@ -494,13 +686,108 @@ func (a *Analyzer) analyzeCaching(ctx context.Context, owner, repo string, repor
 	return nil
 }
 
+// latestVersions looks up the latest version of each language concurrently. A
+// language whose lookup fails is left out; the analysis being cancelled stops
+// the remaining lookups.
+func (a *Analyzer) latestVersions(ctx context.Context, languages []string) (map[string]string, error) {
+	found := make([]string, len(languages))
+	g, ctx := errgroup.WithContext(ctx)
+	for i, lang := range languages {
+		g.Go(func() error {
+			version, err := a.versionChecker.GetLatestVersion(ctx, lang)
+			if err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				a.debugLog("Error getting latest version for %s: %v", lang, err)
+				return nil
+			}
+			found[i] = version
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[string]string, len(languages))
+	for i, lang := range languages {
+		if found[i] != "" {
+			versions[lang] = found[i]
+		}
+	}
+	return versions, nil
+}
+
+// cacheStrategiesFor returns the cache recommendations for a language, picking the
+// package manager or build tool from project's files, or from the repository root
+// when project is nil
+func (a *Analyzer) cacheStrategiesFor(ctx context.Context, owner, repo, lang string, project *subproject, tree []string, workflowContent string, texts []string) ([]models.CacheRecommendation, bool) {
+	// A tool only the job logs mention rates the recommendations for it
+	withConfidence := func(recs []models.CacheRecommendation, tools []packageTool, tool string) []models.CacheRecommendation {
+		if len(tree) == 0 && project == nil {
+			return recs // the root lockfiles are unknown
+		}
+		var files []string
+		if project != nil {
+			files = project.files
+		} else {
+			for _, p := range tree {
+				if !strings.Contains(p, "/") {
+					files = append(files, p)
+				}
+			}
+		}
+		confidence := toolConfidence(tools, tool, files, texts)
+		if confidence == 0 {
+			return recs
+		}
+		rated := make([]models.CacheRecommendation, 0, len(recs))
+		for _, rec := range recs {
+			rec.Confidence = confidence
+			if a.confident(confidence) {
+				rated = append(rated, rec)
+			}
+		}
+		return rated
+	}
+
+	switch lang {
+	case "node":
+		pm := a.packageToolFor(ctx, owner, repo, project, nodePackageManagers, "npm", texts)
+		a.debugLog("Detected Node.js package manager: %s", pm)
+		return withConfidence(nodeCacheStrategies[pm], nodePackageManagers, pm), true
+	case "python":
+		tool := a.packageToolFor(ctx, owner, repo, project, pythonTools, "pip", texts)
+		a.debugLog("Detected Python tooling: %s", tool)
+		return withConfidence(pythonCacheStrategies[tool], pythonTools, tool), true
+	case "java":
+		dir := rootDir
+		if project != nil {
+			dir, tree = project.dir, project.paths()
+		}
+		tools := javaBuildTools(tree, workflowContent)
+		a.debugLog("Detected Java build tools: %v", tools)
+		var strategies []models.CacheRecommendation
+		for _, tool := range tools {
+			strategies = append(strategies, javaCacheStrategies[tool]...)
+			if tool == "gradle" {
+				strategies = append(strategies, a.gradlePropertyAdvice(ctx, owner, repo, dir)...)
+			}
+		}
+		return strategies, true
+	}
+	strategies, ok := cacheStrategies[lang]
+	return strategies, ok
+}
+
 // generateCostSavingTips generates cost optimization recommendations
 func (a *Analyzer) generateCostSavingTips(report *models.PerformanceReport) {
 	tips := []string{
-		"Consider using GitHub Actions cache to speed up dependencies installation",
-		"Use matrix builds for parallel execution",
-		"Implement proper Docker layer caching",
-		fmt.Sprintf("Total execution time: %v - Consider optimizing long-running steps", report.TotalExecutionTime),
+		a.lang.T("Consider using GitHub Actions cache to speed up dependencies installation"),
+		a.lang.T("Use matrix builds for parallel execution"),
+		a.lang.T("Implement proper Docker layer caching"),
+		a.lang.Sprintf("Total execution time: %v - Consider optimizing long-running steps", report.TotalExecutionTime),
 	}
 	report.CostSavingTips = tips
 }
@@ -536,6 +823,25 @@ func analyzeSteps(logs string) ([]models.StepAnalysis, time.Duration) {
 	return steps, totalDuration
 }
 
+// analyzeJobSteps builds step timings from job metadata without downloading logs
+func analyzeJobSteps(jobs []*gh.WorkflowJob) []models.StepAnalysis {
+	var steps []models.StepAnalysis
+	for _, job := range jobs {
+		for _, step := range job.Steps {
+			if step.StartedAt == nil || step.CompletedAt == nil {
+				continue
+			}
+			duration := step.CompletedAt.Sub(step.StartedAt.Time)
+			steps = append(steps, models.StepAnalysis{
+				Name:          fmt.Sprintf("%s / %s", job.GetName(), step.GetName()),
+				ExecutionTime: duration,
+				IsSlowStep:    duration > 5*time.Minute,
+			})
+		}
+	}
+	return steps
+}
+
 // analyzeDockerfile analyzes Dockerfile for optimizations
 func analyzeDockerfile(content string) []models.DockerOptimization {
 	var optimizations []models.DockerOptimization
@@ -659,17 +965,8 @@ func unique(slice []string) []string {
 	return list
 }
 
-// // TODO: This function will be used in future for escaping GitHub expressions in example strings
-// // Currently unused but kept for future implementation
-// func _escapeGitHubExpression(example string) string {
-// 	return strings.ReplaceAll(example, "${{", "\\${\\{")
-// }
-
 // analyzeWorkflowStructure analyzes the workflow structure and patterns
 func (a *Analyzer) analyzeWorkflowStructure(content string, report *models.PerformanceReport) error {
-	// GitHub 표현식 이스케이프 처리 추가
-	content = strings.ReplaceAll(content, "${", "\\${")
-
 	analysis := &models.WorkflowAnalysis{
 		Recommendations:     make([]string, 0),
 		RunnerOptimizations: make([]string, 0),
@@ -679,32 +976,32 @@ func (a *Analyzer) analyzeWorkflowStructure(content string, report *models.Perfo
 	// Check for matrix strategy
 	if !strings.Contains(content, "strategy:") || !strings.Contains(content, "matrix:") {
 		analysis.Recommendations = append(analysis.Recommendations,
-			"Consider using matrix strategy for parallel testing/building across different versions/platforms")
+			a.lang.T("Consider using matrix strategy for parallel testing/building across different versions/platforms"))
 	}
 
 	// Check for job dependencies
 	if strings.Contains(content, "needs:") {
 		analysis.ParallelJobs = true
 		analysis.Recommendations = append(analysis.Recommendations,
-			"Review job dependencies to ensure optimal parallel execution")
+			a.lang.T("Review job dependencies to ensure optimal parallel execution"))
 	}
 
 	// Analyze runners
 	if strings.Contains(content, "runs-on: ubuntu-latest") {
 		analysis.RunnerOptimizations = append(analysis.RunnerOptimizations,
-			"Consider using specific Ubuntu version instead of 'latest' for better reproducibility")
+			a.lang.T("Consider using specific Ubuntu version instead of 'latest' for better reproducibility"))
 	}
 
 	// Security checks
 	if !strings.Contains(content, "permissions:") {
 		analysis.SecurityTips = append(analysis.SecurityTips,
-			"Add explicit permissions to improve workflow security")
+			a.lang.T("Add explicit permissions to improve workflow security"))
 	}
 
 	// Check environment usage
 	if !strings.Contains(content, "environment:") {
 		analysis.SecurityTips = append(analysis.SecurityTips,
-			"Consider using environments for better secret management and deployment control")
+			a.lang.T("Consider using environments for better secret management and deployment control"))
 	}
 
 	report.WorkflowAnalysis = analysis
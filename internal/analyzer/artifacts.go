@@ -0,0 +1,235 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// defaultArtifactName is the name upload-artifact uses when none is given
+const defaultArtifactName = "artifact"
+
+// artifactStep locates an upload or download-artifact step
+type artifactStep struct {
+	job  *workflow.Job
+	step *workflow.Step
+	name string
+}
+
+// usesAction reports whether a step uses the given action at any version
+func usesAction(step *workflow.Step, action string) bool {
+	name, _, _ := strings.Cut(step.Uses, "@")
+	return strings.EqualFold(name, action)
+}
+
+// artifactSteps collects upload-artifact and download-artifact steps by job
+func artifactSteps(wf *workflow.Workflow) (uploads, downloads []artifactStep) {
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			name := step.With["name"]
+			switch {
+			case usesAction(step, "actions/upload-artifact"):
+				if name == "" {
+					name = defaultArtifactName
+				}
+				uploads = append(uploads, artifactStep{job: job, step: step, name: name})
+			case usesAction(step, "actions/download-artifact"):
+				downloads = append(downloads, artifactStep{job: job, step: step, name: name})
+			}
+		}
+	}
+	return uploads, downloads
+}
+
+// needsJob reports whether job directly depends on the job with the given ID
+func needsJob(job *workflow.Job, id string) bool {
+	for _, need := range job.Needs {
+		if need == id {
+			return true
+		}
+	}
+	return false
+}
+
+// checkArtifactPassing finds artifacts handed from one job to the next only and weighs
+// the measured transfer time against merging the jobs or passing data through the cache
+func (a *Analyzer) checkArtifactPassing(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	uploads, downloads := artifactSteps(wf)
+
+	for _, up := range uploads {
+		// Downloads without a name fetch every artifact of the run
+		var consumers []artifactStep
+		for _, down := range downloads {
+			if down.name == up.name || down.name == "" {
+				consumers = append(consumers, down)
+			}
+		}
+		if len(consumers) != 1 || !needsJob(consumers[0].job, up.job.ID) {
+			continue
+		}
+		down := consumers[0]
+
+		uploadTime := average(stepDurations(samples, up.job, up.step))
+		downloadTime := average(stepDurations(samples, down.job, down.step))
+		transfer := uploadTime + downloadTime
+
+		finding := models.Finding{
+			Category: "performance",
+			Severity: models.SeverityInfo,
+			File:     path,
+			Line:     up.step.Line,
+			Message: a.lang.Sprintf("Artifact %q is uploaded by job %s and only consumed by job %s",
+				up.name, up.job.ID, down.job.ID),
+		}
+
+		switch {
+		case transfer == 0:
+			finding.Suggestion = a.lang.T("If the artifact isn't needed after the run, consider merging the two jobs or passing the files through actions/cache keyed on github.sha")
+		case transfer < 30*time.Second:
+			// Fast transfers are cheap enough to keep the jobs separate
+			continue
+		default:
+			consumerTime := average(jobDurations(samples, down.job))
+			finding.Message += " " + a.lang.Sprintf("(upload %v + download %v per run)",
+				uploadTime.Round(time.Second), downloadTime.Round(time.Second))
+			finding.URL = jobURL(samples, up.job)
+			if consumerTime > 0 && consumerTime < 2*transfer {
+				finding.Suggestion = a.lang.Sprintf("Job %s takes %v on average, less than twice the transfer; merging it into job %s avoids the round trip",
+					down.job.ID, consumerTime.Round(time.Second), up.job.ID)
+				finding.EstimatedSavings = a.projectSavings(samples, transfer)
+			} else {
+				finding.Suggestion = a.lang.T("Pass the files through actions/cache keyed on github.sha, which restores faster than artifact downloads for large outputs")
+			}
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// artifactPattern turns an artifact name with expressions into a pattern
+// matching any value of them, e.g. dist-${{ matrix.os }} into ^dist-.+$
+func artifactPattern(name string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for rest := name; rest != ""; {
+		start := strings.Index(rest, "${{")
+		if start < 0 {
+			b.WriteString(regexp.QuoteMeta(rest))
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(rest[:start]))
+		b.WriteString(".+")
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			break
+		}
+		rest = rest[start+end+2:]
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// upstreamJobs returns the IDs of the jobs job waits for, directly or through others
+func upstreamJobs(wf *workflow.Workflow, job *workflow.Job) map[string]bool {
+	byID := make(map[string]*workflow.Job)
+	for _, j := range wf.Jobs {
+		byID[j.ID] = j
+	}
+	upstream := make(map[string]bool)
+	pending := append([]string(nil), job.Needs...)
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if upstream[id] {
+			continue
+		}
+		upstream[id] = true
+		if j := byID[id]; j != nil {
+			pending = append(pending, j.Needs...)
+		}
+	}
+	return upstream
+}
+
+// checkArtifactOrdering finds download-artifact steps that can't find their
+// artifact: no job uploads it, the upload adds a matrix suffix to its name, or
+// the uploading job doesn't run before the downloading one. The download fails,
+// or with continue-on-error silently leaves the files out.
+func (a *Analyzer) checkArtifactOrdering(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	uploads, downloads := artifactSteps(wf)
+
+	for _, down := range downloads {
+		// Downloads of every artifact, by pattern or from other runs can't be matched
+		if down.name == "" || down.step.With["run-id"] != "" {
+			continue
+		}
+		finding := models.Finding{
+			Category: "reliability",
+			Severity: models.SeverityWarning,
+			File:     path,
+			Line:     down.step.Line,
+		}
+
+		var matched []artifactStep
+		var suffixed *artifactStep
+		for i, up := range uploads {
+			switch {
+			case up.name == down.name:
+				matched = append(matched, up)
+			case !strings.Contains(up.name, "${{"):
+			case artifactPattern(up.name).MatchString(down.name):
+				matched = append(matched, up)
+			case down.name == strings.TrimRight(up.name[:strings.Index(up.name, "${{")], "-_. "):
+				suffixed = &uploads[i]
+			}
+		}
+
+		if len(matched) == 0 {
+			switch {
+			case suffixed != nil:
+				finding.Message = a.lang.Sprintf("Job %s downloads artifact %q, but job %s uploads it per matrix entry as %q at line %d",
+					down.job.ID, down.name, suffixed.job.ID, suffixed.name, suffixed.step.Line)
+				prefix := suffixed.name[:strings.Index(suffixed.name, "${{")]
+				finding.Suggestion = a.lang.Sprintf("Download with pattern: %s* and merge-multiple: true to get the artifacts of every matrix entry", prefix)
+			case strings.Contains(down.name, "${{"):
+				continue
+			default:
+				finding.Message = a.lang.Sprintf("Job %s downloads artifact %q, which no job of this workflow uploads", down.job.ID, down.name)
+				finding.Suggestion = a.lang.T("Check the name against the upload-artifact steps, or set run-id to download it from another workflow run")
+			}
+			findings = append(findings, finding)
+			continue
+		}
+
+		upstream := upstreamJobs(wf, down.job)
+		ordered := false
+		for _, up := range matched {
+			if upstream[up.job.ID] || (up.job == down.job && up.step.Index < down.step.Index) {
+				ordered = true
+				break
+			}
+		}
+		if ordered {
+			continue
+		}
+		up := matched[0]
+		if up.job == down.job {
+			finding.Message = a.lang.Sprintf("Job %s downloads artifact %q before uploading it at line %d", down.job.ID, down.name, up.step.Line)
+			finding.Suggestion = a.lang.T("Move the download after the upload, or drop it and use the files directly")
+		} else {
+			finding.Message = a.lang.Sprintf("Job %s downloads artifact %q uploaded by job %s at line %d, but doesn't need that job, so the artifact may not exist yet",
+				down.job.ID, down.name, up.job.ID, up.step.Line)
+			finding.Suggestion = a.lang.Sprintf("Add %s to the needs of job %s", up.job.ID, down.job.ID)
+		}
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
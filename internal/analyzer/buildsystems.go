@@ -0,0 +1,152 @@
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Files that mark a repository as built with each monorepo build system
+var buildSystemFiles = map[string][]string{
+	"turborepo": {"turbo.json", "turbo.jsonc"},
+	"nx":        {"nx.json"},
+	"bazel":     {"MODULE.bazel", "WORKSPACE", "WORKSPACE.bazel", ".bazelversion"},
+}
+
+// Commands or actions in workflows or logs that run each build system
+var buildSystemHints = map[string][]string{
+	"turborepo": {"turbo run", "npx turbo", "pnpm turbo", "yarn turbo", "bunx turbo"},
+	"nx":        {"npx nx", "nx affected", "nx run-many", "pnpm nx", "yarn nx", "nrwl/nx-set-shas"},
+	"bazel":     {"bazel build", "bazel test", "bazelisk", "bazel-contrib/setup-bazel", "bazelbuild/setup-bazelisk"},
+}
+
+// Settings showing a build system already shares its cache beyond one runner
+var remoteCacheHints = map[string][]string{
+	"turborepo": {"TURBO_TOKEN", "TURBO_API", "caching-for-turbo", "turbo-cache"},
+	"nx":        {"NX_CLOUD_ACCESS_TOKEN", "NX_CLOUD_AUTH_TOKEN", "nx-cloud", "NX_SELF_HOSTED_REMOTE_CACHE"},
+	"bazel":     {"--remote_cache", "--remote_executor", "--disk_cache", "disk-cache:"},
+}
+
+// buildSystemCacheStrategies recommend the remote cache of each build system,
+// hosted by its vendor or backed by the GitHub Actions cache
+var buildSystemCacheStrategies = map[string][]models.CacheRecommendation{
+	"turborepo": {
+		{
+			Path:        "Turborepo remote cache",
+			Description: "Share Turborepo task outputs through Vercel Remote Cache",
+			Impact:      "Tasks whose inputs didn't change replay their outputs instead of running again, across jobs, branches and developer machines; actions/cache on .turbo only restores one whole snapshot per key",
+			Example: `    env:
+      TURBO_TOKEN: ${{ secrets.TURBO_TOKEN }}
+      TURBO_TEAM: ${{ vars.TURBO_TEAM }}
+    steps:
+      - run: npx turbo run build test`,
+		},
+		{
+			Path:        "Turborepo remote cache on GitHub",
+			Description: "Serve Turborepo's remote cache from the GitHub Actions cache",
+			Impact:      "Gives per-task remote caching without an external account; entries count toward the repository's Actions cache quota",
+			Example: `      - uses: rharkor/caching-for-turbo@v1
+      - run: npx turbo run build test`,
+		},
+	},
+	"nx": {
+		{
+			Path:        "Nx Cloud remote cache",
+			Description: "Share Nx task outputs through Nx Cloud",
+			Impact:      "Tasks whose inputs didn't change replay their outputs instead of running again, across jobs, branches and developer machines; actions/cache on .nx/cache only restores one whole snapshot per key",
+			Example: `    env:
+      NX_CLOUD_ACCESS_TOKEN: ${{ secrets.NX_CLOUD_ACCESS_TOKEN }}
+    steps:
+      - uses: nrwl/nx-set-shas@v4
+      - run: npx nx affected -t build test`,
+		},
+		{
+			Path:        ".nx/cache",
+			Description: "Restore the Nx local cache from the GitHub Actions cache",
+			Impact:      "Without a remote cache, restoring the latest cache of the branch or main still skips unchanged tasks; nx affected skips unaffected projects altogether",
+			Example: `      - uses: actions/cache@v4
+        with:
+          path: .nx/cache
+          key: ${{ runner.os }}-nx-${{ github.sha }}
+          restore-keys: |
+            ${{ runner.os }}-nx-
+      - uses: nrwl/nx-set-shas@v4
+      - run: npx nx affected -t build test`,
+		},
+	},
+	"bazel": {
+		{
+			Path:        "Bazel remote cache",
+			Description: "Point Bazel at a remote cache such as BuildBuddy, EngFlow or a bazel-remote server",
+			Impact:      "Actions are looked up by their inputs' digest, so only changed targets rebuild, in every job; caching the output base with actions/cache is large and invalidated as a whole",
+			Example: `      - run: |
+          bazel test //... \
+            --remote_cache=grpcs://remote.buildbuddy.io \
+            --remote_header=x-buildbuddy-api-key=${{ secrets.BUILDBUDDY_API_KEY }}`,
+		},
+		{
+			Path:        "Bazel disk and repository cache",
+			Description: "Keep Bazel's disk and repository caches in the GitHub Actions cache with setup-bazel",
+			Impact:      "Without a remote cache, the disk cache is saved per workflow and restored by digest, so unchanged targets don't rebuild",
+			Example: `      - uses: bazel-contrib/setup-bazel@0.15.0
+        with:
+          bazelisk-cache: true
+          disk-cache: ${{ github.workflow }}
+          repository-cache: true
+      - run: bazel test //...`,
+		},
+	},
+}
+
+// detectBuildSystems returns the monorepo build systems the repository root or
+// the workflow's texts show, sorted, leaving out those that already share
+// their cache remotely
+func detectBuildSystems(tree []string, texts []string) []string {
+	rootFiles := make(map[string]bool)
+	for _, p := range tree {
+		if dir, file := path.Split(p); dir == "" {
+			rootFiles[file] = true
+		}
+	}
+	mentions := func(hints []string) bool {
+		for _, hint := range hints {
+			for _, text := range texts {
+				if strings.Contains(text, hint) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	var systems []string
+	for system, files := range buildSystemFiles {
+		found := mentions(buildSystemHints[system])
+		for _, file := range files {
+			found = found || rootFiles[file]
+		}
+		if found && !mentions(remoteCacheHints[system]) {
+			systems = append(systems, system)
+		}
+	}
+	sort.Strings(systems)
+	return systems
+}
+
+// buildSystemCaches returns the remote cache recommendations of the build
+// systems detected
+func (a *Analyzer) buildSystemCaches(tree []string, texts []string) []models.CacheRecommendation {
+	var recs []models.CacheRecommendation
+	for _, system := range detectBuildSystems(tree, texts) {
+		a.debugLog("Detected build system without a remote cache: %s", system)
+		for _, rec := range buildSystemCacheStrategies[system] {
+			rec.Path = a.lang.T(rec.Path)
+			rec.Description = a.lang.T(rec.Description)
+			rec.Impact = a.lang.T(rec.Impact)
+			recs = append(recs, rec)
+		}
+	}
+	return recs
+}
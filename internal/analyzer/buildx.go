@@ -0,0 +1,101 @@
+package analyzer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// slowBuildxSetup is the average setup-buildx-action time from which setting
+// the builder up differently is suggested
+const slowBuildxSetup = 15 * time.Second
+
+// buildsImage reports whether a step builds a container image
+func buildsImage(step *workflow.Step) bool {
+	return usesAction(step, "docker/build-push-action") || usesAction(step, "docker/bake-action") ||
+		strings.Contains(step.Run, "docker build") || strings.Contains(step.Run, "buildx build") ||
+		strings.Contains(step.Run, "buildx bake")
+}
+
+// needsEmulation reports whether a job builds or runs anything for a platform
+// other than its runner's, which needs QEMU
+func needsEmulation(job *workflow.Job) bool {
+	for _, step := range job.Steps {
+		for _, p := range stepPlatforms(step) {
+			if emulated(job, p) {
+				return true
+			}
+		}
+		// Platforms of bake files and of docker run aren't in the workflow
+		if usesAction(step, "docker/bake-action") || strings.Contains(step.Run, "buildx bake") ||
+			(!buildsImage(step) && (strings.Contains(step.Run, "--platform") || strings.Contains(step.Run, "qemu"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBuilderSetup measures the time docker/setup-qemu-action and
+// docker/setup-buildx-action take per run. QEMU set up for jobs that only build
+// for their runner's own platform is wasted, and a slow Buildx setup can be cut
+// with the docker driver or, on self-hosted runners, a persistent builder.
+func (a *Analyzer) checkBuilderSetup(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		builds := false
+		for _, step := range job.Steps {
+			builds = builds || buildsImage(step)
+		}
+		if !builds {
+			continue
+		}
+
+		for _, step := range job.Steps {
+			setup := average(stepDurations(samples, job, step))
+			switch {
+			case usesAction(step, "docker/setup-qemu-action") && !needsEmulation(job):
+				finding := models.Finding{
+					Category:   "docker",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Job %s sets up QEMU but only builds for the platform of its runner", job.ID),
+					Suggestion: a.lang.T("Remove the setup-qemu-action step; QEMU is only needed to build or run images for another CPU architecture"),
+				}
+				if setup > 0 {
+					finding.Message += " " + a.lang.Sprintf("(%v per run)", setup.Round(time.Second))
+					finding.URL = jobURL(samples, job)
+					finding.EstimatedSavings = a.projectSavings(samples, setup)
+				}
+				findings = append(findings, finding)
+
+			case usesAction(step, "docker/setup-buildx-action") && setup >= slowBuildxSetup:
+				finding := models.Finding{
+					Category: "docker",
+					Severity: models.SeverityInfo,
+					File:     path,
+					Line:     step.Line,
+					URL:      jobURL(samples, job),
+					Message:  a.lang.Sprintf("Setting up Buildx in job %s takes %v per run", job.ID, setup.Round(time.Second)),
+				}
+				if selfHosted(job) {
+					finding.Suggestion = a.lang.T("Create a persistent builder on the runner once (docker buildx create --name ci --driver docker-container --bootstrap) and select it with name: ci, keep-state: true and cleanup: false, so the builder and its layer cache survive between jobs")
+					finding.Example = `      - uses: docker/setup-buildx-action@v3
+        with:
+          name: ci
+          keep-state: true
+          cleanup: false`
+				} else {
+					finding.Suggestion = a.lang.T("Builds without multi-platform output or cache export can use driver: docker, which needs no BuildKit container; otherwise pin version: so the buildx binary is reused from the tool cache")
+					finding.Example = `      - uses: docker/setup-buildx-action@v3
+        with:
+          driver: docker`
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
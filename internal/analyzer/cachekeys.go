@@ -0,0 +1,208 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// keyExpression matches a ${{ ... }} expression in a cache key
+	keyExpression = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+	// volatileKeyPart matches key parts that change on every run, so the key never repeats
+	volatileKeyPart = regexp.MustCompile(`(?i)github\.run_(?:id|number|attempt)|(?:steps\.[\w-]+\.outputs|env)\.[\w-]*(?:date|time|stamp)`)
+	// keySeparators matches the separators left where expressions were removed from a key
+	keySeparators = regexp.MustCompile(`[-_.\s]+`)
+	// stableKeyPart matches expressions that don't change with the cached content
+	stableKeyPart = regexp.MustCompile(`^(?:runner\.(?:os|arch|name)|matrix\.[\w.-]+|github\.(?:job|workflow|ref|ref_name|head_ref|base_ref|repository|event_name))$`)
+
+	// actions/cache reports each lookup and save in the job log
+	cacheRestored = regexp.MustCompile(`Cache restored from key: (\S+)`)
+	cacheNotFound = regexp.MustCompile(`Cache not found for input keys: (.+)`)
+	cacheExactHit = regexp.MustCompile(`Cache hit occurred on the primary key (\S+?),? not saving cache`)
+	cacheSaved    = regexp.MustCompile(`Cache saved with key: (\S+)`)
+)
+
+// cacheLockfiles maps cached paths to the lockfile that decides their content
+var cacheLockfiles = []struct {
+	path, lockfile string
+}{
+	{"pnpm", "**/pnpm-lock.yaml"},
+	{"yarn", "**/yarn.lock"},
+	{"node_modules", "**/package-lock.json"},
+	{".npm", "**/package-lock.json"},
+	{"poetry", "**/poetry.lock"},
+	{"pip", "**/requirements*.txt"},
+	{"go-build", "**/go.sum"},
+	{"go/pkg/mod", "**/go.sum"},
+	{".m2", "**/pom.xml"},
+	{".gradle", "**/*.gradle*"},
+	{".cargo", "**/Cargo.lock"},
+	{"target", "**/Cargo.lock"},
+	{"vendor/bundle", "**/Gemfile.lock"},
+	{"composer", "**/composer.lock"},
+	{".nuget", "**/packages.lock.json"},
+}
+
+// cacheKeyStats counts what actions/cache logged for one key across runs
+type cacheKeyStats struct {
+	restored  int // restored from the primary key or a restore key
+	exactHits int // restored from the primary key, so nothing was saved
+	misses    int
+	saves     int
+	missURL   string // a run that missed
+	hitURL    string // a run that restored the key without saving
+}
+
+// cacheKeyPattern turns a key template into a pattern matching its evaluated keys.
+// ok is false when the literal parts are too short to tell keys apart.
+func cacheKeyPattern(key string) (*regexp.Regexp, bool) {
+	parts := keyExpression.Split(key, -1)
+	literal := 0
+	for i, part := range parts {
+		literal += len(strings.TrimSpace(part))
+		parts[i] = regexp.QuoteMeta(strings.TrimSpace(part))
+	}
+	if literal < 3 {
+		return nil, false
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, `\S*`) + "$"), true
+}
+
+// cacheKeyEvidence tallies the lookups and saves of keys matching pattern in the logs
+func cacheKeyEvidence(samples []runSample, pattern *regexp.Regexp) cacheKeyStats {
+	var stats cacheKeyStats
+	for _, sample := range samples {
+		for _, line := range strings.Split(sample.Logs, "\n") {
+			_, message, _ := logTime(strings.TrimRight(line, "\r"))
+			if m := cacheExactHit.FindStringSubmatch(message); m != nil && pattern.MatchString(m[1]) {
+				stats.exactHits++
+				if stats.hitURL == "" {
+					stats.hitURL = sample.Run.GetHTMLURL()
+				}
+			} else if m := cacheRestored.FindStringSubmatch(message); m != nil && pattern.MatchString(m[1]) {
+				stats.restored++
+			} else if m := cacheSaved.FindStringSubmatch(message); m != nil && pattern.MatchString(m[1]) {
+				stats.saves++
+			} else if m := cacheNotFound.FindStringSubmatch(message); m != nil {
+				// The primary key comes first, followed by the restore keys
+				primary, _, _ := strings.Cut(m[1], ",")
+				if pattern.MatchString(strings.TrimSpace(primary)) {
+					stats.misses++
+					if stats.missURL == "" {
+						stats.missURL = sample.Run.GetHTMLURL()
+					}
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// contentInsensitiveKey reports whether every part of a key stays the same when
+// the cached content changes, e.g. "${{ runner.os }}-node-modules"
+func contentInsensitiveKey(key string) bool {
+	for _, m := range keyExpression.FindAllStringSubmatch(key, -1) {
+		if !stableKeyPart.MatchString(m[1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// suggestedCacheKey returns a key on the hash of the lockfile that decides the
+// cached content, with restore keys falling back to the latest cache
+func suggestedCacheKey(step *workflow.Step) string {
+	path := step.With["path"]
+	lockfile := "**/<lockfile>"
+	for _, candidate := range cacheLockfiles {
+		if strings.Contains(path, candidate.path) {
+			lockfile = candidate.lockfile
+			break
+		}
+	}
+
+	// Keep the literal name of the original key, without its expressions
+	name := strings.Trim(keySeparators.ReplaceAllString(keyExpression.ReplaceAllString(step.With["key"], "-"), "-"), "-")
+	if name == "" {
+		name = "deps"
+	}
+
+	return fmt.Sprintf(`      - uses: %s
+        with:
+          path: %s
+          key: ${{ runner.os }}-%s-${{ hashFiles('%s') }}
+          restore-keys: |
+            ${{ runner.os }}-%s-`, step.Uses, strings.TrimSpace(strings.ReplaceAll(path, "\n", "\n                ")), name, lockfile, name)
+}
+
+// checkCacheKeys reviews how specific actions/cache keys are. Keys that change on
+// every run never hit, while keys that ignore the cached content keep restoring
+// stale data because a saved key is never overwritten. Cache lookups in the logs
+// confirm both.
+func (a *Analyzer) checkCacheKeys(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			if !usesAction(step, "actions/cache") && !usesAction(step, "actions/cache/restore") {
+				continue
+			}
+			key := strings.TrimSpace(step.With["key"])
+			if key == "" {
+				continue
+			}
+			hasRestoreKeys := strings.TrimSpace(step.With["restore-keys"]) != ""
+
+			var stats cacheKeyStats
+			if pattern, ok := cacheKeyPattern(key); ok {
+				stats = cacheKeyEvidence(samples, pattern)
+			}
+
+			switch {
+			case !hasRestoreKeys && stats.restored == 0 && stats.exactHits == 0 &&
+				(volatileKeyPart.MatchString(key) || (strings.Contains(key, "github.sha") && stats.misses > 0)):
+				// Keys on the commit are fine for handing files to later jobs of the same run,
+				// which the logs would show as restores
+				finding := models.Finding{
+					Category:   "performance",
+					Severity:   models.SeverityWarning,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Cache key %q in job %s changes on every run, so the cache is never restored", key, job.ID),
+					Suggestion: a.lang.T("Key the cache on the hash of the lockfile instead of run IDs, timestamps or commits, and add restore-keys so a changed lockfile still restores the latest cache"),
+					Example:    suggestedCacheKey(step),
+				}
+				if stats.misses > 0 {
+					finding.Message += "; " + a.lang.Sprintf("it missed in %d lookups and was never restored", stats.misses)
+					finding.URL = stats.missURL
+				}
+				// A key on the commit alone is only suspected from the misses in the logs
+				if !volatileKeyPart.MatchString(key) {
+					finding.Confidence = logConfidence(stats.misses, samples)
+				}
+				findings = append(findings, finding)
+
+			case contentInsensitiveKey(key):
+				finding := models.Finding{
+					Category:   "performance",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Cache key %q in job %s doesn't change with the cached content, so it keeps restoring stale data", key, job.ID),
+					Suggestion: a.lang.T("A saved cache key is never overwritten, so add the hash of the lockfile to the key to save a fresh cache whenever dependencies change"),
+					Example:    suggestedCacheKey(step),
+				}
+				if stats.exactHits > 0 && stats.saves == 0 {
+					finding.Severity = models.SeverityWarning
+					finding.Message += "; " + a.lang.Sprintf("it was restored in %d lookups and never saved again", stats.exactHits)
+					finding.URL = stats.hitURL
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// minCacheSaves is how many runs must have saved a cache before its save time is weighed
+const minCacheSaves = 2
+
+// cacheTimes is the time an actions/cache step spent restoring and saving across runs
+type cacheTimes struct {
+	hitJobs, missJobs []time.Duration // durations of the job in runs that restored the cache and that didn't
+	restores          []time.Duration // durations of the cache step in runs that restored it
+	saveTime          time.Duration   // total duration of the post steps that saved the cache
+	saves             int
+}
+
+// measureCacheTimes splits the sampled runs of job by whether the cache of step
+// was restored, as the logs show, and adds up the time its post step spent saving
+func measureCacheTimes(samples []runSample, job *workflow.Job, step *workflow.Step) (cacheTimes, bool) {
+	var times cacheTimes
+	pattern, ok := cacheKeyPattern(strings.TrimSpace(step.With["key"]))
+	if !ok {
+		return times, false
+	}
+	post := "Post " + apiStepName(step)
+	for _, sample := range samples {
+		run := []runSample{sample}
+		stats := cacheKeyEvidence(run, pattern)
+		if stats.saves > 0 {
+			for _, d := range namedStepDurations(run, job, post) {
+				times.saveTime += d
+			}
+			times.saves++
+		}
+		jobTime := average(jobDurations(run, job))
+		if jobTime == 0 {
+			continue
+		}
+		// Matrix jobs sharing the key may both hit and miss in one run
+		switch restored := stats.restored + stats.exactHits; {
+		case restored > 0 && stats.misses == 0:
+			times.hitJobs = append(times.hitJobs, jobTime)
+			times.restores = append(times.restores, stepDurations(run, job, step)...)
+		case restored == 0 && stats.misses > 0:
+			times.missJobs = append(times.missJobs, jobTime)
+		}
+	}
+	return times, true
+}
+
+// checkCacheTimes weighs the time actions/cache steps spend saving their cache
+// against the time restoring it saves, measured as the difference between the
+// job's duration in runs that missed and runs that restored the cache. A large
+// cache saved on most runs, such as node_modules, can cost more than it saves.
+func (a *Analyzer) checkCacheTimes(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			if !usesAction(step, "actions/cache") {
+				continue
+			}
+			times, ok := measureCacheTimes(samples, job, step)
+			if !ok || times.saves < minCacheSaves || len(times.hitJobs) == 0 || len(times.missJobs) == 0 {
+				continue
+			}
+			hitJob, missJob := average(times.hitJobs), average(times.missJobs)
+			saved := max(missJob-hitJob, 0) * time.Duration(len(times.hitJobs))
+			if times.saveTime <= saved {
+				continue
+			}
+
+			key := strings.TrimSpace(step.With["key"])
+			finding := models.Finding{
+				Category: "performance",
+				Severity: models.SeverityInfo,
+				File:     path,
+				Line:     step.Line,
+				URL:      jobURL(samples, job),
+				Message: a.lang.Sprintf("Saving cache %q in job %s took %v across %d runs, more than the %v its %d restores saved",
+					key, job.ID, times.saveTime.Round(time.Second), times.saves, saved.Round(time.Second), len(times.hitJobs)),
+				Confidence: logConfidence(len(times.hitJobs)+len(times.missJobs), samples),
+			}
+			finding.Message += "; " + a.lang.Sprintf("the job averages %v when the cache misses and %v when it's restored, %v of which is the restore",
+				missJob.Round(time.Second), hitJob.Round(time.Second), average(times.restores).Round(time.Second))
+			if strings.Contains(step.With["path"], "node_modules") {
+				finding.Suggestion = a.lang.T("Cache the package manager's download cache, e.g. ~/.npm, instead of node_modules; it's smaller to save and restore, and npm ci rebuilds node_modules from it quickly")
+			} else {
+				finding.Suggestion = a.lang.T("Cache fewer files: narrow path to downloaded dependencies rather than build outputs, and key it on the lockfile hash so it's only saved when dependencies change")
+			}
+			finding.EstimatedSavings = a.projectSavings(samples, (times.saveTime-saved)/time.Duration(len(samples)))
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
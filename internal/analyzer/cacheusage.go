@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+const (
+	// cacheLimitBytes is the per-repository Actions cache limit; beyond it the least
+	// recently used caches are evicted
+	cacheLimitBytes = 10 << 30
+	// cacheNearLimit is the share of the limit from which eviction becomes a concern
+	cacheNearLimit = 0.8
+	// staleCacheAge is how long a cache may go unused before it counts as stale.
+	// GitHub itself removes caches after 7 days without access.
+	staleCacheAge = 3 * 24 * time.Hour
+	// maxListedCaches caps the cache entries fetched for the breakdown
+	maxListedCaches = 1000
+	// excessiveVariants is the number of entries under one key prefix that suggests a per-run key
+	excessiveVariants = 10
+	// maxExcessiveKeys is how many key prefixes with excessive variants are reported
+	maxExcessiveKeys = 5
+)
+
+// Hashes (hashFiles, github.sha) and run IDs that make up the variable end of a cache key
+var cacheKeySuffix = regexp.MustCompile(`[-_.]?(?:[0-9a-fA-F]{16,}|\d{6,})$`)
+
+// cacheKeyPrefix strips the hash and run ID parts from the end of a cache key
+func cacheKeyPrefix(key string) string {
+	for {
+		trimmed := cacheKeySuffix.ReplaceAllString(key, "")
+		if trimmed == key || trimmed == "" {
+			return key
+		}
+		key = trimmed
+	}
+}
+
+// analyzeCacheUsage reports the repository's Actions cache size against the 10 GB
+// limit, with stale entries, pull request caches and keys saved in many variants
+func (a *Analyzer) analyzeCacheUsage(ctx context.Context, owner, repo string, report *models.PerformanceReport) {
+	bytes, count, err := a.client.GetCacheUsage(ctx, owner, repo)
+	if err != nil {
+		a.debugLog("Error getting cache usage: %v", err)
+		return
+	}
+	if count == 0 {
+		return
+	}
+	caches, err := a.client.ListCaches(ctx, owner, repo, maxListedCaches)
+	if err != nil {
+		a.debugLog("Error listing caches: %v", err)
+	}
+
+	usage := &models.CacheUsage{Bytes: bytes, Count: count, LimitBytes: cacheLimitBytes}
+	prefixes := make(map[string]*models.CacheKeyVariants)
+	now := time.Now()
+	for _, cache := range caches {
+		if now.Sub(cache.LastAccessedAt) > staleCacheAge {
+			usage.StaleBytes += cache.SizeInBytes
+			usage.StaleCount++
+		}
+		if strings.HasPrefix(cache.Ref, "refs/pull/") {
+			usage.PullRequestBytes += cache.SizeInBytes
+		}
+		prefix := cacheKeyPrefix(cache.Key)
+		if prefixes[prefix] == nil {
+			prefixes[prefix] = &models.CacheKeyVariants{Prefix: prefix}
+		}
+		prefixes[prefix].Variants++
+		prefixes[prefix].Bytes += cache.SizeInBytes
+	}
+
+	for _, variants := range prefixes {
+		if variants.Variants > excessiveVariants {
+			usage.ExcessiveKeys = append(usage.ExcessiveKeys, *variants)
+		}
+	}
+	sort.Slice(usage.ExcessiveKeys, func(i, j int) bool {
+		return usage.ExcessiveKeys[i].Bytes > usage.ExcessiveKeys[j].Bytes
+	})
+	if len(usage.ExcessiveKeys) > maxExcessiveKeys {
+		usage.ExcessiveKeys = usage.ExcessiveKeys[:maxExcessiveKeys]
+	}
+
+	usage.Recommendations = a.cacheUsageAdvice(usage)
+	report.CacheUsage = usage
+}
+
+// cacheUsageAdvice turns the cache breakdown into cleanup recommendations
+func (a *Analyzer) cacheUsageAdvice(usage *models.CacheUsage) []string {
+	var advice []string
+	share := float64(usage.Bytes) / float64(usage.LimitBytes)
+
+	if share >= cacheNearLimit {
+		advice = append(advice, a.lang.Sprintf("Cache usage is at %.0f%% of the 10 GB limit. Beyond it, GitHub evicts the least recently used caches, which can include ones every run restores", share*100))
+	}
+	if usage.StaleCount > 0 && (share >= cacheNearLimit || usage.StaleBytes*10 > usage.Bytes) {
+		advice = append(advice, a.lang.Sprintf("%d caches (%s) haven't been used in 3 days. Delete them with gh cache delete or a scheduled cleanup workflow so they don't push out caches in active use",
+			usage.StaleCount, models.FormatBytes(usage.StaleBytes)))
+	}
+	if usage.PullRequestBytes*4 > usage.Bytes {
+		advice = append(advice, a.lang.Sprintf("Pull request caches take %s but can only be restored by the pull request that saved them. Save caches on the default branch only, using actions/cache/restore in pull requests and actions/cache/save on pushes",
+			models.FormatBytes(usage.PullRequestBytes)))
+	}
+	for _, key := range usage.ExcessiveKeys {
+		advice = append(advice, a.lang.Sprintf("Key %q is saved in %d variants (%s). Drop per-run values such as github.sha or github.run_id from the key and rely on restore-keys",
+			key.Prefix, key.Variants, models.FormatBytes(key.Bytes)))
+	}
+	return advice
+}
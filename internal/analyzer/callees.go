@@ -0,0 +1,206 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// DefaultAnalyzeDepth resolves the reusable workflows and actions a workflow
+// calls directly, but not what they call in turn
+const DefaultAnalyzeDepth = 1
+
+// MaxAnalyzeDepth bounds analyze_depth
+const MaxAnalyzeDepth = 5
+
+// maxCalleeFetches caps the files fetched to resolve called workflows and actions
+const maxCalleeFetches = 30
+
+// WithAnalyzeDepth sets how many levels of called reusable workflows, composite
+// actions and local actions are resolved and checked; 0 only checks the workflow
+func WithAnalyzeDepth(depth int) Option {
+	return func(a *Analyzer) {
+		a.analyzeDepth = depth
+	}
+}
+
+// callee is a reusable workflow or an action called by a workflow or a composite
+// action. Local references have no owner, as they resolve against the analyzed
+// repository's default branch.
+type callee struct {
+	owner, repo string
+	path        string // the workflow file, or the action's directory
+	ref         string
+	workflow    bool
+}
+
+// name renders the callee as it appears in findings: the repository path of a
+// local callee, or owner/repo/path@ref
+func (c callee) name(file string) string {
+	if c.owner == "" {
+		return file
+	}
+	return fmt.Sprintf("%s/%s/%s@%s", c.owner, c.repo, file, c.ref)
+}
+
+// parseCallee resolves a uses: reference. ok is false for Docker images and
+// references that can't be resolved, such as expressions.
+func parseCallee(uses string, reusable bool) (callee, bool) {
+	if strings.Contains(uses, "${{") || strings.HasPrefix(uses, "docker://") {
+		return callee{}, false
+	}
+	if local, ok := strings.CutPrefix(uses, "./"); ok {
+		return callee{path: path.Clean(local), workflow: reusable}, true
+	}
+	name, ref, found := strings.Cut(uses, "@")
+	parts := strings.SplitN(name, "/", 3)
+	if !found || len(parts) < 2 {
+		return callee{}, false
+	}
+	c := callee{owner: parts[0], repo: parts[1], ref: ref, workflow: reusable}
+	if len(parts) == 3 {
+		c.path = parts[2]
+	}
+	if reusable && c.path == "" {
+		return callee{}, false
+	}
+	return c, true
+}
+
+// workflowCallees lists the reusable workflows and actions a workflow calls
+func workflowCallees(wf *workflow.Workflow) []callee {
+	var callees []callee
+	for _, job := range wf.Jobs {
+		if c, ok := parseCallee(job.Uses, true); job.Uses != "" && ok {
+			callees = append(callees, c)
+		}
+		callees = append(callees, stepCallees(job.Steps)...)
+	}
+	return callees
+}
+
+// stepCallees lists the actions a list of steps uses
+func stepCallees(steps []*workflow.Step) []callee {
+	var callees []callee
+	for _, step := range steps {
+		if c, ok := parseCallee(step.Uses, false); step.Uses != "" && ok {
+			callees = append(callees, c)
+		}
+	}
+	return callees
+}
+
+// calleeFetches is the most files resolving callees to depth fetches
+func calleeFetches(depth int) int {
+	if depth <= 0 {
+		return 0
+	}
+	return maxCalleeFetches
+}
+
+// fetchCallee returns the file of a callee and its content: the workflow file,
+// or the action's action.yml or action.yaml
+func (a *Analyzer) fetchCallee(ctx context.Context, owner, repo string, c callee) (file, content string, err error) {
+	get := func(file string) (string, error) {
+		if c.owner == "" {
+			return a.client.GetFileContent(ctx, owner, repo, file)
+		}
+		return a.client.GetFileContentAtRef(ctx, c.owner, c.repo, file, c.ref)
+	}
+	if c.workflow {
+		content, err = get(c.path)
+		return c.path, content, err
+	}
+	for _, name := range []string{"action.yml", "action.yaml"} {
+		file = path.Join(c.path, name)
+		if content, err = get(file); err == nil {
+			return file, content, nil
+		}
+	}
+	return "", "", err
+}
+
+// inspectCallees resolves the reusable workflows and actions a workflow calls,
+// level by level up to the analyze depth, and checks them too. Called workflows
+// get every model-based check; composite actions get the checks that apply to
+// steps. Findings in another repository link to the file at the called ref.
+func (a *Analyzer) inspectCallees(ctx context.Context, owner, repo string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	seen := make(map[callee]bool)
+	fetches := 0
+
+	level := workflowCallees(wf)
+	for depth := 1; depth <= a.analyzeDepth && len(level) > 0; depth++ {
+		var next []callee
+		for _, c := range level {
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			if fetches == maxCalleeFetches {
+				a.debugLog("Stopped resolving called workflows and actions after %d files", maxCalleeFetches)
+				return findings
+			}
+			fetches++
+
+			file, content, err := a.fetchCallee(ctx, owner, repo, c)
+			if err != nil {
+				if ctx.Err() != nil {
+					return findings
+				}
+				a.debugLog("Skipping %s: %v", c.name(c.path), err)
+				continue
+			}
+
+			var found []models.Finding
+			if c.workflow {
+				found = a.inspectWorkflow(ctx, c.name(file), content, nil)
+				if called, err := workflow.Parse(content); err == nil {
+					next = append(next, workflowCallees(called)...)
+				}
+			} else if action, err := workflow.ParseAction(content); err == nil && action.Composite() {
+				found = a.inspectCompositeAction(c.name(file), action)
+				next = append(next, stepCallees(action.Steps)...)
+			}
+			if c.owner != "" {
+				for i := range found {
+					if found[i].URL == "" {
+						found[i].URL = blobURL(c.owner, c.repo, c.ref, file, found[i].Line)
+					}
+				}
+			}
+			findings = append(findings, found...)
+		}
+		level = next
+	}
+	return findings
+}
+
+// inspectCompositeAction runs the step checks over a composite action. Its steps
+// are checked as one job; composite actions can't declare permissions, as they
+// run with the token of the calling job.
+func (a *Analyzer) inspectCompositeAction(path string, action *workflow.Action) []models.Finding {
+	id := action.Name
+	if id == "" {
+		id = path
+	}
+	wf := &workflow.Workflow{
+		Name:     action.Name,
+		HasPerms: true,
+		Jobs:     []*workflow.Job{{ID: id, Steps: action.Steps}},
+		Root:     action.Root,
+	}
+	checks := []workflowCheck{a.checkSetupCache, a.checkCredentials}
+	if a.policy != nil {
+		checks = append(checks, a.checkPolicy)
+	}
+	var findings []models.Finding
+	for _, check := range checks {
+		findings = append(findings, check(path, wf)...)
+	}
+	return findings
+}
@@ -0,0 +1,263 @@
+package analyzer
+
+import (
+	"context"
+	"path"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+const (
+	// maxRepoWorkflows caps the workflow files fetched to find workflow_run links and deployments
+	maxRepoWorkflows = 30
+	// maxChainDepth is the deepest workflow_run chain GitHub runs
+	maxChainDepth = 3
+)
+
+// repoWorkflow is one of the repository's workflow files
+type repoWorkflow struct {
+	path        string
+	name        string // what workflow_run triggers refer to; the path when unnamed
+	triggeredBy []string
+	parsed      *workflow.Workflow
+}
+
+// file returns the workflow's file name, as the runs API expects it
+func (w *repoWorkflow) file() string {
+	return path.Base(w.path)
+}
+
+// chainNode is a workflow in the chain with the workflows it triggers
+type chainNode struct {
+	workflow *repoWorkflow
+	depth    int
+	children []*chainNode
+}
+
+// loadWorkflows fetches and parses the repository's workflow files
+func (a *Analyzer) loadWorkflows(ctx context.Context, owner, repo string) []*repoWorkflow {
+	tree, err := a.client.GetTree(ctx, owner, repo)
+	if err != nil {
+		a.debugLog("Error listing repository tree: %v", err)
+		return nil
+	}
+
+	var workflows []*repoWorkflow
+	for _, p := range tree {
+		if path.Dir(p) != ".github/workflows" || (path.Ext(p) != ".yml" && path.Ext(p) != ".yaml") {
+			continue
+		}
+		if len(workflows) == maxRepoWorkflows {
+			a.debugLog("Only the first %d workflow files are checked", maxRepoWorkflows)
+			break
+		}
+		content, err := a.client.GetFileContent(ctx, owner, repo, p)
+		if err != nil {
+			continue
+		}
+		wf, err := workflow.Parse(content)
+		if err != nil {
+			continue
+		}
+		name := wf.Name
+		if name == "" {
+			name = p
+		}
+		workflows = append(workflows, &repoWorkflow{path: p, name: name, triggeredBy: wf.TriggeredBy, parsed: wf})
+	}
+	return workflows
+}
+
+// buildChain finds the workflow that starts the analyzed workflow's chain and the
+// workflows it triggers, directly or through others. It returns nil when the
+// analyzed workflow isn't linked to any other by workflow_run.
+func buildChain(workflows []*repoWorkflow, workflowFile string) *chainNode {
+	var self *repoWorkflow
+	for _, w := range workflows {
+		if w.file() == path.Base(workflowFile) {
+			self = w
+		}
+	}
+	if self == nil {
+		return nil
+	}
+
+	byName := make(map[string]*repoWorkflow)
+	for _, w := range workflows {
+		byName[w.name] = w
+	}
+
+	// Walk up to the workflow that isn't triggered by another one
+	root := self
+	for depth := 0; depth < maxChainDepth; depth++ {
+		var parent *repoWorkflow
+		for _, name := range root.triggeredBy {
+			if byName[name] != nil && byName[name] != root {
+				parent = byName[name]
+				break
+			}
+		}
+		if parent == nil {
+			break
+		}
+		root = parent
+	}
+
+	var grow func(w *repoWorkflow, depth int) *chainNode
+	grow = func(w *repoWorkflow, depth int) *chainNode {
+		node := &chainNode{workflow: w, depth: depth}
+		if depth == maxChainDepth {
+			return node
+		}
+		for _, other := range workflows {
+			for _, name := range other.triggeredBy {
+				if name == w.name && other != w {
+					node.children = append(node.children, grow(other, depth+1))
+					break
+				}
+			}
+		}
+		return node
+	}
+
+	chain := grow(root, 0)
+	if len(chain.children) == 0 {
+		return nil
+	}
+	return chain
+}
+
+// analyzeWorkflowChain follows workflow_run triggers from the analyzed workflow to
+// measure the lead time from the first workflow's start to the last one's completion
+func (a *Analyzer) analyzeWorkflowChain(ctx context.Context, owner, repo string, report *models.PerformanceReport, workflows []*repoWorkflow, samples []runSample) {
+	chain := buildChain(workflows, report.WorkflowFile)
+	if chain == nil {
+		return
+	}
+
+	// Runs of every workflow in the chain, newest first
+	runs := make(map[*repoWorkflow][]*gh.WorkflowRun)
+	var collect func(node *chainNode)
+	collect = func(node *chainNode) {
+		// The analyzed workflow's runs are already sampled when it starts the chain
+		if node.depth == 0 && node.workflow.file() == path.Base(report.WorkflowFile) {
+			for _, sample := range samples {
+				runs[node.workflow] = append(runs[node.workflow], sample.Run)
+			}
+		} else if list, err := a.client.GetWorkflowRuns(ctx, owner, repo, node.workflow.file()); err != nil {
+			a.debugLog("Error getting runs of %s: %v", node.workflow.path, err)
+		} else {
+			runs[node.workflow] = list
+		}
+		for _, child := range node.children {
+			collect(child)
+		}
+	}
+	collect(chain)
+	if ctx.Err() != nil {
+		return
+	}
+
+	times := make(map[*repoWorkflow]*chainStageTimes)
+	record := func(w *repoWorkflow, run, parent *gh.WorkflowRun) {
+		if times[w] == nil {
+			times[w] = &chainStageTimes{}
+		}
+		times[w].add(run, parent)
+	}
+
+	result := &models.WorkflowChain{}
+	var totalLead time.Duration
+	roots := runs[chain.workflow]
+	for _, root := range roots[:min(a.sampleSizeFor(), len(roots))] {
+		if root.GetStatus() != "completed" {
+			continue
+		}
+		end := root.GetUpdatedAt().Time
+		record(chain.workflow, root, nil)
+
+		var follow func(node *chainNode, parent *gh.WorkflowRun)
+		follow = func(node *chainNode, parent *gh.WorkflowRun) {
+			for _, child := range node.children {
+				run := triggeredRun(runs[child.workflow], parent)
+				if run == nil {
+					continue
+				}
+				record(child.workflow, run, parent)
+				if completed := run.GetUpdatedAt().Time; completed.After(end) {
+					end = completed
+				}
+				follow(child, run)
+			}
+		}
+		follow(chain, root)
+
+		lead := end.Sub(root.GetCreatedAt().Time)
+		totalLead += lead
+		if lead > result.MaxLeadTime {
+			result.MaxLeadTime = lead
+		}
+		result.Runs++
+	}
+	if result.Runs == 0 {
+		return
+	}
+	result.AvgLeadTime = totalLead / time.Duration(result.Runs)
+
+	var list func(node *chainNode)
+	list = func(node *chainNode) {
+		stage := models.ChainStage{File: node.workflow.path, Name: node.workflow.name, Depth: node.depth}
+		if t := times[node.workflow]; t != nil {
+			stage.Runs = len(t.durations)
+			stage.AvgDuration = average(t.durations)
+			stage.AvgWait = average(t.waits)
+		}
+		result.Stages = append(result.Stages, stage)
+		for _, child := range node.children {
+			list(child)
+		}
+	}
+	list(chain)
+
+	report.WorkflowChain = result
+}
+
+// chainStageTimes collects the measured durations of one workflow in the chain
+type chainStageTimes struct {
+	durations []time.Duration
+	waits     []time.Duration
+}
+
+// add records a run, and its wait after the triggering run unless it starts the chain
+func (t *chainStageTimes) add(run, parent *gh.WorkflowRun) {
+	start := run.GetRunStartedAt().Time
+	if start.IsZero() {
+		start = run.GetCreatedAt().Time
+	}
+	t.durations = append(t.durations, run.GetUpdatedAt().Sub(start))
+	if parent != nil {
+		t.waits = append(t.waits, start.Sub(parent.GetUpdatedAt().Time))
+	}
+}
+
+// triggeredRun finds the completed workflow_run-triggered run for the same commit
+// that started soonest after parent
+func triggeredRun(runs []*gh.WorkflowRun, parent *gh.WorkflowRun) *gh.WorkflowRun {
+	var match *gh.WorkflowRun
+	for _, run := range runs {
+		if run.GetEvent() != "workflow_run" || run.GetStatus() != "completed" || !strings.EqualFold(run.GetHeadSHA(), parent.GetHeadSHA()) {
+			continue
+		}
+		if run.GetCreatedAt().Before(parent.GetCreatedAt().Time) {
+			continue
+		}
+		if match == nil || run.GetCreatedAt().Before(match.GetCreatedAt().Time) {
+			match = run
+		}
+	}
+	return match
+}
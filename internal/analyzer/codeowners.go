@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// codeownersPaths are the places GitHub looks for CODEOWNERS, in its order
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule assigns the files a pattern matches to owners; a rule without
+// owners leaves the files unowned
+type codeownersRule struct {
+	pattern *regexp.Regexp
+	owners  []string
+}
+
+// parseCodeowners reads the rules of a CODEOWNERS file, skipping comments and
+// lines whose pattern can't be understood
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		pattern := codeownersPattern(fields[0])
+		if pattern == nil {
+			continue
+		}
+		var owners []string
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			owners = append(owners, owner)
+		}
+		rules = append(rules, codeownersRule{pattern: pattern, owners: owners})
+	}
+	return rules
+}
+
+// codeownersPattern translates a gitignore-style CODEOWNERS pattern to a regular
+// expression over repository paths. As on GitHub, a pattern starting with or
+// containing a slash is anchored at the repository root and otherwise matches at
+// any depth, a trailing slash matches everything below a directory, and a
+// trailing /* only matches the directory's direct children.
+func codeownersPattern(pattern string) *regexp.Regexp {
+	if strings.HasPrefix(pattern, "\\") || strings.Contains(pattern, "[") {
+		return nil
+	}
+	dir := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if pattern == "" {
+		return nil
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("(^|/)")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	switch {
+	case dir:
+		expr.WriteString("/")
+	case strings.HasSuffix(pattern, "/*"):
+		expr.WriteString("$")
+	default:
+		expr.WriteString("(/|$)")
+	}
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil
+	}
+	return re
+}
+
+// ownersOf returns the owners of a file: those of the last rule matching it
+func ownersOf(rules []codeownersRule, file string) []string {
+	file = strings.TrimPrefix(file, "/")
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].pattern.MatchString(file) {
+			return rules[i].owners
+		}
+	}
+	return nil
+}
+
+// analyzeOwners assigns the workflow and each finding to the owners CODEOWNERS
+// gives their files, so reports of several workflows or repositories can be
+// grouped by team. Findings without a file belong to the workflow's owners.
+// Without a CODEOWNERS file, nothing is assigned.
+func (a *Analyzer) analyzeOwners(ctx context.Context, owner, repo string, report *models.PerformanceReport) {
+	var rules []codeownersRule
+	for _, p := range codeownersPaths {
+		if content, err := a.client.GetFileContent(ctx, owner, repo, p); err == nil {
+			rules = parseCodeowners(content)
+			break
+		}
+	}
+	if len(rules) == 0 {
+		return
+	}
+
+	workflowPath := report.WorkflowFile
+	if !strings.HasPrefix(workflowPath, ".github/workflows/") {
+		workflowPath = ".github/workflows/" + workflowPath
+	}
+	report.Owners = ownersOf(rules, workflowPath)
+	for i := range report.Findings {
+		f := &report.Findings[i]
+		if f.File == "" || f.File == report.WorkflowFile {
+			f.Owners = report.Owners
+		} else {
+			f.Owners = ownersOf(rules, f.File)
+		}
+	}
+}
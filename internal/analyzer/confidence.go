@@ -0,0 +1,65 @@
+package analyzer
+
+import (
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Confidence of the heuristic findings drawn from job logs. Findings read from
+// the workflow file or measured through the API have none and are always kept.
+const (
+	confidenceLow    = 0.3 // seen in a single run
+	confidenceMedium = 0.6 // seen in a few runs, or a minority of them
+	confidenceHigh   = 0.9 // seen in at least three runs and half of those with logs
+)
+
+// WithMinConfidence drops heuristic findings whose confidence is below min,
+// between 0 and 1, to silence speculative recommendations
+func WithMinConfidence(min float64) Option {
+	return func(a *Analyzer) {
+		a.minConfidence = min
+	}
+}
+
+// logConfidence rates log evidence seen in matched of the sampled runs whose
+// logs were read
+func logConfidence(matched int, samples []runSample) float64 {
+	logged := 0
+	for _, sample := range samples {
+		if sample.Logs != "" {
+			logged++
+		}
+	}
+	return confidenceOf(matched, logged)
+}
+
+// confidenceOf rates log evidence seen in matched of logged runs
+func confidenceOf(matched, logged int) float64 {
+	switch {
+	case matched >= 3 && 2*matched >= logged:
+		return confidenceHigh
+	case matched >= 2:
+		return confidenceMedium
+	}
+	return confidenceLow
+}
+
+// confident reports whether a finding or recommendation of confidence is kept
+func (a *Analyzer) confident(confidence float64) bool {
+	return a.minConfidence <= 0 || confidence == 0 || confidence >= a.minConfidence
+}
+
+// confidentFindings drops the findings below the minimum confidence
+func (a *Analyzer) confidentFindings(findings []models.Finding) []models.Finding {
+	if a.minConfidence <= 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		if a.confident(f.Confidence) {
+			kept = append(kept, f)
+		} else {
+			a.debugLog("Dropping finding below confidence %g: %s", a.minConfidence, f.Message)
+		}
+	}
+	return kept
+}
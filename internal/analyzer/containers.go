@@ -0,0 +1,244 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// The runner announces each image it pulls for job containers, services and
+	// Docker actions, e.g. "Pulling image 'postgres:16'" or "##[command]/usr/bin/docker pull postgres:16"
+	imagePullStart = regexp.MustCompile(`(?:Pulling image|Pull down action image) '?([^\s']+)'?|##\[command\]\S*docker pull (\S+)`)
+	// and reports the finished download, e.g. "Download of image completed" or "Status: Downloaded newer image for postgres:16"
+	imagePullDone = regexp.MustCompile(`Download of image completed|Status: (?:Downloaded newer image|Image is up to date) for`)
+)
+
+const (
+	// Container setup faster than this isn't worth optimizing
+	slowImagePull = 30 * time.Second
+	// Container setup slower than this is reported as a warning
+	verySlowImagePull = 2 * time.Minute
+	// Mirroring or slimming images typically halves the pull time
+	imagePullSavingsRatio = 0.5
+	// initContainersStep is the step the jobs API reports for starting job containers and services
+	initContainersStep = "Initialize containers"
+)
+
+// logTime splits the timestamp GitHub prefixes to each log line from the message
+func logTime(line string) (time.Time, string, bool) {
+	stamp, message, ok := strings.Cut(line, " ")
+	if !ok {
+		return time.Time{}, line, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, stamp)
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	return t, message, true
+}
+
+// imagePull is one image pulled in a run's logs and when
+type imagePull struct {
+	image   string
+	started time.Time
+	took    time.Duration
+}
+
+// imagePulls returns the images pulled in a run's logs. A pull lasts from its
+// announcement until the download completes.
+func imagePulls(logs string) []imagePull {
+	var pulls []imagePull
+	var current *imagePull
+	for _, line := range strings.Split(logs, "\n") {
+		t, message, ok := logTime(strings.TrimRight(line, "\r"))
+		if !ok {
+			continue
+		}
+		if m := imagePullStart.FindStringSubmatch(message); m != nil {
+			current = &imagePull{image: m[1] + m[2], started: t}
+			continue
+		}
+		if current != nil && imagePullDone.MatchString(message) {
+			current.took = t.Sub(current.started)
+			pulls = append(pulls, *current)
+			current = nil
+		}
+	}
+	return pulls
+}
+
+// jobPullTimes returns how long the job took to pull each image across samples.
+// A run's logs hold all of its jobs, so a pull counts for the job when it
+// started while one of the job's runs, e.g. one per matrix entry, was running.
+func jobPullTimes(samples []runSample, job *workflow.Job) map[string][]time.Duration {
+	times := make(map[string][]time.Duration)
+	for _, sample := range samples {
+		pulls := imagePulls(sample.Logs)
+		if len(pulls) == 0 {
+			continue
+		}
+		for _, apiJob := range sample.Jobs {
+			if !matchesJob(apiJob, job) || apiJob.StartedAt == nil || apiJob.CompletedAt == nil {
+				continue
+			}
+			for _, pull := range pulls {
+				if !pull.started.Before(apiJob.StartedAt.Time) && !pull.started.After(apiJob.CompletedAt.Time) {
+					times[pull.image] = append(times[pull.image], pull.took)
+				}
+			}
+		}
+	}
+	return times
+}
+
+// containerSetupDurations returns how long a job took to start its containers across samples
+func containerSetupDurations(samples []runSample, job *workflow.Job) []time.Duration {
+	var durations []time.Duration
+	for _, sample := range samples {
+		for _, apiJob := range sample.Jobs {
+			if !matchesJob(apiJob, job) {
+				continue
+			}
+			for _, apiStep := range apiJob.Steps {
+				if apiStep.GetName() == initContainersStep && apiStep.StartedAt != nil && apiStep.CompletedAt != nil {
+					durations = append(durations, apiStep.CompletedAt.Sub(apiStep.StartedAt.Time))
+				}
+			}
+		}
+	}
+	return durations
+}
+
+// jobImages returns the job container and service images of a job, skipping
+// images chosen by expressions
+func jobImages(job *workflow.Job) []string {
+	var images []string
+	if job.Container != "" {
+		images = append(images, job.Container)
+	}
+	for _, image := range job.Services {
+		images = append(images, image)
+	}
+	var known []string
+	for _, image := range images {
+		if image != "" && !strings.Contains(image, "${{") {
+			known = append(known, image)
+		}
+	}
+	sort.Strings(known)
+	return known
+}
+
+// selfHosted reports whether a job runs on self-hosted runners
+func selfHosted(job *workflow.Job) bool {
+	for _, label := range job.RunsOn {
+		if strings.EqualFold(label, "self-hosted") {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerHubImage reports whether an image reference is pulled from Docker Hub,
+// i.e. its first path component isn't a registry host
+func dockerHubImage(image string) bool {
+	image = strings.TrimPrefix(image, "docker://")
+	host, _, ok := strings.Cut(image, "/")
+	if !ok {
+		return true
+	}
+	if host == "docker.io" || host == "index.docker.io" {
+		return true
+	}
+	return !strings.ContainsAny(host, ".:") && host != "localhost"
+}
+
+// slimImage reports whether an image reference already names a minimal variant
+func slimImage(image string) bool {
+	for _, variant := range []string{"slim", "alpine", "distroless", "minimal", "busybox"} {
+		if strings.Contains(image, variant) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkImagePulls measures the time jobs spend pulling their container and service
+// images and recommends faster registries, smaller images or runner-level caching
+func (a *Analyzer) checkImagePulls(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		images := jobImages(job)
+		if len(images) == 0 {
+			continue
+		}
+		setup := average(containerSetupDurations(samples, job))
+		pulls := jobPullTimes(samples, job)
+
+		var measured []string
+		var slowest time.Duration
+		slowestPulls := 0
+		hub, large := false, false
+		for _, image := range images {
+			if avg := average(pulls[image]); avg > 0 {
+				measured = append(measured, fmt.Sprintf("%s %v", image, avg.Round(time.Second)))
+				if avg > slowest {
+					slowest, slowestPulls = avg, len(pulls[image])
+				}
+			}
+			hub = hub || dockerHubImage(image)
+			large = large || !slimImage(image)
+		}
+		// Without the jobs API's setup step, fall back to the slowest pull in the logs
+		var confidence float64
+		if setup == 0 {
+			setup = slowest
+			confidence = logConfidence(slowestPulls, samples)
+		}
+		if setup < slowImagePull {
+			continue
+		}
+
+		severity := models.SeverityInfo
+		if setup >= verySlowImagePull {
+			severity = models.SeverityWarning
+		}
+		message := a.lang.Sprintf("Job %s spends %v per run on average starting its containers (%s)", job.ID, setup.Round(time.Second), strings.Join(images, ", "))
+		if len(measured) > 0 {
+			message += "; " + a.lang.Sprintf("image pulls: %s", strings.Join(measured, ", "))
+		}
+
+		var suggestions []string
+		if hub {
+			suggestions = append(suggestions, a.lang.T("Mirror Docker Hub images to ghcr.io, which is closer to GitHub-hosted runners and not subject to Docker Hub's pull rate limits"))
+		}
+		if large {
+			suggestions = append(suggestions, a.lang.T("Use smaller variants such as -slim or -alpine tags, which have fewer and smaller layers to download"))
+		}
+		if selfHosted(job) {
+			suggestions = append(suggestions, a.lang.T("On self-hosted runners, bake the images into the runner or use a registry pull-through cache so they're pulled once per runner instead of once per job"))
+		} else {
+			suggestions = append(suggestions, a.lang.T("GitHub-hosted runners start without cached images, so every job pulls them again; caching images at the runner level needs self-hosted runners or larger runners with a custom image"))
+		}
+
+		findings = append(findings, models.Finding{
+			Category:         "docker",
+			Severity:         severity,
+			File:             path,
+			Line:             job.Line,
+			Message:          message,
+			Suggestion:       strings.Join(suggestions, ". "),
+			URL:              jobURL(samples, job),
+			EstimatedSavings: a.projectSavings(samples, time.Duration(float64(setup)*imagePullSavingsRatio)),
+			Confidence:       confidence,
+		})
+	}
+
+	return findings
+}
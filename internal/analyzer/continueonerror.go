@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// DefaultMaskedFailureRate is the share of successful runs a continue-on-error
+// step must fail in to be reported, unless WithMaskedFailureRate sets another
+const DefaultMaskedFailureRate = 0.2
+
+// minMaskedFailures is how many runs the step must have failed in
+const minMaskedFailures = 2
+
+// WithMaskedFailureRate reports continue-on-error steps failing in more than
+// rate of the successful runs, from 0 to 1
+func WithMaskedFailureRate(rate float64) Option {
+	return func(a *Analyzer) {
+		if rate >= 0 && rate < 1 {
+			a.maskedRate = rate
+		}
+	}
+}
+
+// maskedFailures counts the successful runs of a job that include the step and
+// the runs among them in which the step failed, ignored thanks to
+// continue-on-error. Matrix expansions count as separate runs.
+func maskedFailures(samples []runSample, job *workflow.Job, step *workflow.Step) (runs, failed int, url string) {
+	want := apiStepName(step)
+	for _, sample := range samples {
+		for _, apiJob := range sample.Jobs {
+			if !matchesJob(apiJob, job) || apiJob.GetConclusion() != "success" {
+				continue
+			}
+			for _, apiStep := range apiJob.Steps {
+				if apiStep.GetName() != want {
+					continue
+				}
+				runs++
+				if apiStep.GetConclusion() == "failure" {
+					failed++
+					if url == "" {
+						url = apiJob.GetHTMLURL()
+					}
+				}
+				break
+			}
+		}
+	}
+	return runs, failed, url
+}
+
+// outcomeChecked reports whether a later step of the job looks at the step's
+// outcome, handling its failures deliberately
+func outcomeChecked(job *workflow.Job, step *workflow.Step) bool {
+	if step.ID == "" {
+		return false
+	}
+	for _, s := range job.Steps[step.Index+1:] {
+		values := append([]string{s.If, s.Run}, mapValues(s.With)...)
+		values = append(values, mapValues(s.Env)...)
+		for _, v := range values {
+			if strings.Contains(v, "steps."+step.ID+".outcome") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkContinueOnError audits steps with continue-on-error: true against the run
+// history and reports the ones that keep failing in successful runs, where the
+// setting masks real problems nobody looks at
+func (a *Analyzer) checkContinueOnError(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			if step.ContinueOnError != "true" || outcomeChecked(job, step) {
+				continue
+			}
+			runs, failed, url := maskedFailures(samples, job, step)
+			if failed < minMaskedFailures || float64(failed) <= a.maskedRate*float64(runs) {
+				continue
+			}
+			id := step.ID
+			if id == "" {
+				id = "<id>"
+			}
+			findings = append(findings, models.Finding{
+				Category: "reliability",
+				Severity: models.SeverityWarning,
+				File:     path,
+				Line:     step.Line,
+				Message: a.lang.Sprintf("Step %s of job %s failed in %d of %d successful runs, hidden by continue-on-error",
+					step.DisplayName(), job.ID, failed, runs),
+				Suggestion: a.lang.T("Fix the step or remove it; if its failures are expected, check its outcome in a later step so they're reported instead of ignored"),
+				Example: fmt.Sprintf(`      - id: %[1]s
+        continue-on-error: true
+        ...
+      - if: steps.%[1]s.outcome == 'failure'
+        run: echo "::warning::%[2]s failed"`, id, step.DisplayName()),
+				URL: url,
+			})
+		}
+	}
+	return findings
+}
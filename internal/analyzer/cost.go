@@ -0,0 +1,174 @@
+package analyzer
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/pricing"
+)
+
+// runnerRates are GitHub's list prices in USD per minute of the standard hosted
+// runners of private repositories, by OS
+var runnerRates = map[string]float64{
+	"ubuntu":  0.008,
+	"windows": 0.016,
+	"macos":   0.08,
+}
+
+// coreRates are the list prices per core and minute of larger hosted runners
+var coreRates = map[string]float64{
+	"ubuntu":  0.004,
+	"windows": 0.008,
+	"macos":   0.01,
+}
+
+// WithPricing prices runners from a pricing table instead of GitHub's list
+// prices, for self-hosted infrastructure or negotiated rates
+func WithPricing(p *pricing.Pricing) Option {
+	return func(a *Analyzer) {
+		a.pricing = p
+	}
+}
+
+// runnerRate returns the runner type and per-minute price of a job's runner
+// labels. Self-hosted runners cost nothing on GitHub's bill unless the pricing
+// table prices them.
+func (a *Analyzer) runnerRate(labels []string) (string, float64) {
+	discount := 1.0
+	if a.pricing != nil {
+		if r, ok := a.pricing.Match(labels); ok {
+			return r.Name, r.PerMinute
+		}
+		discount -= a.pricing.Discount
+	}
+	for _, label := range labels {
+		if strings.EqualFold(label, "self-hosted") {
+			return "self-hosted", 0
+		}
+	}
+	runner, profile := classifyRunner(labels)
+	osName, _, larger := strings.Cut(runner, "-")
+	if larger {
+		return runner, coreRates[osName] * float64(profile.vCPU) * discount
+	}
+	return runner, runnerRates[osName] * discount
+}
+
+// runBranch returns the branch a run was for and its pull request, when GitHub
+// links one. Pull requests from forks aren't linked, so their runs count for the
+// fork's branch.
+func runBranch(run *gh.WorkflowRun) (string, int) {
+	for _, pr := range run.PullRequests {
+		if pr.GetNumber() > 0 {
+			return run.GetHeadBranch(), pr.GetNumber()
+		}
+	}
+	return run.GetHeadBranch(), 0
+}
+
+// botActor reports whether a GitHub account is a bot, e.g. dependabot[bot]
+func botActor(user *gh.User) bool {
+	return user.GetType() == "Bot" || strings.HasSuffix(user.GetLogin(), "[bot]")
+}
+
+// runCost is the billable minutes and cost of one sampled run's jobs
+type runCost struct {
+	run      *gh.WorkflowRun
+	billable int
+	cost     float64
+}
+
+// runWallTime is how long a run ran, from its start, after any queueing, to its last update
+func runWallTime(run *gh.WorkflowRun) time.Duration {
+	start := run.GetRunStartedAt().Time
+	if start.IsZero() {
+		start = run.GetCreatedAt().Time
+	}
+	return run.GetUpdatedAt().Sub(start)
+}
+
+// attributeRuns splits the runner time among the branches and pull requests of
+// every listed run, rather than of the few sampled ones. A listed run's billable
+// minutes are its wall time scaled by the sampled runs' billable minutes per
+// minute of wall time, which accounts for parallel jobs and GitHub rounding each
+// job up, and its cost follows their average price per minute. Without a listing
+// or timed samples, the sampled runs are attributed as measured.
+func attributeRuns(cost *models.CostEstimate, sampled []runCost, listed []*gh.WorkflowRun) {
+	var wall time.Duration
+	billable, price := 0, 0.0
+	for _, s := range sampled {
+		if d := runWallTime(s.run); d > 0 {
+			wall += d
+			billable += s.billable
+			price += s.cost
+		}
+	}
+	if len(listed) == 0 || wall <= 0 || billable == 0 {
+		for _, s := range sampled {
+			branch, pullRequest := runBranch(s.run)
+			cost.Attribute(branch, pullRequest, botActor(s.run.GetActor()), s.billable, s.cost)
+		}
+		return
+	}
+
+	perWallMinute := float64(billable) / wall.Minutes()
+	perBillable := price / float64(billable)
+	for _, run := range listed {
+		d := runWallTime(run)
+		if timingClass(run) == timingExcluded || d <= 0 {
+			continue
+		}
+		minutes := max(int(math.Round(d.Minutes()*perWallMinute)), 1)
+		branch, pullRequest := runBranch(run)
+		cost.Attribute(branch, pullRequest, botActor(run.GetActor()), minutes, float64(minutes)*perBillable)
+	}
+}
+
+// estimateCost prices the runner minutes of the sampled runs' jobs. GitHub bills
+// each job rounded up to the whole minute.
+func (a *Analyzer) estimateCost(samples []runSample) *models.CostEstimate {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	cost := &models.CostEstimate{Runs: len(samples)}
+	var sampled []runCost
+	for _, sample := range samples {
+		runMinutes, runPrice := 0, 0.0
+		for _, job := range sample.Jobs {
+			if job.StartedAt == nil || job.CompletedAt == nil {
+				continue
+			}
+			minutes := job.CompletedAt.Sub(job.StartedAt.Time).Minutes()
+			if minutes <= 0 {
+				continue
+			}
+			runner, rate := a.runnerRate(job.Labels)
+			billable := int(math.Ceil(minutes))
+			cost.Add(runner, rate, minutes, billable)
+			runMinutes, runPrice = runMinutes+billable, runPrice+float64(billable)*rate
+		}
+		if runMinutes > 0 {
+			sampled = append(sampled, runCost{sample.Run, runMinutes, runPrice})
+		}
+	}
+	if len(cost.Entries) == 0 {
+		return nil
+	}
+	attributeRuns(cost, sampled, samples[0].Listed)
+	cost.Sort()
+
+	cost.MonthlyCost = cost.Cost * runsPerMonth(samples) / float64(len(samples))
+	switch {
+	case a.pricing == nil:
+		cost.Assumptions = a.lang.T("GitHub's list prices for private repositories; standard runners are free for public repositories and self-hosted runners aren't billed")
+	case a.pricing.Discount > 0:
+		cost.Assumptions = a.lang.Sprintf("Prices from %s; other runners at GitHub's list prices less %.0f%%, self-hosted ones free", a.pricing.Source, a.pricing.Discount*100)
+	default:
+		cost.Assumptions = a.lang.Sprintf("Prices from %s; other runners at GitHub's list prices, self-hosted ones free", a.pricing.Source)
+	}
+	return cost
+}
@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// maxCustomActions caps the action.yml files linted per repository
+const maxCustomActions = 20
+
+// deprecatedRuntimes are the Node.js versions GitHub no longer runs actions on
+var deprecatedRuntimes = map[string]string{
+	"node12": "Node.js 12",
+	"node16": "Node.js 16",
+}
+
+// fromLine matches a Dockerfile FROM instruction, capturing the image and the
+// stage name it is given
+var fromLine = regexp.MustCompile(`(?im)^[ \t]*FROM[ \t]+(?:--platform=\S+[ \t]+)?(\S+)(?:[ \t]+AS[ \t]+(\S+))?`)
+
+// actionFiles returns the action.yml and action.yaml files of a repository
+// tree, leaving out dependency and fixture directories
+func actionFiles(tree []string) []string {
+	var files []string
+	for _, file := range tree {
+		if base := path.Base(file); base != "action.yml" && base != "action.yaml" {
+			continue
+		}
+		skip := false
+		for _, part := range strings.Split(path.Dir(file), "/") {
+			skip = skip || ignoredProjectDirs[part]
+		}
+		if !skip {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// analyzeCustomActions lints the actions the repository ships, both published
+// ones at the root and local ones such as those under .github/actions
+func (a *Analyzer) analyzeCustomActions(ctx context.Context, owner, repo string, report *models.PerformanceReport) {
+	tree, err := a.client.GetTree(ctx, owner, repo)
+	if err != nil {
+		a.debugLog("Error listing repository tree: %v", err)
+		return
+	}
+	files := actionFiles(tree)
+	if len(files) > maxCustomActions {
+		a.debugLog("Linting the first %d of %d actions", maxCustomActions, len(files))
+		files = files[:maxCustomActions]
+	}
+
+	for _, file := range files {
+		content, err := a.client.GetFileContent(ctx, owner, repo, file)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		action, err := workflow.ParseAction(content)
+		if err != nil {
+			a.debugLog("Skipping %s: %v", file, err)
+			continue
+		}
+		report.Findings = append(report.Findings, a.lintAction(file, action)...)
+
+		// A Docker action built from a Dockerfile inherits its base images
+		if action.Using == "docker" && action.Image != "" && !strings.HasPrefix(action.Image, "docker://") {
+			dockerfile := path.Join(path.Dir(file), action.Image)
+			if content, err := a.client.GetFileContent(ctx, owner, repo, dockerfile); err == nil {
+				report.Findings = append(report.Findings, a.lintActionDockerfile(dockerfile, content)...)
+			}
+		}
+	}
+}
+
+// lintAction checks an action's metadata: inputs without a description, a
+// deprecated Node.js runtime, composite steps using actions that aren't pinned to
+// a commit SHA, branding of published actions, and unpinned docker:// images
+func (a *Analyzer) lintAction(file string, action *workflow.Action) []models.Finding {
+	var findings []models.Finding
+	add := func(category, severity string, line int, message, suggestion, example string) {
+		findings = append(findings, models.Finding{
+			Category:   category,
+			Severity:   severity,
+			File:       file,
+			Line:       line,
+			Message:    message,
+			Suggestion: suggestion,
+			Example:    example,
+		})
+	}
+
+	for _, input := range action.Inputs {
+		if strings.TrimSpace(input.Description) == "" {
+			add("style", models.SeverityInfo, input.Line,
+				a.lang.Sprintf("Input %s of the action has no description", input.Name),
+				a.lang.T("Describe every input; the description is what users see in the Marketplace and in editor completions"), "")
+		}
+	}
+
+	if runtime, ok := deprecatedRuntimes[action.Using]; ok {
+		add("reliability", models.SeverityWarning, action.UsingLine,
+			a.lang.Sprintf("The action runs on %s, which is end of life and deprecated on GitHub's runners", runtime),
+			a.lang.T("Move to a supported runtime and test the action on it; callers get warnings, and the runner may force the newer runtime anyway"),
+			"runs:\n  using: node24")
+	}
+
+	for _, step := range action.Steps {
+		ref := step.Uses
+		if ref == "" || strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "docker://") {
+			continue
+		}
+		name, version, _ := strings.Cut(ref, "@")
+		if !commitSHA.MatchString(version) {
+			add("security", models.SeverityWarning, step.Line,
+				a.lang.Sprintf("Composite step uses %s, which isn't pinned to a commit SHA", ref),
+				a.lang.T("Pin actions inside a composite action to a full commit SHA with the version in a comment; every workflow using the action inherits a moved tag"),
+				fmt.Sprintf("    - uses: %s@<commit SHA> # %s", name, version))
+		}
+	}
+
+	// Only actions at the repository root can be published to the Marketplace
+	if path.Dir(file) == "." && !action.Branding {
+		add("style", models.SeverityInfo, 1,
+			a.lang.T("The action has no branding"),
+			a.lang.T("Add an icon and color so the action stands out in the Marketplace"),
+			"branding:\n  icon: 'activity'\n  color: 'blue'")
+	}
+
+	if image, ok := strings.CutPrefix(action.Image, "docker://"); ok {
+		if finding, unpinned := a.unpinnedImage(file, action.ImageLine, image); unpinned {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// lintActionDockerfile reports the base images of a Docker action's Dockerfile
+// that aren't pinned by digest
+func (a *Analyzer) lintActionDockerfile(file, content string) []models.Finding {
+	var findings []models.Finding
+	stages := make(map[string]bool)
+	for _, m := range fromLine.FindAllStringSubmatchIndex(content, -1) {
+		image := content[m[2]:m[3]]
+		// Earlier stages, scratch and images from build arguments have nothing to pin
+		if !stages[strings.ToLower(image)] && image != "scratch" && !strings.Contains(image, "$") {
+			line := strings.Count(content[:m[0]], "\n") + 1
+			if finding, unpinned := a.unpinnedImage(file, line, image); unpinned {
+				findings = append(findings, finding)
+			}
+		}
+		if m[4] >= 0 {
+			stages[strings.ToLower(content[m[4]:m[5]])] = true
+		}
+	}
+	return findings
+}
+
+// unpinnedImage reports an action's container image that isn't pinned by digest:
+// as a warning when it floats on latest, otherwise as information
+func (a *Analyzer) unpinnedImage(file string, line int, image string) (models.Finding, bool) {
+	if strings.Contains(image, "@sha256:") {
+		return models.Finding{}, false
+	}
+	finding := models.Finding{
+		Category:   "security",
+		Severity:   models.SeverityInfo,
+		File:       file,
+		Line:       line,
+		Message:    a.lang.Sprintf("The action's image %s isn't pinned by digest", image),
+		Suggestion: a.lang.T("Pin the image by digest, e.g. image:tag@sha256:..., so every run of the action uses the same image; update the digest with Dependabot or Renovate"),
+	}
+	name := image[strings.LastIndex(image, "/")+1:]
+	if _, tag, found := strings.Cut(name, ":"); !found || tag == "latest" {
+		finding.Severity = models.SeverityWarning
+		finding.Message = a.lang.Sprintf("The action's image %s floats on the latest tag", image)
+	}
+	return finding, true
+}
@@ -0,0 +1,125 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// hunkHeader matches the new-file range of a unified diff hunk header
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// isWorkflowPath reports whether path is a workflow definition file
+func isWorkflowPath(path string) bool {
+	return strings.HasPrefix(path, ".github/workflows/") &&
+		(strings.HasSuffix(path, ".yml") || strings.HasSuffix(path, ".yaml"))
+}
+
+// addedLines returns the new-file line numbers added or modified by a unified diff patch
+func addedLines(patch string) map[int]bool {
+	lines := make(map[int]bool)
+	newLine := 0
+	for _, line := range strings.Split(patch, "\n") {
+		if m := hunkHeader.FindStringSubmatch(line); m != nil {
+			newLine, _ = strconv.Atoi(m[1])
+			continue
+		}
+		if newLine == 0 {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "+"):
+			lines[newLine] = true
+			newLine++
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, "\\"):
+			// removed lines and "\ No newline at end of file" don't exist in the new file
+		default:
+			newLine++
+		}
+	}
+	return lines
+}
+
+// AnalyzePullRequest analyzes only the workflow files changed by a pull request and
+// keeps the findings that fall on added or modified lines
+func (a *Analyzer) AnalyzePullRequest(ctx context.Context, owner, repo string, number int) (*models.PerformanceReport, error) {
+	pr, err := a.client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	headSHA := pr.GetHead().GetSHA()
+
+	files, err := a.client.ListPullRequestFiles(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.PerformanceReport{
+		Repository:  fmt.Sprintf("%s/%s", owner, repo),
+		PullRequest: number,
+		HeadSHA:     headSHA,
+		Lang:        a.lang,
+	}
+
+	var changed []string
+	for _, file := range files {
+		path := file.GetFilename()
+		if !isWorkflowPath(path) || file.GetStatus() == "removed" {
+			continue
+		}
+		changed = append(changed, path)
+
+		content, err := a.client.GetFileContentAtRef(ctx, owner, repo, path, headSHA)
+		if err != nil {
+			a.debugLog("Error getting %s at %s: %v", path, headSHA, err)
+			continue
+		}
+
+		touched := addedLines(file.GetPatch())
+		a.debugLog("%s: %d added or modified lines", path, len(touched))
+
+		findings := append(a.lintWorkflow(path, content), a.inspectWorkflow(ctx, path, content, nil)...)
+		for _, finding := range findings {
+			if touched[finding.Line] {
+				report.Findings = append(report.Findings, finding)
+			}
+		}
+	}
+
+	report.WorkflowFile = strings.Join(changed, ", ")
+	linkFindings(owner, repo, report)
+	return report, nil
+}
+
+// PostReview publishes the report's findings as line comments on the pull request
+func (a *Analyzer) PostReview(ctx context.Context, owner, repo string, report *models.PerformanceReport) error {
+	if len(report.Findings) == 0 {
+		return nil
+	}
+
+	var comments []*gh.DraftReviewComment
+	for _, finding := range report.Findings {
+		body := fmt.Sprintf("**[%s]** %s", finding.Severity, finding.Message)
+		if finding.Suggestion != "" {
+			body += "\n\n" + finding.Suggestion
+		}
+		comments = append(comments, &gh.DraftReviewComment{
+			Path: gh.String(finding.File),
+			Line: gh.Int(finding.Line),
+			Side: gh.String("RIGHT"),
+			Body: gh.String(body),
+		})
+	}
+
+	return a.client.CreateReview(ctx, owner, repo, report.PullRequest, &gh.PullRequestReviewRequest{
+		CommitID: gh.String(report.HeadSHA),
+		Body:     gh.String(a.lang.Sprintf("Workflow analyzer found %d issue(s) in the changed lines.", len(report.Findings))),
+		Event:    gh.String("COMMENT"),
+		Comments: comments,
+	})
+}
@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// digestDir is the directory of digest records inside the cache directory
+const digestDir = "digests"
+
+// digestRecord is the report the next digest is compared against
+type digestRecord struct {
+	AnalyzedAt time.Time                 `json:"analyzed_at"`
+	Report     *models.PerformanceReport `json:"report"`
+}
+
+// WithDigest compares each analysis with the previous one recorded in dir and
+// sets the report's digest to what changed, then records the new analysis
+func WithDigest(dir string) Option {
+	return func(a *Analyzer) {
+		a.digest = dir
+	}
+}
+
+// digestPath returns the file the record of a set of workflows is kept in
+func (a *Analyzer) digestPath(owner, repo string, files []string) string {
+	files = append([]string(nil), files...)
+	sort.Strings(files)
+	name := sha256.Sum256([]byte(owner + "/" + repo + "/" + strings.Join(files, ",")))
+	return filepath.Join(a.digest, digestDir, hex.EncodeToString(name[:8])+".json")
+}
+
+// applyDigest sets the digest of a report against the recorded analysis, and
+// records the report for the next digest unless it is partial
+func (a *Analyzer) applyDigest(owner, repo string, files []string, report *models.PerformanceReport) error {
+	path := a.digestPath(owner, repo, files)
+
+	var previous digestRecord
+	if raw, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(raw, &previous); err != nil {
+			a.debugLog("Warning: ignoring unreadable digest record %s: %v", path, err)
+			previous = digestRecord{}
+		}
+	}
+
+	// The record is written before the digest is set, so it holds the report alone
+	digest := models.NewDigest(previous.Report, previous.AnalyzedAt, report)
+	defer func() { report.Digest = digest }()
+
+	if !report.Partial {
+		raw, err := json.Marshal(digestRecord{AnalyzedAt: time.Now().UTC(), Report: report})
+		if err != nil {
+			return fmt.Errorf("failed to encode digest record: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create digest directory: %v", err)
+		}
+		if err := os.WriteFile(path, raw, 0644); err != nil {
+			return fmt.Errorf("failed to write digest record: %v", err)
+		}
+	}
+	return nil
+}
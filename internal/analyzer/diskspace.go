@@ -0,0 +1,200 @@
+package analyzer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// diskFull matches the errors of tools that ran out of disk space
+	diskFull = regexp.MustCompile(`(?i)no space left on device|\bENOSPC\b|not enough space on the disk`)
+	// diskLow matches the runner's warning, e.g. "You are running out of disk space. ... Free space left: 45 MB"
+	diskLow = regexp.MustCompile(`You are running out of disk space.*Free space left: (\d+) MB`)
+	// dfRoot matches the root filesystem line of df output, e.g. "/dev/root  72G  66G  6.2G  92% /"
+	dfRoot = regexp.MustCompile(`^\S+\s+\S+\s+\S+\s+\S+\s+(\d+)%\s+/$`)
+)
+
+const (
+	// nearlyFullDisk is the root filesystem usage df output is reported from
+	nearlyFullDisk = 90
+	// freeDiskSpace removes the preinstalled toolchains most jobs don't need from
+	// GitHub-hosted Ubuntu runners, freeing about 30 GB
+	freeDiskSpace = `      - name: Free disk space
+        run: |
+          sudo rm -rf /usr/share/dotnet /usr/local/lib/android /opt/ghc /opt/hostedtoolcache/CodeQL
+          sudo docker image prune --all --force
+          df -h /`
+)
+
+// diskEvidence is what the logs say about a job's disk space
+type diskEvidence struct {
+	failedRuns int // runs that ran out of space
+	lowRuns    int // runs the runner warned about low disk space
+	minFreeMB  int // least free space the runner reported
+	maxUsed    int // highest root filesystem usage df printed, in percent
+	failedURL  string
+	lowURL     string
+}
+
+// diskSpaceEvidence collects disk space errors and usage from the logs by workflow
+// job ID. Lines that can't be told apart by time between parallel jobs go under "".
+func diskSpaceEvidence(samples []runSample, wf *workflow.Workflow) map[string]*diskEvidence {
+	evidence := make(map[string]*diskEvidence)
+	for _, sample := range samples {
+		failed, low := make(map[string]bool), make(map[string]bool)
+		for _, line := range strings.Split(sample.Logs, "\n") {
+			t, message, ok := logTime(strings.TrimRight(line, "\r"))
+			full, lowSpace, used := diskFull.MatchString(message), diskLow.FindStringSubmatch(message), dfRoot.FindStringSubmatch(message)
+			if !full && lowSpace == nil && used == nil {
+				continue
+			}
+
+			id, url := "", sample.Run.GetHTMLURL()
+			if apiJob := jobAt(sample.Jobs, t); ok && apiJob != nil {
+				for _, job := range wf.Jobs {
+					if matchesJob(apiJob, job) {
+						id, url = job.ID, apiJob.GetHTMLURL()
+						break
+					}
+				}
+			}
+			e := evidence[id]
+			if e == nil {
+				e = &diskEvidence{minFreeMB: -1}
+				evidence[id] = e
+			}
+
+			switch {
+			case full:
+				failed[id] = true
+				if e.failedURL == "" {
+					e.failedURL = url
+				}
+			case lowSpace != nil:
+				low[id] = true
+				if mb, err := strconv.Atoi(lowSpace[1]); err == nil && (e.minFreeMB < 0 || mb < e.minFreeMB) {
+					e.minFreeMB = mb
+				}
+				if e.lowURL == "" {
+					e.lowURL = url
+				}
+			default:
+				if pct, err := strconv.Atoi(used[1]); err == nil && pct > e.maxUsed {
+					e.maxUsed = pct
+				}
+			}
+		}
+		for id := range failed {
+			evidence[id].failedRuns++
+		}
+		for id := range low {
+			evidence[id].lowRuns++
+		}
+	}
+	return evidence
+}
+
+// buildsImages reports whether a job builds container images, which fill the disk
+// with layers and build cache
+func buildsImages(job *workflow.Job) bool {
+	for _, step := range job.Steps {
+		if usesAction(step, "docker/build-push-action") || strings.Contains(step.Run, "docker build") || strings.Contains(step.Run, "docker buildx build") {
+			return true
+		}
+	}
+	return false
+}
+
+// ubuntuRunner reports whether a job runs on GitHub-hosted Ubuntu runners
+func ubuntuRunner(job *workflow.Job) bool {
+	for _, label := range job.RunsOn {
+		if strings.HasPrefix(label, "ubuntu-") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDiskSpace reports jobs that ran out of disk space or came close to it, and
+// recommends freeing the space taken by preinstalled toolchains or larger runners
+func (a *Analyzer) checkDiskSpace(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	evidence := diskSpaceEvidence(samples, wf)
+	if len(evidence) == 0 {
+		return nil
+	}
+
+	var findings []models.Finding
+	report := func(job *workflow.Job, e *diskEvidence) {
+		var message string
+		var confidence float64
+		severity := models.SeverityWarning
+		subject := a.lang.T("A job of this workflow")
+		if job != nil {
+			subject = a.lang.Sprintf("Job %s", job.ID)
+		}
+		switch {
+		case e.failedRuns > 0:
+			message = a.lang.Sprintf("%s ran out of disk space in %d of %d runs", subject, e.failedRuns, len(samples))
+			confidence = logConfidence(e.failedRuns, samples)
+		case e.lowRuns > 0:
+			message = a.lang.Sprintf("%s ran low on disk space in %d of %d runs", subject, e.lowRuns, len(samples))
+			confidence = logConfidence(e.lowRuns, samples)
+		case e.maxUsed >= nearlyFullDisk:
+			severity = models.SeverityInfo
+			message = a.lang.Sprintf("%s filled the root filesystem to %d%%", subject, e.maxUsed)
+			confidence = confidenceLow // the fullest df output of any run
+		default:
+			return
+		}
+		if e.minFreeMB >= 0 {
+			message += "; " + a.lang.Sprintf("as little as %d MB was left", e.minFreeMB)
+		} else if e.maxUsed > 0 && e.failedRuns+e.lowRuns > 0 {
+			message += "; " + a.lang.Sprintf("df showed the root filesystem %d%% full", e.maxUsed)
+		}
+
+		var suggestions []string
+		finding := models.Finding{
+			Category:   "runner",
+			Severity:   severity,
+			File:       path,
+			Line:       wf.OnLine,
+			Message:    message,
+			URL:        e.failedURL,
+			Confidence: confidence,
+		}
+		if finding.URL == "" {
+			finding.URL = e.lowURL
+		}
+		if job == nil || !selfHosted(job) {
+			suggestions = append(suggestions, a.lang.T("Free about 30 GB at the start of the job by removing the preinstalled toolchains it doesn't use, or move it to a larger runner with more disk space"))
+			finding.Example = freeDiskSpace
+		} else {
+			suggestions = append(suggestions, a.lang.T("Clean the workspace and prune Docker images and build cache on the self-hosted runner between jobs, or give it a larger disk"))
+		}
+		if job != nil {
+			finding.Line = job.Line
+			if !ubuntuRunner(job) && !selfHosted(job) {
+				finding.Example = ""
+			}
+			if buildsImages(job) {
+				suggestions = append(suggestions, a.lang.T("Image builds keep every layer and the build cache on disk; prune them between builds with docker builder prune, or push with docker/build-push-action instead of loading images into the local daemon"))
+			}
+		}
+		finding.Suggestion = strings.Join(suggestions, ". ")
+		findings = append(findings, finding)
+	}
+
+	for _, job := range wf.Jobs {
+		if e := evidence[job.ID]; e != nil {
+			report(job, e)
+		}
+	}
+	if e := evidence[""]; e != nil {
+		report(nil, e)
+	}
+	return findings
+}
@@ -0,0 +1,325 @@
+package analyzer
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// maxDeployWorkflows caps the deploy workflows whose runs are listed
+const maxDeployWorkflows = 5
+
+// Commands that deploy from a run: step, beyond actions with deploy in their name
+var deployCommand = regexp.MustCompile(`\b(kubectl (apply|rollout|set image)|helm (upgrade|install)|terraform apply|(flyctl|fly|serverless|sls|cdk|firebase|vercel|netlify|gcloud app|gcloud run|wrangler) deploy)\b`)
+
+// Commands that roll a deployment back to its previous release
+var rollbackCommand = regexp.MustCompile(`\b(kubectl rollout undo|helm rollback|argocd app rollback|vercel rollback|(flyctl|fly) releases rollback)\b`)
+
+// revertCommit matches the subject git revert gives a commit, or a commit naming a rollback
+var revertCommit = regexp.MustCompile(`(?i)^(revert\b|roll(ing|ed)? ?back\b)`)
+
+// WithDeployWorkflows names the workflow files that deploy, instead of detecting them
+func WithDeployWorkflows(files []string) Option {
+	return func(a *Analyzer) {
+		a.deployWorkflows = files
+	}
+}
+
+// pullRequestOnly reports whether a workflow only runs for pull requests
+func pullRequestOnly(w *repoWorkflow) bool {
+	for _, event := range w.parsed.On {
+		if event != "pull_request" && event != "pull_request_target" {
+			return false
+		}
+	}
+	return len(w.parsed.On) > 0
+}
+
+// isDeployWorkflow reports whether a workflow deploys: a job targets an environment
+// or a step runs a deploy action or command. Pull request only workflows never count.
+func isDeployWorkflow(w *repoWorkflow) bool {
+	if pullRequestOnly(w) {
+		return false
+	}
+	for _, job := range w.parsed.Jobs {
+		if job.Environment != "" {
+			return true
+		}
+		for _, step := range job.Steps {
+			action, _, _ := strings.Cut(step.Uses, "@")
+			if strings.Contains(strings.ToLower(action), "deploy") || deployCommand.MatchString(step.Run) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isRollbackWorkflow reports whether a workflow only exists to roll deployments
+// back: its name or file says so, or a step runs a rollback command. Its runs
+// undo deployments rather than ship changes, so they aren't deployments.
+func isRollbackWorkflow(w *repoWorkflow) bool {
+	if pullRequestOnly(w) {
+		return false
+	}
+	if strings.Contains(strings.ToLower(w.name), "rollback") || strings.Contains(strings.ToLower(w.file()), "rollback") {
+		return true
+	}
+	for _, job := range w.parsed.Jobs {
+		for _, step := range job.Steps {
+			if rollbackCommand.MatchString(step.Run) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deployWorkflowFiles returns the configured deploy workflows or the detected
+// ones, and apart from them the rollback workflows among or beside them
+func (a *Analyzer) deployWorkflowFiles(workflows []*repoWorkflow) (files, rollbacks []string) {
+	rollback := make(map[string]bool)
+	for _, w := range workflows {
+		if isRollbackWorkflow(w) {
+			rollback[w.file()] = true
+			rollbacks = append(rollbacks, w.file())
+		}
+	}
+	if len(a.deployWorkflows) > 0 {
+		for _, file := range a.deployWorkflows {
+			if !rollback[path.Base(file)] {
+				files = append(files, path.Base(file))
+			}
+		}
+	} else {
+		for _, w := range workflows {
+			if isDeployWorkflow(w) && !rollback[w.file()] {
+				files = append(files, w.file())
+			}
+		}
+	}
+	if len(files) > maxDeployWorkflows {
+		a.debugLog("Only the first %d deploy workflows are measured", maxDeployWorkflows)
+		files = files[:maxDeployWorkflows]
+	}
+	if len(rollbacks) > maxDeployWorkflows {
+		rollbacks = rollbacks[:maxDeployWorkflows]
+	}
+	return files, rollbacks
+}
+
+// analyzeDeployments computes deployment frequency, lead time for changes, change
+// failure rate and time to restore from the runs of the deploy workflows
+func (a *Analyzer) analyzeDeployments(ctx context.Context, owner, repo string, report *models.PerformanceReport, workflows []*repoWorkflow) {
+	files, rollbacks := a.deployWorkflowFiles(workflows)
+	if len(files) == 0 {
+		return
+	}
+
+	list := func(files []string) map[string][]*gh.WorkflowRun {
+		runs := make(map[string][]*gh.WorkflowRun)
+		for _, file := range files {
+			list, err := a.client.GetWorkflowRuns(ctx, owner, repo, file)
+			if err != nil {
+				a.debugLog("Error getting runs of %s: %v", file, err)
+				continue
+			}
+			runs[file] = list
+		}
+		return runs
+	}
+	runs, rollbackRuns := list(files), list(rollbacks)
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Rollback workflows' runs are rollbacks only, not deployments
+	if metrics := doraMetrics(runs, time.Now()); metrics != nil {
+		metrics.Workflows, metrics.RollbackWorkflows = files, rollbacks
+		measureRollbacks(metrics, runs, rollbackRuns)
+		report.DORA = metrics
+	}
+}
+
+// deployOutcome classifies a run as a successful or failed deployment. Pull request
+// runs, cancelled and skipped runs and runs still in progress are not deployments.
+func deployOutcome(run *gh.WorkflowRun) (deployed, failed bool) {
+	if run.GetStatus() != "completed" || strings.HasPrefix(run.GetEvent(), "pull_request") {
+		return false, false
+	}
+	switch run.GetConclusion() {
+	case "success":
+		return true, false
+	case "failure", "timed_out":
+		return false, true
+	}
+	return false, false
+}
+
+// doraMetrics measures the deploy workflows' runs up to now. It returns nil when
+// none of them deployed or failed to.
+func doraMetrics(runs map[string][]*gh.WorkflowRun, now time.Time) *models.DORAMetrics {
+	metrics := &models.DORAMetrics{}
+	var oldest time.Time
+	var leadTimes, restores []time.Duration
+
+	for _, list := range runs {
+		list = append([]*gh.WorkflowRun(nil), list...)
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].GetCreatedAt().Before(list[j].GetCreatedAt().Time)
+		})
+
+		// A failure opens an incident that the workflow's next successful deployment closes
+		var failingSince time.Time
+		for _, run := range list {
+			deployed, failed := deployOutcome(run)
+			if !deployed && !failed {
+				continue
+			}
+			if created := run.GetCreatedAt().Time; oldest.IsZero() || created.Before(oldest) {
+				oldest = created
+			}
+			finished := run.GetUpdatedAt().Time
+
+			if failed {
+				metrics.FailedDeployments++
+				if failingSince.IsZero() {
+					failingSince = finished
+				}
+				continue
+			}
+			metrics.Deployments++
+			if commit := run.GetHeadCommit(); commit != nil && commit.Timestamp != nil {
+				leadTimes = append(leadTimes, finished.Sub(commit.Timestamp.Time))
+			}
+			if !failingSince.IsZero() {
+				restores = append(restores, finished.Sub(failingSince))
+				failingSince = time.Time{}
+			}
+		}
+	}
+
+	total := metrics.Deployments + metrics.FailedDeployments
+	if total == 0 {
+		return nil
+	}
+	metrics.Period = now.Sub(oldest)
+	weeks := max(metrics.Period.Hours()/(24*7), 1.0/7)
+	metrics.DeploysPerWeek = float64(metrics.Deployments) / weeks
+	metrics.ChangeFailureRate = float64(metrics.FailedDeployments) / float64(total)
+	metrics.FrequencyLevel = frequencyLevel(metrics.DeploysPerWeek)
+	metrics.FailureRateLevel = failureRateLevel(metrics.ChangeFailureRate)
+	if len(leadTimes) > 0 {
+		metrics.LeadTime = median(leadTimes)
+		metrics.LeadTimeLevel = durationLevel(metrics.LeadTime, 24*time.Hour, 7*24*time.Hour, 30*24*time.Hour)
+	}
+	if len(restores) > 0 {
+		metrics.Recoveries = len(restores)
+		metrics.TimeToRestore = median(restores)
+		metrics.RestoreLevel = durationLevel(metrics.TimeToRestore, time.Hour, 24*time.Hour, 7*24*time.Hour)
+	}
+	return metrics
+}
+
+// measureRollbacks counts the successful runs that rolled a deployment back:
+// runs of rollback workflows, deployments of a revert commit, and runs
+// deploying a commit the workflow deployed before, other than its latest. The
+// time from the rolled back deployment to its rollback is averaged.
+func measureRollbacks(metrics *models.DORAMetrics, runs, rollbackRuns map[string][]*gh.WorkflowRun) {
+	type deployment struct {
+		file     string
+		run      *gh.WorkflowRun
+		rollback bool
+	}
+	var deployments []deployment
+	for i, set := range []map[string][]*gh.WorkflowRun{runs, rollbackRuns} {
+		for file, list := range set {
+			for _, run := range list {
+				if deployed, _ := deployOutcome(run); deployed {
+					deployments = append(deployments, deployment{file, run, i == 1})
+				}
+			}
+		}
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].run.GetCreatedAt().Before(deployments[j].run.GetCreatedAt().Time)
+	})
+
+	latest := make(map[string]*gh.WorkflowRun) // the latest deployment of each workflow
+	deployed := make(map[string]bool)          // workflow and commit pairs deployed
+	var last *gh.WorkflowRun                   // the latest deployment that isn't a rollback, of any workflow
+	var pairs []time.Duration
+	for _, d := range deployments {
+		finished := d.run.GetUpdatedAt().Time
+		sha := d.run.GetHeadSHA()
+		var rolledBack *gh.WorkflowRun
+		switch prev := latest[d.file]; {
+		case d.rollback:
+			metrics.Rollbacks++
+			rolledBack, last = last, nil
+		case prev != nil && (revertCommit.MatchString(d.run.GetHeadCommit().GetMessage()) ||
+			(deployed[d.file+"@"+sha] && sha != prev.GetHeadSHA())):
+			metrics.Rollbacks++
+			rolledBack = prev
+		default:
+			last = d.run
+		}
+		if rolledBack != nil {
+			pairs = append(pairs, finished.Sub(rolledBack.GetUpdatedAt().Time))
+		}
+		latest[d.file] = d.run
+		deployed[d.file+"@"+sha] = true
+	}
+	if metrics.Deployments > 0 {
+		metrics.RollbackRate = float64(metrics.Rollbacks) / float64(metrics.Deployments)
+	}
+	if len(pairs) > 0 {
+		metrics.TimeToRollback = average(pairs)
+	}
+}
+
+// frequencyLevel rates deployments per week: daily or more is elite, weekly high
+// and monthly medium
+func frequencyLevel(perWeek float64) string {
+	switch {
+	case perWeek >= 7:
+		return models.DORAElite
+	case perWeek >= 1:
+		return models.DORAHigh
+	case perWeek >= 12.0/52:
+		return models.DORAMedium
+	}
+	return models.DORALow
+}
+
+// failureRateLevel rates the share of deployments that failed
+func failureRateLevel(rate float64) string {
+	switch {
+	case rate <= 0.15:
+		return models.DORAElite
+	case rate <= 0.30:
+		return models.DORAHigh
+	case rate <= 0.45:
+		return models.DORAMedium
+	}
+	return models.DORALow
+}
+
+// durationLevel rates a lead or restore time against the elite, high and medium bounds
+func durationLevel(d, elite, high, medium time.Duration) string {
+	switch {
+	case d < elite:
+		return models.DORAElite
+	case d < high:
+		return models.DORAHigh
+	case d < medium:
+		return models.DORAMedium
+	}
+	return models.DORALow
+}
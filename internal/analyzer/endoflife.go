@@ -0,0 +1,322 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// VersionSource selects where the language versions suggested in examples come from
+type VersionSource string
+
+const (
+	// SourceGitHub reads the languages' GitHub releases, the default
+	SourceGitHub VersionSource = "github"
+	// SourceEndOfLife reads release lines and their end-of-life dates from
+	// endoflife.date, which also reports toolchains that reached end of life
+	SourceEndOfLife VersionSource = "endoflife"
+)
+
+// endOfLifeURL is the endoflife.date API
+const endOfLifeURL = "https://endoflife.date/api"
+
+// ParseVersionSource parses the version_source input, defaulting to github
+func ParseVersionSource(s string) (VersionSource, error) {
+	switch VersionSource(strings.ToLower(strings.TrimSpace(s))) {
+	case "", SourceGitHub:
+		return SourceGitHub, nil
+	case SourceEndOfLife:
+		return SourceEndOfLife, nil
+	default:
+		return "", fmt.Errorf("invalid version source %q: expected github or endoflife", s)
+	}
+}
+
+// WithVersionChecker replaces the GitHub releases lookup of language versions.
+// A LifecycleChecker also has toolchains on end-of-life versions reported.
+func WithVersionChecker(checker VersionChecker) Option {
+	return func(a *Analyzer) {
+		a.versionChecker = checker
+	}
+}
+
+// LifecycleChecker is a VersionChecker that also knows when release lines reach
+// end of life
+type LifecycleChecker interface {
+	VersionChecker
+	// EndOfLife reports whether the release line of a version has reached end
+	// of life and when; the date is zero when the source doesn't give one. known
+	// is false when the version isn't on any release line the source lists.
+	EndOfLife(ctx context.Context, lang, version string) (date time.Time, ended, known bool, err error)
+}
+
+// endOfLifeProducts are the endoflife.date products of the supported languages
+var endOfLifeProducts = map[string]string{
+	"go":     "go",
+	"node":   "nodejs",
+	"python": "python",
+	"java":   "eclipse-temurin",
+	"ruby":   "ruby",
+	"dotnet": "dotnet",
+}
+
+// dateOrBool is an endoflife.date field that is either a date or a boolean
+type dateOrBool struct {
+	set  bool
+	date time.Time
+}
+
+func (d *dateOrBool) UnmarshalJSON(raw []byte) error {
+	var flag bool
+	if err := json.Unmarshal(raw, &flag); err == nil {
+		d.set = flag
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return err
+	}
+	date, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return err
+	}
+	d.set, d.date = true, date
+	return nil
+}
+
+// reached reports whether the date or flag has come by now
+func (d dateOrBool) reached(now time.Time) bool {
+	return d.set && (d.date.IsZero() || !d.date.After(now))
+}
+
+// releaseCycle is a release line as endoflife.date lists it
+type releaseCycle struct {
+	Cycle       string     `json:"cycle"`
+	ReleaseDate string     `json:"releaseDate"`
+	Latest      string     `json:"latest"`
+	EOL         dateOrBool `json:"eol"`
+	LTS         dateOrBool `json:"lts"`
+}
+
+// EndOfLifeChecker looks up language versions and their end of life on
+// endoflife.date. Each product is fetched once, and a failed fetch isn't retried.
+type EndOfLifeChecker struct {
+	client  *http.Client
+	baseURL string
+	channel VersionChannel
+
+	mu     sync.Mutex
+	cycles map[string]cyclesEntry
+}
+
+// cyclesEntry is the outcome of fetching a product's release lines
+type cyclesEntry struct {
+	cycles []releaseCycle
+	err    error
+}
+
+// NewEndOfLifeChecker creates an EndOfLifeChecker suggesting versions on channel.
+// A nil client uses http.DefaultClient.
+func NewEndOfLifeChecker(client *http.Client, channel VersionChannel) *EndOfLifeChecker {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &EndOfLifeChecker{client: client, baseURL: endOfLifeURL, channel: channel, cycles: make(map[string]cyclesEntry)}
+}
+
+// releaseCycles returns the release lines of a language, newest first
+func (e *EndOfLifeChecker) releaseCycles(ctx context.Context, lang string) ([]releaseCycle, error) {
+	product, ok := endOfLifeProducts[lang]
+	if !ok {
+		return nil, fmt.Errorf("unsupported language: %s", lang)
+	}
+	e.mu.Lock()
+	entry, ok := e.cycles[product]
+	e.mu.Unlock()
+	if ok {
+		return entry.cycles, entry.err
+	}
+
+	cycles, err := e.fetchCycles(ctx, product)
+	// A cancelled analysis says nothing about endoflife.date
+	if ctx.Err() == nil {
+		e.mu.Lock()
+		e.cycles[product] = cyclesEntry{cycles, err}
+		e.mu.Unlock()
+	}
+	return cycles, err
+}
+
+// fetchCycles downloads the release lines of an endoflife.date product
+func (e *EndOfLifeChecker) fetchCycles(ctx context.Context, product string) ([]releaseCycle, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/%s.json", e.baseURL, product), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release cycles of %s: %v", product, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("failed to get release cycles of %s: %s: %s", product, resp.Status, strings.TrimSpace(string(body)))
+	}
+	var cycles []releaseCycle
+	if err := json.NewDecoder(resp.Body).Decode(&cycles); err != nil {
+		return nil, fmt.Errorf("failed to decode release cycles of %s: %v", product, err)
+	}
+	return cycles, nil
+}
+
+// GetLatestVersion returns the newest released line of a language on the
+// checker's channel. Languages whose lines are never marked LTS, such as Go and
+// Python, get their newest line on both channels. Lookup failures fall back to
+// the same versions as the GitHub checker.
+func (e *EndOfLifeChecker) GetLatestVersion(ctx context.Context, lang string) (string, error) {
+	if lang == "rust" {
+		return "stable", nil
+	}
+	source, ok := versionSources[lang]
+	if !ok {
+		return "", fmt.Errorf("unsupported language: %s", lang)
+	}
+	cycles, err := e.releaseCycles(ctx, lang)
+	if err != nil {
+		return source.fallback, nil
+	}
+
+	now := time.Now()
+	hasLTS := false
+	for _, c := range cycles {
+		hasLTS = hasLTS || c.LTS.set
+	}
+	for _, c := range cycles {
+		released, err := time.Parse("2006-01-02", c.ReleaseDate)
+		if err != nil || released.After(now) {
+			continue
+		}
+		if e.channel != ChannelLatest && hasLTS && !c.LTS.reached(now) {
+			continue
+		}
+		if source.majorOnly {
+			return c.Cycle, nil
+		}
+		if v, ok := parseSemver(c.Latest, ""); ok {
+			return fmt.Sprintf("%d.%d", v.major, v.minor), nil
+		}
+		return c.Cycle, nil
+	}
+	return source.fallback, nil
+}
+
+// EndOfLife finds the release line of a version, e.g. 18 for Node.js 18.17.0
+// or 3.8 for Python 3.8, and reports its end of life
+func (e *EndOfLifeChecker) EndOfLife(ctx context.Context, lang, version string) (time.Time, bool, bool, error) {
+	cycles, err := e.releaseCycles(ctx, lang)
+	if err != nil {
+		return time.Time{}, false, false, err
+	}
+	for _, c := range cycles {
+		if version == c.Cycle || strings.HasPrefix(version, c.Cycle+".") {
+			return c.EOL.date, c.EOL.reached(time.Now()), true, nil
+		}
+	}
+	return time.Time{}, false, false, nil
+}
+
+// toolchainInput is the version input of a setup action
+type toolchainInput struct {
+	lang  string
+	name  string // how the language is named in findings
+	input string
+}
+
+// toolchainInputs maps setup actions to the input selecting their toolchain version
+var toolchainInputs = map[string]toolchainInput{
+	"actions/setup-node":   {lang: "node", name: "Node.js", input: "node-version"},
+	"actions/setup-python": {lang: "python", name: "Python", input: "python-version"},
+	"actions/setup-go":     {lang: "go", name: "Go", input: "go-version"},
+	"actions/setup-java":   {lang: "java", name: "Java", input: "java-version"},
+	"ruby/setup-ruby":      {lang: "ruby", name: "Ruby", input: "ruby-version"},
+	"actions/setup-dotnet": {lang: "dotnet", name: ".NET", input: "dotnet-version"},
+}
+
+// toolchainVersions returns the versions a setup step installs: its literal
+// version input, or each value of the matrix key it refers to. Ranges, aliases
+// such as lts/* and versions from files aren't resolved.
+func toolchainVersions(job *workflow.Job, value string) []string {
+	value = strings.TrimSpace(value)
+	var values []string
+	if m := matrixLabel.FindStringSubmatch(value); m != nil {
+		values, _ = job.MatrixValues(m[1])
+	} else if !strings.Contains(value, "${{") {
+		values = strings.Fields(value)
+	}
+
+	var versions []string
+	for _, v := range values {
+		v = strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(strings.Trim(v, `'"`), "v"), ".x"), ".*")
+		if _, err := strconv.Atoi(strings.ReplaceAll(v, ".", "")); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// checkToolchainEOL reports setup steps installing a language version whose
+// release line reached end of life. It needs a LifecycleChecker, such as the
+// endoflife.date one.
+func (a *Analyzer) checkToolchainEOL(ctx context.Context, path string, wf *workflow.Workflow) []models.Finding {
+	checker, ok := a.versionChecker.(LifecycleChecker)
+	if !ok {
+		return nil
+	}
+
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			action, _, _ := strings.Cut(step.Uses, "@")
+			toolchain, ok := toolchainInputs[action]
+			if !ok {
+				continue
+			}
+			for _, version := range toolchainVersions(job, step.With[toolchain.input]) {
+				date, ended, known, err := checker.EndOfLife(ctx, toolchain.lang, version)
+				if err != nil {
+					a.debugLog("Error looking up the end of life of %s %s: %v", toolchain.name, version, err)
+					break
+				}
+				if !known || !ended {
+					continue
+				}
+				message := a.lang.Sprintf("Job %s installs %s %s, which has reached end of life", job.ID, toolchain.name, version)
+				if !date.IsZero() {
+					message = a.lang.Sprintf("Job %s installs %s %s, which reached end of life on %s", job.ID, toolchain.name, version, date.Format("2006-01-02"))
+				}
+				finding := models.Finding{
+					Category:   "security",
+					Severity:   models.SeverityWarning,
+					File:       path,
+					Line:       step.Line,
+					Message:    message,
+					Suggestion: a.lang.T("Move to a supported version; end-of-life toolchains no longer get security fixes"),
+				}
+				if latest, err := checker.GetLatestVersion(ctx, toolchain.lang); err == nil {
+					finding.Example = fmt.Sprintf("      - uses: %s\n        with:\n          %s: '%s'", step.Uses, toolchain.input, latest)
+				}
+				findings = append(findings, finding)
+			}
+		}
+	}
+	return findings
+}
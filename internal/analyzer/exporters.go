@@ -0,0 +1,62 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/telemetry"
+)
+
+// WithExporters sends the analyzed runs with their jobs and steps to monitoring
+// backends, e.g. an OpenTelemetry collector as traces
+func WithExporters(exporters ...telemetry.Exporter) Option {
+	return func(a *Analyzer) {
+		a.exporters = exporters
+	}
+}
+
+// exportListedRuns sends the runs a reused report was made from to each
+// exporter, listing them and their jobs again as the analysis would, so the
+// result cache doesn't keep runs from the backends
+func (a *Analyzer) exportListedRuns(ctx context.Context, owner, repo, workflowFile string, report *models.PerformanceReport) {
+	if len(a.exporters) == 0 {
+		return
+	}
+	runs, err := a.client.GetWorkflowRuns(ctx, owner, repo, workflowFile)
+	if err != nil {
+		fmt.Fprintf(a.progressOf(ctx), "Warning: exporting runs failed: %v\n", err)
+		return
+	}
+	selected := a.selectRuns(runs)
+	var samples []runSample
+	for i, run := range runs {
+		if a.mode == ModeDeep && !selected[i] {
+			continue
+		}
+		jobs, err := a.client.GetWorkflowJobs(ctx, owner, repo, run.GetID(), run.GetRunAttempt())
+		if err != nil {
+			fmt.Fprintf(a.progressOf(ctx), "Warning: exporting runs failed: %v\n", err)
+			return
+		}
+		samples = append(samples, runSample{Run: run, Jobs: jobs, Listed: runs})
+	}
+	a.exportRuns(ctx, report, samples)
+}
+
+// exportRuns sends the sampled runs to each exporter. A backend that can't be
+// reached only costs its export, not the analysis.
+func (a *Analyzer) exportRuns(ctx context.Context, report *models.PerformanceReport, samples []runSample) {
+	runs := make([]telemetry.Run, 0, len(samples))
+	for _, sample := range samples {
+		runs = append(runs, telemetry.Run{Run: sample.Run, Jobs: sample.Jobs})
+	}
+	for _, exporter := range a.exporters {
+		n, err := exporter.Export(ctx, report.Repository, runs)
+		if err != nil {
+			fmt.Fprintf(a.progressOf(ctx), "Warning: exporting runs to %s failed: %v\n", exporter.Name(), err)
+			continue
+		}
+		fmt.Fprintf(a.progressOf(ctx), "Exported %d runs to %s\n", n, exporter.Name())
+	}
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// duplicatedExpressionSteps is how many steps of a job may repeat a long
+	// expression before hoisting it is suggested
+	duplicatedExpressionSteps = 3
+	// longExpression is the length from which a repeated expression is worth hoisting
+	longExpression = 40
+	// maxExpressionConditions is how many conditions joined with && and || an
+	// expression may have before it is hard to read
+	maxExpressionConditions = 4
+	// maxExpressionLength is the length from which a single expression is hard to read
+	maxExpressionLength = 150
+)
+
+// stepExpression is a ${{ }} expression found in a step
+type stepExpression struct {
+	expr string // with whitespace normalized
+	step *workflow.Step
+	line int
+}
+
+// stepExpressions collects the ${{ }} expressions of a step's values, at the
+// line each one is on, and its if: condition, which may leave out the ${{ }}
+func stepExpressions(step *workflow.Step) []stepExpression {
+	var found []stepExpression
+	if cond := workflow.Lookup(step.Node, "if"); cond != nil && !strings.Contains(cond.Value, "${{") {
+		found = append(found, stepExpression{expr: strings.Join(strings.Fields(cond.Value), " "), step: step, line: cond.Line})
+	}
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode {
+			for _, loc := range expressionPattern.FindAllStringSubmatchIndex(node.Value, -1) {
+				expr := strings.Join(strings.Fields(node.Value[loc[2]:loc[3]]), " ")
+				line := workflow.ScalarStartLine(node) + strings.Count(node.Value[:loc[0]], "\n")
+				found = append(found, stepExpression{expr: expr, step: step, line: line})
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	if step.Node != nil {
+		walk(step.Node)
+	}
+	return found
+}
+
+// expressionConditions counts the conditions an expression joins with && and ||
+func expressionConditions(expr string) int {
+	expr = stringLiteral.ReplaceAllString(expr, "''")
+	return strings.Count(expr, "&&") + strings.Count(expr, "||") + 1
+}
+
+// checkExpressions finds long ${{ }} expressions repeated across the steps of a
+// job, which drift apart when one copy is edited, and expressions too complex to
+// read at a glance. Both read better computed once under a descriptive name.
+func (a *Analyzer) checkExpressions(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+
+	for _, job := range wf.Jobs {
+		var order []string
+		uses := make(map[string][]stepExpression)
+		for _, step := range job.Steps {
+			for _, e := range stepExpressions(step) {
+				prev := uses[e.expr]
+				if len(prev) > 0 && prev[len(prev)-1].step == step {
+					continue
+				}
+				if len(prev) == 0 {
+					order = append(order, e.expr)
+				}
+				uses[e.expr] = append(prev, e)
+			}
+		}
+
+		for _, expr := range order {
+			found := uses[expr]
+			first := found[0]
+			if len(expr) >= longExpression && len(found) >= duplicatedExpressionSteps {
+				var lines []string
+				for _, e := range found {
+					lines = append(lines, fmt.Sprint(e.line))
+				}
+				finding := models.Finding{
+					Category: "style",
+					Severity: models.SeverityInfo,
+					File:     path,
+					Line:     first.line,
+					Message: a.lang.Sprintf("Expression ${{ %s }} is repeated in %d steps of job %s (lines %s)",
+						expr, len(found), job.ID, strings.Join(lines, ", ")),
+				}
+				// Job env is evaluated before any step runs, so step results need an output
+				if strings.Contains(expr, "steps.") {
+					finding.Suggestion = a.lang.T("Compute it once in a step that writes it to $GITHUB_OUTPUT and refer to that output in the other steps")
+				} else {
+					finding.Suggestion = a.lang.T("Hoist it into the job's env: under a descriptive name and refer to that variable in the steps")
+				}
+				findings = append(findings, finding)
+				continue
+			}
+
+			conditions := expressionConditions(expr)
+			if conditions <= maxExpressionConditions && len(expr) < maxExpressionLength {
+				continue
+			}
+			findings = append(findings, models.Finding{
+				Category:   "style",
+				Severity:   models.SeverityInfo,
+				File:       path,
+				Line:       first.line,
+				Message:    a.lang.Sprintf("Step %d of job %s has an expression of %d characters with %d conditions", first.step.Index+1, job.ID, len(expr), conditions),
+				Suggestion: a.lang.T("Split it into named parts in the job's env: or a step output, so each condition can be read and reused on its own"),
+			})
+		}
+	}
+
+	return findings
+}
@@ -0,0 +1,55 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// workflowFingerprint reduces a workflow to its triggers, runners, reusable
+// workflows, actions and commands, leaving out job names, action versions and
+// inputs, for finding copies of it in other repositories
+func workflowFingerprint(path string, wf *workflow.Workflow) models.WorkflowFingerprint {
+	seen := make(map[string]bool)
+	var features []string
+	add := func(feature string) {
+		if !seen[feature] {
+			seen[feature] = true
+			features = append(features, feature)
+		}
+	}
+	unversioned := func(ref string) string {
+		name, _, _ := strings.Cut(ref, "@")
+		return name
+	}
+
+	for _, event := range wf.On {
+		add("on:" + event)
+	}
+	for _, job := range wf.Jobs {
+		if len(job.RunsOn) > 0 {
+			add("runs-on:" + strings.Join(job.RunsOn, ","))
+		}
+		if job.Uses != "" {
+			add("call:" + unversioned(job.Uses))
+		}
+		if job.HasMatrix {
+			add("matrix")
+		}
+		if job.Container != "" {
+			add("container")
+		}
+		for _, step := range job.Steps {
+			switch {
+			case step.Uses != "":
+				add("uses:" + unversioned(step.Uses))
+			case step.Run != "":
+				add("run:" + strings.Join(strings.Fields(step.Run), " "))
+			}
+		}
+	}
+	sort.Strings(features)
+	return models.WorkflowFingerprint{File: path, Features: features}
+}
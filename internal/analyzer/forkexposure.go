@@ -0,0 +1,308 @@
+package analyzer
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// forkRef matches checkout refs pointing at the pull request's or the
+	// triggering run's code rather than the base repository's
+	forkRef = regexp.MustCompile(`github\.event\.pull_request\.head\.(?:sha|ref)|github\.head_ref|refs/pull/|github\.event\.workflow_run\.head_(?:sha|branch)|github\.event\.number`)
+	// untrustedInput matches contexts a pull request's author controls
+	untrustedInput = regexp.MustCompile(`github\.event\.(?:pull_request\.(?:title|body|head\.ref|head\.label|head\.repo\.default_branch)|issue\.(?:title|body)|comment\.body|review\.body|review_comment\.body|head_commit\.(?:message|author\.name|author\.email)|workflow_run\.(?:head_branch|display_title|head_commit\.(?:message|author\.name|author\.email)))|github\.head_ref`)
+	// expression matches a ${{ }} expression
+	expression = regexp.MustCompile(`\$\{\{[^}]*\}\}`)
+)
+
+// artifactDownloads are actions that download artifacts from another run
+var artifactDownloads = map[string]bool{
+	"actions/download-artifact":        true,
+	"dawidd6/action-download-artifact": true,
+}
+
+// forkRisks is what a fork's pull request can reach through one workflow
+type forkRisks struct {
+	runsForkCode bool
+	injected     []string // untrusted contexts interpolated into scripts
+	secrets      []string
+	inherits     bool     // a reusable workflow gets all secrets with secrets: inherit
+	writes       []string // permissions granted for writing
+	defaultPerms bool     // the GITHUB_TOKEN has the repository's default permissions
+}
+
+// privileged reports whether the workflow holds anything worth stealing
+func (r *forkRisks) privileged() bool {
+	return len(r.secrets) > 0 || r.inherits || len(r.writes) > 0 || r.defaultPerms
+}
+
+// rating rates the exposure: a fork's code or input running with secrets or a
+// write token is high, without them medium, and privileges alone low
+func (r *forkRisks) rating() string {
+	untrusted := r.runsForkCode || len(r.injected) > 0
+	switch {
+	case untrusted && r.privileged():
+		return models.ExposureHigh
+	case untrusted:
+		return models.ExposureMedium
+	default:
+		return models.ExposureLow
+	}
+}
+
+// writePermissions returns the scopes a permissions block grants write access to
+func writePermissions(perms map[string]string) []string {
+	var writes []string
+	for scope, access := range perms {
+		if access == "write" || access == "write-all" {
+			writes = append(writes, scope)
+		}
+	}
+	sort.Strings(writes)
+	return writes
+}
+
+// assessForkRisks finds what a workflow running with the base repository's
+// privileges does with a pull request's code and input
+func assessForkRisks(wf *workflow.Workflow, trigger string) *forkRisks {
+	risks := &forkRisks{}
+	seenInput := make(map[string]bool)
+	seenWrite := make(map[string]bool)
+	secretValues := mapValues(wf.Env)
+
+	for _, job := range wf.Jobs {
+		perms, hasPerms := wf.Permissions, wf.HasPerms
+		if job.HasPerms {
+			perms, hasPerms = job.Permissions, true
+		}
+		if !hasPerms {
+			risks.defaultPerms = true
+		}
+		for _, scope := range writePermissions(perms) {
+			if !seenWrite[scope] {
+				seenWrite[scope] = true
+				risks.writes = append(risks.writes, scope)
+			}
+		}
+		secretValues = append(secretValues, mapValues(job.Env)...)
+		if passed := workflow.Lookup(job.Node, "secrets"); passed != nil {
+			risks.inherits = risks.inherits || (passed.Kind == yaml.ScalarNode && passed.Value == "inherit")
+			secretValues = append(secretValues, workflow.Scalars(passed)...)
+		}
+
+		for _, step := range job.Steps {
+			action, _, _ := strings.Cut(step.Uses, "@")
+			action = strings.ToLower(action)
+			secretValues = append(secretValues, step.Run)
+			secretValues = append(secretValues, mapValues(step.With)...)
+			secretValues = append(secretValues, mapValues(step.Env)...)
+
+			switch {
+			case action == "actions/checkout" && forkRef.MatchString(step.With["ref"]+" "+step.With["repository"]):
+				risks.runsForkCode = true
+			case trigger == "workflow_run" && artifactDownloads[action]:
+				// Artifacts of the triggering run were built from the fork's code
+				risks.runsForkCode = true
+			}
+
+			scripts := []string{step.Run}
+			if action == "actions/github-script" {
+				scripts = append(scripts, step.With["script"])
+			}
+			for _, script := range scripts {
+				for _, expr := range expression.FindAllString(script, -1) {
+					for _, input := range untrustedInput.FindAllString(expr, -1) {
+						if !seenInput[input] {
+							seenInput[input] = true
+							risks.injected = append(risks.injected, input)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, name := range secretNames(secretValues...) {
+		if !strings.EqualFold(name, "GITHUB_TOKEN") {
+			risks.secrets = append(risks.secrets, name)
+		}
+	}
+	sort.Strings(risks.secrets)
+	return risks
+}
+
+// pullRequestTriggered reports whether a workflow runs for pull requests, which
+// includes pull requests from forks
+func pullRequestTriggered(wf *workflow.Workflow) bool {
+	return wf.HasTrigger("pull_request") || wf.HasTrigger("pull_request_target")
+}
+
+// analyzeForkExposure rates the repository's exposure to malicious pull requests
+// from forks. Workflows triggered by pull_request run without secrets and with a
+// read-only token, so only pull_request_target workflows and workflow_run ones
+// following pull request workflows, which run with the base repository's
+// privileges, are assessed. The exposure stays unset when no workflow runs for
+// pull requests.
+func (a *Analyzer) analyzeForkExposure(report *models.PerformanceReport, workflows []*repoWorkflow) {
+	byName := make(map[string]*repoWorkflow)
+	exposed := false
+	for _, w := range workflows {
+		byName[w.name] = w
+		exposed = exposed || pullRequestTriggered(w.parsed) || w.parsed.HasTrigger("workflow_run")
+	}
+	if !exposed {
+		return
+	}
+
+	exposure := &models.ForkExposure{Rating: models.ExposureNone}
+	for _, w := range workflows {
+		wf := w.parsed
+		var trigger string
+		switch {
+		case wf.HasTrigger("pull_request_target"):
+			trigger = "pull_request_target"
+		case wf.HasTrigger("workflow_run"):
+			// Only runs of workflows that pull requests trigger come from forks;
+			// triggering workflows outside the checked files are assumed to
+			reachable := len(w.triggeredBy) == 0
+			for _, name := range w.triggeredBy {
+				parent, ok := byName[name]
+				reachable = reachable || !ok || pullRequestTriggered(parent.parsed)
+			}
+			if !reachable {
+				continue
+			}
+			trigger = "workflow_run"
+		default:
+			continue
+		}
+
+		risks := assessForkRisks(wf, trigger)
+		exposed := models.ForkExposedWorkflow{File: w.path, Trigger: trigger, Rating: risks.rating()}
+		if risks.runsForkCode {
+			if trigger == "workflow_run" {
+				exposed.Risks = append(exposed.Risks, a.lang.T("Checks out or downloads code built from the pull request"))
+			} else {
+				exposed.Risks = append(exposed.Risks, a.lang.T("Checks out the pull request's code"))
+			}
+		}
+		if len(risks.injected) > 0 {
+			exposed.Risks = append(exposed.Risks, a.lang.Sprintf("Interpolates %s into scripts", strings.Join(risks.injected, ", ")))
+		}
+		if len(risks.secrets) > 0 {
+			exposed.Risks = append(exposed.Risks, a.lang.Sprintf("Uses secrets %s", strings.Join(risks.secrets, ", ")))
+		}
+		if risks.inherits {
+			exposed.Risks = append(exposed.Risks, a.lang.T("Passes every secret to a reusable workflow with secrets: inherit"))
+		}
+		if len(risks.writes) > 0 {
+			exposed.Risks = append(exposed.Risks, a.lang.Sprintf("Grants the GITHUB_TOKEN write access to %s", strings.Join(risks.writes, ", ")))
+		}
+		if risks.defaultPerms {
+			exposed.Risks = append(exposed.Risks, a.lang.T("Leaves the GITHUB_TOKEN with the repository's default permissions, which may include write access"))
+		}
+		exposure.Add(exposed)
+
+		if exposed.Rating == models.ExposureLow {
+			continue
+		}
+		finding := models.Finding{
+			Category: "security",
+			Severity: models.SeverityWarning,
+			File:     w.path,
+			Line:     wf.OnLine,
+			Message:  a.lang.Sprintf("Workflow %s runs a fork's code or input from %s", w.name, trigger),
+		}
+		if exposed.Rating == models.ExposureHigh {
+			finding.Severity = models.SeverityCritical
+			finding.Message = a.lang.Sprintf("Workflow %s runs a fork's code or input from %s with secrets or a write token", w.name, trigger)
+		}
+		if trigger == "pull_request_target" {
+			finding.Suggestion = a.lang.T("Split the workflow in two: build and test the pull request under pull_request, without secrets, and act on its uploaded results in a workflow_run workflow that never executes them")
+			finding.Example = forkSplitExample(w, risks)
+		} else {
+			finding.Suggestion = a.lang.T("Treat the triggering run's code, artifacts and branch names as untrusted: never execute them, and pass event values to scripts through env variables")
+			finding.Example = `      - run: echo "Testing $HEAD_BRANCH"
+        env:
+          HEAD_BRANCH: ${{ github.event.workflow_run.head_branch }}`
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+	report.ForkExposure = exposure
+}
+
+// forkSplitExample generates the two-workflow split of a pull_request_target
+// workflow: an unprivileged pull_request build uploading its results, and a
+// privileged workflow_run workflow downloading them as data
+func forkSplitExample(w *repoWorkflow, risks *forkRisks) string {
+	base := strings.TrimSuffix(w.file(), path.Ext(w.file()))
+	buildName := w.name + " build"
+	if w.name == w.path {
+		buildName = base + "-build"
+	}
+
+	writes := risks.writes
+	if len(writes) == 0 {
+		writes = []string{"pull-requests"}
+	}
+	var perms strings.Builder
+	perms.WriteString("      actions: read\n")
+	for _, scope := range writes {
+		if scope == "*" || scope == "actions" {
+			continue
+		}
+		fmt.Fprintf(&perms, "      %s: write\n", scope)
+	}
+	var env strings.Builder
+	for _, name := range risks.secrets {
+		if env.Len() == 0 {
+			env.WriteString("        env:\n")
+		}
+		fmt.Fprintf(&env, "          %s: ${{ secrets.%s }}\n", name, name)
+	}
+
+	example := fmt.Sprintf(`# .github/workflows/%[1]s-build.yml: runs the fork's code without secrets
+name: %[2]s
+on: pull_request
+permissions:
+  contents: read
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: make test > results.txt
+      - uses: actions/upload-artifact@v4
+        with:
+          name: results
+          path: results.txt
+
+# %[3]s: acts on the results with the repository's privileges
+name: %[4]s
+on:
+  workflow_run:
+    workflows: [%[2]q]
+    types: [completed]
+jobs:
+  report:
+    if: github.event.workflow_run.event == 'pull_request'
+    runs-on: ubuntu-latest
+    permissions:
+%[5]s    steps:
+      - uses: actions/download-artifact@v4
+        with:
+          name: results
+          run-id: ${{ github.event.workflow_run.id }}
+          github-token: ${{ secrets.GITHUB_TOKEN }}
+      # The artifact was built from untrusted code: read it as data, never run it
+      - run: ./scripts/report.sh results.txt
+%[6]s`, base, buildName, w.path, w.name, perms.String(), env.String())
+	return strings.TrimRight(example, "\n")
+}
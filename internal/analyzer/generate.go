@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/patch"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// Fixes a generated workflow can make, as named in the fixes input
+const (
+	FixPermissions  = "permissions"   // read-only token permissions
+	FixConcurrency  = "concurrency"   // a group cancelling superseded pull request runs
+	FixCache        = "cache"         // the built-in dependency cache of setup-* actions
+	FixRunnerImages = "runner_images" // retired runner images moved to their replacement
+	FixPinning      = "pinning"       // unpinned actions pinned to their latest release
+)
+
+// Fixes lists the fixes a generated workflow can make
+func Fixes() []string {
+	return []string{FixPermissions, FixConcurrency, FixCache, FixRunnerImages, FixPinning}
+}
+
+// GeneratedWorkflow is a workflow file rewritten with the analyzer's fixes
+type GeneratedWorkflow struct {
+	Path    string // the original workflow file in the repository
+	Content string // the rewritten workflow
+	Diff    string // the changes as a unified diff; empty when nothing needed fixing
+	Changes int    // number of places changed
+}
+
+// Generate rewrites a workflow file with the fixes the analysis would suggest as
+// patches, limited to fixes when it isn't empty, for review before it replaces
+// the original. It reads only the workflow file and, for pinning, the latest
+// releases of its actions, so it costs a handful of API requests.
+func (a *Analyzer) Generate(ctx context.Context, owner, repo, workflowFile string, fixes []string) (*GeneratedWorkflow, error) {
+	workflowPath := workflowFile
+	if !strings.HasPrefix(workflowPath, ".github/workflows/") {
+		workflowPath = fmt.Sprintf(".github/workflows/%s", workflowPath)
+	}
+	content, err := a.client.GetFileContent(ctx, owner, repo, workflowPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow file: %v", err)
+	}
+	if _, err := workflow.Parse(content); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow file: %v", err)
+	}
+
+	var wanted map[string]bool
+	if len(fixes) > 0 {
+		wanted = make(map[string]bool)
+		for _, fix := range fixes {
+			wanted[fix] = true
+		}
+	}
+	edits := a.workflowEdits(ctx, content, wanted)
+	a.debugLog("Generating %s with %d changes", workflowPath, len(edits))
+	return &GeneratedWorkflow{
+		Path:    workflowPath,
+		Content: patch.Apply(content, edits),
+		Diff:    patch.Unified(workflowPath, content, edits),
+		Changes: len(edits),
+	}, nil
+}
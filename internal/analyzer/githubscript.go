@@ -0,0 +1,167 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// largeInlineScript is the number of lines from which an inline github-script
+// is better kept in a file
+const largeInlineScript = 40
+
+var (
+	// restCall matches REST API calls in a script, e.g. github.rest.issues.createComment
+	restCall = regexp.MustCompile(`\bgithub\.(?:rest\.)?([a-zA-Z]+)\.([a-zA-Z]+)\s*\(`)
+	// writeMethod matches the REST methods that modify resources
+	writeMethod = regexp.MustCompile(`^(?:create|update|delete|add|remove|set|merge|lock|unlock|dispatch|rerun|reRun|cancel|request|submit|dismiss|upload|approve|enable|disable|replace)`)
+)
+
+// restPermissions maps REST API namespaces to the token permission they need
+var restPermissions = map[string]string{
+	"issues":   "issues",
+	"pulls":    "pull-requests",
+	"repos":    "contents",
+	"git":      "contents",
+	"actions":  "actions",
+	"checks":   "checks",
+	"packages": "packages",
+	"projects": "repository-projects",
+}
+
+// scriptPermissions returns the token permissions the REST calls of a script need,
+// each "read" or "write"
+func scriptPermissions(script string) map[string]string {
+	needed := make(map[string]string)
+	for _, m := range restCall.FindAllStringSubmatch(script, -1) {
+		scope, ok := restPermissions[m[1]]
+		if !ok {
+			continue
+		}
+		switch method := m[2]; {
+		case m[1] == "repos" && strings.Contains(method, "Deployment"):
+			scope = "deployments"
+		case m[1] == "repos" && strings.Contains(method, "CommitStatus"):
+			scope = "statuses"
+		}
+		if writeMethod.MatchString(m[2]) {
+			needed[scope] = "write"
+		} else if needed[scope] == "" {
+			needed[scope] = "read"
+		}
+	}
+	return needed
+}
+
+// grantedPermission returns the level the declared permissions of a job, or else
+// of the workflow, grant for scope
+func grantedPermission(wf *workflow.Workflow, job *workflow.Job, scope string) string {
+	perms := wf.Permissions
+	if job.HasPerms {
+		perms = job.Permissions
+	}
+	switch perms["*"] {
+	case "write-all":
+		return "write"
+	case "read-all":
+		return "read"
+	}
+	if level := perms[scope]; level != "" {
+		return level
+	}
+	return "none"
+}
+
+// permissionsBlock renders a job permissions block granting the needed scopes
+func permissionsBlock(needed map[string]string) string {
+	scopes := make([]string, 0, len(needed))
+	for scope := range needed {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	block := "    permissions:"
+	for _, scope := range scopes {
+		block += fmt.Sprintf("\n      %s: %s", scope, needed[scope])
+	}
+	return block
+}
+
+// checkGithubScript reviews actions/github-script steps: long inline scripts that
+// can't be tested or reused, and the token permissions their API calls need
+func (a *Analyzer) checkGithubScript(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			if !usesAction(step, "actions/github-script") {
+				continue
+			}
+			script := step.With["script"]
+
+			if lines := strings.Count(strings.TrimSpace(script), "\n") + 1; lines >= largeInlineScript {
+				name := step.ID
+				if name == "" {
+					name = job.ID
+				}
+				findings = append(findings, models.Finding{
+					Category:   "reliability",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Step %q in job %s runs a %d-line inline github-script", step.DisplayName(), job.ID, lines),
+					Suggestion: a.lang.T("Move the script into a versioned file under .github/scripts that exports a function, so it can be linted and unit tested, or into a custom JavaScript action when several workflows share it; the job must check out the repository first"),
+					Example: fmt.Sprintf(`      - uses: %s
+        with:
+          script: |
+            const run = require('./.github/scripts/%s.js')
+            await run({ github, context, core })`, step.Uses, name),
+				})
+			}
+
+			// A custom token's permissions can't be seen from the workflow
+			if _, ok := step.With["github-token"]; ok {
+				continue
+			}
+			needed := scriptPermissions(script)
+			if len(needed) == 0 {
+				continue
+			}
+
+			var missing []string
+			declared := wf.HasPerms || job.HasPerms
+			for scope, level := range needed {
+				if granted := grantedPermission(wf, job, scope); granted == "none" || (level == "write" && granted != "write") {
+					missing = append(missing, fmt.Sprintf("%s: %s", scope, level))
+				}
+			}
+			sort.Strings(missing)
+
+			switch {
+			case !declared:
+				findings = append(findings, models.Finding{
+					Category:   "security",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("github-script step %q in job %s relies on the default token permissions for its API calls", step.DisplayName(), job.ID),
+					Suggestion: a.lang.T("Declare the permissions the script needs on the job, so it keeps working when the default token permissions are read-only and gets nothing more"),
+					Example:    permissionsBlock(needed),
+				})
+			case len(missing) > 0:
+				findings = append(findings, models.Finding{
+					Category:   "security",
+					Severity:   models.SeverityWarning,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("github-script step %q in job %s calls APIs needing %s, which the job's permissions don't grant", step.DisplayName(), job.ID, strings.Join(missing, ", ")),
+					Suggestion: a.lang.T("Grant the missing permissions on the job, or the API calls fail with 403 Resource not accessible by integration"),
+					Example:    permissionsBlock(needed),
+				})
+			}
+		}
+	}
+	return findings
+}
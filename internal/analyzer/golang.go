@@ -0,0 +1,314 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// go test prints one line per package, e.g. "ok  	example.com/pkg	1.234s" or "ok  	example.com/pkg	(cached)"
+	goTestResult = regexp.MustCompile(`(?:^|\s)(?:ok|FAIL)\s+(\S+)\s+(\d+\.\d+)s\b`)
+	goTestCached = regexp.MustCompile(`(?:^|\s)ok\s+\S+\s+\(cached\)`)
+	goModVersion = regexp.MustCompile(`@v(\d+)`)
+)
+
+const (
+	// Test suites faster than this aren't worth a per-package breakdown
+	slowGoTests = time.Minute
+	// go test -race runs shorter than this are cheap enough to keep on every run
+	slowGoRace = 2 * time.Minute
+	// go mod download steps faster than this are already served well by the proxy
+	slowGoDownload = 30 * time.Second
+	// slowGoPackages is how many of the slowest packages are named
+	slowGoPackages = 5
+)
+
+// goTestTimes sums go test durations per package in a run's logs and counts
+// packages whose result was served from the test cache
+func goTestTimes(logs string) (times map[string]time.Duration, cached int) {
+	times = make(map[string]time.Duration)
+	for _, line := range strings.Split(logs, "\n") {
+		if goTestCached.MatchString(line) {
+			cached++
+			continue
+		}
+		if m := goTestResult.FindStringSubmatch(line); m != nil {
+			seconds, _ := strconv.ParseFloat(m[2], 64)
+			times[m[1]] += time.Duration(seconds * float64(time.Second))
+		}
+	}
+	return times, cached
+}
+
+// goFlags returns GOFLAGS as seen by a step, which overrides the job and workflow env
+func goFlags(wf *workflow.Workflow, job *workflow.Job, step *workflow.Step) string {
+	for _, env := range []map[string]string{step.Env, job.Env, wf.Env} {
+		if v, ok := env["GOFLAGS"]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// goCacheRestored reports whether a job restores the Go build cache, either through
+// setup-go (cached by default since v4) or an actions/cache step on ~/.cache/go-build
+func goCacheRestored(job *workflow.Job) bool {
+	for _, step := range job.Steps {
+		switch {
+		case usesAction(step, "actions/setup-go"):
+			if v, ok := step.With["cache"]; ok {
+				if strings.TrimSpace(v) == "true" {
+					return true
+				}
+				continue
+			}
+			// Without an explicit input, v4 and later cache by default; SHA pins are assumed current
+			if m := goModVersion.FindStringSubmatch(step.Uses); m != nil {
+				if major, _ := strconv.Atoi(m[1]); major < 4 {
+					continue
+				}
+			}
+			return true
+		case usesAction(step, "actions/cache"), usesAction(step, "actions/cache/restore"):
+			if strings.Contains(step.With["path"], "go-build") || strings.Contains(step.With["path"], "GOCACHE") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rewritesGoMod reports whether a run script may modify go.mod or go.sum
+func rewritesGoMod(run string) bool {
+	for _, line := range strings.Split(run, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.Contains(line, "go get ") || (strings.Contains(line, "go mod tidy") && !strings.Contains(line, "-diff")) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGoBuild reviews how Go jobs test, cache and download modules, using
+// per-package go test timings and measured step durations from the sampled runs
+func (a *Analyzer) checkGoBuild(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+
+	// Per-package test time averaged over the runs that ran go test
+	totals := make(map[string]time.Duration)
+	runsWithTests, cachedResults := 0, 0
+	testsURL := "" // newest run that ran go test
+	for _, sample := range samples {
+		times, cached := goTestTimes(sample.Logs)
+		if len(times) == 0 && cached == 0 {
+			continue
+		}
+		if runsWithTests == 0 {
+			testsURL = sample.Run.GetHTMLURL()
+		}
+		runsWithTests++
+		cachedResults += cached
+		for pkg, d := range times {
+			totals[pkg] += d
+		}
+	}
+
+	reportedTests := false
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			if !strings.Contains(step.Run, "go test") {
+				continue
+			}
+
+			if !reportedTests && runsWithTests > 0 {
+				// Logs aren't split by step, so the breakdown is reported once, at the first go test step
+				reportedTests = true
+				if finding, ok := a.goTestBreakdown(path, step, totals, runsWithTests); ok {
+					finding.URL = testsURL
+					finding.Confidence = logConfidence(runsWithTests, samples)
+					findings = append(findings, finding)
+				}
+			}
+
+			if strings.Contains(step.Run, "-race") {
+				if finding, ok := a.goRaceCost(path, job, step, samples); ok {
+					findings = append(findings, finding)
+				}
+			}
+		}
+	}
+
+	for _, job := range wf.Jobs {
+		var goStep *workflow.Step
+		for _, step := range job.Steps {
+			if usesAction(step, "actions/setup-go") || strings.Contains(step.Run, "go build") || strings.Contains(step.Run, "go test") {
+				goStep = step
+				break
+			}
+		}
+		if goStep == nil {
+			continue
+		}
+		restored := goCacheRestored(job)
+
+		if !restored {
+			finding := models.Finding{
+				Category:   "performance",
+				Severity:   models.SeverityWarning,
+				File:       path,
+				Line:       goStep.Line,
+				Message:    a.lang.Sprintf("Job %s builds Go code without restoring the build cache (GOCACHE)", job.ID),
+				Suggestion: a.lang.T("Use actions/setup-go v4 or later with caching enabled (the default), which restores ~/.cache/go-build and the module cache keyed on go.sum"),
+				Example: `      - uses: actions/setup-go@v5
+        with:
+          go-version-file: go.mod
+          cache-dependency-path: '**/go.sum'`,
+			}
+			if runsWithTests > 0 && cachedResults == 0 {
+				finding.Message += " " + a.lang.Sprintf("(no test result was cached in %d sampled runs)", runsWithTests)
+			}
+			for _, fix := range setupCacheFixes(job) {
+				if fix.action == "actions/setup-go" {
+					finding.Suggestion = a.lang.T("Turn on the cache input of actions/setup-go v3, or upgrade to v4 or later, which caches by default")
+					finding.Example = setupCacheExample(fix)
+				}
+			}
+			findings = append(findings, finding)
+		} else if runsWithTests > 1 && cachedResults == 0 && !jobRunsUncached(job) {
+			findings = append(findings, models.Finding{
+				Category:   "performance",
+				Severity:   models.SeverityInfo,
+				File:       path,
+				Line:       goStep.Line,
+				Message:    a.lang.Sprintf("Job %s restores the Go build cache, but no test result was cached in %d sampled runs", job.ID, runsWithTests),
+				Suggestion: a.lang.T("Test results are only reused when the package, its environment variables and the files it reads are unchanged; check that cache-dependency-path covers every go.sum and that tests don't depend on per-run values such as timestamps or temporary paths"),
+				Confidence: logConfidence(runsWithTests, samples),
+			})
+		}
+
+		for _, step := range job.Steps {
+			flags := goFlags(wf, job, step)
+			if strings.Contains(flags, "-mod=readonly") || strings.Contains(flags, "-mod=vendor") {
+				continue
+			}
+			if rewritesGoMod(step.Run) || strings.Contains(flags, "-mod=mod") {
+				findings = append(findings, models.Finding{
+					Category:   "performance",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Step %q in job %s may rewrite go.mod and go.sum during the build", apiStepName(step), job.ID),
+					Suggestion: a.lang.T("Set GOFLAGS=-mod=readonly in the workflow env so builds fail when go.mod is out of date instead of silently updating it, and check tidiness with go mod tidy -diff (Go 1.23+)"),
+					Example: `env:
+  GOFLAGS: -mod=readonly`,
+				})
+			}
+		}
+
+		for _, step := range job.Steps {
+			if !strings.Contains(step.Run, "go mod download") {
+				continue
+			}
+			avg := average(stepDurations(samples, job, step))
+			if avg < slowGoDownload {
+				continue
+			}
+			finding := models.Finding{
+				Category: "performance",
+				Severity: models.SeverityInfo,
+				File:     path,
+				Line:     step.Line,
+				Message:  a.lang.Sprintf("go mod download in job %s takes %v per run on average", job.ID, avg.Round(time.Second)),
+				URL:      jobURL(samples, job),
+			}
+			if restored {
+				finding.Suggestion = a.lang.T("Modules are still downloaded with the module cache restored. Check that cache-dependency-path covers every go.sum; vendoring (go mod vendor with -mod=vendor) removes the download from proxy.golang.org entirely, at the cost of a larger repository and noisier dependency diffs")
+			} else {
+				finding.Suggestion = a.lang.T("Restore the module cache with actions/setup-go so modules come from the cache instead of proxy.golang.org on every run; vendoring is the alternative when the proxy is slow or unreachable, at the cost of a larger repository")
+				finding.EstimatedSavings = a.projectSavings(samples, avg)
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// jobRunsUncached reports whether the job deliberately bypasses the test cache
+func jobRunsUncached(job *workflow.Job) bool {
+	for _, step := range job.Steps {
+		if strings.Contains(step.Run, "-count=1") || strings.Contains(step.Run, "go clean -testcache") {
+			return true
+		}
+	}
+	return false
+}
+
+// goTestBreakdown names the slowest test packages when the suite is slow enough to matter
+func (a *Analyzer) goTestBreakdown(path string, step *workflow.Step, totals map[string]time.Duration, runs int) (models.Finding, bool) {
+	var total time.Duration
+	packages := make([]string, 0, len(totals))
+	for pkg, d := range totals {
+		total += d
+		packages = append(packages, pkg)
+	}
+	perRun := total / time.Duration(runs)
+	if perRun < slowGoTests {
+		return models.Finding{}, false
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		if totals[packages[i]] != totals[packages[j]] {
+			return totals[packages[i]] > totals[packages[j]]
+		}
+		return packages[i] < packages[j]
+	})
+	var slowest []string
+	for _, pkg := range packages[:min(slowGoPackages, len(packages))] {
+		slowest = append(slowest, fmt.Sprintf("%s %v", pkg, (totals[pkg]/time.Duration(runs)).Round(100*time.Millisecond)))
+	}
+
+	return models.Finding{
+		Category:   "performance",
+		Severity:   models.SeverityInfo,
+		File:       path,
+		Line:       step.Line,
+		Message:    a.lang.Sprintf("Go tests take %v per run on average; slowest packages: %s", perRun.Round(time.Second), strings.Join(slowest, ", ")),
+		Suggestion: a.lang.T("Give slow packages their own matrix job, skip long tests on pull requests with testing.Short(), and check that independent tests call t.Parallel()"),
+	}, true
+}
+
+// goRaceCost flags go test -race steps that are slow or repeated across a matrix
+func (a *Analyzer) goRaceCost(path string, job *workflow.Job, step *workflow.Step, samples []runSample) (models.Finding, bool) {
+	avg := average(stepDurations(samples, job, step))
+	if avg < slowGoRace && !job.HasMatrix {
+		return models.Finding{}, false
+	}
+
+	message := a.lang.Sprintf("Job %s runs go test with -race, which typically makes tests 2-20x slower and uses 5-10x more memory", job.ID)
+	if job.HasMatrix {
+		message = a.lang.Sprintf("Job %s runs go test with -race in every matrix entry; the race detector typically makes tests 2-20x slower and uses 5-10x more memory", job.ID)
+	}
+	url := ""
+	if avg > 0 {
+		message += " " + a.lang.Sprintf("(measured %v per run)", avg.Round(time.Second))
+		url = jobURL(samples, job)
+	}
+
+	return models.Finding{
+		Category:   "performance",
+		Severity:   models.SeverityInfo,
+		File:       path,
+		Line:       step.Line,
+		Message:    message,
+		Suggestion: a.lang.T("Run the race detector in a single matrix entry, or on pushes to the default branch and a nightly schedule, and keep pull request tests without -race"),
+		URL:        url,
+	}, true
+}
@@ -0,0 +1,141 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// runSample is the data collected for a single analyzed run
+type runSample struct {
+	Run  *gh.WorkflowRun
+	Jobs []*gh.WorkflowJob
+	Logs string
+
+	// Listed are all the runs listed for the workflow, which the sample is drawn from
+	Listed []*gh.WorkflowRun
+}
+
+// matchesJob reports whether an API job belongs to the workflow job, including
+// matrix expansions which the API names "<name> (<values>)"
+func matchesJob(apiJob *gh.WorkflowJob, job *workflow.Job) bool {
+	name := apiJob.GetName()
+	for _, want := range []string{job.Name, job.ID} {
+		if want == "" {
+			continue
+		}
+		if name == want || strings.HasPrefix(name, want+" (") {
+			return true
+		}
+	}
+	return false
+}
+
+// apiStepName returns the name the jobs API reports for a workflow step
+func apiStepName(step *workflow.Step) string {
+	switch {
+	case step.Name != "":
+		return step.Name
+	case step.Uses != "":
+		return "Run " + step.Uses
+	default:
+		firstLine, _, _ := strings.Cut(strings.TrimSpace(step.Run), "\n")
+		return "Run " + firstLine
+	}
+}
+
+// stepDurations returns the measured durations of a workflow step across samples
+func stepDurations(samples []runSample, job *workflow.Job, step *workflow.Step) []time.Duration {
+	return namedStepDurations(samples, job, apiStepName(step))
+}
+
+// namedStepDurations returns the measured durations of the steps of a workflow
+// job the jobs API reports under want, e.g. the post step of an action
+func namedStepDurations(samples []runSample, job *workflow.Job, want string) []time.Duration {
+	var durations []time.Duration
+	for _, sample := range samples {
+		for _, apiJob := range sample.Jobs {
+			if !matchesJob(apiJob, job) {
+				continue
+			}
+			for _, apiStep := range apiJob.Steps {
+				if apiStep.GetName() != want || apiStep.StartedAt == nil || apiStep.CompletedAt == nil {
+					continue
+				}
+				durations = append(durations, apiStep.CompletedAt.Sub(apiStep.StartedAt.Time))
+			}
+		}
+	}
+	return durations
+}
+
+// jobURL links the newest sampled run of a workflow job, where its timings can be
+// checked; samples are ordered newest first
+func jobURL(samples []runSample, job *workflow.Job) string {
+	for _, sample := range samples {
+		for _, apiJob := range sample.Jobs {
+			if matchesJob(apiJob, job) && apiJob.GetHTMLURL() != "" {
+				return apiJob.GetHTMLURL()
+			}
+		}
+	}
+	return ""
+}
+
+// jobAt returns the job of a run that was running at t, or nil when none or
+// several were, as run logs don't say which job a line comes from
+func jobAt(jobs []*gh.WorkflowJob, t time.Time) *gh.WorkflowJob {
+	var found *gh.WorkflowJob
+	for _, job := range jobs {
+		if job.StartedAt == nil || job.CompletedAt == nil || t.Before(job.StartedAt.Time) || t.After(job.CompletedAt.Time) {
+			continue
+		}
+		if found != nil {
+			return nil
+		}
+		found = job
+	}
+	return found
+}
+
+// jobDurations returns the measured durations of a workflow job across samples
+func jobDurations(samples []runSample, job *workflow.Job) []time.Duration {
+	var durations []time.Duration
+	for _, sample := range samples {
+		for _, apiJob := range sample.Jobs {
+			if !matchesJob(apiJob, job) || apiJob.StartedAt == nil || apiJob.CompletedAt == nil {
+				continue
+			}
+			durations = append(durations, apiJob.CompletedAt.Sub(apiJob.StartedAt.Time))
+		}
+	}
+	return durations
+}
+
+// average returns the mean of durations, or zero when empty
+func average(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	return total / time.Duration(len(durations))
+}
+
+// median returns the middle of durations, or zero when empty
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	if len(sorted)%2 == 0 {
+		return (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,73 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// workflowCheck inspects a parsed workflow and returns located findings
+type workflowCheck func(path string, wf *workflow.Workflow) []models.Finding
+
+// evidenceCheck inspects a parsed workflow together with measured run history
+type evidenceCheck func(path string, wf *workflow.Workflow, samples []runSample) []models.Finding
+
+// inspectWorkflow validates a workflow file and runs every model-based check over it.
+// samples may be nil when no run history was collected (e.g. in diff mode).
+func (a *Analyzer) inspectWorkflow(ctx context.Context, path, content string, samples []runSample) []models.Finding {
+	findings := a.validateWorkflow(ctx, path, content)
+
+	wf, err := workflow.Parse(content)
+	if err != nil {
+		return findings
+	}
+
+	checks := []workflowCheck{
+		a.checkOIDC,
+		a.checkShells,
+		a.checkWindowsSteps,
+		a.checkRunnerImages,
+		a.checkGithubScript,
+		a.checkSetupCache,
+		a.checkCredentials,
+		a.checkArtifactOrdering,
+		a.checkRegistryLogins,
+	}
+	if a.styleChecks {
+		checks = append(checks, a.checkStyle, a.checkExpressions)
+	}
+	if a.policy != nil {
+		checks = append(checks, a.checkPolicy)
+	}
+	for _, check := range checks {
+		findings = append(findings, check(path, wf)...)
+	}
+	findings = append(findings, a.checkToolchainEOL(ctx, path, wf)...)
+
+	evidenceChecks := []evidenceCheck{
+		a.checkArtifactPassing,
+		a.checkMultiArchBuild,
+		a.checkBuilderSetup,
+		a.checkGoBuild,
+		a.checkPackageInstalls,
+		a.checkImagePulls,
+		a.checkCacheKeys,
+		a.checkCacheTimes,
+		a.checkDiskSpace,
+		a.checkLineEndings,
+		a.checkMLWorkloads,
+		a.checkNetworkFlakiness,
+		a.checkContinueOnError,
+	}
+	for _, check := range evidenceChecks {
+		findings = append(findings, check(path, wf, samples)...)
+	}
+
+	// Custom policies see the findings of every built-in check
+	if a.rego != nil {
+		findings = append(findings, a.checkRego(ctx, path, wf, findings)...)
+	}
+
+	return findings
+}
@@ -0,0 +1,161 @@
+package analyzer
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Build files that identify each Java build tool
+var javaBuildFiles = map[string][]string{
+	"maven":  {"pom.xml"},
+	"gradle": {"build.gradle", "build.gradle.kts", "settings.gradle", "settings.gradle.kts"},
+}
+
+// javaCacheStrategies are the Java cache recommendations for each build tool
+var javaCacheStrategies = map[string][]models.CacheRecommendation{
+	"maven": {
+		{
+			Path:        "~/.m2/repository",
+			Description: "Cache Maven dependencies",
+			Impact:      "Can significantly reduce build time by caching Maven dependencies",
+			Example: `      - name: Set up Java
+        uses: actions/setup-java@v4
+        with:
+          java-version: '%s'
+          distribution: 'temurin'
+          cache: 'maven'
+
+      - uses: actions/cache@v4
+        with:
+          path: ~/.m2/repository
+          key: ${{ runner.os }}-maven-${{ hashFiles('**/pom.xml') }}
+          restore-keys: |
+            ${{ runner.os }}-maven-`,
+		},
+	},
+	"gradle": {
+		{
+			Path:        "~/.gradle",
+			Description: "Cache Gradle dependencies and wrapper",
+			Impact:      "Can significantly reduce build time by caching Gradle dependencies",
+			Example: `      - name: Set up Java
+        uses: actions/setup-java@v4
+        with:
+          java-version: '%s'
+          distribution: 'temurin'
+          cache: 'gradle'
+
+      - uses: actions/cache@v4
+        with:
+          path: |
+            ~/.gradle/caches
+            ~/.gradle/wrapper
+          key: ${{ runner.os }}-gradle-${{ hashFiles('**/*.gradle*', '**/gradle-wrapper.properties') }}
+          restore-keys: |
+            ${{ runner.os }}-gradle-`,
+		},
+	},
+}
+
+// gradleProperties are settings that speed up Gradle builds beyond dependency caching
+var gradleProperties = []struct {
+	key string
+	rec models.CacheRecommendation
+}{
+	{
+		key: "org.gradle.caching",
+		rec: models.CacheRecommendation{
+			Path:        "gradle.properties",
+			Description: "Enable the Gradle build cache",
+			Impact:      "Reuses task outputs from earlier builds instead of re-running unchanged tasks",
+			Example: `# gradle.properties
+org.gradle.caching=true
+
+# The workflow then restores ~/.gradle/caches/build-cache-1 with the other caches:
+      - uses: gradle/actions/setup-gradle@v4`,
+		},
+	},
+	{
+		key: "org.gradle.configuration-cache",
+		rec: models.CacheRecommendation{
+			Path:        "gradle.properties",
+			Description: "Enable the Gradle configuration cache",
+			Impact:      "Skips the configuration phase when build scripts haven't changed",
+			Example: `# gradle.properties
+org.gradle.configuration-cache=true
+
+# setup-gradle saves and restores the configuration cache between runs
+      - uses: gradle/actions/setup-gradle@v4
+        with:
+          cache-encryption-key: ${{ secrets.GRADLE_ENCRYPTION_KEY }}`,
+		},
+	},
+}
+
+// javaBuildTools returns the build tools whose files appear in the repository tree.
+// Without a tree, the workflow's commands decide; if nothing matches both tools are returned.
+func javaBuildTools(paths []string, workflowContent string) []string {
+	found := make(map[string]bool)
+	for _, p := range paths {
+		base := path.Base(p)
+		for tool, files := range javaBuildFiles {
+			for _, file := range files {
+				if base == file {
+					found[tool] = true
+				}
+			}
+		}
+	}
+
+	if len(found) == 0 {
+		if strings.Contains(workflowContent, "mvn") || strings.Contains(workflowContent, "maven") {
+			found["maven"] = true
+		}
+		if strings.Contains(workflowContent, "gradle") {
+			found["gradle"] = true
+		}
+	}
+
+	var tools []string
+	for _, tool := range []string{"maven", "gradle"} {
+		if found[tool] || len(found) == 0 {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// gradlePropertyAdvice recommends the build and configuration caches when the
+// gradle.properties in dir doesn't enable them already
+func (a *Analyzer) gradlePropertyAdvice(ctx context.Context, owner, repo, dir string) []models.CacheRecommendation {
+	properties, err := a.client.GetFileContent(ctx, owner, repo, path.Join(dir, "gradle.properties"))
+	if err != nil {
+		properties = ""
+	}
+
+	var recs []models.CacheRecommendation
+	for _, prop := range gradleProperties {
+		if !gradlePropertyEnabled(properties, prop.key) {
+			recs = append(recs, prop.rec)
+		}
+	}
+	return recs
+}
+
+// gradlePropertyEnabled reports whether key=true is set in a properties file
+func gradlePropertyEnabled(properties, key string) bool {
+	for _, line := range strings.Split(properties, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if ok && strings.TrimSpace(k) == key && strings.TrimSpace(v) == "true" {
+			return true
+		}
+	}
+	return false
+}
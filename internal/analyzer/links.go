@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// serverURL returns the web address of the GitHub instance, which differs from
+// github.com on GitHub Enterprise Server
+func serverURL() string {
+	if u := os.Getenv("GITHUB_SERVER_URL"); u != "" {
+		return strings.TrimSuffix(u, "/")
+	}
+	return "https://github.com"
+}
+
+// blobURL links a line of a repository file at ref
+func blobURL(owner, repo, ref, file string, line int) string {
+	segments := strings.Split(strings.TrimPrefix(file, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	u := fmt.Sprintf("%s/%s/%s/blob/%s/%s", serverURL(), owner, repo, url.PathEscape(ref), strings.Join(segments, "/"))
+	if line > 0 {
+		u += fmt.Sprintf("#L%d", line)
+	}
+	return u
+}
+
+// linkFindings points the findings that don't link to a measurement at the file
+// line they were found on. Files are read from the default branch, or from the
+// head commit when reviewing a pull request.
+func linkFindings(owner, repo string, report *models.PerformanceReport) {
+	ref := report.HeadSHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	for i, finding := range report.Findings {
+		if finding.URL == "" && finding.File != "" {
+			report.Findings[i].URL = blobURL(owner, repo, ref, finding.File, finding.Line)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// lintWorkflow runs line-aware checks over a workflow file so findings can be
+// attached to the exact line that caused them
+func (a *Analyzer) lintWorkflow(path, content string) []models.Finding {
+	var findings []models.Finding
+	lines := strings.Split(content, "\n")
+
+	onLine := 0
+	hasPermissions := false
+
+	for i, raw := range lines {
+		lineNo := i + 1
+		key, value := splitYAMLLine(raw)
+
+		switch key {
+		case "on", "\"on\"", "true":
+			// "on" is parsed as a boolean by some YAML 1.1 tools, so accept both spellings
+			if indentOf(raw) == 0 && onLine == 0 {
+				onLine = lineNo
+			}
+		case "permissions":
+			hasPermissions = true
+		case "runs-on":
+			if strings.HasSuffix(value, "-latest") {
+				findings = append(findings, models.Finding{
+					Category:   "runner",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       lineNo,
+					Message:    a.lang.Sprintf("Runner label %s floats to the newest image", value),
+					Suggestion: a.lang.T("Consider using specific Ubuntu version instead of 'latest' for better reproducibility"),
+				})
+			}
+		case "uses":
+			if finding, ok := a.checkActionRef(path, lineNo, value); ok {
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	if !hasPermissions {
+		findings = append(findings, models.Finding{
+			Category:   "security",
+			Severity:   models.SeverityWarning,
+			File:       path,
+			Line:       max(onLine, 1),
+			Message:    a.lang.T("No permissions block found; the workflow runs with the default token permissions"),
+			Suggestion: a.lang.T("Add explicit permissions to improve workflow security"),
+		})
+	}
+
+	return findings
+}
+
+// checkActionRef flags action references that are unpinned or track a branch
+func (a *Analyzer) checkActionRef(path string, lineNo int, ref string) (models.Finding, bool) {
+	if ref == "" || strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "docker://") {
+		return models.Finding{}, false
+	}
+
+	action, version, found := strings.Cut(ref, "@")
+	switch {
+	case !found:
+		return models.Finding{
+			Category:   "security",
+			Severity:   models.SeverityWarning,
+			File:       path,
+			Line:       lineNo,
+			Message:    a.lang.Sprintf("Action %s is not pinned to a version", action),
+			Suggestion: a.lang.T("Pin actions to a release tag or full commit SHA"),
+		}, true
+	case version == "main" || version == "master" || version == "HEAD":
+		return models.Finding{
+			Category:   "security",
+			Severity:   models.SeverityWarning,
+			File:       path,
+			Line:       lineNo,
+			Message:    a.lang.Sprintf("Action %s tracks the %s branch", action, version),
+			Suggestion: a.lang.T("Pin actions to a release tag or full commit SHA"),
+		}, true
+	}
+	return models.Finding{}, false
+}
+
+// splitYAMLLine returns the key and unquoted scalar value of a "key: value" line,
+// ignoring list markers and trailing comments
+func splitYAMLLine(line string) (string, string) {
+	trimmed := strings.TrimSpace(line)
+	trimmed = strings.TrimPrefix(trimmed, "- ")
+	if strings.HasPrefix(trimmed, "#") {
+		return "", ""
+	}
+
+	key, value, found := strings.Cut(trimmed, ":")
+	if !found || strings.ContainsAny(key, " \t") && !strings.HasPrefix(key, "\"") {
+		return "", ""
+	}
+
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = value[:idx]
+	}
+	value = strings.Trim(strings.TrimSpace(value), "'\"")
+	return strings.TrimSpace(key), value
+}
+
+// indentOf returns the number of leading spaces of a line
+func indentOf(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
@@ -0,0 +1,202 @@
+package analyzer
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// Lockfiles that pin the resolved dependencies of each language's projects
+var lockfileNames = map[string][]string{
+	"node":   {"package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lock", "bun.lockb"},
+	"python": {"uv.lock", "poetry.lock", "Pipfile.lock", "pdm.lock"},
+	"go":     {"go.sum"},
+	"ruby":   {"Gemfile.lock"},
+}
+
+// Commands installing each language's dependencies from the project's manifest
+var dependencyInstall = map[string]*regexp.Regexp{
+	"node":   regexp.MustCompile(`(?m)\b(npm (install|i|ci)|yarn install|pnpm (install|i)|bun install)\b|\byarn\s*$`),
+	"python": regexp.MustCompile(`\bpip3? install\b[^\n]*(-r\s|-e\s+\.|\s\.(\s|$|\[))|\b(poetry install|pipenv (install|sync)|uv sync|pdm install)\b`),
+	"go":     regexp.MustCompile(`\bgo (build|test|vet|install|run|generate|mod download)\b`),
+	"ruby":   regexp.MustCompile(`(?m)\bbundle( install)?\s*$|\bbundle install\b`),
+}
+
+var (
+	// lockfileNotFound is the error setup actions log when cache: is set without a lockfile
+	lockfileNotFound = regexp.MustCompile(`Dependencies lock file is not found|No file in .* matched to \[.*\], make sure you have checked out the target repository`)
+	// goSumMismatch is what the go command logs when go.sum is missing entries or go.mod is out of date
+	goSumMismatch = regexp.MustCompile(`missing go\.sum entry|updates to go\.mod needed|verifying .*: checksum mismatch`)
+	// goDownload is what the go command logs for each module it fetches
+	goDownload = regexp.MustCompile(`go: downloading `)
+)
+
+// unpinnedRequirements returns the requirements of a requirements file that
+// don't pin an exact version or a URL
+func unpinnedRequirements(content string) (unpinned []string, total int) {
+	for _, line := range strings.Split(content, "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "\\"))
+		if line == "" || strings.HasPrefix(line, "-") {
+			continue // options such as -r, -c, -e and --index-url
+		}
+		total++
+		spec, _, _ := strings.Cut(line, ";")
+		if !strings.Contains(spec, "==") && !strings.Contains(spec, " @ ") && !strings.Contains(spec, "://") {
+			unpinned = append(unpinned, strings.TrimSpace(spec))
+		}
+	}
+	return unpinned, total
+}
+
+// installStep returns the first step of a workflow installing a language's dependencies
+func installStep(wf *workflow.Workflow, lang string) *workflow.Step {
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			if dependencyInstall[lang].MatchString(step.Run) ||
+				(lang == "ruby" && usesAction(step, "ruby/setup-ruby") && step.With["bundler-cache"] == "true") {
+				return step
+			}
+		}
+	}
+	return nil
+}
+
+// checkLockfiles finds projects the workflow installs dependencies for that
+// have no lockfile, or whose requirements file leaves versions open. Every run
+// then resolves dependencies anew, so builds aren't reproducible and caches
+// keyed on the lockfile's hash can't work. The sampled logs add evidence: how
+// many runs installed, setup actions failing to find a lockfile to key their
+// cache on, and the go command reporting an out of date go.sum.
+func (a *Analyzer) checkLockfiles(ctx context.Context, owner, repo, workflowPath, workflowContent string, projects map[string][]subproject, samples []runSample) []models.Finding {
+	wf, err := workflow.Parse(workflowContent)
+	if err != nil {
+		return nil
+	}
+	langs := make([]string, 0, len(lockfileNames))
+	for lang := range lockfileNames {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	logged, lockfileErrors, goSumErrors, goDownloads := 0, 0, 0, 0
+	for _, sample := range samples {
+		if sample.Logs == "" {
+			continue
+		}
+		logged++
+		if lockfileNotFound.MatchString(sample.Logs) {
+			lockfileErrors++
+		}
+		if goSumMismatch.MatchString(sample.Logs) {
+			goSumErrors++
+		}
+		if goDownload.MatchString(sample.Logs) {
+			goDownloads++
+		}
+	}
+
+	var findings []models.Finding
+	for _, lang := range langs {
+		step := installStep(wf, lang)
+		if step == nil {
+			continue
+		}
+		installs := 0
+		for _, sample := range samples {
+			if dependencyInstall[lang].MatchString(sample.Logs) {
+				installs++
+			}
+		}
+
+		scoped := projects[lang]
+		if len(scoped) > maxSubprojects {
+			scoped = scoped[:maxSubprojects]
+		}
+		missing := false
+		for _, project := range scoped {
+			// A module without dependencies has no go.sum, so only downloads show one is missing
+			if project.lockfile(lockfileNames[lang]) != "" || (lang == "go" && goDownloads+goSumErrors == 0) {
+				continue
+			}
+			finding := models.Finding{
+				Category: "reliability",
+				Severity: models.SeverityWarning,
+				File:     workflowPath,
+				Line:     step.Line,
+			}
+			switch lang {
+			case "node":
+				finding.Message = a.lang.Sprintf("%s has no lockfile, so every install resolves dependency versions anew", path.Join(project.dir, "package.json"))
+				finding.Suggestion = a.lang.T("Commit the lockfile your package manager writes, such as package-lock.json, yarn.lock or pnpm-lock.yaml, and install with npm ci or --frozen-lockfile; builds become reproducible and caches keyed on the lockfile's hash start to hit")
+			case "go":
+				finding.Message = a.lang.Sprintf("%s has no go.sum, so module checksums aren't verified and the module cache can't be keyed on them", path.Join(project.dir, "go.mod"))
+				finding.Suggestion = a.lang.T("Run go mod tidy and commit go.sum")
+				finding.Confidence = logConfidence(max(goDownloads, goSumErrors), samples)
+			case "ruby":
+				finding.Message = a.lang.Sprintf("%s has no Gemfile.lock, so every install resolves gem versions anew", path.Join(project.dir, "Gemfile"))
+				finding.Suggestion = a.lang.T("Run bundle lock and commit Gemfile.lock; bundler-cache keys on it")
+			case "python":
+				if !a.pythonUnpinned(ctx, owner, repo, project, &finding) {
+					continue
+				}
+			}
+			if installs > 0 {
+				finding.Message += " " + a.lang.Sprintf("(installed in %d of %d logged runs)", installs, logged)
+			}
+			if lockfileErrors > 0 && (lang == "node" || lang == "python") {
+				finding.Message += "; " + a.lang.Sprintf("setup actions found no lockfile to key their cache on in %d of %d logged runs", lockfileErrors, logged)
+			}
+			findings = append(findings, finding)
+			missing = true
+		}
+
+		// go.sum exists but is out of date: the build adds entries or fails on them
+		if lang == "go" && goSumErrors > 0 && !missing {
+			findings = append(findings, models.Finding{
+				Category:   "reliability",
+				Severity:   models.SeverityWarning,
+				File:       workflowPath,
+				Line:       step.Line,
+				Message:    a.lang.Sprintf("The go command reported go.mod or go.sum out of date in %d of %d logged runs", goSumErrors, logged),
+				Suggestion: a.lang.T("Run go mod tidy and commit both files, and add a check that fails when go mod tidy changes them"),
+				Confidence: logConfidence(goSumErrors, samples),
+			})
+		}
+	}
+	return findings
+}
+
+// pythonUnpinned describes a Python project without a lockfile in finding,
+// unless its requirements file pins every version, and reports whether it does
+func (a *Analyzer) pythonUnpinned(ctx context.Context, owner, repo string, project subproject, finding *models.Finding) bool {
+	// Conda environments are resolved by conda, not pinned by a lockfile
+	if project.lockfile([]string{"environment.yml", "environment.yaml"}) != "" {
+		return false
+	}
+	if project.lockfile([]string{"requirements.txt"}) == "" {
+		manifest := project.lockfile([]string{"pyproject.toml", "Pipfile", "setup.py"})
+		finding.Message = a.lang.Sprintf("%s has no lockfile or pinned requirements, so every install resolves dependency versions anew", path.Join(project.dir, manifest))
+		finding.Suggestion = a.lang.T("Commit the lockfile of uv, Poetry or PDM, or compile pinned requirements with pip-compile or uv pip compile; builds become reproducible and caches keyed on the lockfile's hash start to hit")
+		return true
+	}
+
+	file := path.Join(project.dir, "requirements.txt")
+	content, err := a.client.GetFileContent(ctx, owner, repo, file)
+	if err != nil {
+		return false
+	}
+	unpinned, total := unpinnedRequirements(content)
+	if len(unpinned) == 0 {
+		return false
+	}
+	examples := strings.Join(unpinned[:min(3, len(unpinned))], ", ")
+	finding.Message = a.lang.Sprintf("%s leaves %d of %d requirements unpinned, e.g. %s, so every install may resolve different versions", file, len(unpinned), total, examples)
+	finding.Suggestion = a.lang.T("Keep the loose requirements in requirements.in and compile them into pinned requirements.txt with pip-compile or uv pip compile, so installs are reproducible and the pip cache keyed on the file stays valid")
+	return true
+}
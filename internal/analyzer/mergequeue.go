@@ -0,0 +1,138 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// slowMergeQueueJob is the average duration above which a required job is
+// recommended to leave the merge queue's path
+const slowMergeQueueJob = 10 * time.Minute
+
+// mergeGroupEvent is the event of runs a merge queue triggers
+const mergeGroupEvent = "merge_group"
+
+// summarizeMergeQueue measures the queue's throughput and per-entry latency from
+// the merge_group runs, or returns nil when the merge queue didn't trigger any
+func summarizeMergeQueue(runs []*gh.WorkflowRun) *models.MergeQueue {
+	queue := &models.MergeQueue{}
+	var oldest, newest time.Time
+	for _, run := range runs {
+		if run.GetEvent() != mergeGroupEvent {
+			continue
+		}
+		switch timingClass(run) {
+		case timingSuccessful:
+			queue.Merged.Add(runDuration(run))
+		case timingFailed:
+			queue.Failed.Add(runDuration(run))
+		default:
+			continue
+		}
+		created := run.GetCreatedAt().Time
+		if oldest.IsZero() || created.Before(oldest) {
+			oldest = created
+		}
+		newest = maxTime(newest, created)
+	}
+	if queue.Merged.Runs+queue.Failed.Runs == 0 {
+		return nil
+	}
+	queue.Period = newest.Sub(oldest)
+	queue.PerDay = float64(queue.Merged.Runs) / max(queue.Period.Hours()/24, 1)
+	return queue
+}
+
+// maxTime returns the later of two times
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+// analyzeMergeQueue checks the analyzed workflow's readiness for the merge queue
+// and the jobs holding up its entries. A workflow reporting required checks
+// without a merge_group trigger leaves the queue waiting when other workflows
+// show the repository uses one. The sampled merge_group runs give the queue's
+// job durations, and slow required jobs get the advice to run on pull requests
+// only, as the queue waits for every required check of each entry.
+func (a *Analyzer) analyzeMergeQueue(report *models.PerformanceReport, workflows []*repoWorkflow, samples []runSample) {
+	current := analyzedWorkflow(report, workflows)
+	if current == nil {
+		return
+	}
+
+	required := make(map[string]bool)
+	reportsRequired := false
+	if rc := report.RequiredChecks; rc != nil {
+		for _, c := range rc.Checks {
+			required[c.Context] = true
+			reportsRequired = reportsRequired || c.File == current.path
+		}
+	}
+	queued := false
+	for _, w := range workflows {
+		queued = queued || w.parsed.HasTrigger(mergeGroupEvent)
+	}
+	if queued && reportsRequired && !current.parsed.HasTrigger(mergeGroupEvent) {
+		report.Findings = append(report.Findings, models.Finding{
+			Category:   "reliability",
+			Severity:   models.SeverityWarning,
+			File:       current.path,
+			Line:       current.parsed.OnLine,
+			Message:    a.lang.Sprintf("Workflow %s reports required checks of %s but isn't triggered by merge_group, so merge queue entries wait for them until the queue times out", current.name, report.RequiredChecks.Branch),
+			Suggestion: a.lang.T("Trigger the workflow on merge_group as well, so its checks also report on the merge queue's temporary branches"),
+			Example:    "on:\n  pull_request:\n  merge_group:",
+		})
+	}
+
+	if report.MergeQueue == nil {
+		return
+	}
+	durations := make(map[string][]time.Duration)
+	for _, sample := range samples {
+		if sample.Run.GetEvent() != mergeGroupEvent {
+			continue
+		}
+		for _, job := range sample.Jobs {
+			if job.GetConclusion() == "success" && job.StartedAt != nil && job.CompletedAt != nil {
+				durations[job.GetName()] = append(durations[job.GetName()], job.CompletedAt.Sub(job.StartedAt.Time))
+			}
+		}
+	}
+	for name, d := range durations {
+		report.MergeQueue.Jobs = append(report.MergeQueue.Jobs, models.MergeQueueJob{
+			Name: name, AvgDuration: average(d), Runs: len(d), Required: required[name],
+		})
+	}
+	sort.Slice(report.MergeQueue.Jobs, func(i, j int) bool {
+		return report.MergeQueue.Jobs[i].AvgDuration > report.MergeQueue.Jobs[j].AvgDuration
+	})
+
+	for _, queueJob := range report.MergeQueue.Jobs {
+		// Without the required checks, every job is taken to hold up the queue
+		if queueJob.AvgDuration <= slowMergeQueueJob || (len(required) > 0 && !queueJob.Required) {
+			continue
+		}
+		finding := models.Finding{
+			Category:   "performance",
+			Severity:   models.SeverityWarning,
+			File:       current.path,
+			Message:    a.lang.Sprintf("Job %s takes %v on average in the merge queue, and every entry waits for it before merging", queueJob.Name, queueJob.AvgDuration.Round(time.Second)),
+			Suggestion: a.lang.T("Run it on pull requests only and keep fast checks that catch conflicts between pull requests in the queue; a job skipped in the merge group still satisfies its required check"),
+		}
+		for _, job := range current.parsed.Jobs {
+			if matchesJob(&gh.WorkflowJob{Name: gh.String(queueJob.Name)}, job) {
+				finding.Line = job.Line
+				finding.Example = fmt.Sprintf("  %s:\n    if: github.event_name != 'merge_group'", job.ID)
+				break
+			}
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+}
@@ -0,0 +1,240 @@
+package analyzer
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// Paths of other CI systems' configuration files
+const (
+	gitlabCIPath = ".gitlab-ci.yml"
+	circleCIPath = ".circleci/config.yml"
+)
+
+// gitlabReservedKeys are top-level .gitlab-ci.yml keys that aren't jobs
+var gitlabReservedKeys = keySet("stages", "variables", "image", "services", "before_script", "after_script",
+	"cache", "include", "default", "workflow")
+
+// construct maps a source CI keyword to its GitHub Actions equivalent
+type construct struct {
+	target    string
+	note      string
+	carryOver bool
+}
+
+var gitlabConstructs = map[string]construct{
+	"stage":         {target: "needs", note: "Stages become needs: chains between jobs", carryOver: true},
+	"image":         {target: "container", note: "Run the job in the same image, or use runs-on with setup-* actions", carryOver: true},
+	"services":      {target: "services", note: "Service containers map one to one", carryOver: true},
+	"script":        {target: "steps[].run", note: "Each script line becomes part of a run step", carryOver: true},
+	"before_script": {target: "steps[].run", note: "Prepend as a separate run step", carryOver: true},
+	"cache":         {target: "actions/cache", note: "Cache key and paths carry over; use hashFiles() for lockfile keys", carryOver: true},
+	"artifacts":     {target: "actions/upload-artifact", note: "Use upload-artifact/download-artifact between jobs", carryOver: true},
+	"needs":         {target: "needs", note: "DAG dependencies carry over directly", carryOver: true},
+	"dependencies":  {target: "actions/download-artifact", note: "Download only the artifacts the job needs", carryOver: true},
+	"parallel":      {target: "strategy.matrix", note: "parallel:matrix maps to a matrix; numeric parallel needs manual test splitting", carryOver: true},
+	"rules":         {target: "on / if", note: "Pipeline rules split into workflow triggers and job if: conditions", carryOver: false},
+	"only":          {target: "on / if", note: "Branch filters become on.push.branches or if: conditions", carryOver: false},
+	"except":        {target: "on / if", note: "Use branches-ignore or negated if: conditions", carryOver: false},
+	"tags":          {target: "runs-on", note: "Runner tags become self-hosted runner labels", carryOver: true},
+	"variables":     {target: "env", note: "Job variables become env:", carryOver: true},
+	"environment":   {target: "environment", note: "Use GitHub environments for protection rules", carryOver: true},
+	"when":          {target: "if", note: "manual jobs need workflow_dispatch or environment approvals", carryOver: false},
+	"retry":         {target: "-", note: "No built-in job retry; use a retry action or re-run failed jobs", carryOver: false},
+	"timeout":       {target: "timeout-minutes", note: "Convert the duration to minutes", carryOver: true},
+	"extends":       {target: "reusable workflow / composite action", note: "Templates become reusable workflows or composite actions", carryOver: false},
+}
+
+var circleConstructs = map[string]construct{
+	"docker":               {target: "container", note: "The primary image becomes the job container; extra images become services", carryOver: true},
+	"machine":              {target: "runs-on ubuntu-*", note: "Machine executors map to hosted VM runners", carryOver: true},
+	"macos":                {target: "runs-on macos-*", note: "Pick the matching macOS runner image", carryOver: true},
+	"resource_class":       {target: "runs-on larger runner", note: "Map the class to a larger runner size", carryOver: true},
+	"parallelism":          {target: "strategy.matrix", note: "Shard tests with a matrix index; CircleCI timing-based splitting has no built-in equivalent", carryOver: false},
+	"checkout":             {target: "actions/checkout", note: "Direct equivalent", carryOver: true},
+	"restore_cache":        {target: "actions/cache", note: "Keys carry over; {{ checksum \"file\" }} becomes hashFiles('file')", carryOver: true},
+	"save_cache":           {target: "actions/cache", note: "actions/cache saves automatically in its post step", carryOver: true},
+	"persist_to_workspace": {target: "actions/upload-artifact", note: "Workspaces become artifacts", carryOver: true},
+	"attach_workspace":     {target: "actions/download-artifact", note: "Workspaces become artifacts", carryOver: true},
+	"store_artifacts":      {target: "actions/upload-artifact", note: "Direct equivalent", carryOver: true},
+	"store_test_results":   {target: "test reporter action", note: "Use a JUnit reporter action or the job summary", carryOver: false},
+	"run":                  {target: "steps[].run", note: "Direct equivalent", carryOver: true},
+	"setup_remote_docker":  {target: "docker/setup-buildx-action", note: "Docker is available on hosted runners; add Buildx for layer caching", carryOver: true},
+	"orbs":                 {target: "marketplace actions", note: "Replace each orb with an equivalent action", carryOver: false},
+	"requires":             {target: "needs", note: "Workflow requires become needs:", carryOver: true},
+}
+
+// analyzeMigrations inspects GitLab CI and CircleCI configs in the repository and maps
+// their jobs to GitHub Actions constructs
+func (a *Analyzer) analyzeMigrations(ctx context.Context, owner, repo string, report *models.PerformanceReport) {
+	if content, err := a.client.GetFileContent(ctx, owner, repo, gitlabCIPath); err == nil {
+		if advice := a.adviseGitLab(content); advice != nil {
+			report.Migrations = append(report.Migrations, *advice)
+		}
+	}
+	if content, err := a.client.GetFileContent(ctx, owner, repo, circleCIPath); err == nil {
+		if advice := a.adviseCircleCI(content); advice != nil {
+			report.Migrations = append(report.Migrations, *advice)
+		}
+	}
+}
+
+// parseConfig decodes a CI config into its top-level mapping node
+func parseConfig(content string) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil || len(doc.Content) == 0 {
+		return nil
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	return doc.Content[0]
+}
+
+// adviseGitLab maps .gitlab-ci.yml jobs to GitHub Actions constructs
+func (a *Analyzer) adviseGitLab(content string) *models.MigrationAdvice {
+	root := parseConfig(content)
+	if root == nil {
+		return nil
+	}
+
+	advice := &models.MigrationAdvice{Source: "GitLab CI", File: gitlabCIPath}
+	used := make(map[string]bool)
+
+	for _, pair := range workflow.Pairs(root) {
+		name := pair[0].Value
+		if gitlabReservedKeys[name] || strings.HasPrefix(name, ".") {
+			// Global keywords still need mapping
+			if _, ok := gitlabConstructs[name]; ok {
+				used[name] = true
+			}
+			continue
+		}
+		if pair[1].Kind != yaml.MappingNode {
+			continue
+		}
+
+		job := models.MigratedJob{Name: name, Line: pair[0].Line}
+		for _, kv := range workflow.Pairs(pair[1]) {
+			if _, ok := gitlabConstructs[kv[0].Value]; ok {
+				used[kv[0].Value] = true
+				job.Keywords = append(job.Keywords, kv[0].Value)
+			}
+			if kv[0].Value == "needs" {
+				job.Needs = gitlabNeeds(kv[1])
+			}
+		}
+		advice.Jobs = append(advice.Jobs, job)
+	}
+
+	a.fillMappings(advice, gitlabConstructs, used)
+	return advice
+}
+
+// gitlabNeeds returns the job names of a needs: list, which mixes plain names
+// and {job: name, artifacts: bool} entries
+func gitlabNeeds(node *yaml.Node) []string {
+	if node.Kind != yaml.SequenceNode {
+		return workflow.Strings(node)
+	}
+	var needs []string
+	for _, item := range node.Content {
+		if item.Kind == yaml.MappingNode {
+			item = workflow.Lookup(item, "job")
+		}
+		if item != nil && item.Kind == yaml.ScalarNode {
+			needs = append(needs, item.Value)
+		}
+	}
+	return needs
+}
+
+// adviseCircleCI maps .circleci/config.yml jobs and workflows to GitHub Actions constructs
+func (a *Analyzer) adviseCircleCI(content string) *models.MigrationAdvice {
+	root := parseConfig(content)
+	if root == nil {
+		return nil
+	}
+
+	advice := &models.MigrationAdvice{Source: "CircleCI", File: circleCIPath}
+	used := make(map[string]bool)
+	if workflow.Lookup(root, "orbs") != nil {
+		used["orbs"] = true
+	}
+
+	// Dependencies between jobs are declared in workflows
+	needs := make(map[string][]string)
+	for _, wfPair := range workflow.Pairs(workflow.Lookup(root, "workflows")) {
+		jobs := workflow.Lookup(wfPair[1], "jobs")
+		if jobs == nil {
+			continue
+		}
+		for _, item := range jobs.Content {
+			if item.Kind != yaml.MappingNode {
+				continue
+			}
+			for _, jobPair := range workflow.Pairs(item) {
+				if requires := workflow.Strings(workflow.Lookup(jobPair[1], "requires")); len(requires) > 0 {
+					used["requires"] = true
+					needs[jobPair[0].Value] = append(needs[jobPair[0].Value], requires...)
+				}
+			}
+		}
+	}
+
+	for _, pair := range workflow.Pairs(workflow.Lookup(root, "jobs")) {
+		job := models.MigratedJob{Name: pair[0].Value, Line: pair[0].Line, Needs: needs[pair[0].Value]}
+		keywords := make(map[string]bool)
+
+		for _, kv := range workflow.Pairs(pair[1]) {
+			if _, ok := circleConstructs[kv[0].Value]; ok {
+				keywords[kv[0].Value] = true
+			}
+		}
+		if steps := workflow.Lookup(pair[1], "steps"); steps != nil {
+			for _, step := range steps.Content {
+				name := step.Value
+				if step.Kind == yaml.MappingNode && len(step.Content) > 0 {
+					name = step.Content[0].Value
+				}
+				if _, ok := circleConstructs[name]; ok {
+					keywords[name] = true
+				}
+			}
+		}
+
+		for keyword := range keywords {
+			used[keyword] = true
+			job.Keywords = append(job.Keywords, keyword)
+		}
+		sort.Strings(job.Keywords)
+		advice.Jobs = append(advice.Jobs, job)
+	}
+
+	a.fillMappings(advice, circleConstructs, used)
+	return advice
+}
+
+// fillMappings records the GitHub Actions equivalent of every keyword the config uses
+func (a *Analyzer) fillMappings(advice *models.MigrationAdvice, constructs map[string]construct, used map[string]bool) {
+	var keywords []string
+	for keyword := range used {
+		keywords = append(keywords, keyword)
+	}
+	sort.Strings(keywords)
+
+	for _, keyword := range keywords {
+		c := constructs[keyword]
+		advice.Mappings = append(advice.Mappings, models.ConstructMapping{
+			Source:    keyword,
+			Target:    c.target,
+			Note:      a.lang.T(c.note),
+			CarryOver: c.carryOver,
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// Assumptions of the GPU and larger runner trade-off
+const (
+	// slowMLStep is the average duration above which an ML job's step is worth
+	// moving to faster hardware
+	slowMLStep = 10 * time.Minute
+	// gpuRunnerRate is the list price per minute of GitHub's Linux GPU runner
+	// with 4 cores and an NVIDIA T4
+	gpuRunnerRate = 0.07
+	// gpuSpeedup is the assumed speedup of training and inference steps on a T4
+	// over the cores of a standard runner
+	gpuSpeedup = 5.0
+	// largerRunner and largerRunnerSpeedup are the larger CPU runner compared,
+	// and its assumed speedup, as tensor libraries use every core but not linearly
+	largerRunner        = "ubuntu-latest-16-cores"
+	largerRunnerSpeedup = 2.5
+)
+
+var (
+	// mlTooling matches run steps installing or using ML frameworks and CUDA
+	mlTooling = regexp.MustCompile(`(?i)\b(pip3?|uv pip|conda|mamba|poetry add)\b[^\n]*\b(torch|torchvision|tensorflow(-gpu)?|jax\[?\w*\]?|transformers|keras|onnxruntime-gpu|xgboost|lightgbm|pytorch)\b|\bcuda\b|nvidia-smi|nvcc|CUDA_VISIBLE_DEVICES`)
+	// cpuOnlyWheels matches installs of the CPU-only builds of PyTorch
+	cpuOnlyWheels = regexp.MustCompile(`download\.pytorch\.org/whl/cpu|\+cpu\b`)
+	// installCommand matches run steps that only install dependencies
+	installCommand = regexp.MustCompile(`^\s*(sudo )?(pip3?|python3? -m pip|uv|conda|mamba|poetry|pipenv|apt(-get)?|npm|yarn) (install|sync|add|ci|create|env)\b`)
+	// gpuLabel matches runner labels of GPU runners
+	gpuLabel = regexp.MustCompile(`(?i)gpu|cuda|nvidia|\bt4\b|a10|a100|h100`)
+)
+
+// gpuRunnerExample moves a job to a GPU larger runner, labeled by its organization
+const gpuRunnerExample = `    # A GPU larger runner created in the organization's settings, e.g. Linux 4-core with an NVIDIA T4
+    runs-on: gpu-t4-4-core`
+
+// mlJob reports whether a job installs or uses ML frameworks, and whether it
+// installs their CPU-only builds
+func mlJob(job *workflow.Job) (ml, cpuOnly bool) {
+	for _, step := range job.Steps {
+		if mlTooling.MatchString(step.Run) || strings.Contains(strings.ToLower(step.Uses), "cuda-toolkit") {
+			ml = true
+		}
+		if cpuOnlyWheels.MatchString(step.Run) {
+			cpuOnly = true
+		}
+	}
+	return ml, cpuOnly
+}
+
+// gpuRate returns the per-minute price of a GPU runner, from the pricing table
+// when it prices one
+func (a *Analyzer) gpuRate() float64 {
+	if a.pricing == nil {
+		return gpuRunnerRate
+	}
+	if r, ok := a.pricing.Match([]string{"gpu"}); ok {
+		return r.PerMinute
+	}
+	return gpuRunnerRate * (1 - a.pricing.Discount)
+}
+
+// checkMLWorkloads reports ML jobs on standard Linux runners whose slowest
+// compute step takes minutes, and weighs a GPU runner and a larger runner
+// against the current one by time and cost per run. The speedups are assumptions
+// that the finding states, as they depend on the model and the batch sizes.
+func (a *Analyzer) checkMLWorkloads(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	if len(samples) == 0 {
+		return nil
+	}
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		ml, cpuOnly := mlJob(job)
+		if !ml || selfHosted(job) || gpuLabel.MatchString(strings.Join(job.RunsOn, " ")) {
+			continue
+		}
+		runner, rate := a.runnerRate(job.RunsOn)
+		if !strings.HasPrefix(runner, "ubuntu") || strings.Contains(runner, "-") {
+			continue
+		}
+
+		var slowest *workflow.Step
+		var slowestAvg time.Duration
+		for _, step := range job.Steps {
+			if step.Run == "" || installCommand.MatchString(step.Run) {
+				continue
+			}
+			if avg := average(stepDurations(samples, job, step)); avg > slowestAvg {
+				slowest, slowestAvg = step, avg
+			}
+		}
+		jobAvg := average(jobDurations(samples, job))
+		if slowest == nil || slowestAvg < slowMLStep || jobAvg < slowestAvg {
+			continue
+		}
+
+		// Each runner is priced for the whole job, with only the slow step sped up
+		option := func(speedup, perMinute float64) string {
+			d := jobAvg - slowestAvg + time.Duration(float64(slowestAvg)/speedup)
+			return a.lang.Sprintf("~%s and $%.2f per run", humanDuration(d), d.Minutes()*perMinute)
+		}
+		_, largerRate := a.runnerRate([]string{largerRunner})
+		options := []string{a.lang.Sprintf("16-core runner: %s, assuming a %.1fx speedup", option(largerRunnerSpeedup, largerRate), largerRunnerSpeedup)}
+		example := ""
+		if !cpuOnly {
+			options = append([]string{a.lang.Sprintf("T4 GPU runner: %s, assuming a %.0fx speedup", option(gpuSpeedup, a.gpuRate()), gpuSpeedup)}, options...)
+			example = gpuRunnerExample
+		}
+
+		findings = append(findings, models.Finding{
+			Category: "runner",
+			Severity: models.SeverityInfo,
+			File:     path,
+			Line:     slowest.Line,
+			Message: a.lang.Sprintf("Step %q in ML job %s averages %s on CPU, %.0f%% of the job's %s ($%.2f per run on %s)",
+				slowest.DisplayName(), job.ID, humanDuration(slowestAvg), 100*slowestAvg.Seconds()/jobAvg.Seconds(), humanDuration(jobAvg), jobAvg.Minutes()*rate, runner),
+			Suggestion: a.lang.Sprintf("Estimated on faster runners: %s. Measure the speedup on a run before switching; GPU runners need the CUDA builds of the frameworks", strings.Join(options, "; ")),
+			Example:    example,
+			URL:        jobURL(samples, job),
+		})
+	}
+	return findings
+}
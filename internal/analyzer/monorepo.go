@@ -0,0 +1,228 @@
+package analyzer
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// rootDir is the directory of a project at the repository root
+const rootDir = "."
+
+// maxSubprojects caps the scoped recommendations per language in large monorepos
+const maxSubprojects = 10
+
+// Manifests that mark a directory as a project of each language
+var projectManifests = map[string][]string{
+	"go":     {"go.mod"},
+	"node":   {"package.json"},
+	"python": {"pyproject.toml", "requirements.txt", "Pipfile", "setup.py", "environment.yml", "environment.yaml"},
+	"java":   {"pom.xml", "build.gradle", "build.gradle.kts"},
+	"ruby":   {"Gemfile"},
+	"rust":   {"Cargo.toml"},
+	"dotnet": {".csproj", ".fsproj", ".sln"}, // matched as suffixes
+}
+
+// Files a scoped cache key or cache-dependency-path should track, in order of preference
+var dependencyFiles = map[string][]string{
+	"go":     {"go.sum", "go.mod"},
+	"node":   {"pnpm-lock.yaml", "yarn.lock", "bun.lock", "bun.lockb", "package-lock.json", "npm-shrinkwrap.json"},
+	"python": {"uv.lock", "poetry.lock", "Pipfile.lock", "environment.yml", "environment.yaml", "requirements.txt", "pyproject.toml"},
+	"java":   {"pom.xml", "build.gradle.kts", "build.gradle"},
+	"ruby":   {"Gemfile.lock"},
+	"rust":   {"Cargo.lock"},
+	"dotnet": {"packages.lock.json"},
+}
+
+// Directories that hold dependencies, fixtures or tooling rather than projects
+var ignoredProjectDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"testdata":     true,
+	"third_party":  true,
+}
+
+var hashFilesArg = regexp.MustCompile(`hashFiles\(([^)]*)\)`)
+
+// subproject is a directory holding a project of one language
+type subproject struct {
+	dir   string
+	files []string // names of the files directly in dir
+}
+
+// lockfile returns the first of names present in the subproject
+func (p subproject) lockfile(names []string) string {
+	for _, name := range names {
+		for _, file := range p.files {
+			if file == name {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// paths returns the subproject's files relative to the repository root
+func (p subproject) paths() []string {
+	paths := make([]string, len(p.files))
+	for i, file := range p.files {
+		paths[i] = path.Join(p.dir, file)
+	}
+	return paths
+}
+
+// detectProjects groups the repository tree into projects per language. Only the
+// outermost project of a language is kept, since nested manifests usually belong to
+// the same workspace, multi-module build or Cargo workspace.
+func detectProjects(tree []string) map[string][]subproject {
+	filesByDir := make(map[string][]string)
+	for _, p := range tree {
+		dir, file := path.Split(p)
+		dir = path.Clean(dir)
+		if skipProjectDir(dir) {
+			continue
+		}
+		filesByDir[dir] = append(filesByDir[dir], file)
+	}
+
+	dirs := make([]string, 0, len(filesByDir))
+	for dir := range filesByDir {
+		dirs = append(dirs, dir)
+	}
+	// Parents sort before their children, so the outermost project is seen first
+	sort.Strings(dirs)
+
+	projects := make(map[string][]subproject)
+	for _, dir := range dirs {
+		files := filesByDir[dir]
+		for lang, manifests := range projectManifests {
+			if !hasManifest(lang, manifests, files) || insideProject(projects[lang], dir) {
+				continue
+			}
+			projects[lang] = append(projects[lang], subproject{dir: dir, files: files})
+		}
+	}
+	return projects
+}
+
+// skipProjectDir reports whether dir is a dependency, fixture or hidden directory
+func skipProjectDir(dir string) bool {
+	if dir == rootDir {
+		return false
+	}
+	for _, part := range strings.Split(dir, "/") {
+		if ignoredProjectDirs[part] || strings.HasPrefix(part, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasManifest(lang string, manifests, files []string) bool {
+	for _, file := range files {
+		for _, manifest := range manifests {
+			if file == manifest || (lang == "dotnet" && strings.HasSuffix(file, manifest)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// insideProject reports whether dir is nested in one of projects
+func insideProject(projects []subproject, dir string) bool {
+	for _, p := range projects {
+		if p.dir == rootDir || strings.HasPrefix(dir, p.dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// isMonorepo reports whether a language's projects live outside the repository root,
+// so recommendations need to be scoped to their directories
+func isMonorepo(projects []subproject) bool {
+	return len(projects) > 0 && !(len(projects) == 1 && projects[0].dir == rootDir)
+}
+
+// treeLanguages returns the languages found in the tree but not in detected, sorted
+func treeLanguages(projects map[string][]subproject, detected []string) []string {
+	seen := make(map[string]bool)
+	for _, lang := range detected {
+		seen[lang] = true
+	}
+	var langs []string
+	for lang := range projects {
+		if !seen[lang] {
+			langs = append(langs, lang)
+		}
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// toolFromFiles picks the first tool whose lockfile is among files
+func toolFromFiles(tools []packageTool, files []string) string {
+	for _, tool := range tools {
+		for _, lockfile := range tool.lockfiles {
+			for _, file := range files {
+				if file == lockfile {
+					return tool.name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// scopeCacheStrategy points a recommendation at a subproject: relative paths, hashFiles
+// patterns and cache-dependency-path move into its directory, cache keys get a
+// per-directory prefix, and setup actions that cache by lockfile are told which one to use
+func scopeCacheStrategy(rec models.CacheRecommendation, lang string, project subproject) models.CacheRecommendation {
+	dir := project.dir
+	rec.Directory = dir
+	if !strings.HasPrefix(rec.Path, "~") && !strings.HasPrefix(rec.Path, "/") && !strings.HasPrefix(rec.Path, "$") && !strings.HasPrefix(rec.Path, ".") {
+		rec.Path = path.Join(dir, rec.Path)
+	}
+
+	rec.Example = hashFilesArg.ReplaceAllStringFunc(rec.Example, func(call string) string {
+		args := strings.Split(hashFilesArg.FindStringSubmatch(call)[1], ",")
+		for i, arg := range args {
+			arg = strings.Trim(strings.TrimSpace(arg), "'")
+			args[i] = "'" + dir + "/" + arg + "'"
+		}
+		return "hashFiles(" + strings.Join(args, ", ") + ")"
+	})
+
+	// Subprojects share the runner, so their cache keys need distinct prefixes
+	rec.Example = strings.ReplaceAll(rec.Example, "${{ runner.os }}-", "${{ runner.os }}-"+strings.ReplaceAll(dir, "/", "-")+"-")
+
+	lockfile := project.lockfile(dependencyFiles[lang])
+	hasDependencyPath := strings.Contains(rec.Example, "cache-dependency-path:")
+
+	var lines []string
+	for _, line := range strings.Split(rec.Example, "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		switch {
+		case strings.HasPrefix(trimmed, "**/"):
+			// An entry of a multi-line cache-dependency-path
+			line = indent + dir + "/" + trimmed
+		case strings.HasPrefix(trimmed, "cache-dependency-glob: "):
+			line = indent + "cache-dependency-glob: " + dir + "/" + strings.TrimPrefix(trimmed, "cache-dependency-glob: ")
+		}
+		lines = append(lines, line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "cache: ") && !hasDependencyPath && lockfile != "":
+			lines = append(lines, indent+"cache-dependency-path: "+dir+"/"+lockfile)
+		case strings.HasPrefix(trimmed, "bundler-cache: "):
+			lines = append(lines, indent+"working-directory: "+dir)
+		}
+	}
+	rec.Example = strings.Join(lines, "\n")
+	return rec
+}
@@ -0,0 +1,230 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// BuildKit prefixes each vertex with its number and platform, e.g. "#12 [linux/arm64 builder 3/5] RUN make"
+	buildkitVertex = regexp.MustCompile(`#(\d+) \[(linux/[a-z0-9]+(?:/v\d)?)[ \]]`)
+	// and reports completion as "#12 DONE 42.3s"
+	buildkitDone = regexp.MustCompile(`#(\d+) DONE (\d+(?:\.\d+)?)s`)
+	platformFlag = regexp.MustCompile(`--platform[= ]([^\s]+)`)
+)
+
+// runnerArchHints map runner label fragments to the architecture they run on,
+// checked in order; runners without any are taken for amd64, as hosted ones are
+var runnerArchHints = []struct{ hint, arch string }{
+	{"aarch64", "arm64"},
+	{"arm", "arm64"},
+	{"ppc64le", "ppc64le"},
+	{"s390x", "s390x"},
+	{"riscv64", "riscv64"},
+}
+
+// hostedRunners are the GitHub-hosted runners building each architecture natively
+var hostedRunners = map[string]string{
+	"amd64": "ubuntu-24.04",
+	"386":   "ubuntu-24.04",
+	"arm64": "ubuntu-24.04-arm",
+}
+
+// platformBuildTimes sums BuildKit step durations per target platform in a run's logs
+func platformBuildTimes(logs string) map[string]time.Duration {
+	vertexPlatform := make(map[string]string)
+	times := make(map[string]time.Duration)
+
+	for _, line := range strings.Split(logs, "\n") {
+		if m := buildkitVertex.FindStringSubmatch(line); m != nil {
+			vertexPlatform[m[1]] = m[2]
+			continue
+		}
+		if m := buildkitDone.FindStringSubmatch(line); m != nil {
+			platform, ok := vertexPlatform[m[1]]
+			if !ok {
+				continue
+			}
+			seconds, _ := strconv.ParseFloat(m[2], 64)
+			times[platform] += time.Duration(seconds * float64(time.Second))
+			// vertex numbers restart for every build, so forget the mapping once done
+			delete(vertexPlatform, m[1])
+		}
+	}
+	return times
+}
+
+// stepPlatforms returns the target platforms of a buildx build step
+func stepPlatforms(step *workflow.Step) []string {
+	var raw string
+	switch {
+	case usesAction(step, "docker/build-push-action"), usesAction(step, "docker/bake-action"):
+		raw = step.With["platforms"]
+	case strings.Contains(step.Run, "buildx build"):
+		if m := platformFlag.FindStringSubmatch(step.Run); m != nil {
+			raw = m[1]
+		}
+	}
+
+	var platforms []string
+	for _, p := range strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '\n' || r == ' ' }) {
+		platforms = append(platforms, strings.TrimSpace(p))
+	}
+	return platforms
+}
+
+// platformArch returns the architecture of a platform, e.g. arm for linux/arm/v7
+func platformArch(platform string) string {
+	parts := strings.Split(platform, "/")
+	if len(parts) < 2 {
+		return platform
+	}
+	return parts[1]
+}
+
+// runnerArch returns the architecture the job's runner labels suggest
+func runnerArch(job *workflow.Job) string {
+	for _, hint := range runnerArchHints {
+		for _, label := range job.RunsOn {
+			if strings.Contains(strings.ToLower(label), hint.hint) {
+				return hint.arch
+			}
+		}
+	}
+	return "amd64"
+}
+
+// emulated reports whether the job's runner needs QEMU to build for platform.
+// amd64 runners also run 386 natively; 32-bit ARM is emulated even on arm64
+// runners, as not every arm64 CPU executes it.
+func emulated(job *workflow.Job, platform string) bool {
+	runner, arch := runnerArch(job), platformArch(platform)
+	return arch != runner && !(runner == "amd64" && arch == "386")
+}
+
+// checkMultiArchBuild flags multi-platform buildx builds that emulate foreign
+// architectures with QEMU, with measured per-platform build times as evidence
+func (a *Analyzer) checkMultiArchBuild(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+
+	// Average build time per platform across sampled runs that contain BuildKit output
+	totals := make(map[string]time.Duration)
+	runsWithBuilds := 0
+	for _, sample := range samples {
+		times := platformBuildTimes(sample.Logs)
+		if len(times) == 0 {
+			continue
+		}
+		runsWithBuilds++
+		for platform, d := range times {
+			totals[platform] += d
+		}
+	}
+
+	for _, job := range wf.Jobs {
+		usesQEMU := false
+		for _, step := range job.Steps {
+			if usesAction(step, "docker/setup-qemu-action") {
+				usesQEMU = true
+			}
+		}
+
+		for _, step := range job.Steps {
+			platforms := stepPlatforms(step)
+			if len(platforms) < 2 {
+				continue
+			}
+
+			var foreign []string
+			for _, p := range platforms {
+				if emulated(job, p) {
+					foreign = append(foreign, p)
+				}
+			}
+			if len(foreign) == 0 || (!usesQEMU && !strings.Contains(step.Run, "binfmt")) {
+				continue
+			}
+
+			finding := models.Finding{
+				Category: "docker",
+				Severity: models.SeverityWarning,
+				File:     path,
+				Line:     step.Line,
+				Message: a.lang.Sprintf("Job %s builds %s in a single buildx invocation, emulating %s with QEMU",
+					job.ID, strings.Join(platforms, ", "), strings.Join(foreign, ", ")),
+				Suggestion: a.lang.T("Build each platform on a native runner (e.g. ubuntu-24.04-arm for linux/arm64) in a matrix and merge the digests with docker buildx imagetools create, or use a remote native builder"),
+				Example:    multiArchExample(platforms),
+			}
+
+			if runsWithBuilds > 0 {
+				var evidence []string
+				sorted := append([]string(nil), platforms...)
+				sort.Strings(sorted)
+				for _, p := range sorted {
+					if d, ok := totals[p]; ok {
+						evidence = append(evidence, fmt.Sprintf("%s %v", p, (d/time.Duration(runsWithBuilds)).Round(time.Second)))
+					}
+				}
+				if len(evidence) > 0 {
+					finding.Message += " " + a.lang.Sprintf("(average build time per run: %s)", strings.Join(evidence, ", "))
+					finding.URL = jobURL(samples, job)
+					finding.Confidence = logConfidence(runsWithBuilds, samples)
+				}
+
+				// Native builds of each platform take roughly as long as the fastest one and run in parallel
+				var slowest, fastest time.Duration
+				for _, p := range platforms {
+					d, ok := totals[p]
+					if !ok {
+						continue
+					}
+					if d > slowest {
+						slowest = d
+					}
+					if fastest == 0 || d < fastest {
+						fastest = d
+					}
+				}
+				finding.EstimatedSavings = a.projectSavings(samples, (slowest-fastest)/time.Duration(runsWithBuilds))
+			}
+
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// multiArchExample renders a matrix that builds each platform on a native runner,
+// a self-hosted one labelled with the architecture where GitHub hosts none
+func multiArchExample(platforms []string) string {
+	var include strings.Builder
+	for _, p := range platforms {
+		arch := platformArch(p)
+		if runner, ok := hostedRunners[arch]; ok {
+			fmt.Fprintf(&include, "          - platform: %s\n            runner: %s\n", p, runner)
+		} else {
+			fmt.Fprintf(&include, "          - platform: %s\n            runner: %s # self-hosted, GitHub hosts no %s runners\n", p, arch, arch)
+		}
+	}
+	return `  build:
+    strategy:
+      matrix:
+        include:
+` + include.String() + `    runs-on: ${{ matrix.runner }}
+    steps:
+      - uses: docker/setup-buildx-action@v3
+      - uses: docker/build-push-action@v6
+        with:
+          platforms: ${{ matrix.platform }}
+          outputs: type=image,push-by-digest=true,push=true
+  # A follow-up job merges the per-platform digests with
+  # docker buildx imagetools create -t <image>:<tag> <digest>...`
+}
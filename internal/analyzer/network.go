@@ -0,0 +1,234 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// Transient network error classes
+const (
+	netTimeout = "timeout"
+	netReset   = "connection reset"
+	netDNS     = "DNS"
+	netTLS     = "TLS handshake"
+	netServer  = "server error"
+)
+
+// networkErrors classify the transient network errors of common tools, checked in order
+var networkErrors = []struct {
+	class   string
+	pattern *regexp.Regexp
+}{
+	{netTLS, regexp.MustCompile(`(?i)TLS handshake timeout|tls: handshake failure|SSL_ERROR_SYSCALL|SSL: UNEXPECTED_EOF`)},
+	{netDNS, regexp.MustCompile(`(?i)EAI_AGAIN|Could not resolve host|Temporary failure in name resolution|no such host|getaddrinfo ENOTFOUND`)},
+	{netTimeout, regexp.MustCompile(`(?i)ETIMEDOUT|ESOCKETTIMEDOUT|i/o timeout|Read timed out|Connection timed out|operation timed out|context deadline exceeded \(Client\.Timeout`)},
+	{netReset, regexp.MustCompile(`(?i)ECONNRESET|ECONNREFUSED|connection reset by peer|Connection refused|Connection aborted`)},
+	{netServer, regexp.MustCompile(`(?i)\b50[234] (?:Bad Gateway|Service Unavailable|Gateway Time-?out)|(?:status|status code|HTTP error|HTTP status|npm ERR! code E)[: ]*50[0234]\b`)},
+}
+
+// endpointHost finds the host a network error names, e.g. in a URL, pip's
+// "host='files.pythonhosted.org'" or curl's "Could not resolve host: example.com"
+var endpointHost = regexp.MustCompile(`https?://([\w.-]+)|host='([\w.-]+)'|resolve host:? ([\w.-]+)|lookup ([\w.-]+)|dial tcp:? ([\w.-]+):\d+`)
+
+const (
+	// minFlakyRuns is how many runs an endpoint must fail in to be reported,
+	// unless a run failed because of it
+	minFlakyRuns = 2
+	// maxFlakyEndpoints caps the endpoints reported per workflow
+	maxFlakyEndpoints = 5
+)
+
+// endpointAdvice holds the retry and mirror advice for well-known hosts
+var endpointAdvice = []struct {
+	hosts  []string
+	advice string
+}{
+	{[]string{"registry.npmjs.org", "registry.yarnpkg.com", "npm.pkg.github.com"},
+		"Install from the lockfile with npm ci, yarn install --frozen-lockfile or pnpm install --frozen-lockfile, cache the package store, and raise npm's fetch-retries"},
+	{[]string{"pypi.org", "files.pythonhosted.org"},
+		"Install from a pinned requirements or lock file, cache pip's download cache, and pass --retries 5 --timeout 60 to pip"},
+	{[]string{"docker.io", "registry-1.docker.io", "auth.docker.io", "production.cloudflare.docker.com", "ghcr.io", "quay.io", "gcr.io", "mcr.microsoft.com"},
+		"Retry image pulls, and mirror the images to a registry close to the runners such as ghcr.io or a pull-through cache"},
+	{[]string{"archive.ubuntu.com", "azure.archive.ubuntu.com", "security.ubuntu.com", "packages.microsoft.com", "ppa.launchpadcontent.net"},
+		"Let apt retry with -o Acquire::Retries=3, or bake the packages into a container image so jobs don't install them on every run"},
+	{[]string{"repo.maven.apache.org", "repo1.maven.org", "plugins.gradle.org", "services.gradle.org", "jcenter.bintray.com"},
+		"Cache ~/.m2 or ~/.gradle keyed on the build files, and resolve dependencies through a repository mirror with retries"},
+	{[]string{"proxy.golang.org", "sum.golang.org"},
+		"Cache the Go module cache keyed on go.sum so modules are downloaded only when dependencies change"},
+	{[]string{"github.com", "api.github.com", "objects.githubusercontent.com", "codeload.github.com", "raw.githubusercontent.com"},
+		"Retry downloads with backoff, e.g. curl --retry 5 --retry-all-errors, and cache downloaded tools with actions/cache or a setup action"},
+}
+
+// flakyEndpoint is what the logs say about transient errors reaching one host
+type flakyEndpoint struct {
+	host       string
+	errors     int
+	runs       int // runs with at least one error
+	failedRuns int // of those, runs that failed
+	classes    map[string]int
+	jobs       map[string]bool // workflow job IDs the errors could be attributed to
+	url        string
+}
+
+// classifyNetworkError returns the class of a transient network error in a log
+// message and the host it names, or "" when the message isn't one
+func classifyNetworkError(message string) (class, host string) {
+	for _, e := range networkErrors {
+		if e.pattern.MatchString(message) {
+			class = e.class
+			break
+		}
+	}
+	if class == "" {
+		return "", ""
+	}
+	host = "unknown"
+	if m := endpointHost.FindStringSubmatch(message); m != nil {
+		for _, group := range m[1:] {
+			if group != "" {
+				host = strings.ToLower(strings.TrimSuffix(group, "."))
+				break
+			}
+		}
+	}
+	return class, host
+}
+
+// flakyEndpoints tallies transient network errors in the logs by host, most
+// failure-prone first
+func flakyEndpoints(samples []runSample, wf *workflow.Workflow) []*flakyEndpoint {
+	endpoints := make(map[string]*flakyEndpoint)
+	for _, sample := range samples {
+		seen := make(map[string]bool)
+		for _, line := range strings.Split(sample.Logs, "\n") {
+			t, message, ok := logTime(strings.TrimRight(line, "\r"))
+			class, host := classifyNetworkError(message)
+			if class == "" {
+				continue
+			}
+			e := endpoints[host]
+			if e == nil {
+				e = &flakyEndpoint{host: host, classes: make(map[string]int), jobs: make(map[string]bool)}
+				endpoints[host] = e
+			}
+			e.errors++
+			e.classes[class]++
+			if !seen[host] {
+				seen[host] = true
+				e.runs++
+				if sample.Run.GetConclusion() == "failure" {
+					e.failedRuns++
+				}
+			}
+			url := sample.Run.GetHTMLURL()
+			if apiJob := jobAt(sample.Jobs, t); ok && apiJob != nil {
+				for _, job := range wf.Jobs {
+					if matchesJob(apiJob, job) {
+						e.jobs[job.ID] = true
+						url = apiJob.GetHTMLURL()
+						break
+					}
+				}
+			}
+			if e.url == "" {
+				e.url = url
+			}
+		}
+	}
+
+	var list []*flakyEndpoint
+	for _, e := range endpoints {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].runs != list[j].runs {
+			return list[i].runs > list[j].runs
+		}
+		if list[i].errors != list[j].errors {
+			return list[i].errors > list[j].errors
+		}
+		return list[i].host < list[j].host
+	})
+	return list
+}
+
+// advice returns the retry and mirror advice for the endpoint's host
+func (e *flakyEndpoint) advice() string {
+	for _, known := range endpointAdvice {
+		for _, host := range known.hosts {
+			if e.host == host || strings.HasSuffix(e.host, "."+host) {
+				return known.advice
+			}
+		}
+	}
+	return "Retry the step with backoff, e.g. with nick-fields/retry, and fetch through a mirror or caching proxy closer to the runners"
+}
+
+// describeClasses lists the error classes of an endpoint, most frequent first
+func (e *flakyEndpoint) describeClasses(lang i18n.Lang) string {
+	classes := make([]string, 0, len(e.classes))
+	for class := range e.classes {
+		classes = append(classes, class)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		if e.classes[classes[i]] != e.classes[classes[j]] {
+			return e.classes[classes[i]] > e.classes[classes[j]]
+		}
+		return classes[i] < classes[j]
+	})
+	parts := make([]string, len(classes))
+	for i, class := range classes {
+		parts[i] = fmt.Sprintf("%s ×%d", lang.T(class), e.classes[class])
+	}
+	return strings.Join(parts, ", ")
+}
+
+// checkNetworkFlakiness reports the external endpoints that transient network
+// errors in the logs point at, with retry, mirror and lockfile advice for each
+func (a *Analyzer) checkNetworkFlakiness(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+	for _, e := range flakyEndpoints(samples, wf) {
+		if len(findings) == maxFlakyEndpoints {
+			break
+		}
+		if e.runs < minFlakyRuns && e.failedRuns == 0 {
+			continue
+		}
+
+		severity := models.SeverityInfo
+		if e.failedRuns > 0 {
+			severity = models.SeverityWarning
+		}
+		message := a.lang.Sprintf("Transient network errors reaching %s in %d of %d runs (%s)", e.host, e.runs, len(samples), e.describeClasses(a.lang))
+		if e.failedRuns > 0 {
+			message += "; " + a.lang.Sprintf("%d of those runs failed", e.failedRuns)
+		}
+
+		finding := models.Finding{
+			Category:   "reliability",
+			Severity:   severity,
+			File:       path,
+			Line:       wf.OnLine,
+			Message:    message,
+			Suggestion: a.lang.T(e.advice()),
+			URL:        e.url,
+			Confidence: logConfidence(e.runs, samples),
+		}
+		// Errors from a single job are located at it
+		if len(e.jobs) == 1 {
+			for _, job := range wf.Jobs {
+				if e.jobs[job.ID] {
+					finding.Line = job.Line
+				}
+			}
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import "github.com/somaz94/github-action-analyzer/internal/models"
+
+// nodePackageManagers in detection order; npm is the fallback
+var nodePackageManagers = []packageTool{
+	{name: "pnpm", lockfiles: []string{"pnpm-lock.yaml"}, hints: []string{"pnpm/action-setup", "pnpm install", "pnpm i "}},
+	{name: "yarn", lockfiles: []string{"yarn.lock"}, hints: []string{"yarn install", "yarn --frozen-lockfile", "cache: 'yarn'", "cache: yarn"}},
+	{name: "bun", lockfiles: []string{"bun.lockb", "bun.lock"}, hints: []string{"oven-sh/setup-bun", "bun install"}},
+	{name: "npm", lockfiles: []string{"package-lock.json", "npm-shrinkwrap.json"}},
+}
+
+// nodeCacheStrategies are the Node.js cache recommendations for each package manager
+var nodeCacheStrategies = map[string][]models.CacheRecommendation{
+	"npm": {
+		{
+			Path:        "~/.npm",
+			Description: "Cache npm dependencies",
+			Impact:      "Can reduce npm install time by up to 50%",
+			Example: `      - name: Get npm cache directory
+        id: npm-cache-dir
+        shell: bash
+        run: echo "dir=$(npm config get cache)" >> ${GITHUB_OUTPUT}
+
+      - name: Setup Node.js
+        uses: actions/setup-node@v4
+        with:
+          node-version: '%s'
+          cache: 'npm'  # This enables npm cache
+
+      - uses: actions/cache@v4
+        id: npm-cache
+        with:
+          path: ${{ steps.npm-cache-dir.outputs.dir }}
+          key: ${{ runner.os }}-node-${{ hashFiles('**/package-lock.json') }}
+          restore-keys: |
+            ${{ runner.os }}-node-`,
+		},
+		{
+			Path:        "node_modules",
+			Description: "Cache node_modules directory",
+			Impact:      "Can significantly reduce installation time for large projects",
+			Example: `      - uses: actions/cache@v4
+        with:
+          path: '**/node_modules'
+          key: ${{ runner.os }}-modules-${{ hashFiles('**/package-lock.json') }}`,
+		},
+	},
+	"pnpm": {
+		{
+			Path:        "~/.local/share/pnpm/store",
+			Description: "Cache the pnpm store",
+			Impact:      "Can reduce pnpm install time by up to 60%",
+			Example: `      - uses: pnpm/action-setup@v4
+
+      - name: Setup Node.js
+        uses: actions/setup-node@v4
+        with:
+          node-version: '%s'
+          cache: 'pnpm'  # Caches the pnpm store, keyed on pnpm-lock.yaml
+
+      - run: pnpm install --frozen-lockfile`,
+		},
+	},
+	"yarn": {
+		{
+			Path:        "~/.cache/yarn",
+			Description: "Cache yarn dependencies",
+			Impact:      "Can reduce yarn install time by up to 50%",
+			Example: `      - name: Setup Node.js
+        uses: actions/setup-node@v4
+        with:
+          node-version: '%s'
+          cache: 'yarn'  # Caches the directory from yarn cache dir, keyed on yarn.lock
+
+      # Yarn 1: yarn install --frozen-lockfile
+      # Yarn 2+: enable Corepack first and use yarn install --immutable
+      - run: yarn install --frozen-lockfile`,
+		},
+	},
+	"bun": {
+		{
+			Path:        "~/.bun/install/cache",
+			Description: "Cache bun dependencies",
+			Impact:      "Avoids re-downloading packages on every bun install",
+			Example: `      - uses: oven-sh/setup-bun@v2
+
+      # actions/setup-node has no bun cache option, so cache the install cache directly
+      - uses: actions/cache@v4
+        with:
+          path: ~/.bun/install/cache
+          key: ${{ runner.os }}-bun-${{ hashFiles('**/bun.lockb', '**/bun.lock') }}
+          restore-keys: |
+            ${{ runner.os }}-bun-
+
+      - run: bun install --frozen-lockfile`,
+		},
+	},
+}
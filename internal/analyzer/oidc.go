@@ -0,0 +1,115 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// cloudLogin describes a cloud login action and the inputs that carry long-lived secrets
+type cloudLogin struct {
+	action     string
+	provider   string
+	secretKeys []string
+	example    func(with map[string]string) string
+}
+
+var cloudLogins = []cloudLogin{
+	{
+		action:     "aws-actions/configure-aws-credentials",
+		provider:   "AWS",
+		secretKeys: []string{"aws-access-key-id", "aws-secret-access-key"},
+		example: func(with map[string]string) string {
+			region := with["aws-region"]
+			if region == "" {
+				region = "<region>"
+			}
+			return fmt.Sprintf(`      - uses: aws-actions/configure-aws-credentials@v4
+        with:
+          role-to-assume: arn:aws:iam::<account-id>:role/<role-name>
+          aws-region: %s`, region)
+		},
+	},
+	{
+		action:     "google-github-actions/auth",
+		provider:   "Google Cloud",
+		secretKeys: []string{"credentials_json"},
+		example: func(with map[string]string) string {
+			return `      - uses: google-github-actions/auth@v2
+        with:
+          workload_identity_provider: projects/<project-number>/locations/global/workloadIdentityPools/<pool>/providers/<provider>
+          service_account: <name>@<project-id>.iam.gserviceaccount.com`
+		},
+	},
+	{
+		action:     "azure/login",
+		provider:   "Azure",
+		secretKeys: []string{"creds"},
+		example: func(with map[string]string) string {
+			return `      - uses: azure/login@v2
+        with:
+          client-id: ${{ secrets.AZURE_CLIENT_ID }}
+          tenant-id: ${{ secrets.AZURE_TENANT_ID }}
+          subscription-id: ${{ secrets.AZURE_SUBSCRIPTION_ID }}`
+		},
+	},
+}
+
+// oidcPermissions is the permissions block OIDC federation requires
+const oidcPermissions = `    permissions:
+      id-token: write
+      contents: read`
+
+// checkOIDC flags cloud login steps that authenticate with static secrets instead of OIDC
+func (a *Analyzer) checkOIDC(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			action, _, _ := strings.Cut(step.Uses, "@")
+			for _, login := range cloudLogins {
+				if !strings.EqualFold(action, login.action) {
+					continue
+				}
+
+				var used []string
+				for _, key := range login.secretKeys {
+					if _, ok := step.With[key]; ok {
+						used = append(used, key)
+					}
+				}
+				if len(used) == 0 {
+					continue
+				}
+
+				suggestion := a.lang.Sprintf("Switch to OIDC federation so no long-lived %s credentials are stored as secrets", login.provider)
+				if !grantsIDToken(wf, job) {
+					suggestion += " " + a.lang.T("The job also needs the id-token: write permission.")
+				}
+
+				findings = append(findings, models.Finding{
+					Category:   "security",
+					Severity:   models.SeverityWarning,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Job %s authenticates to %s with static credentials (%s)", job.ID, login.provider, strings.Join(used, ", ")),
+					Suggestion: suggestion,
+					Example:    oidcPermissions + "\n    steps:\n" + login.example(step.With),
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+// grantsIDToken reports whether the job (or the workflow, when the job sets none) grants id-token: write
+func grantsIDToken(wf *workflow.Workflow, job *workflow.Job) bool {
+	perms := wf.Permissions
+	if job.HasPerms {
+		perms = job.Permissions
+	}
+	return perms["id-token"] == "write" || perms["*"] == "write-all"
+}
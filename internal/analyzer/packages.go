@@ -0,0 +1,184 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+const (
+	// Package installs faster than this aren't worth caching
+	slowPackageInstall = 30 * time.Second
+	// Package installs slower than this are reported as warnings
+	verySlowPackageInstall = 2 * time.Minute
+)
+
+// packageManagers maps the OS package managers checked to their install subcommand
+var packageManagers = map[string]string{
+	"apt-get": "install",
+	"apt":     "install",
+	"brew":    "install",
+	"choco":   "install",
+}
+
+// preinstalled lists packages that GitHub-hosted runner images already ship, per
+// package manager. Installing them again only checks or upgrades the version.
+var preinstalled = map[string]map[string]bool{
+	"apt": {
+		"curl": true, "wget": true, "git": true, "jq": true, "zip": true, "unzip": true,
+		"make": true, "gcc": true, "g++": true, "python3": true, "rsync": true,
+		"shellcheck": true, "zstd": true, "openssl": true, "sqlite3": true,
+	},
+	"brew": {
+		"jq": true, "wget": true, "gnu-tar": true, "zstd": true, "gh": true, "git": true, "xz": true,
+	},
+	"choco": {
+		"7zip": true, "git": true, "jq": true, "gh": true, "curl": true,
+	},
+}
+
+// packageInstall is one OS package install command found in a run script
+type packageInstall struct {
+	manager  string // apt, brew or choco
+	packages []string
+	// noRecommends is set when apt skips recommended packages
+	noRecommends bool
+}
+
+// packageInstalls returns the OS package install commands in a run script
+func packageInstalls(script string) []packageInstall {
+	script = strings.ReplaceAll(script, "\\\n", " ")
+	var installs []packageInstall
+	for _, line := range strings.Split(script, "\n") {
+		for _, command := range strings.FieldsFunc(line, func(r rune) bool { return r == ';' || r == '&' || r == '|' }) {
+			fields := strings.Fields(command)
+			// Skip sudo and leading VAR=value assignments
+			for len(fields) > 0 && (fields[0] == "sudo" || strings.Contains(fields[0], "=")) {
+				fields = fields[1:]
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			subcommand, ok := packageManagers[fields[0]]
+			if !ok {
+				continue
+			}
+			install := packageInstall{manager: fields[0]}
+			if install.manager == "apt-get" {
+				install.manager = "apt"
+			}
+			found := false
+			for _, field := range fields[1:] {
+				switch {
+				case field == subcommand:
+					found = true
+				case field == "--no-install-recommends":
+					install.noRecommends = true
+				case found && !strings.HasPrefix(field, "-") && !strings.Contains(field, "::"):
+					install.packages = append(install.packages, field)
+				}
+			}
+			if found && len(install.packages) > 0 {
+				installs = append(installs, install)
+			}
+		}
+	}
+	return installs
+}
+
+// preinstalledPackages returns the packages of an install that the runner image already ships
+func preinstalledPackages(install packageInstall) []string {
+	var names []string
+	for _, pkg := range install.packages {
+		// apt pins versions as name=version
+		name, _, _ := strings.Cut(pkg, "=")
+		if preinstalled[install.manager][name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkPackageInstalls flags run steps that install OS packages with apt, brew or
+// choco on every run and measures what they cost
+func (a *Analyzer) checkPackageInstalls(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var findings []models.Finding
+
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			installs := packageInstalls(step.Run)
+			if len(installs) == 0 {
+				continue
+			}
+			avg := average(stepDurations(samples, job, step))
+			if avg < slowPackageInstall {
+				continue
+			}
+
+			var packages, aptPackages, managers, present []string
+			seen := make(map[string]bool)
+			apt, aptRecommends, brew := false, false, false
+			for _, install := range installs {
+				packages = append(packages, install.packages...)
+				present = append(present, preinstalledPackages(install)...)
+				if !seen[install.manager] {
+					seen[install.manager] = true
+					managers = append(managers, install.manager)
+				}
+				switch install.manager {
+				case "apt":
+					apt = true
+					aptPackages = append(aptPackages, install.packages...)
+					aptRecommends = aptRecommends || !install.noRecommends
+				case "brew":
+					brew = true
+				}
+			}
+
+			severity := models.SeverityInfo
+			if avg >= verySlowPackageInstall {
+				severity = models.SeverityWarning
+			}
+			finding := models.Finding{
+				Category:         "performance",
+				Severity:         severity,
+				File:             path,
+				Line:             step.Line,
+				Message:          a.lang.Sprintf("Step %q in job %s installs %s packages (%s) on every run, taking %v on average", step.DisplayName(), job.ID, strings.Join(managers, "/"), strings.Join(packages, ", "), avg.Round(time.Second)),
+				URL:              jobURL(samples, job),
+				EstimatedSavings: a.projectSavings(samples, avg),
+			}
+
+			var suggestions []string
+			if len(present) > 0 {
+				suggestions = append(suggestions, a.lang.Sprintf("%s already ship with the GitHub-hosted runner images, so installing them again can usually be dropped", strings.Join(present, ", ")))
+			}
+			switch {
+			case apt:
+				suggestions = append(suggestions, a.lang.T("Cache the packages with awalsh128/cache-apt-pkgs-action, or run the job in a container image with them baked in"))
+				if aptRecommends {
+					suggestions = append(suggestions, a.lang.T("Pass --no-install-recommends so apt only installs what the packages require"))
+				}
+				finding.Example = "      - uses: awalsh128/cache-apt-pkgs-action@v1\n" +
+					"        with:\n" +
+					"          packages: " + strings.Join(aptPackages, " ") + "\n" +
+					"          version: 1.0"
+			case brew:
+				suggestions = append(suggestions, a.lang.T("Set HOMEBREW_NO_AUTO_UPDATE and HOMEBREW_NO_INSTALL_CLEANUP so brew install doesn't update Homebrew itself first, which often takes most of the step; prefer tools preinstalled on the macOS image"))
+				finding.Example = "        env:\n" +
+					"          HOMEBREW_NO_AUTO_UPDATE: 1\n" +
+					"          HOMEBREW_NO_INSTALL_CLEANUP: 1"
+			default:
+				suggestions = append(suggestions, a.lang.T("Pass --no-progress to choco install, cache the Chocolatey package cache with actions/cache, or use the tools preinstalled on the Windows image"))
+			}
+			finding.Suggestion = strings.Join(suggestions, ". ")
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
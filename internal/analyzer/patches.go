@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/patch"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// maxPinLookups caps the latest-release lookups made to pin action references
+const maxPinLookups = 10
+
+// Block inserted when a workflow has no permissions; jobs that need more ask for it themselves
+var readOnlyPermissions = []string{
+	"permissions:",
+	"  contents: read",
+}
+
+// Block inserted when a push or pull request workflow has no concurrency group
+var concurrencyGroup = []string{
+	"concurrency:",
+	"  group: ${{ github.workflow }}-${{ github.ref }}",
+	"  cancel-in-progress: ${{ github.event_name == 'pull_request' }}",
+}
+
+// workflowPatches proposes fixes to a workflow file as a unified diff for git apply:
+// read-only permissions and a concurrency group after the triggers, the built-in cache
+// of setup-* steps, runs-on labels of retired runner images moved to their
+// replacement, and actions that are unpinned or track a branch pinned to their
+// latest release
+func (a *Analyzer) workflowPatches(ctx context.Context, path, content string) string {
+	return patch.Unified(path, content, a.workflowEdits(ctx, content, nil))
+}
+
+// workflowEdits returns the edits making the fixes to a workflow file, or those of
+// every fix when fixes is nil
+func (a *Analyzer) workflowEdits(ctx context.Context, content string, fixes map[string]bool) []patch.Edit {
+	wf, err := workflow.Parse(content)
+	if err != nil {
+		return nil
+	}
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	wanted := func(fix string) bool {
+		return fixes == nil || fixes[fix]
+	}
+
+	var edits []patch.Edit
+	var header []string
+	if !wf.HasPerms && wanted(FixPermissions) {
+		header = append(header, readOnlyPermissions...)
+	}
+	if !wf.HasConcurrency && (wf.HasTrigger("push") || wf.HasTrigger("pull_request")) && wanted(FixConcurrency) {
+		header = append(header, concurrencyGroup...)
+	}
+	if len(header) > 0 && wf.OnLine > 0 {
+		at := afterTriggers(wf, lines)
+		if at > len(lines) {
+			header = append([]string{""}, header...)
+		} else {
+			header = append(header, "")
+		}
+		edits = append(edits, patch.Edit{Line: at, Insert: header})
+	}
+
+	if wanted(FixCache) {
+		edits = append(edits, setupCacheEdits(wf)...)
+	}
+	if wanted(FixRunnerImages) {
+		edits = append(edits, runnerImageEdits(wf, lines)...)
+	}
+	if wanted(FixPinning) {
+		edits = append(edits, a.pinEdits(ctx, lines)...)
+	}
+	return edits
+}
+
+// afterTriggers returns the line to insert top-level keys at: before the key that
+// follows on:, along with the comments directly above it, or past the end of the file
+func afterTriggers(wf *workflow.Workflow, lines []string) int {
+	next := 0
+	for _, pair := range workflow.Pairs(wf.Root) {
+		if line := pair[0].Line; line > wf.OnLine && (next == 0 || line < next) {
+			next = line
+		}
+	}
+	if next == 0 {
+		return len(lines) + 1
+	}
+	for next > wf.OnLine+1 && strings.HasPrefix(strings.TrimSpace(lines[next-2]), "#") {
+		next--
+	}
+	return next
+}
+
+// pinEdits rewrites uses: lines whose action is unpinned or tracks a branch to the
+// action's latest release tag. Actions without releases are left alone.
+func (a *Analyzer) pinEdits(ctx context.Context, lines []string) []patch.Edit {
+	var edits []patch.Edit
+	tags := make(map[string]string)
+	lookups := 0
+
+	for i, raw := range lines {
+		key, ref := splitYAMLLine(raw)
+		if key != "uses" || ref == "" || strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "docker://") {
+			continue
+		}
+		action, version, found := strings.Cut(ref, "@")
+		if found && version != "main" && version != "master" && version != "HEAD" {
+			continue
+		}
+		parts := strings.SplitN(action, "/", 3)
+		if len(parts) < 2 {
+			continue
+		}
+
+		repo := parts[0] + "/" + parts[1]
+		tag, seen := tags[repo]
+		if !seen {
+			if lookups == maxPinLookups {
+				continue
+			}
+			lookups++
+			if release, err := a.client.GetLatestRelease(ctx, parts[0], parts[1]); err == nil {
+				tag = release.GetTagName()
+			} else {
+				a.debugLog("No release to pin %s to: %v", repo, err)
+			}
+			tags[repo] = tag
+		}
+		if tag == "" {
+			continue
+		}
+
+		edits = append(edits, patch.Edit{
+			Line:   i + 1,
+			Delete: 1,
+			Insert: []string{strings.Replace(raw, ref, action+"@"+tag, 1)},
+		})
+	}
+	return edits
+}
@@ -0,0 +1,191 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// maxVersionLookups is the number of languages the version checker can query
+const maxVersionLookups = 7
+
+// Plan enumerates the API calls an analysis would make without performing it.
+// Only the run listing, the jobs of the latest run and the rate limit are fetched.
+func (a *Analyzer) Plan(ctx context.Context, owner, repo, workflowFile string) (*models.AnalysisPlan, error) {
+	plan := &models.AnalysisPlan{
+		Repository:   fmt.Sprintf("%s/%s", owner, repo),
+		WorkflowFile: workflowFile,
+		Mode:         string(a.mode),
+		Lang:         a.lang,
+	}
+
+	runs, err := a.client.GetWorkflowRuns(ctx, owner, repo, workflowFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow runs: %v", err)
+	}
+	plan.RunsAvailable = len(runs)
+
+	// Estimate jobs per run from the most recent run
+	jobsPerRun := 1
+	if len(runs) > 0 {
+		jobs, err := a.client.GetWorkflowJobs(ctx, owner, repo, runs[0].GetID(), runs[0].GetRunAttempt())
+		if err == nil && len(jobs) > 0 {
+			jobsPerRun = len(jobs)
+		}
+	}
+
+	plan.Calls = append(plan.Calls, models.PlannedCall{
+		Endpoint: "GET /repos/{owner}/{repo}/actions/workflows/{file}/runs",
+		Purpose:  a.lang.T("List workflow runs"),
+		Count:    1,
+	})
+
+	switch a.mode {
+	case ModeSurvey:
+		plan.RunsToAnalyze = len(runs)
+		plan.Calls = append(plan.Calls, models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs",
+			Purpose:  a.lang.T("Fetch job and step timings for each run"),
+			Count:    len(runs),
+		})
+	default:
+		plan.RunsToAnalyze = len(a.selectRuns(runs))
+		plan.Calls = append(plan.Calls,
+			models.PlannedCall{
+				Endpoint: "GET /repos/{owner}/{repo}/actions/runs/{run_id}/jobs",
+				Purpose:  a.lang.T("List jobs of each sampled run"),
+				Count:    plan.RunsToAnalyze,
+			},
+			models.PlannedCall{
+				Endpoint: "GET /repos/{owner}/{repo}/actions/jobs/{job_id}/logs",
+				Purpose:  a.lang.T("Download job logs of each sampled run"),
+				Count:    plan.RunsToAnalyze * jobsPerRun,
+				Note:     a.lang.Sprintf("Assumes %d jobs per run based on the latest run", jobsPerRun),
+			},
+		)
+	}
+
+	plan.Calls = append(plan.Calls,
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/contents/{path}",
+			Purpose:  a.lang.T("Fetch the workflow file (three times), the Dockerfile and GitLab CI/CircleCI configs"),
+			Count:    6,
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/contents/{path}",
+			Purpose:  a.lang.T("Check for Node.js and Python lockfiles"),
+			Count:    lockfileCount(nodePackageManagers) + lockfileCount(pythonTools),
+			Note:     a.lang.T("Upper bound; only when Node.js or Python is detected"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/contents/{path}",
+			Purpose:  a.lang.Sprintf("Fetch the reusable workflows and actions called, %d levels deep", a.analyzeDepth),
+			Count:    calleeFetches(a.analyzeDepth),
+			Note:     a.lang.T("Upper bound; once per called workflow or action"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/contents/{path}",
+			Purpose:  a.lang.T("Fetch the repository's own action.yml files and Docker action Dockerfiles to lint them"),
+			Count:    maxCustomActions,
+			Note:     a.lang.T("Upper bound; only when the repository has actions"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/git/trees/HEAD",
+			Purpose:  a.lang.T("List repository files to find subprojects and Java build tools"),
+			Count:    1,
+			Note:     a.lang.T("Gradle projects also fetch gradle.properties, and Python projects without a lockfile requirements.txt"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/contents/{path}",
+			Purpose:  a.lang.T("Fetch the other workflow files to find workflow_run chains and deploy workflows"),
+			Count:    maxRepoWorkflows,
+			Note:     a.lang.T("Upper bound; also lists the repository tree once"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/workflows/{file}/runs",
+			Purpose:  a.lang.T("List runs of the workflows chained by workflow_run"),
+			Count:    maxRepoWorkflows,
+			Note:     a.lang.T("Upper bound; only workflows linked to the analyzed one"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/workflows/{file}/runs",
+			Purpose:  a.lang.T("List runs of the deploy workflows for the DORA metrics"),
+			Count:    maxDeployWorkflows,
+			Note:     a.lang.T("Upper bound; only configured or detected deploy workflows"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/workflows",
+			Purpose:  a.lang.T("List the repository's workflows to find disabled ones"),
+			Count:    1,
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/workflows/{file}/runs",
+			Purpose:  a.lang.Sprintf("Count each workflow's runs in the last %d days to find workflows that never run", unusedWorkflowDays),
+			Count:    maxRepoWorkflows,
+			Note:     a.lang.T("Upper bound; once per workflow file"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/branches/{branch}/protection/required_status_checks",
+			Purpose:  a.lang.T("Get the status checks the default branch requires, with the repository and its rulesets"),
+			Count:    3,
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/cache/usage",
+			Purpose:  a.lang.T("Get the total size of the repository's Actions caches"),
+			Count:    1,
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/actions/caches",
+			Purpose:  a.lang.T("List Actions caches for the stale, pull request and key variant breakdown"),
+			Count:    maxListedCaches / 100,
+			Note:     a.lang.T("Upper bound; 100 caches per page, only when caches exist"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}",
+			Purpose:  a.lang.T("Check whether third-party action repositories are archived"),
+			Count:    maxSupplyChainLookups,
+			Note:     a.lang.T("Upper bound; once per action repository"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/releases/latest",
+			Purpose:  a.lang.T("Check when third-party actions last released"),
+			Count:    maxSupplyChainLookups,
+			Note:     a.lang.T("Upper bound; once per action repository"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/contributors",
+			Purpose:  a.lang.T("Check whether third-party actions have a single contributor"),
+			Count:    maxSupplyChainLookups,
+			Note:     a.lang.T("Upper bound; once per action repository"),
+		},
+		models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/releases/latest",
+			Purpose:  a.lang.T("Look up release tags to pin unpinned actions in the suggested patches"),
+			Count:    maxPinLookups,
+			Note:     a.lang.T("Upper bound; only actions without a version or tracking a branch"),
+		},
+	)
+	// Other version checkers, such as endoflife.date, don't call the GitHub API
+	if _, ok := a.versionChecker.(*GitHubVersionChecker); ok {
+		plan.Calls = append(plan.Calls, models.PlannedCall{
+			Endpoint: "GET /repos/{owner}/{repo}/releases",
+			Purpose:  a.lang.T("Look up the latest version of each detected language"),
+			Count:    maxVersionLookups,
+			Note:     a.lang.T("Upper bound; only detected languages are queried"),
+		})
+	}
+
+	for _, call := range plan.Calls {
+		plan.EstimatedCost += call.Count
+	}
+
+	if rate, err := a.client.GetRateLimit(ctx); err == nil {
+		plan.RateLimitRemaining = rate.Remaining
+		plan.RateLimitReset = rate.Reset.Time
+	} else {
+		a.debugLog("Error getting rate limit: %v", err)
+	}
+
+	return plan, nil
+}
@@ -0,0 +1,124 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/policy"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// CategoryPolicy is the category of policy violations
+const CategoryPolicy = "policy"
+
+// WithPolicy enforces an organization policy, reporting violations under the
+// policy category
+func WithPolicy(p *policy.Policy) Option {
+	return func(a *Analyzer) {
+		a.policy = p
+	}
+}
+
+// matrixLabel matches a runner label taken from the matrix, e.g. ${{ matrix.os }}
+var matrixLabel = regexp.MustCompile(`^\$\{\{\s*matrix\.([\w-]+)\s*\}\}$`)
+
+// runnerLabels returns the runner labels a job may run on, expanding matrix
+// labels. Labels from other expressions can't be known and are left out.
+func runnerLabels(job *workflow.Job) []string {
+	var labels []string
+	for _, label := range job.RunsOn {
+		if m := matrixLabel.FindStringSubmatch(label); m != nil {
+			values, _ := job.MatrixValues(m[1])
+			labels = append(labels, values...)
+			continue
+		}
+		if !strings.Contains(label, "${{") {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// checkPolicy reports violations of the organization policy: runner labels that
+// aren't allowed, banned actions and permissions beyond what the policy permits
+func (a *Analyzer) checkPolicy(path string, wf *workflow.Workflow) []models.Finding {
+	p := a.policy
+	var findings []models.Finding
+	violation := func(line int, message, suggestion string) {
+		findings = append(findings, models.Finding{
+			Category:   CategoryPolicy,
+			Severity:   models.SeverityCritical,
+			File:       path,
+			Line:       line,
+			Message:    message,
+			Suggestion: suggestion,
+		})
+	}
+
+	checkPermissions := func(line int, owner string, perms map[string]string) {
+		if p.Permissions.ForbidWriteAll && perms["*"] == "write-all" {
+			violation(line, a.lang.Sprintf("%s grants write-all permissions, which the policy forbids", owner),
+				a.lang.T("Grant only the scopes the jobs need, e.g. contents: read"))
+		}
+		scopes := make([]string, 0, len(perms))
+		for scope := range perms {
+			scopes = append(scopes, scope)
+		}
+		sort.Strings(scopes)
+		for _, scope := range scopes {
+			if scope == "*" {
+				// write-all and read-all grant every scope at that level
+				for _, exceeded := range p.ExceededByAll(perms[scope]) {
+					max := p.Permissions.Max[exceeded]
+					violation(line, a.lang.Sprintf("%s grants %s, which includes %s: %s, but the policy allows at most %s", owner, perms[scope], exceeded, strings.TrimSuffix(perms[scope], "-all"), max),
+						a.lang.Sprintf("Replace %s with the scopes the jobs need, granting %s at most %s", perms[scope], exceeded, max))
+				}
+				continue
+			}
+			if max, exceeds := p.ExceedsMax(scope, perms[scope]); exceeds {
+				violation(line, a.lang.Sprintf("%s grants %s: %s, but the policy allows at most %s", owner, scope, perms[scope], max),
+					a.lang.Sprintf("Lower %s to %s, or ask for an exception to the policy", scope, max))
+			}
+		}
+	}
+
+	if wf.HasPerms {
+		checkPermissions(wf.OnLine, a.lang.T("The workflow"), wf.Permissions)
+	}
+	for _, job := range wf.Jobs {
+		subject := a.lang.Sprintf("Job %s", job.ID)
+
+		for _, label := range runnerLabels(job) {
+			if !p.AllowsRunner(label) {
+				line := job.RunsOnLine
+				if line == 0 {
+					line = job.Line
+				}
+				violation(line, a.lang.Sprintf("%s runs on %q, which isn't an allowed runner label", subject, label),
+					a.lang.Sprintf("Use one of the allowed runner labels: %s", strings.Join(p.Runners.Allowed, ", ")))
+			}
+		}
+
+		if job.Uses != "" && !p.AllowsAction(job.Uses) {
+			violation(job.Line, a.lang.Sprintf("%s calls the reusable workflow %s, which the policy doesn't allow", subject, job.Uses),
+				a.lang.T("Replace it with an approved action or workflow, or ask for it to be added to the policy"))
+		}
+		for _, step := range job.Steps {
+			if step.Uses != "" && !p.AllowsAction(step.Uses) {
+				violation(step.Line, a.lang.Sprintf("%s uses %s, which the policy doesn't allow", subject, step.Uses),
+					a.lang.T("Replace it with an approved action or workflow, or ask for it to be added to the policy"))
+			}
+		}
+
+		switch {
+		case job.HasPerms:
+			checkPermissions(job.Line, subject, job.Permissions)
+		case !wf.HasPerms && p.Permissions.RequireExplicit:
+			violation(job.Line, a.lang.Sprintf("%s doesn't declare permissions, so it gets the default token permissions", subject),
+				a.lang.T("Declare permissions on the workflow or the job; the policy requires explicit permissions"))
+		}
+	}
+	return findings
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import "github.com/somaz94/github-action-analyzer/internal/models"
+
+// pythonTools in detection order; pip is the fallback
+var pythonTools = []packageTool{
+	{name: "uv", lockfiles: []string{"uv.lock"}, hints: []string{"astral-sh/setup-uv", "uv sync", "uv pip install"}},
+	{name: "poetry", lockfiles: []string{"poetry.lock"}, hints: []string{"snok/install-poetry", "pipx install poetry", "poetry install"}},
+	{name: "pipenv", lockfiles: []string{"Pipfile.lock"}, hints: []string{"pipenv install", "pipenv sync"}},
+	{name: "conda", lockfiles: []string{"environment.yml", "environment.yaml"}, hints: []string{"conda-incubator/setup-miniconda", "mamba-org/setup-micromamba", "conda env create", "conda install"}},
+}
+
+// pythonCacheStrategies are the Python cache recommendations for each tool
+var pythonCacheStrategies = map[string][]models.CacheRecommendation{
+	"pip": {
+		{
+			Path:        "~/.cache/pip",
+			Description: "Cache pip dependencies",
+			Impact:      "Can reduce pip install time significantly",
+			Example: `      - name: Set up Python
+        id: setup-python
+        uses: actions/setup-python@v5
+        with:
+          python-version: '%s'
+          cache: 'pip'
+          cache-dependency-path: |
+            **/requirements.txt
+            **/requirements-dev.txt
+
+      - uses: actions/cache@v4
+        with:
+          path: |
+            ~/.cache/pip
+            ~/.local/share/virtualenvs
+          key: ${{ runner.os }}-python-${{ hashFiles('**/requirements.txt') }}
+          restore-keys: |
+            ${{ runner.os }}-python-`,
+		},
+	},
+	"uv": {
+		{
+			Path:        "~/.cache/uv",
+			Description: "Cache the uv cache directory",
+			Impact:      "Can reduce uv sync time to seconds on a warm cache",
+			Example: `      - name: Set up uv
+        uses: astral-sh/setup-uv@v6
+        with:
+          python-version: '%s'
+          enable-cache: true  # Caches the uv cache dir, keyed on uv.lock
+          cache-dependency-glob: uv.lock
+
+      - run: uv sync --locked`,
+		},
+	},
+	"poetry": {
+		{
+			Path:        "~/.cache/pypoetry",
+			Description: "Cache Poetry dependencies and virtualenvs",
+			Impact:      "Can reduce poetry install time significantly",
+			Example: `      - run: pipx install poetry
+
+      - name: Set up Python
+        uses: actions/setup-python@v5
+        with:
+          python-version: '%s'
+          cache: 'poetry'  # Caches Poetry's cache and virtualenvs, keyed on poetry.lock
+
+      # In-project virtualenvs keep the cached environment next to the code
+      - run: poetry config virtualenvs.in-project true
+      - run: poetry install --no-interaction`,
+		},
+	},
+	"pipenv": {
+		{
+			Path:        "~/.local/share/virtualenvs",
+			Description: "Cache Pipenv virtualenvs",
+			Impact:      "Can reduce pipenv install time significantly",
+			Example: `      - name: Set up Python
+        uses: actions/setup-python@v5
+        with:
+          python-version: '%s'
+          cache: 'pipenv'  # Caches the virtualenvs, keyed on Pipfile.lock
+
+      - run: pip install pipenv
+      - run: pipenv install --deploy --dev`,
+		},
+	},
+	"conda": {
+		{
+			Path:        "~/conda_pkgs_dir",
+			Description: "Cache conda packages",
+			Impact:      "Avoids re-downloading conda packages on every run",
+			Example: `      - uses: actions/cache@v4
+        with:
+          path: ~/conda_pkgs_dir
+          key: ${{ runner.os }}-conda-${{ hashFiles('environment.yml') }}
+
+      - uses: conda-incubator/setup-miniconda@v3
+        with:
+          python-version: '%s'
+          environment-file: environment.yml
+          use-only-tar-bz2: true  # Required for the package cache to be reused`,
+		},
+	},
+}
@@ -0,0 +1,155 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// dockerLogin matches a docker login command up to the end of its line
+	dockerLogin = regexp.MustCompile(`\bdocker\s+login\b([^\n|;&]*)`)
+	// imagePush matches commands publishing images or charts to a registry
+	imagePush = regexp.MustCompile(`\bdocker\s+(?:image\s+)?push\b|\bbuildx\s+(?:build|bake)\b[^\n]*--push\b|\bdocker\s+compose\s+push\b|\bhelm\s+push\b|\bcrane\s+(?:push|copy|cp|tag)\b|\bskopeo\s+copy\b|\bko\s+(?:build|publish)\b|\bjib\b|\bpack\s+build\b[^\n]*--publish\b`)
+)
+
+// defaultRegistry is the registry docker login and docker/login-action use when none is given
+const defaultRegistry = "docker.io"
+
+// registryLogin is a step logging in to a container registry
+type registryLogin struct {
+	step     *workflow.Step
+	registry string
+	// lingers is set when the credentials stay on the runner after the job:
+	// docker login without a docker logout, or an action told not to log out
+	lingers bool
+}
+
+// registryLogins returns the registry login steps of a job
+func registryLogins(job *workflow.Job) []registryLogin {
+	var logins []registryLogin
+	loggedOut := false
+	for _, step := range job.Steps {
+		if strings.Contains(step.Run, "docker logout") {
+			loggedOut = true
+		}
+	}
+	for _, step := range job.Steps {
+		switch {
+		case usesAction(step, "docker/login-action"):
+			registry := step.With["registry"]
+			if registry == "" {
+				registry = defaultRegistry
+			}
+			logins = append(logins, registryLogin{step: step, registry: registry, lingers: step.With["logout"] == "false"})
+		case usesAction(step, "aws-actions/amazon-ecr-login"):
+			// Without registries, the action logs in to the account's default registry
+			registry := "ecr"
+			if accounts := step.With["registries"]; accounts != "" {
+				registry += ":" + accounts
+			}
+			logins = append(logins, registryLogin{step: step, registry: registry, lingers: step.With["skip-logout"] == "true"})
+		case step.Run != "":
+			for _, m := range dockerLogin.FindAllStringSubmatch(step.Run, -1) {
+				logins = append(logins, registryLogin{step: step, registry: loginRegistry(m[1]), lingers: !loggedOut})
+			}
+		}
+	}
+	return logins
+}
+
+// loginRegistry returns the registry named by the arguments of docker login
+func loginRegistry(args string) string {
+	fields := strings.Fields(args)
+	for i := 0; i < len(fields); i++ {
+		switch f := fields[i]; {
+		case f == "-u" || f == "-p" || f == "--username" || f == "--password":
+			i++ // the flag's value
+		case strings.HasPrefix(f, "-") || f == "\\":
+		default:
+			return strings.Trim(f, `"'`)
+		}
+	}
+	return defaultRegistry
+}
+
+// pushesImages reports whether a job publishes anything to a registry
+func pushesImages(job *workflow.Job) bool {
+	for _, step := range job.Steps {
+		switch {
+		case usesAction(step, "docker/build-push-action"), usesAction(step, "docker/bake-action"):
+			if step.With["push"] == "true" || strings.Contains(step.With["push"], "${{") ||
+				strings.Contains(step.With["outputs"], "push=true") {
+				return true
+			}
+		case imagePush.MatchString(step.Run):
+			return true
+		}
+	}
+	return false
+}
+
+// checkRegistryLogins finds container registry logins that repeat an earlier
+// login of the same job, logins in jobs that never push, and credentials left
+// in the Docker config of self-hosted runners, where the next job can read them
+func (a *Analyzer) checkRegistryLogins(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		logins := registryLogins(job)
+		if len(logins) == 0 {
+			continue
+		}
+
+		seen := make(map[string]*workflow.Step)
+		for _, login := range logins {
+			if first, ok := seen[login.registry]; ok && first != login.step {
+				findings = append(findings, models.Finding{
+					Category:   "performance",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       login.step.Line,
+					Message:    a.lang.Sprintf("Job %s logs in to %s again after the login at line %d", job.ID, login.registry, first.Line),
+					Suggestion: a.lang.T("Remove the repeated login; a login lasts for the rest of the job"),
+				})
+			} else if !ok {
+				seen[login.registry] = login.step
+			}
+		}
+
+		if !pushesImages(job) {
+			findings = append(findings, models.Finding{
+				Category:   "security",
+				Severity:   models.SeverityInfo,
+				File:       path,
+				Line:       logins[0].step.Line,
+				Message:    a.lang.Sprintf("Job %s logs in to a container registry but never pushes", job.ID),
+				Suggestion: a.lang.T("Remove the login unless the job pulls private images; every login puts a registry token within reach of the job's steps"),
+			})
+		}
+
+		if !selfHosted(job) {
+			continue
+		}
+		for _, login := range logins {
+			if !login.lingers {
+				continue
+			}
+			finding := models.Finding{
+				Category: "security",
+				Severity: models.SeverityWarning,
+				File:     path,
+				Line:     login.step.Line,
+				Message:  a.lang.Sprintf("Job %s leaves its %s credentials in the Docker config of a self-hosted runner", job.ID, login.registry),
+			}
+			if login.step.Run != "" {
+				finding.Suggestion = a.lang.T("Add a final step with if: always() running docker logout, or use docker/login-action, which logs out when the job ends")
+			} else {
+				finding.Suggestion = a.lang.T("Keep the action's default logout, so the next job on the runner can't reuse the credentials")
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
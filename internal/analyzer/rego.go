@@ -0,0 +1,69 @@
+package analyzer
+
+import (
+	"context"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/policy"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// regoInput is the document custom Rego policies see as input
+type regoInput struct {
+	File     string             `json:"file"`
+	Workflow *workflow.Workflow `json:"workflow"`
+	Findings []models.Finding   `json:"findings"`
+}
+
+// WithRegoPolicies evaluates custom Rego policies against every workflow
+func WithRegoPolicies(r *policy.Rego) Option {
+	return func(a *Analyzer) {
+		a.rego = r
+	}
+}
+
+// checkRego evaluates the custom Rego policies against the parsed workflow and
+// the findings of the built-in checks. Deny rules are reported as critical policy
+// violations and warn rules as warnings.
+func (a *Analyzer) checkRego(ctx context.Context, path string, wf *workflow.Workflow, findings []models.Finding) []models.Finding {
+	if findings == nil {
+		findings = []models.Finding{}
+	}
+	violations, err := a.rego.Evaluate(ctx, regoInput{File: path, Workflow: wf, Findings: findings})
+	if err != nil {
+		// A policy that can't be evaluated mustn't pass silently
+		return []models.Finding{{
+			Category:   CategoryPolicy,
+			Severity:   models.SeverityCritical,
+			File:       path,
+			Line:       wf.OnLine,
+			Message:    a.lang.Sprintf("Custom policies could not be evaluated: %v", err),
+			Suggestion: a.lang.T("Fix the policy error; test policies locally with opa eval -d <policy_dir> -i <input.json> data.analyzer"),
+		}}
+	}
+
+	var results []models.Finding
+	for _, v := range violations {
+		finding := models.Finding{
+			Category:   CategoryPolicy,
+			Severity:   models.SeverityCritical,
+			File:       path,
+			Line:       v.Line,
+			Message:    v.Message,
+			Suggestion: v.Suggestion,
+		}
+		if v.Rule == "warn" {
+			finding.Severity = models.SeverityWarning
+		}
+		if finding.Line == 0 {
+			finding.Line = wf.OnLine
+			for _, job := range wf.Jobs {
+				if job.ID == v.Job {
+					finding.Line = job.Line
+				}
+			}
+		}
+		results = append(results, finding)
+	}
+	return results
+}
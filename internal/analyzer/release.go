@@ -0,0 +1,200 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// releaseActivityTypes are the release event types that fire once per published release
+var releaseActivityTypes = keySet("published", "released", "prereleased")
+
+// releaseTriggers describes the triggers that run a workflow for tags or
+// releases and whether all of them are filtered down to release tags or
+// published releases. It returns no triggers for other workflows.
+func releaseTriggers(wf *workflow.Workflow) (triggers []string, filtered bool) {
+	on := workflow.Lookup(wf.Root, "on")
+	filtered = true
+	if tags := workflow.Strings(workflow.Lookup(workflow.Lookup(on, "push"), "tags")); len(tags) > 0 {
+		triggers = append(triggers, "push tags "+strings.Join(tags, ", "))
+		for _, tag := range tags {
+			if tag == "*" || tag == "**" {
+				filtered = false
+			}
+		}
+	}
+	if wf.HasTrigger("release") {
+		types := workflow.Strings(workflow.Lookup(workflow.Lookup(on, "release"), "types"))
+		if len(types) == 0 {
+			triggers = append(triggers, "release")
+			filtered = false
+		} else {
+			triggers = append(triggers, "release "+strings.Join(types, ", "))
+		}
+		for _, t := range types {
+			if !releaseActivityTypes[t] {
+				filtered = false
+			}
+		}
+	}
+	// create fires for every branch and tag created
+	if wf.HasTrigger("create") {
+		triggers = append(triggers, "create")
+		filtered = false
+	}
+	return triggers, filtered
+}
+
+// attestsProvenance reports whether a step generates a build provenance attestation
+func attestsProvenance(step *workflow.Step) bool {
+	switch {
+	case usesAction(step, "actions/attest-build-provenance"), usesAction(step, "actions/attest"):
+		return true
+	case usesAction(step, "docker/build-push-action"):
+		return step.With["provenance"] != "" && step.With["provenance"] != "false"
+	}
+	return strings.Contains(step.Run, "--provenance")
+}
+
+// signsArtifacts reports whether a step signs images or files
+func signsArtifacts(step *workflow.Step) bool {
+	return usesAction(step, "sigstore/cosign-installer") || usesAction(step, "sigstore/gh-action-sigstore-python") ||
+		strings.Contains(step.Run, "cosign sign") || strings.Contains(step.Run, "gpg --detach-sign") ||
+		strings.Contains(step.Run, "gpg -ab") || strings.Contains(step.Run, "gpg --armor --detach-sign")
+}
+
+// releaseAssets returns the files a step uploads to a GitHub release
+func releaseAssets(step *workflow.Step) []string {
+	var raw string
+	switch {
+	case usesAction(step, "softprops/action-gh-release"):
+		raw = step.With["files"]
+	case usesAction(step, "svenstaro/upload-release-action"):
+		raw = step.With["file"]
+	case usesAction(step, "actions/upload-release-asset"):
+		raw = step.With["asset_path"]
+	case usesAction(step, "ncipollo/release-action"):
+		raw = step.With["artifacts"]
+	default:
+		// gh release upload|create <tag> <files>...
+		for _, line := range strings.Split(step.Run, "\n") {
+			_, args, found := strings.Cut(line, "gh release upload ")
+			if !found {
+				_, args, found = strings.Cut(line, "gh release create ")
+			}
+			if !found {
+				continue
+			}
+			fields := strings.Fields(args)
+			for i, f := range fields {
+				if i == 0 || strings.HasPrefix(f, "-") || f == "\\" {
+					continue
+				}
+				if prev := fields[i-1]; strings.HasPrefix(prev, "-") && !strings.Contains(prev, "=") && i > 1 {
+					continue // the value of a flag such as --title
+				}
+				raw += f + "\n"
+			}
+		}
+	}
+	var assets []string
+	for _, asset := range strings.FieldsFunc(raw, func(r rune) bool { return r == '\n' || r == ',' }) {
+		if asset = strings.Trim(strings.TrimSpace(asset), `"'`); asset != "" {
+			assets = append(assets, asset)
+		}
+	}
+	return assets
+}
+
+// checkRelease reviews workflows triggered by tags or releases: their trigger
+// filters, build provenance and signing, and release assets uploaded more than
+// once, and sums the checks up for the release hardening section
+func (a *Analyzer) checkRelease(path string, wf *workflow.Workflow) ([]models.Finding, *models.ReleaseHardening) {
+	triggers, filtered := releaseTriggers(wf)
+	if len(triggers) == 0 {
+		return nil, nil
+	}
+	hardening := &models.ReleaseHardening{File: path, Triggers: triggers, TagFilter: filtered, UniqueUploads: true}
+	var findings []models.Finding
+	if !filtered {
+		findings = append(findings, models.Finding{
+			Category:   "security",
+			Severity:   models.SeverityWarning,
+			File:       path,
+			Line:       max(wf.OnLine, 1),
+			Message:    a.lang.Sprintf("Release workflow runs on %s, which also matches tags or release events that aren't releases", strings.Join(triggers, "; ")),
+			Suggestion: a.lang.T("Filter push tags to the release pattern, e.g. v[0-9]+.[0-9]+.[0-9]+, and release events to types: [published], so test tags and edited releases don't publish"),
+		})
+	}
+
+	type upload struct {
+		job  *workflow.Job
+		step *workflow.Step
+	}
+	uploaded := make(map[string]upload)
+	for _, job := range wf.Jobs {
+		if strings.HasPrefix(job.Uses, "slsa-framework/slsa-github-generator") {
+			hardening.Provenance = true
+		}
+		for _, step := range job.Steps {
+			hardening.Provenance = hardening.Provenance || attestsProvenance(step)
+			hardening.Signing = hardening.Signing || signsArtifacts(step)
+
+			for _, asset := range releaseAssets(step) {
+				if first, ok := uploaded[asset]; ok && first.step != step {
+					hardening.UniqueUploads = false
+					findings = append(findings, models.Finding{
+						Category:   "reliability",
+						Severity:   models.SeverityWarning,
+						File:       path,
+						Line:       step.Line,
+						Message:    a.lang.Sprintf("Release asset %s is uploaded again after job %s uploaded it at line %d", asset, first.job.ID, first.step.Line),
+						Suggestion: a.lang.T("Upload each asset from one step; a second upload either fails because the asset exists or silently replaces it"),
+					})
+					continue
+				}
+				uploaded[asset] = upload{job, step}
+				// Every matrix entry uploads the same file unless its name varies with the matrix
+				if job.HasMatrix && !strings.Contains(asset, "${{") {
+					hardening.UniqueUploads = false
+					findings = append(findings, models.Finding{
+						Category:   "reliability",
+						Severity:   models.SeverityWarning,
+						File:       path,
+						Line:       step.Line,
+						Message:    a.lang.Sprintf("Every matrix entry of job %s uploads release asset %s", job.ID, asset),
+						Suggestion: a.lang.T("Name the asset after the matrix entry, e.g. with ${{ matrix.os }}, or upload from a single job after the matrix finishes"),
+					})
+				}
+			}
+		}
+	}
+
+	if !hardening.Provenance {
+		findings = append(findings, models.Finding{
+			Category:   "security",
+			Severity:   models.SeverityInfo,
+			File:       path,
+			Line:       max(wf.OnLine, 1),
+			Message:    a.lang.T("Release workflow publishes without a build provenance attestation"),
+			Suggestion: a.lang.T("Attest the built artifacts with actions/attest-build-provenance, which needs id-token: write and attestations: write, or call the SLSA generator's reusable workflow"),
+			Example: `      - uses: actions/attest-build-provenance@v2
+        with:
+          subject-path: dist/*`,
+		})
+	}
+	if !hardening.Signing {
+		findings = append(findings, models.Finding{
+			Category:   "security",
+			Severity:   models.SeverityInfo,
+			File:       path,
+			Line:       max(wf.OnLine, 1),
+			Message:    a.lang.T("Release workflow doesn't sign what it publishes"),
+			Suggestion: a.lang.T("Sign images and files keylessly with cosign, which needs id-token: write, so users can verify they came from this workflow"),
+			Example: `      - uses: sigstore/cosign-installer@v3
+      - run: cosign sign --yes ghcr.io/${{ github.repository }}@${{ steps.build.outputs.digest }}`,
+		})
+	}
+	return findings, hardening
+}
@@ -0,0 +1,207 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// actionsAppID is the ID of the GitHub Actions app, which reports the checks of
+// workflow jobs
+const actionsAppID = 15368
+
+// slowRequiredCheck is the average duration above which a required check is
+// reported as holding up merges
+const slowRequiredCheck = 15 * time.Minute
+
+var (
+	// gatingJob matches names and IDs of jobs that usually gate merges: tests,
+	// linters, builds and type checks
+	gatingJob = regexp.MustCompile(`(?i)(^|[\s_-])(tests?|lint|build|check|verify|vet|unit|e2e|integration|typecheck)([\s_-]|$)`)
+	// checkExpression matches an expression in a job name, which only resolves at run time
+	checkExpression = regexp.MustCompile(`\$\{\{.*?\}\}`)
+)
+
+// checkPattern matches the check names a job reports: its name, or its ID when
+// unnamed, followed by the matrix values of matrix jobs and the called
+// workflow's job names for reusable workflow calls. Names that are only an
+// expression could report any check, so they get no pattern.
+func checkPattern(job *workflow.Job) *regexp.Regexp {
+	name := job.Name
+	if name == "" {
+		name = job.ID
+	}
+	if strings.TrimSpace(checkExpression.ReplaceAllString(name, "")) == "" {
+		return nil
+	}
+	var parts []string
+	for _, part := range checkExpression.Split(name, -1) {
+		parts = append(parts, regexp.QuoteMeta(part))
+	}
+	return regexp.MustCompile(`^` + strings.Join(parts, `.*`) + `( \(.*\))?( / .*)?$`)
+}
+
+// externalCheck reports whether a required check is reported by another app
+// than GitHub Actions, or looks like a commit status of another CI service or
+// bot, e.g. ci/circleci: build or codecov/patch
+func externalCheck(check *gh.RequiredStatusCheck) bool {
+	if id := check.GetAppID(); id > 0 && id != actionsAppID {
+		return true
+	}
+	return strings.Contains(check.Context, "/") && !strings.Contains(check.Context, " / ")
+}
+
+// gatesPullRequests reports whether a workflow's jobs report checks on pull requests
+func gatesPullRequests(wf *workflow.Workflow) bool {
+	return wf.HasTrigger("pull_request") || wf.HasTrigger("pull_request_target") || wf.HasTrigger("merge_group")
+}
+
+// analyzedWorkflow returns the analyzed workflow among the repository's, or nil
+func analyzedWorkflow(report *models.PerformanceReport, workflows []*repoWorkflow) *repoWorkflow {
+	for _, w := range workflows {
+		if w.file() == path.Base(report.WorkflowFile) {
+			return w
+		}
+	}
+	return nil
+}
+
+// analyzeRequiredChecks maps the status checks the default branch requires to
+// the jobs of the repository's workflows. It reports required checks no job
+// reports anymore, which block every pull request, slow required checks of the
+// analyzed workflow, and its test, lint and build jobs that aren't required.
+// Without readable branch protection or rulesets requiring checks, nothing is
+// reported, as no required checks and no access to them look the same.
+func (a *Analyzer) analyzeRequiredChecks(ctx context.Context, owner, repo string, report *models.PerformanceReport, workflows []*repoWorkflow, samples []runSample) {
+	if len(workflows) == 0 {
+		return
+	}
+	repository, err := a.client.GetRepository(ctx, owner, repo)
+	if err != nil {
+		a.debugLog("Error getting repository: %v", err)
+		return
+	}
+	branch := repository.GetDefaultBranch()
+	required, err := a.client.GetRequiredStatusChecks(ctx, owner, repo, branch)
+	if err != nil {
+		a.debugLog("Error getting required status checks: %v", err)
+		return
+	}
+	if len(required) == 0 {
+		return
+	}
+
+	// The analyzed workflow is matched first, as its jobs have measured durations
+	current := analyzedWorkflow(report, workflows)
+	ordered := make([]*repoWorkflow, 0, len(workflows))
+	for _, w := range workflows {
+		if w == current {
+			ordered = append([]*repoWorkflow{w}, ordered...)
+		} else {
+			ordered = append(ordered, w)
+		}
+	}
+	workflowPath := ".github/workflows/" + path.Base(report.WorkflowFile)
+	if current != nil {
+		workflowPath = current.path
+	}
+
+	checks := &models.RequiredChecks{Branch: branch}
+	requiredJobs := make(map[*workflow.Job]bool) // of the analyzed workflow
+	for _, check := range required {
+		c := models.RequiredCheck{Context: check.Context}
+		var matched *workflow.Job
+		for _, w := range ordered {
+			for _, job := range w.parsed.Jobs {
+				if pattern := checkPattern(job); matched == nil && pattern != nil && pattern.MatchString(check.Context) {
+					matched, c.File, c.Job = job, w.path, job.ID
+					requiredJobs[job] = w == current
+				}
+			}
+			if matched != nil {
+				break
+			}
+		}
+		c.External = c.Job == "" && externalCheck(check)
+		if c.File == workflowPath {
+			var durations []time.Duration
+			for _, sample := range samples {
+				for _, apiJob := range sample.Jobs {
+					if apiJob.GetName() == check.Context && apiJob.GetConclusion() == "success" && apiJob.StartedAt != nil && apiJob.CompletedAt != nil {
+						durations = append(durations, apiJob.CompletedAt.Sub(apiJob.StartedAt.Time))
+					}
+				}
+			}
+			c.AvgDuration, c.Runs = average(durations), len(durations)
+		}
+		checks.Checks = append(checks.Checks, c)
+
+		switch {
+		case c.Job == "" && !c.External:
+			report.Findings = append(report.Findings, models.Finding{
+				Category:   "reliability",
+				Severity:   models.SeverityWarning,
+				File:       workflowPath,
+				Message:    a.lang.Sprintf("Required check %q of %s isn't reported by any workflow job, so pull requests wait for it and can't merge", check.Context, branch),
+				Suggestion: a.lang.T("Update the branch protection or ruleset to the job's current check name, or remove the check; renaming a job, adding a matrix or calling a reusable workflow changes the name its check reports"),
+			})
+		case c.AvgDuration > slowRequiredCheck:
+			report.Findings = append(report.Findings, models.Finding{
+				Category:   "performance",
+				Severity:   models.SeverityWarning,
+				File:       workflowPath,
+				Line:       matched.Line,
+				Message:    a.lang.Sprintf("Required check %q takes %v on average, and every pull request to %s waits for it before merging", check.Context, c.AvgDuration.Round(time.Second), branch),
+				Suggestion: a.lang.T("Shard its tests across parallel jobs and cache its dependencies, or move the slowest part out of the required checks, e.g. to run on push to the default branch or in the merge queue"),
+				URL:        jobURL(samples, matched),
+			})
+		}
+	}
+	report.RequiredChecks = checks
+
+	if current == nil || !gatesPullRequests(current.parsed) {
+		return
+	}
+	var ungated []string
+	line := 0
+	for _, job := range current.parsed.Jobs {
+		if requiredJobs[job] || !(gatingJob.MatchString(job.ID) || gatingJob.MatchString(job.Name)) {
+			continue
+		}
+		ungated = append(ungated, job.ID)
+		if line == 0 {
+			line = job.Line
+		}
+	}
+	if len(ungated) == 0 {
+		return
+	}
+	report.Findings = append(report.Findings, models.Finding{
+		Category:   "reliability",
+		Severity:   models.SeverityInfo,
+		File:       workflowPath,
+		Line:       line,
+		Message:    a.lang.Sprintf("Jobs %s run on pull requests but aren't required checks of %s, so pull requests can merge while they fail", strings.Join(ungated, ", "), branch),
+		Suggestion: a.lang.T("Require them in the branch protection or ruleset, or add one job that needs them all and require only that one, so renaming or adding jobs doesn't change the required checks"),
+		Example:    requiredGateExample(ungated),
+	})
+}
+
+// requiredGateExample is a job that fails unless every job it needs succeeded
+// or was skipped, to be required in place of them
+func requiredGateExample(jobs []string) string {
+	return fmt.Sprintf(`  required:
+    if: always()
+    needs: [%s]
+    runs-on: ubuntu-latest
+    steps:
+      - if: contains(needs.*.result, 'failure') || contains(needs.*.result, 'cancelled')
+        run: exit 1`, strings.Join(jobs, ", "))
+}
@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// resultCacheDir is the directory of cached reports inside the cache directory
+const resultCacheDir = "reports"
+
+// cachedResult is a report stored with the state it was computed from
+type cachedResult struct {
+	Key        string                    `json:"key"`
+	AnalyzedAt time.Time                 `json:"analyzed_at"`
+	Report     *models.PerformanceReport `json:"report"`
+}
+
+// WithResultCache keeps each workflow's report in dir and returns it again while
+// neither the workflow file, its run history nor the analysis settings changed
+func WithResultCache(dir string) Option {
+	return func(a *Analyzer) {
+		a.resultCache = dir
+	}
+}
+
+// settingsFingerprint describes the settings a report depends on
+func (a *Analyzer) settingsFingerprint() string {
+	fingerprint := fmt.Sprintf("%s|%d|%+v|%s|%t|%g|%s|%t|%d|%g|%g|%s", a.mode, a.sampleSize, a.sampling, a.lang,
+		a.sustainability, a.gridCarbon, strings.Join(a.deployWorkflows, ","), a.styleChecks, a.analyzeDepth, a.minConfidence,
+		a.maskedRate, a.versionChannel)
+	// The version source decides which setup steps are reported as end-of-life
+	fingerprint += fmt.Sprintf("|%T", a.versionChecker)
+	if a.policy != nil {
+		fingerprint += fmt.Sprintf("|%+v", *a.policy)
+	}
+	if a.rego != nil {
+		fingerprint += "|" + a.rego.Digest()
+	}
+	if a.pricing != nil {
+		fingerprint += "|" + a.pricing.Source + "|" + a.pricing.Digest()
+	}
+	return fingerprint
+}
+
+// resultKey identifies the inputs of a workflow's analysis: the workflow file's
+// content, its newest run and the latest update to any run, and the settings
+func (a *Analyzer) resultKey(ctx context.Context, owner, repo, workflowFile string) (string, error) {
+	runs, err := a.client.GetWorkflowRuns(ctx, owner, repo, workflowFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workflow runs: %v", err)
+	}
+	workflowPath := workflowFile
+	if !strings.HasPrefix(workflowPath, ".github/workflows/") {
+		workflowPath = fmt.Sprintf(".github/workflows/%s", workflowPath)
+	}
+	content, err := a.client.GetFileContent(ctx, owner, repo, workflowPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workflow file: %v", err)
+	}
+
+	var newest int64
+	var updated time.Time
+	for _, run := range runs {
+		if run.GetID() > newest {
+			newest = run.GetID()
+		}
+		if t := run.GetUpdatedAt().Time; t.After(updated) {
+			updated = t
+		}
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%d\n%s\n%s\n", content, newest, updated.UTC().Format(time.RFC3339), a.settingsFingerprint())
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// resultPath returns the file a workflow's cached report is kept in
+func (a *Analyzer) resultPath(owner, repo, workflowFile string) string {
+	name := sha256.Sum256([]byte(owner + "/" + repo + "/" + workflowFile))
+	return filepath.Join(a.resultCache, resultCacheDir, hex.EncodeToString(name[:8])+".json")
+}
+
+// cachedReport returns the stored report of a workflow if it was computed from
+// the same key, marking it as reused
+func (a *Analyzer) cachedReport(owner, repo, workflowFile, key string) *models.PerformanceReport {
+	raw, err := os.ReadFile(a.resultPath(owner, repo, workflowFile))
+	if err != nil {
+		return nil
+	}
+	var cached cachedResult
+	if err := json.Unmarshal(raw, &cached); err != nil || cached.Key != key || cached.Report == nil {
+		return nil
+	}
+	cached.Report.CachedAt = &cached.AnalyzedAt
+	return cached.Report
+}
+
+// storeReport keeps a complete report for the next analysis
+func (a *Analyzer) storeReport(owner, repo, workflowFile, key string, report *models.PerformanceReport) error {
+	raw, err := json.Marshal(cachedResult{Key: key, AnalyzedAt: time.Now().UTC(), Report: report})
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %v", err)
+	}
+	path := a.resultPath(owner, repo, workflowFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report cache directory: %v", err)
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		return fmt.Errorf("failed to write cached report: %v", err)
+	}
+	return nil
+}
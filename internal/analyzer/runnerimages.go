@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/patch"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// runnerImage is a GitHub-hosted runner image GitHub retired or is retiring
+type runnerImage struct {
+	label       string
+	replacement string
+	deprecated  time.Time // when the deprecation was announced and brownouts started
+	removed     time.Time
+	note        string // what changes with the replacement, if anything
+}
+
+// date returns midnight UTC of a day, for the image lifecycle table
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// runnerImages is the lifecycle of retired runner images, from GitHub's
+// runner-images announcements. Images still supported without a removal date
+// aren't listed.
+var runnerImages = []runnerImage{
+	{label: "ubuntu-18.04", replacement: "ubuntu-24.04", deprecated: date(2022, time.August, 8), removed: date(2023, time.April, 3)},
+	{label: "ubuntu-20.04", replacement: "ubuntu-24.04", deprecated: date(2025, time.February, 1), removed: date(2025, time.April, 15)},
+	{label: "macos-10.15", replacement: "macos-15", deprecated: date(2022, time.May, 31), removed: date(2022, time.December, 1), note: "macOS 15 runners are Apple silicon; use macos-15-intel for x64 builds"},
+	{label: "macos-11", replacement: "macos-15", deprecated: date(2024, time.January, 15), removed: date(2024, time.June, 28), note: "macOS 15 runners are Apple silicon; use macos-15-intel for x64 builds"},
+	{label: "macos-12", replacement: "macos-15", deprecated: date(2024, time.October, 7), removed: date(2024, time.December, 3), note: "macOS 15 runners are Apple silicon; use macos-15-intel for x64 builds"},
+	{label: "macos-13", replacement: "macos-15", deprecated: date(2025, time.September, 1), removed: date(2025, time.December, 4), note: "macOS 15 runners are Apple silicon; use macos-15-intel for x64 builds"},
+	{label: "windows-2016", replacement: "windows-2025", deprecated: date(2021, time.November, 16), removed: date(2022, time.March, 15)},
+	{label: "windows-2019", replacement: "windows-2025", deprecated: date(2025, time.June, 1), removed: date(2025, time.June, 30), note: "Windows Server 2025 runners come with Visual Studio 2022 instead of 2019"},
+}
+
+// lookupRunnerImage returns the lifecycle of a runner label and the label to
+// move to. Larger runner variants such as macos-13-xlarge move to the same
+// variant of the replacement.
+func lookupRunnerImage(label string) (runnerImage, string, bool) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	for _, image := range runnerImages {
+		if label == image.label {
+			return image, image.replacement, true
+		}
+		if suffix, ok := strings.CutPrefix(label, image.label+"-"); ok {
+			return image, image.replacement + "-" + suffix, true
+		}
+	}
+	return runnerImage{}, "", false
+}
+
+// jobRunnerLabels returns the runner labels of a job, with the values of the
+// matrix keys runs-on reads. Self-hosted runners take any label, so their jobs
+// have none.
+func jobRunnerLabels(job *workflow.Job) []string {
+	var labels []string
+	for _, label := range job.RunsOn {
+		if strings.EqualFold(label, "self-hosted") {
+			return nil
+		}
+		if !strings.Contains(label, "${{") {
+			labels = append(labels, label)
+			continue
+		}
+		if m := matrixRef.FindStringSubmatch(label); m != nil {
+			values, _ := job.MatrixValues(m[1])
+			labels = append(labels, values...)
+		}
+	}
+	return labels
+}
+
+// checkRunnerImages reports jobs running on retired runner images, whose jobs
+// no longer start, and on images being retired, whose jobs fail during GitHub's
+// brownouts until the removal date
+func (a *Analyzer) checkRunnerImages(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	now := time.Now()
+
+	for _, job := range wf.Jobs {
+		reported := make(map[string]bool)
+		for _, label := range jobRunnerLabels(job) {
+			image, target, ok := lookupRunnerImage(label)
+			if !ok || reported[label] || now.Before(image.deprecated) {
+				continue
+			}
+			reported[label] = true
+
+			line := job.RunsOnLine
+			if line == 0 {
+				line = job.Line
+			}
+			finding := models.Finding{
+				Category: "runner",
+				File:     path,
+				Line:     line,
+				Example:  fmt.Sprintf("    runs-on: %s", target),
+			}
+			if now.Before(image.removed) {
+				finding.Severity = models.SeverityWarning
+				finding.Message = a.lang.Sprintf("Job %s runs on %s, which GitHub removes on %s; until then, jobs on it fail during scheduled brownouts",
+					job.ID, label, image.removed.Format("2006-01-02"))
+			} else {
+				finding.Severity = models.SeverityCritical
+				finding.Message = a.lang.Sprintf("Job %s runs on %s, which GitHub removed on %s, so the job no longer starts",
+					job.ID, label, image.removed.Format("2006-01-02"))
+			}
+			finding.Suggestion = a.lang.Sprintf("Move to %s and check the preinstalled tool versions, which change between images", target)
+			// Larger runner variants keep their architecture
+			if image.note != "" && target == image.replacement {
+				finding.Suggestion += "; " + a.lang.T(image.note)
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// runnerImageEdits moves runs-on lines naming a retired runner image to its
+// replacement. Labels from a matrix are left to the finding, as the matrix may
+// be shared with other keys.
+func runnerImageEdits(wf *workflow.Workflow, lines []string) []patch.Edit {
+	var edits []patch.Edit
+	now := time.Now()
+	for _, job := range wf.Jobs {
+		if job.RunsOnLine == 0 || job.RunsOnLine > len(lines) {
+			continue
+		}
+		raw := lines[job.RunsOnLine-1]
+		if !strings.Contains(raw, "runs-on") {
+			continue
+		}
+		edited := raw
+		for _, label := range job.RunsOn {
+			if strings.EqualFold(label, "self-hosted") {
+				edited = raw
+				break
+			}
+			if image, target, ok := lookupRunnerImage(label); ok && !now.Before(image.removed) {
+				edited = strings.Replace(edited, label, target, 1)
+			}
+		}
+		if edited != raw {
+			edits = append(edits, patch.Edit{Line: job.RunsOnLine, Delete: 1, Insert: []string{edited}})
+		}
+	}
+	return edits
+}
@@ -0,0 +1,150 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Run timing classes by conclusion
+const (
+	timingSuccessful = "successful"
+	timingFailed     = "failed"
+	timingCancelled  = "cancelled"
+	timingExcluded   = ""
+)
+
+// supersedeSlack allows for the delay between a newer run starting and the
+// concurrency group cancelling the older one
+const supersedeSlack = time.Minute
+
+// timingClass says which timing aggregate a run belongs to. Runs still in progress
+// haven't got a final duration, and cancelled, skipped or neutral runs stopped
+// for reasons that say nothing about the workflow's speed.
+func timingClass(run *gh.WorkflowRun) string {
+	if run.GetStatus() != "completed" || run.CreatedAt == nil || run.UpdatedAt == nil {
+		return timingExcluded
+	}
+	switch run.GetConclusion() {
+	case "success":
+		return timingSuccessful
+	case "failure", "timed_out":
+		return timingFailed
+	case "cancelled":
+		return timingCancelled
+	}
+	return timingExcluded
+}
+
+// runDuration is the wall-clock time of a run from creation to its last update
+func runDuration(run *gh.WorkflowRun) time.Duration {
+	return run.GetUpdatedAt().Sub(run.GetCreatedAt().Time)
+}
+
+// summarizeRuns aggregates the durations of runs separately for successful and
+// failed runs. Cancelled runs are tallied on their own: those superseded by a
+// newer run show the duplicate work a concurrency group saves.
+func summarizeRuns(runs []*gh.WorkflowRun) *models.RunStats {
+	stats := &models.RunStats{}
+	var cancelled []*gh.WorkflowRun
+	for _, run := range runs {
+		switch timingClass(run) {
+		case timingSuccessful:
+			stats.Successful.Add(runDuration(run))
+		case timingFailed:
+			stats.Failed.Add(runDuration(run))
+		case timingCancelled:
+			cancelled = append(cancelled, run)
+		default:
+			stats.Excluded++
+		}
+	}
+
+	for _, run := range cancelled {
+		ran := runDuration(run)
+		stats.Cancelled.Runs++
+		stats.Cancelled.Duration += ran
+		if supersededBy(run, runs) != nil {
+			stats.Cancelled.Superseded++
+			// Had it finished, the run would have taken about as long as a successful one
+			stats.Cancelled.Saved += max(stats.Successful.Average-ran, 0)
+		}
+	}
+	return stats
+}
+
+// supersededBy returns the newer run of the same branch and event that started
+// while run was going and so presumably cancelled it, or nil when run was
+// cancelled by hand
+func supersededBy(run *gh.WorkflowRun, runs []*gh.WorkflowRun) *gh.WorkflowRun {
+	created, cancelled := run.GetCreatedAt().Time, run.GetUpdatedAt().Add(supersedeSlack)
+	for _, other := range runs {
+		if other.GetID() == run.GetID() || other.GetHeadBranch() != run.GetHeadBranch() || other.GetEvent() != run.GetEvent() {
+			continue
+		}
+		if started := other.GetCreatedAt().Time; started.After(created) && !started.After(cancelled) {
+			return other
+		}
+	}
+	return nil
+}
+
+// runTrend compares the older half of the completed runs with the newer half. It
+// returns nil with fewer than four runs to compare.
+func runTrend(runs []*gh.WorkflowRun) *models.RunTrend {
+	var timed []*gh.WorkflowRun
+	for _, run := range runs {
+		if class := timingClass(run); class == timingSuccessful || class == timingFailed {
+			timed = append(timed, run)
+		}
+	}
+	if len(timed) < 4 {
+		return nil
+	}
+	sort.Slice(timed, func(i, j int) bool {
+		return timed[i].GetCreatedAt().Before(timed[j].GetCreatedAt().Time)
+	})
+
+	trend := &models.RunTrend{}
+	half := len(timed) / 2
+	for i, run := range timed {
+		durations, failed, total := &trend.Recent, &trend.RecentFailed, &trend.RecentRuns
+		if i < half {
+			durations, failed, total = &trend.Previous, &trend.PreviousFailed, &trend.PreviousRuns
+		}
+		*total++
+		if timingClass(run) == timingFailed {
+			*failed++
+		} else {
+			durations.Add(runDuration(run))
+		}
+	}
+	return trend
+}
+
+// minHeatmapRuns is the number of runs below which a heatmap of their start
+// times shows chance rather than a pattern
+const minHeatmapRuns = 10
+
+// runHeatmap counts the start times of runs by day of the week and hour, or
+// returns nil for too few runs. Runs count when they started rather than when
+// they were created, as re-runs start long after the event that created them.
+func runHeatmap(runs []*gh.WorkflowRun) *models.RunHeatmap {
+	heatmap := &models.RunHeatmap{}
+	for _, run := range runs {
+		started := run.GetRunStartedAt()
+		if started.IsZero() {
+			started = run.GetCreatedAt()
+		}
+		if started.IsZero() {
+			continue
+		}
+		heatmap.Add(started.Time)
+	}
+	if heatmap.Runs < minHeatmapRuns {
+		return nil
+	}
+	return heatmap
+}
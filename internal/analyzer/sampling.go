@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+// Sampling strategies for choosing which runs are analyzed in depth
+const (
+	SampleLatest    = "latest"     // the N most recent runs
+	SampleRandom    = "random"     // N runs chosen at random
+	SamplePerBranch = "per-branch" // the N most recent runs of each branch
+)
+
+// Sampling selects the runs whose jobs and logs are analyzed in deep mode
+type Sampling struct {
+	Strategy string
+	Size     int // 0 uses the analysis depth
+}
+
+// ParseSampling parses strategy:N, e.g. "random:20". An empty string keeps the
+// default of the latest analysis_depth runs.
+func ParseSampling(s string) (Sampling, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Sampling{Strategy: SampleLatest}, nil
+	}
+
+	strategy, size, ok := strings.Cut(s, ":")
+	strategy = strings.ToLower(strings.TrimSpace(strategy))
+	if strategy != SampleLatest && strategy != SampleRandom && strategy != SamplePerBranch {
+		return Sampling{}, fmt.Errorf("invalid sample %q: expected latest:N, random:N or per-branch:N", s)
+	}
+	sampling := Sampling{Strategy: strategy}
+	if ok {
+		n, err := strconv.Atoi(strings.TrimSpace(size))
+		if err != nil || n <= 0 {
+			return Sampling{}, fmt.Errorf("invalid sample %q: N must be a positive integer", s)
+		}
+		sampling.Size = n
+	}
+	return sampling, nil
+}
+
+// WithSampling sets how runs are chosen for deep analysis
+func WithSampling(sampling Sampling) Option {
+	return func(a *Analyzer) {
+		if sampling.Strategy != "" {
+			a.sampling = sampling
+		}
+	}
+}
+
+// sampleSizeFor returns the configured sample size, falling back to the analysis depth
+func (a *Analyzer) sampleSizeFor() int {
+	if a.sampling.Size > 0 {
+		return a.sampling.Size
+	}
+	return a.sampleSize
+}
+
+// describeSampling records the sampling method for the report
+func (a *Analyzer) describeSampling(runs []*gh.WorkflowRun, selected map[int]bool) string {
+	if a.mode == ModeSurvey {
+		return a.lang.Sprintf("all (%d runs, survey mode)", len(runs))
+	}
+	desc := a.lang.Sprintf("%s:%d (%d of %d runs)", a.sampling.Strategy, a.sampleSizeFor(), len(selected), len(runs))
+	if a.sampling.Strategy == SampleRandom && len(runs) > 0 {
+		desc += fmt.Sprintf(", seed %d", runs[0].GetID())
+	}
+	return desc
+}
+
+// selectRuns returns the indexes of the runs to analyze in depth. runs are
+// ordered newest first, as returned by the API.
+func (a *Analyzer) selectRuns(runs []*gh.WorkflowRun) map[int]bool {
+	selected := make(map[int]bool)
+	n := a.sampleSizeFor()
+
+	switch a.sampling.Strategy {
+	case SampleRandom:
+		if len(runs) == 0 {
+			break
+		}
+		// Seeding with the newest run ID makes the sample reproducible for the same history
+		rng := rand.New(rand.NewSource(runs[0].GetID()))
+		for _, i := range rng.Perm(len(runs))[:min(n, len(runs))] {
+			selected[i] = true
+		}
+	case SamplePerBranch:
+		perBranch := make(map[string]int)
+		for i, run := range runs {
+			branch := run.GetHeadBranch()
+			if perBranch[branch] < n {
+				perBranch[branch]++
+				selected[i] = true
+			}
+		}
+	default:
+		for i := 0; i < min(n, len(runs)); i++ {
+			selected[i] = true
+		}
+	}
+	return selected
+}
@@ -0,0 +1,170 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// installSteps are step-name fragments identifying each language's dependency install
+// and build steps, which caching speeds up
+var installSteps = map[string][]string{
+	"go":     {"go mod download", "go build", "go test"},
+	"node":   {"npm ci", "npm install", "yarn install", "yarn --frozen-lockfile", "pnpm install", "bun install"},
+	"python": {"pip install", "poetry install", "pipenv install", "uv sync", "uv pip install", "conda env create", "conda install"},
+	"java":   {"mvn", "gradle"},
+	"ruby":   {"bundle install"},
+	"rust":   {"cargo build", "cargo test", "cargo fetch"},
+	"dotnet": {"dotnet restore", "dotnet build"},
+}
+
+// cacheSavingRatio is the typical share of install/build time a warm cache saves
+var cacheSavingRatio = map[string]float64{
+	"go":     0.5,
+	"node":   0.7,
+	"python": 0.6,
+	"java":   0.5,
+	"ruby":   0.7,
+	"rust":   0.5,
+	"dotnet": 0.5,
+}
+
+// dockerBuildSteps identify image build steps that benefit from layer caching
+var dockerBuildSteps = []string{"docker build", "buildx build", "build and push", "build-push-action", "build docker image"}
+
+// dockerLayerCacheRatio is the typical share of build time saved by a warm layer cache
+const dockerLayerCacheRatio = 0.4
+
+// measuredSteps returns the name and durations of API steps whose name contains any pattern
+func measuredSteps(samples []runSample, patterns []string) (string, []time.Duration) {
+	var durations []time.Duration
+	counts := make(map[string]int)
+	for _, sample := range samples {
+		for _, job := range sample.Jobs {
+			for _, step := range job.Steps {
+				if step.StartedAt == nil || step.CompletedAt == nil {
+					continue
+				}
+				name := strings.ToLower(step.GetName())
+				for _, pattern := range patterns {
+					if strings.Contains(name, pattern) {
+						durations = append(durations, step.CompletedAt.Sub(step.StartedAt.Time))
+						counts[step.GetName()]++
+						break
+					}
+				}
+			}
+		}
+	}
+
+	// Describe the evidence by the most frequently matched step
+	var names []string
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	if len(names) == 0 {
+		return "", nil
+	}
+	return strings.TrimPrefix(names[0], "Run "), durations
+}
+
+// runsPerMonth extrapolates the workflow's monthly run count from the runs
+// listed for it, the window of history the samples are drawn from. Samples
+// picked from the listing, e.g. the latest ones, would skew the rate.
+func runsPerMonth(samples []runSample) float64 {
+	var runs []*gh.WorkflowRun
+	if len(samples) > 0 {
+		runs = samples[0].Listed
+	}
+	if len(runs) == 0 {
+		for _, sample := range samples {
+			runs = append(runs, sample.Run)
+		}
+	}
+
+	var oldest, newest time.Time
+	for _, run := range runs {
+		created := run.GetCreatedAt().Time
+		if created.IsZero() {
+			continue
+		}
+		if oldest.IsZero() || created.Before(oldest) {
+			oldest = created
+		}
+		if created.After(newest) {
+			newest = created
+		}
+	}
+
+	span := newest.Sub(oldest)
+	if span < 24*time.Hour {
+		// Too little history to extrapolate; assume the listing is one day of runs
+		span = 24 * time.Hour
+	}
+	return float64(len(runs)) / span.Hours() * 24 * 30
+}
+
+// estimateSavings projects the time a recommendation saves from the measured durations of
+// the steps it speeds up; it returns nil when the history has no matching steps
+func (a *Analyzer) estimateSavings(samples []runSample, patterns []string, ratio float64) *models.Savings {
+	if len(samples) == 0 || len(patterns) == 0 || ratio == 0 {
+		return nil
+	}
+
+	name, durations := measuredSteps(samples, patterns)
+	if len(durations) == 0 {
+		return nil
+	}
+
+	avg := average(durations)
+	stepsPerRun := float64(len(durations)) / float64(len(samples))
+	perRun := time.Duration(float64(avg) * ratio * stepsPerRun).Round(time.Second)
+	perMonth := time.Duration(float64(perRun) * runsPerMonth(samples)).Round(time.Minute)
+
+	return &models.Savings{
+		PerRun:   perRun,
+		PerMonth: perMonth,
+		Basis: a.lang.Sprintf("%s averages %s across %d runs; caching typically saves ~%d%% → ~%s per run, ~%s/month",
+			name, humanDuration(avg), len(samples), int(ratio*100), humanDuration(perRun), humanDuration(perMonth)),
+	}
+}
+
+// projectSavings extrapolates a measured per-run saving to a monthly figure
+func (a *Analyzer) projectSavings(samples []runSample, perRun time.Duration) *models.Savings {
+	if len(samples) == 0 || perRun <= 0 {
+		return nil
+	}
+	perRun = perRun.Round(time.Second)
+	perMonth := time.Duration(float64(perRun) * runsPerMonth(samples)).Round(time.Minute)
+	return &models.Savings{
+		PerRun:   perRun,
+		PerMonth: perMonth,
+		Basis:    a.lang.Sprintf("~%s per run, ~%s/month based on %d runs", humanDuration(perRun), humanDuration(perMonth), len(samples)),
+	}
+}
+
+// humanDuration rounds d to a readable precision, e.g. 3m12s, 2m or 4.2h
+func humanDuration(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return strings.TrimSuffix(fmt.Sprintf("%.1f", d.Hours()), ".0") + "h"
+	case d >= time.Minute:
+		d = d.Round(time.Second)
+		if d%time.Minute == 0 {
+			return strings.TrimSuffix(d.String(), "0s")
+		}
+		return d.String()
+	default:
+		return d.Round(time.Second).String()
+	}
+}
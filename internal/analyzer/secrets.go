@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// secretRef matches a secret reference, e.g. secrets.NPM_TOKEN or secrets['NPM_TOKEN']
+var secretRef = regexp.MustCompile(`\bsecrets\.([A-Za-z_][A-Za-z0-9_]*)|\bsecrets\[\s*['"]([^'"]+)['"]\s*\]`)
+
+// secretNames returns the distinct secrets referenced in values
+func secretNames(values ...string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, value := range values {
+		for _, m := range secretRef.FindAllStringSubmatch(value, -1) {
+			name := m[1] + m[2]
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// mapValues returns the values of a string map
+func mapValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+	return values
+}
+
+// thirdPartyAction reports whether an action or reusable workflow reference comes
+// from outside the repository owner and GitHub's own actions
+func thirdPartyAction(uses, owner string) bool {
+	if strings.HasPrefix(uses, "./") {
+		return false
+	}
+	if strings.HasPrefix(uses, "docker://") {
+		return true
+	}
+	actionOwner, _, _ := strings.Cut(uses, "/")
+	return !firstPartyOwners[strings.ToLower(actionOwner)] && !strings.EqualFold(actionOwner, owner)
+}
+
+// secretsInventory lists the secrets a workflow references, the jobs using them
+// and the actions they're passed to. Secrets in workflow or job env reach every
+// action of the job.
+func secretsInventory(path string, wf *workflow.Workflow, owner string) *models.SecretsInventory {
+	usages := make(map[string]*models.SecretUsage)
+	use := func(name, job string) *models.SecretUsage {
+		u := usages[name]
+		if u == nil {
+			u = &models.SecretUsage{Name: name}
+			usages[name] = u
+		}
+		if len(u.Jobs) == 0 || u.Jobs[len(u.Jobs)-1] != job {
+			u.Jobs = append(u.Jobs, job)
+		}
+		return u
+	}
+	passTo := func(u *models.SecretUsage, uses string) {
+		action, _, _ := strings.Cut(uses, "@")
+		u.Actions = appendUnique(u.Actions, action)
+		if thirdPartyAction(uses, owner) {
+			u.ThirdPartyActions = appendUnique(u.ThirdPartyActions, action)
+		}
+	}
+
+	inventory := &models.SecretsInventory{}
+	workflowEnv := secretNames(mapValues(wf.Env)...)
+	for _, job := range wf.Jobs {
+		id := path + ":" + job.ID
+		for _, name := range secretNames(workflow.Scalars(job.Node)...) {
+			use(name, id)
+		}
+		// A reusable workflow gets the secrets mapped under secrets:, or all of them,
+		// but not the caller's env
+		if job.Uses != "" {
+			secrets := workflow.Lookup(job.Node, "secrets")
+			if secrets != nil && secrets.Value == "inherit" {
+				inventory.InheritedBy = append(inventory.InheritedBy, id)
+			}
+			for _, name := range secretNames(workflow.Scalars(secrets)...) {
+				passTo(use(name, id), job.Uses)
+			}
+			continue
+		}
+
+		shared := append(append([]string(nil), workflowEnv...), secretNames(mapValues(job.Env)...)...)
+		for _, name := range shared {
+			use(name, id)
+		}
+		for _, step := range job.Steps {
+			if step.Uses == "" {
+				continue
+			}
+			names := append(secretNames(append(mapValues(step.With), mapValues(step.Env)...)...), shared...)
+			for _, name := range names {
+				passTo(use(name, id), step.Uses)
+			}
+		}
+	}
+
+	for _, u := range usages {
+		sort.Strings(u.Actions)
+		sort.Strings(u.ThirdPartyActions)
+		inventory.Secrets = append(inventory.Secrets, *u)
+	}
+	sort.Slice(inventory.Secrets, func(i, j int) bool { return inventory.Secrets[i].Name < inventory.Secrets[j].Name })
+	return inventory
+}
+
+// appendUnique appends value unless values already holds it
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
@@ -0,0 +1,179 @@
+package analyzer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/patch"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// cacheTool is a package manager a setup action caches for, recognized by the
+// commands of the job's run steps
+type cacheTool struct {
+	value   string // the value of the cache input
+	command *regexp.Regexp
+}
+
+// setupAction is an actions/setup-* action with a built-in cache input
+type setupAction struct {
+	action   string
+	minMajor int // the first major version with the cache input
+	tools    []cacheTool
+}
+
+// setupActions lists the setup actions that cache dependencies themselves, with
+// their package managers in detection order
+var setupActions = []setupAction{
+	{action: "actions/setup-node", minMajor: 2, tools: []cacheTool{
+		{value: "pnpm", command: regexp.MustCompile(`\bpnpm\s+(?:install|i)\b`)},
+		{value: "yarn", command: regexp.MustCompile(`(?m)\byarn(?:\s+install\b|\s+--|\s*$)`)},
+		{value: "npm", command: regexp.MustCompile(`\bnpm\s+(?:ci|install|i)\b`)},
+	}},
+	{action: "actions/setup-python", minMajor: 2, tools: []cacheTool{
+		{value: "poetry", command: regexp.MustCompile(`\bpoetry\s+install\b`)},
+		{value: "pipenv", command: regexp.MustCompile(`\bpipenv\s+(?:install|sync)\b`)},
+		{value: "pip", command: regexp.MustCompile(`\bpip3?\s+install\b`)},
+	}},
+	{action: "actions/setup-java", minMajor: 2, tools: []cacheTool{
+		{value: "gradle", command: regexp.MustCompile(`\bgradlew?\b`)},
+		{value: "maven", command: regexp.MustCompile(`\bmvnw?\b`)},
+		{value: "sbt", command: regexp.MustCompile(`\bsbt\b`)},
+	}},
+	// v4 and later cache by default, so only v3 needs the input
+	{action: "actions/setup-go", minMajor: 3, tools: []cacheTool{
+		{value: "true", command: regexp.MustCompile(`\bgo\s+(?:build|test|install|vet|run|generate|mod\s+download)\b`)},
+	}},
+}
+
+// setupCacheFix is a setup step that should turn on its cache input
+type setupCacheFix struct {
+	step   *workflow.Step
+	action string
+	value  string
+}
+
+// setupCacheFixes returns the setup steps of a job that leave their built-in cache
+// off although the job installs dependencies with the package manager it caches.
+// Jobs that restore a cache with actions/cache are left alone.
+func setupCacheFixes(job *workflow.Job) []setupCacheFix {
+	var runs strings.Builder
+	for _, step := range job.Steps {
+		if usesAction(step, "actions/cache") || usesAction(step, "actions/cache/restore") {
+			return nil
+		}
+		runs.WriteString(step.Run)
+		runs.WriteString("\n")
+	}
+
+	var fixes []setupCacheFix
+	for _, step := range job.Steps {
+		for _, setup := range setupActions {
+			if !usesAction(step, setup.action) {
+				continue
+			}
+			if _, ok := step.With["cache"]; ok {
+				continue
+			}
+			// SHA pins are assumed current
+			if m := goModVersion.FindStringSubmatch(step.Uses); m != nil {
+				major, _ := strconv.Atoi(m[1])
+				if major < setup.minMajor || (setup.action == "actions/setup-go" && major >= 4) {
+					continue
+				}
+			} else if setup.action == "actions/setup-go" {
+				continue
+			}
+			for _, tool := range setup.tools {
+				if tool.command.MatchString(runs.String()) {
+					fixes = append(fixes, setupCacheFix{step: step, action: setup.action, value: tool.value})
+					break
+				}
+			}
+		}
+	}
+	return fixes
+}
+
+// setupCacheExample renders the one-line change that turns on a setup step's cache
+func setupCacheExample(fix setupCacheFix) string {
+	lines := []string{"       - uses: " + fix.step.Uses}
+	if len(fix.step.With) > 0 {
+		lines = append(lines, "         with:")
+	} else {
+		lines = append(lines, "+        with:")
+	}
+	lines = append(lines, "+          cache: "+fix.value)
+	return strings.Join(lines, "\n")
+}
+
+// setupCacheEdit inserts the cache input into a setup step: as the first entry of
+// its with: block, or as a new with: block after uses:
+func setupCacheEdit(fix setupCacheFix) (patch.Edit, bool) {
+	var usesKey *yaml.Node
+	for _, pair := range workflow.Pairs(fix.step.Node) {
+		switch pair[0].Value {
+		case "uses":
+			usesKey = pair[0]
+		case "with":
+			with := pair[1]
+			if with.Kind != yaml.MappingNode || with.Style&yaml.FlowStyle != 0 || len(with.Content) == 0 {
+				return patch.Edit{}, false
+			}
+			first := with.Content[0]
+			return patch.Edit{
+				Line:   first.Line,
+				Insert: []string{strings.Repeat(" ", first.Column-1) + "cache: " + fix.value},
+			}, true
+		}
+	}
+	if usesKey == nil {
+		return patch.Edit{}, false
+	}
+	indent := strings.Repeat(" ", usesKey.Column-1)
+	return patch.Edit{
+		Line:   usesKey.Line + 1,
+		Insert: []string{indent + "with:", indent + "  cache: " + fix.value},
+	}, true
+}
+
+// setupCacheEdits turns on the built-in cache of every setup step that should use it
+func setupCacheEdits(wf *workflow.Workflow) []patch.Edit {
+	var edits []patch.Edit
+	for _, job := range wf.Jobs {
+		for _, fix := range setupCacheFixes(job) {
+			if edit, ok := setupCacheEdit(fix); ok {
+				edits = append(edits, edit)
+			}
+		}
+	}
+	return edits
+}
+
+// checkSetupCache reports setup-node, setup-python and setup-java steps that leave
+// their built-in cache off, a one-line fix compared to adding an actions/cache
+// step. Go is reported by checkGoBuild.
+func (a *Analyzer) checkSetupCache(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		for _, fix := range setupCacheFixes(job) {
+			if fix.action == "actions/setup-go" {
+				continue
+			}
+			findings = append(findings, models.Finding{
+				Category: "performance",
+				Severity: models.SeverityWarning,
+				File:     path,
+				Line:     fix.step.Line,
+				Message: a.lang.Sprintf("Job %s installs dependencies with %s, but %s doesn't cache them",
+					job.ID, fix.value, fix.action),
+				Suggestion: a.lang.Sprintf("Add cache: %s to the step's inputs; it restores and saves the package manager's download cache keyed on the lockfile, without a separate actions/cache step", fix.value),
+				Example:    setupCacheExample(fix),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,202 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// matrixRef captures the matrix key a runs-on expression reads
+	matrixRef = regexp.MustCompile(`matrix\.([\w-]+)`)
+	// bashSyntax matches lines that only work in a POSIX shell, not in PowerShell
+	bashSyntax = regexp.MustCompile(`(?m)^\s*(export \w+=|if \[|fi$|then$|done$|for \w+ in .*; do|\w+=\$\(|source |chmod |rm -(rf|fr) )|/dev/null|\[\[ `)
+	// pipeline matches a pipe that isn't part of ||
+	pipeline = regexp.MustCompile(`[^|]\|[^|]`)
+)
+
+// defaultsExample is the defaults block that gives every run step the same shell
+const defaultsExample = `    defaults:
+      run:
+        shell: bash`
+
+// runnerPlatform maps a runner label to windows, macos or linux
+func runnerPlatform(label string) string {
+	label = strings.ToLower(label)
+	switch {
+	case strings.Contains(label, "windows"):
+		return "windows"
+	case strings.Contains(label, "macos"):
+		return "macos"
+	default:
+		return "linux"
+	}
+}
+
+// jobPlatforms returns the platforms a job runs on, following runs-on into the
+// matrix. ok is false when the platforms can't be known before the run.
+func jobPlatforms(job *workflow.Job) (platforms map[string]bool, ok bool) {
+	platforms = make(map[string]bool)
+	selfHosted := false
+	for _, label := range job.RunsOn {
+		if strings.Contains(label, "${{") {
+			m := matrixRef.FindStringSubmatch(label)
+			if m == nil {
+				return nil, false
+			}
+			values, known := job.MatrixValues(m[1])
+			if !known {
+				return nil, false
+			}
+			for _, v := range values {
+				platforms[runnerPlatform(v)] = true
+			}
+			continue
+		}
+		if strings.EqualFold(label, "self-hosted") {
+			selfHosted = true
+			continue
+		}
+		platforms[runnerPlatform(label)] = true
+	}
+	// Self-hosted runners are selected by their other labels; without an OS label they're most often Linux
+	if len(platforms) == 0 && !selfHosted {
+		return nil, false
+	}
+	if len(platforms) == 0 {
+		platforms["linux"] = true
+	}
+	return platforms, true
+}
+
+// commandLines counts the non-blank, non-comment lines of a script
+func commandLines(script string) int {
+	n := 0
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			n++
+		}
+	}
+	return n
+}
+
+// stopsOnError reports whether a custom shell template such as "bash -e {0}" exits
+// at the first failing command
+func stopsOnError(shell string) bool {
+	for _, field := range strings.Fields(shell)[1:] {
+		if strings.HasPrefix(field, "-") && !strings.HasPrefix(field, "--") && strings.Contains(field, "e") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkShells flags run steps whose shell differs between the platforms of a
+// matrix, bash scripts run by PowerShell on Windows, and multi-line scripts that
+// keep going after a command fails
+func (a *Analyzer) checkShells(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+
+	for _, job := range wf.Jobs {
+		platforms, known := jobPlatforms(job)
+		windows := known && platforms["windows"]
+		mixed := windows && len(platforms) > 1
+
+		defaultShell := job.DefaultShell
+		if defaultShell == "" {
+			defaultShell = wf.DefaultShell
+		}
+
+		reportedMixed, reportedPipefail := false, false
+		for _, step := range job.Steps {
+			if step.Run == "" {
+				continue
+			}
+			shell := step.Shell
+			if shell == "" {
+				shell = defaultShell
+			}
+			multiLine := commandLines(step.Run) > 1
+
+			if shell == "" {
+				switch {
+				case mixed:
+					if !reportedMixed {
+						reportedMixed = true
+						findings = append(findings, models.Finding{
+							Category:   "reliability",
+							Severity:   models.SeverityWarning,
+							File:       path,
+							Line:       job.Line,
+							Message:    a.lang.Sprintf("Job %s runs on Windows and other platforms without a default shell, so its run steps use PowerShell on Windows and bash elsewhere", job.ID),
+							Suggestion: a.lang.T("Set defaults.run.shell so every matrix entry runs the same shell; Git Bash is installed on GitHub-hosted Windows runners"),
+							Example:    defaultsExample,
+						})
+					}
+					continue
+				case windows && bashSyntax.MatchString(step.Run):
+					findings = append(findings, models.Finding{
+						Category:   "reliability",
+						Severity:   models.SeverityWarning,
+						File:       path,
+						Line:       step.Line,
+						Message:    a.lang.Sprintf("Step %q in job %s is written for bash but runs with PowerShell, the default shell on Windows", step.DisplayName(), job.ID),
+						Suggestion: a.lang.T("Set shell: bash on the step, or defaults.run.shell for the job; Git Bash is installed on GitHub-hosted Windows runners"),
+					})
+					continue
+				case windows:
+					shell = "pwsh"
+				case known && !reportedPipefail && pipeline.MatchString(step.Run) && !strings.Contains(step.Run, "pipefail"):
+					// The implicit default is bash -e {0}; only an explicit shell: bash adds -o pipefail
+					reportedPipefail = true
+					findings = append(findings, models.Finding{
+						Category:   "reliability",
+						Severity:   models.SeverityInfo,
+						File:       path,
+						Line:       step.Line,
+						Message:    a.lang.Sprintf("Step %q in job %s pipes commands with the implicit default shell, which doesn't set pipefail, so a failure before the last command of a pipeline is ignored", step.DisplayName(), job.ID),
+						Suggestion: a.lang.T("Set defaults.run.shell to bash, which runs scripts with -eo pipefail"),
+						Example:    defaultsExample,
+					})
+					continue
+				}
+			}
+			// The implicit bash -e stops at the first failing command
+			if strings.TrimSpace(shell) == "" || !multiLine || strings.Contains(step.Run, "set +e") {
+				continue
+			}
+
+			var message, suggestion string
+			severity := models.SeverityWarning
+			switch name := strings.Fields(shell)[0]; {
+			case strings.Contains(shell, "{0}") && (name == "bash" || name == "sh") && !stopsOnError(shell) &&
+				!strings.Contains(step.Run, "set -e") && !strings.Contains(step.Run, "errexit"):
+				message = a.lang.Sprintf("Step %q in job %s runs with shell %q, which keeps going after a command fails", step.DisplayName(), job.ID, shell)
+				suggestion = a.lang.T("Add -e to the shell command (e.g. bash -eo pipefail {0}) or start the script with set -e")
+			case (name == "pwsh" || name == "powershell") && !strings.Contains(step.Run, "LASTEXITCODE") &&
+				!strings.Contains(step.Run, "PSNativeCommandUseErrorActionPreference"):
+				message = a.lang.Sprintf("Step %q in job %s runs a multi-line PowerShell script; a failing native command other than the last one doesn't fail the step", step.DisplayName(), job.ID)
+				suggestion = a.lang.T("Set $PSNativeCommandUseErrorActionPreference = $true at the top of the script (PowerShell 7.3+), or check $LASTEXITCODE after each command")
+				severity = models.SeverityInfo
+			case name == "cmd" && !strings.Contains(strings.ToLower(step.Run), "errorlevel") && !strings.Contains(step.Run, "|| exit"):
+				message = a.lang.Sprintf("Step %q in job %s runs a multi-line cmd script; only the last command's exit code fails the step", step.DisplayName(), job.ID)
+				suggestion = a.lang.T("Append || exit /b to each command, or use shell: pwsh or bash")
+			default:
+				continue
+			}
+			findings = append(findings, models.Finding{
+				Category:   "reliability",
+				Severity:   severity,
+				File:       path,
+				Line:       step.Line,
+				Message:    message,
+				Suggestion: suggestion,
+			})
+		}
+	}
+
+	return findings
+}
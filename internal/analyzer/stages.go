@@ -0,0 +1,139 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Budget groups shared by the analysis stages
+const (
+	BudgetRuns  = "runs"  // listing runs and their jobs
+	BudgetLogs  = "logs"  // downloading job logs
+	BudgetFiles = "files" // fetching workflow, Dockerfile and other repository files
+)
+
+// StageBudgets limits how long each budget group may take. Groups without a
+// budget are only bounded by the overall timeout.
+type StageBudgets map[string]time.Duration
+
+// ParseStageBudgets parses a comma-separated list of group=minutes pairs,
+// e.g. "runs=10,logs=30,files=5"
+func ParseStageBudgets(s string) (StageBudgets, error) {
+	budgets := make(StageBudgets)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		group, value, ok := strings.Cut(part, "=")
+		group = strings.TrimSpace(group)
+		if !ok || (group != BudgetRuns && group != BudgetLogs && group != BudgetFiles) {
+			return nil, fmt.Errorf("invalid stage timeout %q: expected runs=N, logs=N or files=N", part)
+		}
+		minutes, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || minutes <= 0 {
+			return nil, fmt.Errorf("invalid stage timeout %q: minutes must be a positive integer", part)
+		}
+		budgets[group] = time.Duration(minutes) * time.Minute
+	}
+	return budgets, nil
+}
+
+// WithStageBudgets sets per-group time budgets within the overall timeout
+func WithStageBudgets(budgets StageBudgets) Option {
+	return func(a *Analyzer) {
+		a.budgets = budgets
+	}
+}
+
+// rateLimitReporter is implemented by clients that know when they used up the
+// API rate limit
+type rateLimitReporter interface {
+	RateLimited() bool
+}
+
+// rateLimited reports whether the client used up the API rate limit
+func (a *Analyzer) rateLimited() bool {
+	r, ok := a.client.(rateLimitReporter)
+	return ok && r.RateLimited()
+}
+
+// stage is one step of an analysis. Stages without a budget group are local:
+// they only use data already collected and run even after a timeout.
+type stage struct {
+	name   string
+	budget string
+	run    func(ctx context.Context) error
+}
+
+// runStages runs stages in order, printing a collapsible progress group for each.
+// A stage that runs out of time or API rate limit is recorded in
+// report.SkippedStages and keeps whatever it collected; other errors abort the
+// analysis.
+func (a *Analyzer) runStages(ctx context.Context, stages []stage, report *models.PerformanceReport) error {
+	// A group's budget starts with its first stage and is shared by the rest
+	deadlines := make(map[string]time.Time)
+
+	for _, st := range stages {
+		local := st.budget == ""
+		if !local && ctx.Err() != nil {
+			if ctx.Err() == context.Canceled {
+				fmt.Fprintf(a.progressOf(ctx), "Skipping stage %s: analysis cancelled\n", st.name)
+			} else {
+				fmt.Fprintf(a.progressOf(ctx), "Skipping stage %s: analysis timed out\n", st.name)
+			}
+			report.SkippedStages = append(report.SkippedStages, st.name)
+			continue
+		}
+		if !local && a.rateLimited() {
+			fmt.Fprintf(a.progressOf(ctx), "Skipping stage %s: API rate limit used up\n", st.name)
+			report.SkippedStages = append(report.SkippedStages, st.name)
+			continue
+		}
+
+		stageCtx, cancel := context.WithCancel(ctx)
+		if budget := a.budgets[st.budget]; budget > 0 {
+			if _, started := deadlines[st.budget]; !started {
+				deadlines[st.budget] = time.Now().Add(budget)
+			}
+			cancel()
+			stageCtx, cancel = context.WithDeadline(ctx, deadlines[st.budget])
+		}
+
+		fmt.Fprintf(a.progressOf(ctx), "::group::Stage %s\n", st.name)
+		start := time.Now()
+		err := st.run(stageCtx)
+		timedOut := !local && stageCtx.Err() == context.DeadlineExceeded
+		limited := !local && a.rateLimited()
+		cancel()
+		elapsed := time.Since(start).Round(time.Millisecond)
+
+		switch {
+		case timedOut:
+			if ctx.Err() == nil {
+				fmt.Fprintf(a.progressOf(ctx), "Stage %s exceeded its %s budget after %v\n", st.name, st.budget, elapsed)
+			} else {
+				fmt.Fprintf(a.progressOf(ctx), "Stage %s timed out after %v\n", st.name, elapsed)
+			}
+			report.SkippedStages = append(report.SkippedStages, st.name)
+		case limited:
+			fmt.Fprintf(a.progressOf(ctx), "Stage %s used up the API rate limit after %v\n", st.name, elapsed)
+			report.SkippedStages = append(report.SkippedStages, st.name)
+		case err != nil:
+			fmt.Fprintf(a.progressOf(ctx), "Stage %s failed after %v: %v\n", st.name, elapsed, err)
+			fmt.Fprintln(a.progressOf(ctx), "::endgroup::")
+			return err
+		default:
+			fmt.Fprintf(a.progressOf(ctx), "Stage %s finished in %v\n", st.name, elapsed)
+		}
+		fmt.Fprintln(a.progressOf(ctx), "::endgroup::")
+	}
+
+	report.Partial = len(report.SkippedStages) > 0
+	return nil
+}
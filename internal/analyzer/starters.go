@@ -0,0 +1,102 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// starterWorkflowDir holds the workflow templates of an organization's .github
+// repository, offered to every repository of the organization
+const starterWorkflowDir = "workflow-templates"
+
+// maxStarterWorkflows caps how many templates are fetched and checked
+const maxStarterWorkflows = 50
+
+// isOrgProfileRepo reports whether repo is an organization's .github repository
+func isOrgProfileRepo(repo string) bool {
+	return strings.EqualFold(repo, ".github")
+}
+
+// analyzeStarterWorkflows checks the workflow templates of an organization's
+// .github repository like a workflow file. Repositories created from a template
+// copy its anti-patterns, so fixing the template fixes them for every new one.
+func (a *Analyzer) analyzeStarterWorkflows(ctx context.Context, owner, repo string, report *models.PerformanceReport) {
+	if !isOrgProfileRepo(repo) {
+		return
+	}
+	tree, err := a.client.GetTree(ctx, owner, repo)
+	if err != nil {
+		a.debugLog("Error listing repository tree: %v", err)
+		return
+	}
+
+	for _, p := range tree {
+		if path.Dir(p) != starterWorkflowDir || (path.Ext(p) != ".yml" && path.Ext(p) != ".yaml") {
+			continue
+		}
+		if len(report.StarterWorkflows) == maxStarterWorkflows {
+			a.debugLog("Only the first %d workflow templates are checked", maxStarterWorkflows)
+			break
+		}
+		content, err := a.client.GetFileContent(ctx, owner, repo, p)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		starter := models.StarterWorkflow{Path: p, Name: a.starterName(ctx, owner, repo, p)}
+		findings := append(a.lintWorkflow(p, content), a.inspectWorkflow(ctx, p, content, nil)...)
+		categories := make(map[string]bool)
+		for _, f := range findings {
+			starter.Findings++
+			switch f.Severity {
+			case models.SeverityCritical:
+				starter.Critical++
+			case models.SeverityWarning:
+				starter.Warnings++
+			}
+			if !categories[f.Category] {
+				categories[f.Category] = true
+				starter.Categories = append(starter.Categories, f.Category)
+			}
+		}
+		sort.Strings(starter.Categories)
+		report.StarterWorkflows = append(report.StarterWorkflows, starter)
+		report.Findings = append(report.Findings, findings...)
+	}
+	a.debugLog("Checked %d workflow templates", len(report.StarterWorkflows))
+
+	// The templates spreading the most anti-patterns come first
+	sort.SliceStable(report.StarterWorkflows, func(i, j int) bool {
+		x, y := report.StarterWorkflows[i], report.StarterWorkflows[j]
+		if x.Critical != y.Critical {
+			return x.Critical > y.Critical
+		}
+		return x.Findings > y.Findings
+	})
+}
+
+// starterName returns the name a template is offered under, from the
+// properties file next to it, or its file name when it has none
+func (a *Analyzer) starterName(ctx context.Context, owner, repo, templatePath string) string {
+	base := strings.TrimSuffix(templatePath, path.Ext(templatePath))
+	var properties struct {
+		Name string `json:"name"`
+	}
+	if content, err := a.client.GetFileContent(ctx, owner, repo, base+".properties.json"); err == nil {
+		if err := json.Unmarshal([]byte(content), &properties); err != nil {
+			a.debugLog("Error parsing the properties of %s: %v", templatePath, err)
+		}
+	}
+	if properties.Name != "" {
+		return properties.Name
+	}
+	return path.Base(base)
+}
@@ -0,0 +1,211 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	commitSHA    = regexp.MustCompile(`^[0-9a-f]{40}$`)
+	majorVersion = regexp.MustCompile(`^v?\d+$`)
+	fullVersion  = regexp.MustCompile(`^v?\d+\.\d+`)
+)
+
+// maxSingleJobSteps is how many steps a workflow's only job may have before
+// splitting it into parallel jobs is suggested
+const maxSingleJobSteps = 25
+
+// WithStyleChecks enables the optional style rules: naming conventions, unnamed
+// run steps, inconsistent action references, oversized single-job workflows and
+// repeated or complex expressions
+func WithStyleChecks(enabled bool) Option {
+	return func(a *Analyzer) {
+		a.styleChecks = enabled
+	}
+}
+
+// idConvention names the naming convention of a job ID, or "" for a single
+// lowercase word, which fits every convention
+func idConvention(id string) string {
+	switch {
+	case strings.ContainsRune(id, '-') && strings.ContainsRune(id, '_'):
+		return "mixed"
+	case strings.ContainsRune(id, '-'):
+		return "kebab-case"
+	case strings.ContainsRune(id, '_'):
+		return "snake_case"
+	case strings.ToLower(id) != id:
+		return "camelCase"
+	default:
+		return ""
+	}
+}
+
+// refStyle classifies an action version as a commit SHA, a major tag, a full
+// version or a branch
+func refStyle(version string) string {
+	switch {
+	case commitSHA.MatchString(version):
+		return "commit SHA"
+	case majorVersion.MatchString(version):
+		return "major tag"
+	case fullVersion.MatchString(version):
+		return "full version"
+	default:
+		return "branch"
+	}
+}
+
+// majority returns the most common key of counts, breaking ties alphabetically
+func majority(counts map[string]int) string {
+	var keys []string
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	best := ""
+	for _, k := range keys {
+		if best == "" || counts[k] > counts[best] {
+			best = k
+		}
+	}
+	return best
+}
+
+// checkStyle runs the optional style rules over a workflow
+func (a *Analyzer) checkStyle(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+
+	// Job IDs should follow one naming convention
+	conventions := make(map[string]int)
+	for _, job := range wf.Jobs {
+		if c := idConvention(job.ID); c != "" && c != "mixed" {
+			conventions[c]++
+		}
+	}
+	usual := majority(conventions)
+	for _, job := range wf.Jobs {
+		c := idConvention(job.ID)
+		if c == "" || c == usual || usual == "" {
+			continue
+		}
+		findings = append(findings, models.Finding{
+			Category:   "style",
+			Severity:   models.SeverityInfo,
+			File:       path,
+			Line:       job.Line,
+			Message:    a.lang.Sprintf("Job ID %s doesn't follow the %s naming used by the other jobs", job.ID, usual),
+			Suggestion: a.lang.T("Rename the job to match; remember to update needs: and required status checks that refer to it"),
+		})
+	}
+
+	var named, lowercase int
+	var firstLowercase *workflow.Step
+	refs := make(map[string]string)
+	styles := make(map[string]int)
+	var uses []*workflow.Step
+	for _, job := range wf.Jobs {
+		for _, step := range job.Steps {
+			// Step names should share their capitalization
+			if step.Name != "" {
+				named++
+				if first := []rune(step.Name)[0]; unicode.IsLower(first) {
+					lowercase++
+					if firstLowercase == nil {
+						firstLowercase = step
+					}
+				}
+			}
+
+			// Unnamed multi-line scripts show up in the log as "Run" plus their first line
+			if step.Name == "" && step.Run != "" && commandLines(step.Run) > 1 {
+				findings = append(findings, models.Finding{
+					Category:   "style",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Step %d of job %s runs a multi-line script without a name, so the log only shows its first line", step.Index+1, job.ID),
+					Suggestion: a.lang.T("Add a name: that says what the step does"),
+				})
+			}
+
+			if step.Uses == "" || strings.HasPrefix(step.Uses, "./") || strings.HasPrefix(step.Uses, "docker://") {
+				continue
+			}
+			action, version, found := strings.Cut(step.Uses, "@")
+			if !found {
+				continue
+			}
+			// The same action should be referenced the same way throughout
+			if prev, ok := refs[action]; ok && prev != version {
+				findings = append(findings, models.Finding{
+					Category:   "style",
+					Severity:   models.SeverityInfo,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Action %s is referenced as @%s here but as @%s earlier in the workflow", action, version, prev),
+					Suggestion: a.lang.T("Use the same version of an action everywhere in a workflow so upgrades update every step"),
+				})
+			} else if !ok {
+				refs[action] = version
+			}
+			styles[refStyle(version)]++
+			uses = append(uses, step)
+		}
+	}
+
+	if firstLowercase != nil && lowercase < named-lowercase {
+		findings = append(findings, models.Finding{
+			Category:   "style",
+			Severity:   models.SeverityInfo,
+			File:       path,
+			Line:       firstLowercase.Line,
+			Message:    a.lang.Sprintf("%d of %d step names start with a lowercase letter, unlike the rest", lowercase, named),
+			Suggestion: a.lang.T("Capitalize step names consistently so the log reads evenly"),
+		})
+	}
+
+	// Action references should share one style: commit SHAs, major tags or full versions
+	if len(styles) > 1 {
+		usual := majority(styles)
+		var others []string
+		var line int
+		for _, step := range uses {
+			_, version, _ := strings.Cut(step.Uses, "@")
+			if style := refStyle(version); style != usual {
+				others = append(others, step.Uses)
+				if line == 0 {
+					line = step.Line
+				}
+			}
+		}
+		findings = append(findings, models.Finding{
+			Category:   "style",
+			Severity:   models.SeverityInfo,
+			File:       path,
+			Line:       line,
+			Message:    a.lang.Sprintf("Most actions are pinned by %s, unlike %s", a.lang.T(usual), strings.Join(others, ", ")),
+			Suggestion: a.lang.T("Pin every action the same way; commit SHAs with a version comment are the most robust"),
+		})
+	}
+
+	// A workflow with a single long job runs everything sequentially
+	if len(wf.Jobs) == 1 && len(wf.Jobs[0].Steps) > maxSingleJobSteps {
+		job := wf.Jobs[0]
+		findings = append(findings, models.Finding{
+			Category:   "style",
+			Severity:   models.SeverityInfo,
+			File:       path,
+			Line:       job.Line,
+			Message:    a.lang.Sprintf("Job %s is the only job of the workflow and has %d steps", job.ID, len(job.Steps)),
+			Suggestion: a.lang.T("Split it into jobs such as lint, test and build linked with needs:, so independent parts run in parallel and a failure points at the right job"),
+		})
+	}
+
+	return findings
+}
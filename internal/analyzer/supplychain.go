@@ -0,0 +1,169 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+const (
+	// maxSupplyChainLookups caps the action repositories checked per analysis
+	maxSupplyChainLookups = 20
+	// staleReleaseAge is how old the latest release may be before an action counts as unmaintained
+	staleReleaseAge = 365 * 24 * time.Hour
+)
+
+// First-party owners whose actions are maintained by GitHub; only archiving is checked for them
+var firstPartyOwners = map[string]bool{
+	"actions": true,
+	"github":  true,
+}
+
+// Maintained replacements for well-known archived or abandoned actions
+var maintainedAlternatives = map[string]string{
+	"actions/create-release":                "softprops/action-gh-release",
+	"actions/upload-release-asset":          "softprops/action-gh-release",
+	"actions/setup-ruby":                    "ruby/setup-ruby",
+	"actions-rs/toolchain":                  "dtolnay/rust-toolchain",
+	"actions-rs/audit-check":                "rustsec/audit-check",
+	"crazy-max/ghaction-docker-buildx":      "docker/setup-buildx-action",
+	"azure/docker-login":                    "docker/login-action",
+	"marvinpinto/action-automatic-releases": "softprops/action-gh-release",
+}
+
+// actionUse is the first place a workflow references an action repository
+type actionUse struct {
+	repo string // owner/repo
+	line int
+}
+
+// actionRepos returns the repositories of the actions and reusable workflows a
+// workflow uses, in order of first use. Local and Docker references are skipped.
+func actionRepos(wf *workflow.Workflow) []actionUse {
+	var uses []actionUse
+	seen := make(map[string]bool)
+	add := func(ref string, line int) {
+		if ref == "" || strings.HasPrefix(ref, "./") || strings.HasPrefix(ref, "docker://") {
+			return
+		}
+		name, _, _ := strings.Cut(ref, "@")
+		parts := strings.SplitN(name, "/", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return
+		}
+		repo := strings.ToLower(parts[0] + "/" + parts[1])
+		if seen[repo] {
+			return
+		}
+		seen[repo] = true
+		uses = append(uses, actionUse{repo: repo, line: line})
+	}
+
+	for _, job := range wf.Jobs {
+		add(job.Uses, job.Line)
+		for _, step := range job.Steps {
+			add(step.Uses, step.Line)
+		}
+	}
+	return uses
+}
+
+// analyzeSupplyChain flags actions whose repositories are archived, haven't released
+// in a year or have a single contributor, since nobody may be around to fix a compromise
+func (a *Analyzer) analyzeSupplyChain(ctx context.Context, owner, repo string, report *models.PerformanceReport) {
+	workflowPath := report.WorkflowFile
+	if !strings.HasPrefix(workflowPath, ".github/workflows/") {
+		workflowPath = fmt.Sprintf(".github/workflows/%s", workflowPath)
+	}
+	content, err := a.client.GetFileContent(ctx, owner, repo, workflowPath)
+	if err != nil {
+		return
+	}
+	wf, err := workflow.Parse(content)
+	if err != nil {
+		return
+	}
+
+	uses := actionRepos(wf)
+	if len(uses) > maxSupplyChainLookups {
+		a.debugLog("Checking the first %d of %d action repositories", maxSupplyChainLookups, len(uses))
+		uses = uses[:maxSupplyChainLookups]
+	}
+
+	for _, use := range uses {
+		if ctx.Err() != nil {
+			return
+		}
+		actionOwner, actionRepo, _ := strings.Cut(use.repo, "/")
+		// Actions from the analyzed repository's owner aren't third-party
+		if strings.EqualFold(actionOwner, owner) {
+			continue
+		}
+		if finding, ok := a.checkActionRepository(ctx, workflowPath, use.line, actionOwner, actionRepo); ok {
+			report.Findings = append(report.Findings, finding)
+		}
+	}
+}
+
+// checkActionRepository collects the supply-chain risks of one action repository
+func (a *Analyzer) checkActionRepository(ctx context.Context, path string, line int, owner, repo string) (models.Finding, bool) {
+	name := owner + "/" + repo
+	repository, err := a.client.GetRepository(ctx, owner, repo)
+	if err != nil {
+		a.debugLog("Error getting repository %s: %v", name, err)
+		return models.Finding{}, false
+	}
+
+	var risks []string
+	severity := models.SeverityInfo
+	if repository.GetArchived() {
+		risks = append(risks, a.lang.T("its repository is archived"))
+		severity = models.SeverityWarning
+	}
+
+	if !firstPartyOwners[owner] {
+		release, err := a.client.GetLatestRelease(ctx, owner, repo)
+		switch {
+		case err != nil:
+			// Also returned for repositories that only push tags; the last push tells them apart
+			if time.Since(repository.GetPushedAt().Time) > staleReleaseAge {
+				risks = append(risks, a.lang.Sprintf("no release and no push in %d months", monthsSince(repository.GetPushedAt().Time)))
+			}
+		case time.Since(release.GetPublishedAt().Time) > staleReleaseAge:
+			risks = append(risks, a.lang.Sprintf("no release in %d months", monthsSince(release.GetPublishedAt().Time)))
+		}
+
+		contributors, err := a.client.ListContributors(ctx, owner, repo, 2)
+		if err != nil {
+			a.debugLog("Error listing contributors of %s: %v", name, err)
+		} else if len(contributors) == 1 {
+			risks = append(risks, a.lang.T("a single contributor"))
+		}
+	}
+
+	if len(risks) == 0 {
+		return models.Finding{}, false
+	}
+
+	finding := models.Finding{
+		Category:   "security",
+		Severity:   severity,
+		File:       path,
+		Line:       line,
+		Message:    a.lang.Sprintf("Action %s is a supply-chain risk: %s", name, strings.Join(risks, ", ")),
+		Suggestion: a.lang.T("Pin it to a full commit SHA and review its code, or replace it with a maintained action or an inline script"),
+	}
+	if alternative, ok := maintainedAlternatives[name]; ok {
+		finding.Suggestion = a.lang.Sprintf("Replace it with %s", alternative)
+	}
+	return finding, true
+}
+
+// monthsSince returns the whole months elapsed since t
+func monthsSince(t time.Time) int {
+	return int(time.Since(t).Hours() / 24 / 30)
+}
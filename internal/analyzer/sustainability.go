@@ -0,0 +1,123 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Power model coefficients, following the Cloud Carbon Footprint methodology
+const (
+	wattsPerVCPU       = 2.12  // average of 0.74W idle and 3.5W max per vCPU at 50% utilization
+	wattsPerGBMemory   = 0.392 // memory power per GB
+	powerUsageEffect   = 1.135 // data center PUE of the hosted runner cloud
+	defaultGridCarbon  = 400.0 // gCO2e per kWh, roughly the global grid average
+	defaultRunnerLabel = "ubuntu"
+)
+
+// runnerProfile is the hardware a runner label is assumed to provide
+type runnerProfile struct {
+	vCPU     int
+	memoryGB int
+}
+
+// Standard GitHub-hosted runner sizes by OS
+var runnerProfiles = map[string]runnerProfile{
+	"ubuntu":  {vCPU: 4, memoryGB: 16},
+	"windows": {vCPU: 4, memoryGB: 16},
+	"macos":   {vCPU: 3, memoryGB: 7},
+}
+
+// largerRunnerCores matches larger runner labels such as ubuntu-latest-8-cores or linux-16core
+var largerRunnerCores = regexp.MustCompile(`(\d+)-?cores?`)
+
+// WithSustainability enables the energy and CO2 estimate; gridCarbon is in gCO2e/kWh
+// and falls back to the global average when zero
+func WithSustainability(enabled bool, gridCarbon float64) Option {
+	return func(a *Analyzer) {
+		a.sustainability = enabled
+		if gridCarbon > 0 {
+			a.gridCarbon = gridCarbon
+		}
+	}
+}
+
+// classifyRunner maps a job's runner labels to an OS name and hardware profile
+func classifyRunner(labels []string) (string, runnerProfile) {
+	osName := defaultRunnerLabel
+	joined := strings.ToLower(strings.Join(labels, " "))
+	// Sorted, so labels naming several OSes always map to the same one
+	names := make([]string, 0, len(runnerProfiles))
+	for name := range runnerProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.Contains(joined, name) {
+			osName = name
+			break
+		}
+	}
+
+	profile := runnerProfiles[osName]
+	if m := largerRunnerCores.FindStringSubmatch(joined); m != nil {
+		if cores, err := strconv.Atoi(m[1]); err == nil && cores > 0 {
+			profile = runnerProfile{vCPU: cores, memoryGB: cores * 4}
+			return osName + "-" + m[1] + "core", profile
+		}
+	}
+	return osName, profile
+}
+
+// estimateSustainability converts runner minutes per OS/size into energy and CO2 figures
+func (a *Analyzer) estimateSustainability(samples []runSample) *models.Sustainability {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	entries := make(map[string]*models.SustainabilityEntry)
+	for _, sample := range samples {
+		for _, job := range sample.Jobs {
+			if job.StartedAt == nil || job.CompletedAt == nil {
+				continue
+			}
+			runner, profile := classifyRunner(job.Labels)
+			minutes := job.CompletedAt.Sub(job.StartedAt.Time).Minutes()
+
+			entry, ok := entries[runner]
+			if !ok {
+				entry = &models.SustainabilityEntry{Runner: runner, VCPU: profile.vCPU, MemoryGB: profile.memoryGB}
+				entries[runner] = entry
+			}
+			watts := float64(profile.vCPU)*wattsPerVCPU + float64(profile.memoryGB)*wattsPerGBMemory
+			kwh := watts * powerUsageEffect * minutes / 60 / 1000
+
+			entry.Minutes += minutes
+			entry.EnergyKWh += kwh
+			entry.CO2Grams += kwh * a.gridCarbon
+		}
+	}
+
+	result := &models.Sustainability{
+		GridCarbon: a.gridCarbon,
+		Runs:       len(samples),
+	}
+	for _, entry := range entries {
+		result.Entries = append(result.Entries, *entry)
+		result.Minutes += entry.Minutes
+		result.EnergyKWh += entry.EnergyKWh
+		result.CO2Grams += entry.CO2Grams
+	}
+	sort.Slice(result.Entries, func(i, j int) bool { return result.Entries[i].CO2Grams > result.Entries[j].CO2Grams })
+
+	monthly := runsPerMonth(samples) / float64(len(samples))
+	result.MonthlyEnergyKWh = result.EnergyKWh * monthly
+	result.MonthlyCO2Grams = result.CO2Grams * monthly
+	result.Assumptions = a.lang.Sprintf("%.2fW per vCPU, %.3fW per GB memory, PUE %.3f, %.0f gCO2e/kWh grid intensity",
+		wattsPerVCPU, wattsPerGBMemory, powerUsageEffect, a.gridCarbon)
+
+	return result
+}
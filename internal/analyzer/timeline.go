@@ -0,0 +1,79 @@
+package analyzer
+
+import (
+	"sort"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+// jobGraph lists the workflow's jobs with their needs and average measured durations
+func jobGraph(wf *workflow.Workflow, samples []runSample) []models.JobNode {
+	nodes := make([]models.JobNode, 0, len(wf.Jobs))
+	for _, job := range wf.Jobs {
+		durations := jobDurations(samples, job)
+		nodes = append(nodes, models.JobNode{
+			ID:          job.ID,
+			Name:        job.Name,
+			Needs:       job.Needs,
+			AvgDuration: average(durations),
+			Runs:        len(durations),
+		})
+	}
+	return nodes
+}
+
+// runTimeline lays out the jobs and steps of the newest sampled run that has job
+// timings; samples are ordered newest first
+func runTimeline(samples []runSample) *models.RunTimeline {
+	for _, sample := range samples {
+		run := sample.Run
+		start := run.GetRunStartedAt().Time
+		if start.IsZero() {
+			start = run.GetCreatedAt().Time
+		}
+
+		timeline := &models.RunTimeline{
+			RunID:      run.GetID(),
+			RunNumber:  run.GetRunNumber(),
+			URL:        run.GetHTMLURL(),
+			Conclusion: run.GetConclusion(),
+			StartedAt:  start,
+		}
+		for _, apiJob := range sample.Jobs {
+			if apiJob.StartedAt == nil || apiJob.CompletedAt == nil {
+				continue
+			}
+			job := models.TimelineJob{
+				TimelineSpan: models.TimelineSpan{
+					Name:       apiJob.GetName(),
+					Conclusion: apiJob.GetConclusion(),
+					Start:      apiJob.StartedAt.Sub(start),
+					End:        apiJob.CompletedAt.Sub(start),
+				},
+				Runner: apiJob.GetRunnerName(),
+			}
+			for _, apiStep := range apiJob.Steps {
+				if apiStep.StartedAt == nil || apiStep.CompletedAt == nil || apiStep.GetConclusion() == "skipped" {
+					continue
+				}
+				job.Steps = append(job.Steps, models.TimelineSpan{
+					Name:       apiStep.GetName(),
+					Conclusion: apiStep.GetConclusion(),
+					Start:      apiStep.StartedAt.Sub(start),
+					End:        apiStep.CompletedAt.Sub(start),
+				})
+			}
+			timeline.Jobs = append(timeline.Jobs, job)
+			timeline.Duration = max(timeline.Duration, job.End)
+		}
+		if len(timeline.Jobs) == 0 {
+			continue
+		}
+		sort.SliceStable(timeline.Jobs, func(i, j int) bool {
+			return timeline.Jobs[i].Start < timeline.Jobs[j].Start
+		})
+		return timeline
+	}
+	return nil
+}
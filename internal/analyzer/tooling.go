@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+)
+
+// packageTool identifies a package manager by the lockfiles it writes and the
+// commands or actions that use it
+type packageTool struct {
+	name      string
+	lockfiles []string
+	hints     []string
+}
+
+// lockfileCount is the most lockfile checks detecting one of tools can make
+func lockfileCount(tools []packageTool) int {
+	n := 0
+	for _, tool := range tools {
+		n += len(tool.lockfiles)
+	}
+	return n
+}
+
+// detectPackageTool picks the first tool whose lockfile exists at the repository root,
+// then the first tool mentioned in texts (workflow content, job logs), then fallback
+func (a *Analyzer) detectPackageTool(ctx context.Context, owner, repo string, tools []packageTool, fallback string, texts ...string) string {
+	for _, tool := range tools {
+		for _, lockfile := range tool.lockfiles {
+			exists, err := a.client.FileExists(ctx, owner, repo, lockfile)
+			if err != nil {
+				a.debugLog("Error checking %s: %v", lockfile, err)
+				continue
+			}
+			if exists {
+				return tool.name
+			}
+		}
+	}
+
+	return packageToolFromHints(tools, fallback, texts...)
+}
+
+// packageToolFromHints picks the first tool mentioned in texts, then fallback
+func packageToolFromHints(tools []packageTool, fallback string, texts ...string) string {
+	for _, tool := range tools {
+		for _, hint := range tool.hints {
+			for _, text := range texts {
+				if strings.Contains(text, hint) {
+					return tool.name
+				}
+			}
+		}
+	}
+	return fallback
+}
+
+// packageToolFor detects a tool from the lockfiles of a subproject, or of the
+// repository root when project is nil
+func (a *Analyzer) packageToolFor(ctx context.Context, owner, repo string, project *subproject, tools []packageTool, fallback string, texts []string) string {
+	if project == nil {
+		return a.detectPackageTool(ctx, owner, repo, tools, fallback, texts...)
+	}
+	if tool := toolFromFiles(tools, project.files); tool != "" {
+		return tool
+	}
+	return packageToolFromHints(tools, fallback, texts...)
+}
+
+// toolConfidence rates picking tool from texts, the workflow content followed
+// by the job logs. A tool whose lockfile is among files or that the workflow
+// mentions has none; one only the logs mention is rated by how many do.
+func toolConfidence(tools []packageTool, tool string, files []string, texts []string) float64 {
+	if toolFromFiles(tools, files) == tool || packageToolFromHints(tools, "", texts[0]) == tool {
+		return 0
+	}
+	matched := 0
+	for _, text := range texts[1:] {
+		if packageToolFromHints(tools, "", text) == tool {
+			matched++
+		}
+	}
+	if matched == 0 {
+		return 0
+	}
+	return confidenceOf(matched, len(texts)-1)
+}
+
+// toolingTexts returns the workflow content and the downloaded job logs, where
+// package manager commands show up
+func toolingTexts(workflowContent string, samples []runSample) []string {
+	texts := []string{workflowContent}
+	for _, sample := range samples {
+		if sample.Logs != "" {
+			texts = append(texts, sample.Logs)
+		}
+	}
+	return texts
+}
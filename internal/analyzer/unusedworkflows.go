@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// unusedWorkflowDays is how far back runs are counted to find workflows that never run
+const unusedWorkflowDays = 90
+
+// workflowEvents are the events GitHub Actions can trigger a workflow on
+var workflowEvents = map[string]bool{
+	"branch_protection_rule": true, "check_run": true, "check_suite": true, "create": true,
+	"delete": true, "deployment": true, "deployment_status": true, "discussion": true,
+	"discussion_comment": true, "fork": true, "gollum": true, "issue_comment": true,
+	"issues": true, "label": true, "merge_group": true, "milestone": true,
+	"page_build": true, "public": true, "pull_request": true, "pull_request_review": true,
+	"pull_request_review_comment": true, "pull_request_target": true, "push": true,
+	"registry_package": true, "release": true, "repository_dispatch": true, "schedule": true,
+	"status": true, "watch": true, "workflow_call": true, "workflow_dispatch": true,
+	"workflow_run": true,
+}
+
+// disabledStates describes the states of a workflow GitHub no longer runs.
+// Workflows of forks are disabled until enabled in the fork, so they're left out.
+var disabledStates = map[string]string{
+	"disabled_manually":   "disabled manually",
+	"disabled_inactivity": "disabled after 60 days without repository activity",
+}
+
+// analyzeUnusedWorkflows cross-references the repository's workflow files with
+// their run history and reports the ones that can't or didn't run: triggers that
+// aren't GitHub events, disabled workflows and workflows without runs in the last
+// unusedWorkflowDays days. Reusable workflows only run through their callers.
+func (a *Analyzer) analyzeUnusedWorkflows(ctx context.Context, owner, repo string, report *models.PerformanceReport, workflows []*repoWorkflow) {
+	if len(workflows) == 0 {
+		return
+	}
+	states := make(map[string]string)
+	if registered, err := a.client.ListWorkflows(ctx, owner, repo); err == nil {
+		for _, w := range registered {
+			states[w.GetPath()] = w.GetState()
+		}
+	} else {
+		a.debugLog("Error listing workflows: %v", err)
+	}
+	since := time.Now().AddDate(0, 0, -unusedWorkflowDays)
+
+	for _, w := range workflows {
+		wf := w.parsed
+		finding := models.Finding{
+			Category: "maintenance",
+			Severity: models.SeverityInfo,
+			File:     w.path,
+			Line:     wf.OnLine,
+		}
+
+		var unknown []string
+		for _, event := range wf.On {
+			if !workflowEvents[event] {
+				unknown = append(unknown, event)
+			}
+		}
+		switch {
+		case len(unknown) > 0:
+			finding.Severity = models.SeverityWarning
+			finding.Message = a.lang.Sprintf("Workflow %s is triggered by %s, which isn't a GitHub Actions event", w.name, strings.Join(unknown, ", "))
+			finding.Suggestion = a.lang.T("Check the spelling against the events GitHub documents; a misspelled trigger never starts the workflow")
+		case disabledStates[states[w.path]] != "":
+			finding.Message = a.lang.Sprintf("Workflow %s is %s", w.name, a.lang.T(disabledStates[states[w.path]]))
+			finding.Suggestion = a.lang.T("Delete the workflow file if it's no longer needed, or enable it again; a disabled workflow still reads as live to anyone browsing the repository")
+		case len(wf.On) == 0 || (len(wf.On) == 1 && wf.HasTrigger("workflow_call")):
+			continue
+		default:
+			runs, err := a.client.CountWorkflowRuns(ctx, owner, repo, w.file(), since)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				a.debugLog("Error counting runs of %s: %v", w.path, err)
+				continue
+			}
+			if runs > 0 {
+				continue
+			}
+			finding.Message = a.lang.Sprintf("Workflow %s hasn't run in the last %d days", w.name, unusedWorkflowDays)
+			finding.Suggestion = a.lang.T("Delete the workflow if it's dead, or check its triggers: branch and path filters that no longer match keep it from ever starting")
+		}
+		report.Findings = append(report.Findings, finding)
+	}
+}
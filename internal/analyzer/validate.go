@@ -0,0 +1,256 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// Allowed keys at each level of a workflow file
+var (
+	workflowKeys = keySet("name", "run-name", "on", "permissions", "env", "defaults", "concurrency", "jobs")
+	jobKeys      = keySet("name", "needs", "runs-on", "permissions", "environment", "concurrency", "outputs",
+		"env", "defaults", "if", "steps", "timeout-minutes", "strategy", "continue-on-error", "container",
+		"services", "uses", "with", "secrets", "snapshot")
+	stepKeys = keySet("id", "if", "name", "uses", "run", "working-directory", "shell", "with", "env",
+		"continue-on-error", "timeout-minutes")
+	expressionContexts = keySet("github", "env", "vars", "job", "jobs", "steps", "runner", "secrets",
+		"strategy", "matrix", "needs", "inputs")
+	expressionKeywords = keySet("true", "false", "null", "NaN", "Infinity")
+)
+
+var (
+	expressionPattern = regexp.MustCompile(`\$\{\{(.*?)\}\}`)
+	stringLiteral     = regexp.MustCompile(`'(?:[^']|'')*'`)
+	identifierPattern = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_-]*`)
+	yamlErrorLine     = regexp.MustCompile(`line (\d+)`)
+)
+
+func keySet(keys ...string) map[string]bool {
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// validateWorkflow checks workflow syntax and semantics: YAML errors, unknown keys,
+// broken job references, unknown expression contexts and shellcheck issues in run blocks
+func (a *Analyzer) validateWorkflow(ctx context.Context, path, content string) []models.Finding {
+	var findings []models.Finding
+	add := func(line int, message string) {
+		findings = append(findings, models.Finding{
+			Category: "syntax",
+			Severity: models.SeverityCritical,
+			File:     path,
+			Line:     line,
+			Message:  message,
+		})
+	}
+
+	wf, err := workflow.Parse(content)
+	if err != nil {
+		line := 0
+		if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+			line, _ = strconv.Atoi(m[1])
+		}
+		add(line, a.lang.Sprintf("Invalid workflow YAML: %v", err))
+		return findings
+	}
+
+	for _, pair := range workflow.Pairs(wf.Root) {
+		if !workflowKeys[pair[0].Value] {
+			add(pair[0].Line, a.lang.Sprintf("Unknown workflow key %q", pair[0].Value))
+		}
+	}
+	if wf.OnLine == 0 {
+		add(1, a.lang.T("Workflow has no \"on\" trigger"))
+	}
+	if len(wf.Jobs) == 0 {
+		add(1, a.lang.T("Workflow defines no jobs"))
+	}
+
+	for _, job := range wf.Jobs {
+		for _, pair := range workflow.Pairs(job.Node) {
+			if !jobKeys[pair[0].Value] {
+				add(pair[0].Line, a.lang.Sprintf("Unknown key %q in job %s", pair[0].Value, job.ID))
+			}
+		}
+
+		switch {
+		case job.Uses == "" && len(job.RunsOn) == 0:
+			add(job.Line, a.lang.Sprintf("Job %s has no runs-on", job.ID))
+		case job.Uses == "" && len(job.Steps) == 0:
+			add(job.Line, a.lang.Sprintf("Job %s has no steps", job.ID))
+		}
+
+		for _, need := range job.Needs {
+			if wf.Job(need) == nil {
+				add(job.Line, a.lang.Sprintf("Job %s needs unknown job %q", job.ID, need))
+			}
+		}
+
+		for _, step := range job.Steps {
+			for _, pair := range workflow.Pairs(step.Node) {
+				if !stepKeys[pair[0].Value] {
+					add(pair[0].Line, a.lang.Sprintf("Unknown key %q in step %q", pair[0].Value, step.DisplayName()))
+				}
+			}
+			if (step.Uses == "") == (step.Run == "") {
+				add(step.Line, a.lang.Sprintf("Step %q must define exactly one of uses or run", step.DisplayName()))
+			}
+		}
+	}
+
+	findings = append(findings, a.validateExpressions(path, wf.Root)...)
+	findings = append(findings, a.shellcheckWorkflow(ctx, path, wf)...)
+
+	return findings
+}
+
+// validateExpressions reports ${{ }} expressions referencing unknown contexts
+func (a *Analyzer) validateExpressions(path string, root *yaml.Node) []models.Finding {
+	var findings []models.Finding
+
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode {
+			for _, m := range expressionPattern.FindAllStringSubmatch(node.Value, -1) {
+				for _, name := range expressionRoots(m[1]) {
+					// Context names are case-insensitive in expressions
+					if expressionContexts[strings.ToLower(name)] || expressionKeywords[name] {
+						continue
+					}
+					findings = append(findings, models.Finding{
+						Category: "syntax",
+						Severity: models.SeverityCritical,
+						File:     path,
+						Line:     node.Line,
+						Message:  a.lang.Sprintf("Unknown context %q in expression ${{%s}}", name, m[1]),
+					})
+				}
+			}
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	return findings
+}
+
+// expressionRoots returns the identifiers in an expression that start a property
+// access chain and aren't function calls
+func expressionRoots(expr string) []string {
+	expr = stringLiteral.ReplaceAllString(expr, "''")
+
+	var roots []string
+	for _, loc := range identifierPattern.FindAllStringIndex(expr, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && (expr[start-1] == '.' || isDigit(expr[start-1])) {
+			continue
+		}
+		rest := strings.TrimLeft(expr[end:], " ")
+		if strings.HasPrefix(rest, "(") {
+			continue
+		}
+		roots = append(roots, expr[start:end])
+	}
+	return roots
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// shellcheckComment is one entry of shellcheck's json1 output
+type shellcheckComment struct {
+	Line    int    `json:"line"`
+	Level   string `json:"level"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// shellcheckWorkflow runs shellcheck over bash/sh run blocks when the binary is available
+func (a *Analyzer) shellcheckWorkflow(ctx context.Context, path string, wf *workflow.Workflow) []models.Finding {
+	bin, err := exec.LookPath("shellcheck")
+	if err != nil {
+		a.debugLog("shellcheck not found, skipping run block checks")
+		return nil
+	}
+
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		// Run steps without a shell use PowerShell on Windows
+		platforms, known := jobPlatforms(job)
+		defaultShell := job.DefaultShell
+		if defaultShell == "" {
+			defaultShell = wf.DefaultShell
+		}
+		for _, step := range job.Steps {
+			stepShell := step.Shell
+			if stepShell == "" {
+				stepShell = defaultShell
+			}
+			if stepShell == "" && known && platforms["windows"] {
+				continue
+			}
+			if stepShell != "" {
+				stepShell = strings.Fields(stepShell)[0]
+			}
+			if step.Run == "" || (stepShell != "" && stepShell != "bash" && stepShell != "sh") {
+				continue
+			}
+
+			shell := "bash"
+			if stepShell == "sh" {
+				shell = "sh"
+			}
+
+			// Expressions are substituted before the script runs; mask them so shellcheck sees valid shell
+			script := expressionPattern.ReplaceAllString(step.Run, "EXPR")
+
+			cmd := exec.CommandContext(ctx, bin, "--format=json1", "--shell="+shell, "--exclude=SC2034", "-")
+			cmd.Stdin = strings.NewReader(script)
+			var out bytes.Buffer
+			cmd.Stdout = &out
+			_ = cmd.Run() // shellcheck exits non-zero when it reports issues
+
+			var result struct {
+				Comments []shellcheckComment `json:"comments"`
+			}
+			if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+				a.debugLog("Error parsing shellcheck output for %s: %v", step.DisplayName(), err)
+				continue
+			}
+
+			sort.Slice(result.Comments, func(i, j int) bool { return result.Comments[i].Line < result.Comments[j].Line })
+			for _, c := range result.Comments {
+				severity := models.SeverityInfo
+				if c.Level == "error" {
+					severity = models.SeverityCritical
+				} else if c.Level == "warning" {
+					severity = models.SeverityWarning
+				}
+				findings = append(findings, models.Finding{
+					Category: "syntax",
+					Severity: severity,
+					File:     path,
+					Line:     step.RunLine + c.Line - 1,
+					Message:  a.lang.Sprintf("shellcheck SC%d in step %q: %s", c.Code, step.DisplayName(), c.Message),
+				})
+			}
+		}
+	}
+	return findings
+}
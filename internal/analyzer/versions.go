@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+// VersionChannel selects which release line the suggested language versions follow
+type VersionChannel string
+
+const (
+	// ChannelLTS suggests long-term support lines where a language has them, e.g.
+	// even Node.js majors and Java 17 or 21
+	ChannelLTS VersionChannel = "lts"
+	// ChannelLatest suggests the newest stable release
+	ChannelLatest VersionChannel = "latest"
+)
+
+// maxListedReleases is the number of recent releases a version lookup looks through
+const maxListedReleases = 100
+
+// ParseVersionChannel parses the version_channel input, defaulting to lts
+func ParseVersionChannel(s string) (VersionChannel, error) {
+	switch VersionChannel(strings.ToLower(strings.TrimSpace(s))) {
+	case "", ChannelLTS:
+		return ChannelLTS, nil
+	case ChannelLatest:
+		return ChannelLatest, nil
+	default:
+		return "", fmt.Errorf("invalid version channel %q: expected lts or latest", s)
+	}
+}
+
+// WithVersionChannel sets the release line suggested versions follow
+func WithVersionChannel(channel VersionChannel) Option {
+	return func(a *Analyzer) {
+		a.versionChannel = channel
+	}
+}
+
+// semver is a release version parsed from a tag. Missing parts are zero.
+type semver struct {
+	major, minor, patch int
+	prerelease          bool
+}
+
+// versionTag matches the numbers of a tag once its prefix is trimmed. Ruby
+// separates them with underscores; anything else after them but build metadata
+// marks a prerelease, such as rc1, a1, -ea or _preview1.
+var versionTag = regexp.MustCompile(`^(\d+)(?:[._](\d+))?(?:[._](\d+))?(.*)$`)
+
+// parseSemver parses a tag such as go1.24.2, v22.11.0, jdk-21.0.5+11 or v3_3_6
+// once prefix is trimmed
+func parseSemver(tag, prefix string) (semver, bool) {
+	m := versionTag.FindStringSubmatch(strings.TrimPrefix(tag, prefix))
+	if m == nil {
+		return semver{}, false
+	}
+	var v semver
+	v.major, _ = strconv.Atoi(m[1])
+	v.minor, _ = strconv.Atoi(m[2])
+	v.patch, _ = strconv.Atoi(m[3])
+	v.prerelease = m[4] != "" && !strings.HasPrefix(m[4], "+")
+	return v, true
+}
+
+// less orders versions by their numbers
+func (v semver) less(o semver) bool {
+	if v.major != o.major {
+		return v.major < o.major
+	}
+	if v.minor != o.minor {
+		return v.minor < o.minor
+	}
+	return v.patch < o.patch
+}
+
+// languageReleases describes where a language releases and how its versions are
+// suggested: the repository, the tag prefix, whether only the major version is
+// used, the long-term support rule and the version used when the lookup fails
+type languageReleases struct {
+	owner, repo string
+	// tagged languages tag their versions without publishing GitHub releases
+	tagged bool
+	// endOfLife languages have neither in one repository, so their versions are
+	// looked up on endoflife.date even by the GitHub checker
+	endOfLife bool
+	prefix    string
+	majorOnly bool
+	// lts reports whether a release is on a long-term support line; nil when the
+	// language has none, so both channels suggest the newest release
+	lts      func(release *gh.RepositoryRelease, v semver) bool
+	fallback string
+}
+
+// versionSources are the languages whose versions are looked up
+var versionSources = map[string]languageReleases{
+	"go":     {owner: "golang", repo: "go", tagged: true, prefix: "go", fallback: "1.24"},
+	"python": {owner: "python", repo: "cpython", tagged: true, prefix: "v", fallback: "3.12"},
+	"ruby":   {owner: "ruby", repo: "ruby", prefix: "v", fallback: "3.2"},
+	"node": {owner: "nodejs", repo: "node", prefix: "v", fallback: "20.11",
+		// Release names mark LTS lines, e.g. "2024-11-20, Version 22.11.0 'Jod' (LTS)".
+		// Even majors only become LTS in October, six months after their release.
+		lts: func(release *gh.RepositoryRelease, v semver) bool {
+			return strings.Contains(release.GetName(), "(LTS)")
+		}},
+	// Temurin publishes each major version from its own repository
+	"java": {endOfLife: true, majorOnly: true, fallback: "17"},
+	"dotnet": {owner: "dotnet", repo: "core", prefix: "v", fallback: "7.0",
+		lts: func(release *gh.RepositoryRelease, v semver) bool {
+			return v.major%2 == 0
+		}},
+}
+
+// GitHubVersionChecker implements VersionChecker using GitHub API
+type GitHubVersionChecker struct {
+	client    GithubClient
+	channel   VersionChannel
+	endOfLife *EndOfLifeChecker // for the languages without GitHub releases or tags
+}
+
+// NewGitHubVersionChecker creates a GitHubVersionChecker suggesting versions on
+// channel. Languages GitHub has no versions of are looked up on endoflife.date
+// through hc, or http.DefaultClient when it is nil.
+func NewGitHubVersionChecker(client GithubClient, hc *http.Client, channel VersionChannel) *GitHubVersionChecker {
+	return &GitHubVersionChecker{client: client, channel: channel, endOfLife: NewEndOfLifeChecker(hc, channel)}
+}
+
+// GetLatestVersion retrieves the newest version of a language on the checker's
+// channel, leaving out prereleases. The language's fallback version is returned
+// when its releases can't be listed or none qualifies.
+func (g *GitHubVersionChecker) GetLatestVersion(ctx context.Context, lang string) (string, error) {
+	if lang == "rust" {
+		// Rust toolchains are installed by channel rather than version
+		return "stable", nil
+	}
+	source, ok := versionSources[lang]
+	if !ok {
+		return "", fmt.Errorf("unsupported language: %s", lang)
+	}
+	if source.endOfLife {
+		return g.endOfLife.GetLatestVersion(ctx, lang)
+	}
+
+	releases, err := g.releases(ctx, source)
+	if err != nil {
+		return source.fallback, nil
+	}
+	var best semver
+	found := false
+	for _, release := range releases {
+		if release.GetPrerelease() || release.GetDraft() {
+			continue
+		}
+		v, ok := parseSemver(release.GetTagName(), source.prefix)
+		if !ok || v.prerelease {
+			continue
+		}
+		if g.channel != ChannelLatest && source.lts != nil && !source.lts(release, v) {
+			continue
+		}
+		if !found || best.less(v) {
+			best, found = v, true
+		}
+	}
+	if !found {
+		return source.fallback, nil
+	}
+	if source.majorOnly {
+		return strconv.Itoa(best.major), nil
+	}
+	return fmt.Sprintf("%d.%d", best.major, best.minor), nil
+}
+
+// releases lists a language's recent releases, or its tags as releases for
+// tagged languages
+func (g *GitHubVersionChecker) releases(ctx context.Context, source languageReleases) ([]*gh.RepositoryRelease, error) {
+	if !source.tagged {
+		return g.client.ListReleases(ctx, source.owner, source.repo, maxListedReleases)
+	}
+	names, err := g.client.ListTagNames(ctx, source.owner, source.repo, source.prefix)
+	if err != nil {
+		return nil, err
+	}
+	releases := make([]*gh.RepositoryRelease, len(names))
+	for i, name := range names {
+		releases[i] = &gh.RepositoryRelease{TagName: gh.String(name)}
+	}
+	return releases, nil
+}
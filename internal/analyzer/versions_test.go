@@ -0,0 +1,159 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+func TestParseSemver(t *testing.T) {
+	tests := []struct {
+		tag, prefix string
+		want        semver
+		ok          bool
+	}{
+		{"go1.24.2", "go", semver{major: 1, minor: 24, patch: 2}, true},
+		{"go1.21", "go", semver{major: 1, minor: 21}, true},
+		{"go1.25rc1", "go", semver{major: 1, minor: 25, prerelease: true}, true},
+		{"v22.11.0", "v", semver{major: 22, minor: 11}, true},
+		{"v3.14.0a1", "v", semver{major: 3, minor: 14, prerelease: true}, true},
+		{"v3_3_6", "v", semver{major: 3, minor: 3, patch: 6}, true},
+		{"v3_4_0_preview1", "v", semver{major: 3, minor: 4, prerelease: true}, true},
+		{"jdk-21.0.5+11", "jdk-", semver{major: 21, patch: 5}, true},
+		{"jdk-23-ea", "jdk-", semver{major: 23, prerelease: true}, true},
+		{"weekly.2011-01-20", "go", semver{}, false},
+		{"release", "v", semver{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseSemver(tt.tag, tt.prefix)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseSemver(%q, %q) = %+v, %t, want %+v, %t", tt.tag, tt.prefix, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSemverLess(t *testing.T) {
+	tests := []struct {
+		a, b semver
+		want bool
+	}{
+		{semver{major: 1, minor: 9}, semver{major: 1, minor: 10}, true},
+		{semver{major: 2}, semver{major: 1, minor: 99}, false},
+		{semver{major: 3, minor: 1, patch: 2}, semver{major: 3, minor: 1, patch: 10}, true},
+		{semver{major: 3, minor: 1}, semver{major: 3, minor: 1}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.a.less(tt.b); got != tt.want {
+			t.Errorf("%+v.less(%+v) = %t, want %t", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseVersionChannel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    VersionChannel
+		wantErr bool
+	}{
+		{"", ChannelLTS, false},
+		{"lts", ChannelLTS, false},
+		{" Latest ", ChannelLatest, false},
+		{"stable", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersionChannel(tt.in)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("ParseVersionChannel(%q) = %q, %v, want %q, error %t", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// releaseClient serves releases and tags by repository, failing for others
+type releaseClient struct {
+	GithubClient
+	releases map[string][]*gh.RepositoryRelease
+	tags     map[string][]string
+}
+
+func (c releaseClient) ListReleases(ctx context.Context, owner, repo string, limit int) ([]*gh.RepositoryRelease, error) {
+	if releases, ok := c.releases[owner+"/"+repo]; ok {
+		return releases, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (c releaseClient) ListTagNames(ctx context.Context, owner, repo, prefix string) ([]string, error) {
+	if tags, ok := c.tags[owner+"/"+repo]; ok {
+		return tags, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func release(tag, name string, prerelease bool) *gh.RepositoryRelease {
+	return &gh.RepositoryRelease{TagName: gh.String(tag), Name: gh.String(name), Prerelease: gh.Bool(prerelease)}
+}
+
+func TestGitHubVersionChecker(t *testing.T) {
+	client := releaseClient{
+		releases: map[string][]*gh.RepositoryRelease{
+			"nodejs/node": {
+				release("v23.3.0", "2024-11-20, Version 23.3.0 (Current)", false),
+				release("v22.11.0", "2024-10-29, Version 22.11.0 'Jod' (LTS)", false),
+				release("v20.18.1", "2024-11-20, Version 20.18.1 'Iron' (LTS)", false),
+				release("v24.0.0-rc.1", "Version 24.0.0 RC", true),
+			},
+			"dotnet/core": {
+				release("v9.0.0", ".NET 9.0.0", false),
+				release("v8.0.11", ".NET 8.0.11", false),
+				release("v10.0.0-preview.1", ".NET 10 Preview 1", true),
+			},
+			"ruby/ruby": {
+				release("v3_3_6", "3.3.6", false),
+				release("v3_4_0_preview2", "3.4.0-preview2", false),
+			},
+		},
+		tags: map[string][]string{
+			"golang/go":      {"go1.22.10", "go1.23.4", "go1.24rc1", "go1.9"},
+			"python/cpython": {"v3.12.8", "v3.13.1", "v3.14.0a2", "v2.7.18"},
+		},
+	}
+	tests := []struct {
+		lang    string
+		channel VersionChannel
+		want    string
+	}{
+		{"node", ChannelLTS, "22.11"},
+		{"node", ChannelLatest, "23.3"},
+		{"dotnet", ChannelLTS, "8.0"},
+		{"dotnet", ChannelLatest, "9.0"},
+		{"ruby", ChannelLTS, "3.3"},
+		{"go", ChannelLTS, "1.23"},
+		{"python", ChannelLatest, "3.13"},
+		{"rust", ChannelLTS, "stable"},
+	}
+	for _, tt := range tests {
+		checker := NewGitHubVersionChecker(client, nil, tt.channel)
+		got, err := checker.GetLatestVersion(context.Background(), tt.lang)
+		if err != nil || got != tt.want {
+			t.Errorf("GetLatestVersion(%s) on %s = %q, %v, want %q", tt.lang, tt.channel, got, err, tt.want)
+		}
+	}
+}
+
+func TestGitHubVersionCheckerFallback(t *testing.T) {
+	checker := NewGitHubVersionChecker(releaseClient{}, nil, ChannelLTS)
+	for lang, source := range versionSources {
+		if source.endOfLife {
+			continue
+		}
+		got, err := checker.GetLatestVersion(context.Background(), lang)
+		if err != nil || got != source.fallback {
+			t.Errorf("GetLatestVersion(%s) = %q, %v, want the fallback %q", lang, got, err, source.fallback)
+		}
+	}
+	if _, err := checker.GetLatestVersion(context.Background(), "cobol"); err == nil {
+		t.Error("GetLatestVersion(cobol) succeeded, want an unsupported language error")
+	}
+}
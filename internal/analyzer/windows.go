@@ -0,0 +1,154 @@
+package analyzer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/workflow"
+)
+
+var (
+	// bareCommandFile matches a workflow command file read as a shell variable,
+	// e.g. >> $GITHUB_OUTPUT, which PowerShell only knows as $env:GITHUB_OUTPUT
+	bareCommandFile = regexp.MustCompile(`(^|[^:\w])\$\{?(GITHUB_OUTPUT|GITHUB_ENV|GITHUB_PATH|GITHUB_STEP_SUMMARY|GITHUB_STATE)\b`)
+	// windowsPathContext matches expressions that expand to a Windows path with
+	// backslashes on Windows runners
+	windowsPathContext = regexp.MustCompile(`\$\{\{\s*(github\.workspace|runner\.temp|runner\.tool_cache|runner\.workspace|github\.action_path)\s*\}\}`)
+	// lineEndingError matches the logs of tools tripping over CRLF line endings:
+	// git converting files on checkout, bash running a CRLF script, and linters
+	// and formatters expecting LF
+	lineEndingError = regexp.MustCompile(`LF will be replaced by CRLF|\$'\\r': command not found|bad interpreter: .*\^M|bash\\r|Delete ` + "`␍`" + `|Expected linebreaks to be 'LF' but found 'CRLF'`)
+)
+
+// autocrlfExample turns off git's line ending conversion on Windows runners
+// before the checkout
+const autocrlfExample = `      - name: Keep LF line endings
+        run: |
+          git config --global core.autocrlf false
+          git config --global core.eol lf
+      - uses: actions/checkout@v4`
+
+// stepShell returns the shell a run step uses, or "" for the runner's default
+func stepShell(wf *workflow.Workflow, job *workflow.Job, step *workflow.Step) string {
+	switch {
+	case step.Shell != "":
+		return step.Shell
+	case job.DefaultShell != "":
+		return job.DefaultShell
+	}
+	return wf.DefaultShell
+}
+
+// checkWindowsSteps flags run steps of Windows jobs that misread workflow
+// command files in PowerShell, and bash steps that interpolate Windows paths,
+// whose backslashes bash takes for escapes. Bash syntax run by PowerShell is
+// reported by checkShells.
+func (a *Analyzer) checkWindowsSteps(path string, wf *workflow.Workflow) []models.Finding {
+	var findings []models.Finding
+	for _, job := range wf.Jobs {
+		platforms, known := jobPlatforms(job)
+		if !known || !platforms["windows"] {
+			continue
+		}
+		for _, step := range job.Steps {
+			if step.Run == "" {
+				continue
+			}
+			shell := stepShell(wf, job, step)
+			name := ""
+			if fields := strings.Fields(shell); len(fields) > 0 {
+				name = fields[0]
+			}
+
+			switch {
+			case (name == "pwsh" || name == "powershell" || shell == "" && len(platforms) == 1 && !bashSyntax.MatchString(step.Run)) && bareCommandFile.MatchString(step.Run):
+				file := bareCommandFile.FindStringSubmatch(step.Run)[2]
+				findings = append(findings, models.Finding{
+					Category:   "reliability",
+					Severity:   models.SeverityWarning,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Step %q in job %s writes to $%s in PowerShell, where it's an unset variable, so the value is lost", step.DisplayName(), job.ID, file),
+					Suggestion: a.lang.Sprintf("Use $env:%s in PowerShell, or set shell: bash on the step", file),
+					Example:    fmt.Sprintf(`          "name=value" >> $env:%s`, file),
+				})
+			case (name == "bash" || name == "sh") && windowsPathContext.MatchString(step.Run):
+				expr := windowsPathContext.FindStringSubmatch(step.Run)[1]
+				variable := strings.ToUpper(strings.ReplaceAll(expr, ".", "_"))
+				if expr == "runner.workspace" {
+					variable = "RUNNER_WORKSPACE"
+				}
+				findings = append(findings, models.Finding{
+					Category:   "reliability",
+					Severity:   models.SeverityWarning,
+					File:       path,
+					Line:       step.Line,
+					Message:    a.lang.Sprintf("Step %q in job %s pastes ${{ %s }} into a bash script on Windows, where the path's backslashes are read as escapes", step.DisplayName(), job.ID, expr),
+					Suggestion: a.lang.Sprintf("Read the path from the quoted environment variable \"$%s\" instead, and convert it with cygpath -u for tools that expect a POSIX path", variable),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// checkLineEndings reports Windows jobs whose logs show git converting line
+// endings to CRLF on checkout, or scripts, linters and formatters failing on
+// CRLF line endings
+func (a *Analyzer) checkLineEndings(path string, wf *workflow.Workflow, samples []runSample) []models.Finding {
+	var windowsJobs []*workflow.Job
+	for _, job := range wf.Jobs {
+		if platforms, known := jobPlatforms(job); known && platforms["windows"] {
+			windowsJobs = append(windowsJobs, job)
+		}
+	}
+	if len(windowsJobs) == 0 {
+		return nil
+	}
+
+	runs := make(map[*workflow.Job]int)
+	urls := make(map[*workflow.Job]string)
+	for _, sample := range samples {
+		seen := make(map[*workflow.Job]bool)
+		for _, line := range strings.Split(sample.Logs, "\n") {
+			t, message, ok := logTime(strings.TrimRight(line, "\r"))
+			if !lineEndingError.MatchString(message) {
+				continue
+			}
+			apiJob := jobAt(sample.Jobs, t)
+			if !ok || apiJob == nil {
+				continue
+			}
+			for _, job := range windowsJobs {
+				if matchesJob(apiJob, job) && !seen[job] {
+					seen[job] = true
+					runs[job]++
+					if urls[job] == "" {
+						urls[job] = apiJob.GetHTMLURL()
+					}
+				}
+			}
+		}
+	}
+
+	var findings []models.Finding
+	for _, job := range windowsJobs {
+		if runs[job] == 0 {
+			continue
+		}
+		findings = append(findings, models.Finding{
+			Category:   "reliability",
+			Severity:   models.SeverityWarning,
+			File:       path,
+			Line:       job.Line,
+			Message:    a.lang.Sprintf("Job %s checks out files with CRLF line endings on Windows, and the logs of %d of %d runs show scripts or linters tripping over them", job.ID, runs[job], len(samples)),
+			Suggestion: a.lang.T("Git converts line endings on checkout because core.autocrlf is true on Windows runners; commit a .gitattributes with * text=auto eol=lf, or turn the conversion off before actions/checkout"),
+			Example:    autocrlfExample,
+			URL:        urls[job],
+			Confidence: logConfidence(runs[job], samples),
+		})
+	}
+	return findings
+}
@@ -0,0 +1,58 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// AnalyzeWorkflows analyzes each workflow file and combines the results into one
+// report, listing a finding shared by several workflows once with all its
+// locations. A single file is analyzed exactly like Analyze. With WithDigest, the
+// report also carries what changed since the previous analysis of the same files,
+// and with WithAdoptionTracking, the recommendations adopted since. A workflow
+// whose analysis fails is listed among the skipped stages of the partial report
+// of the others; only when none can be analyzed does the analysis fail.
+func (a *Analyzer) AnalyzeWorkflows(ctx context.Context, owner, repo string, files []string) (*models.PerformanceReport, error) {
+	var reports []*models.PerformanceReport
+	var failed []string
+	var lastErr error
+	for _, file := range files {
+		report, err := a.Analyze(ctx, owner, repo, file)
+		if err != nil {
+			if len(files) == 1 {
+				return nil, err
+			}
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("%s: %v", file, err)
+			}
+			fmt.Fprintf(a.progressOf(ctx), "Warning: skipping %s: %v\n", file, err)
+			failed, lastErr = append(failed, file), err
+			continue
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) == 0 {
+		if lastErr != nil {
+			return nil, fmt.Errorf("every workflow failed to analyze, the last one with: %v", lastErr)
+		}
+		return nil, fmt.Errorf("no workflow files to analyze")
+	}
+	report := models.MergeReports(reports)
+	if len(failed) > 0 {
+		report.Partial = true
+		report.SkippedStages = append(report.SkippedStages, failed...)
+	}
+	if a.digest != "" {
+		if err := a.applyDigest(owner, repo, files, report); err != nil {
+			a.debugLog("Warning: %v", err)
+		}
+	}
+	if a.adoption != "" {
+		if err := a.trackAdoption(owner, repo, files, report); err != nil {
+			a.debugLog("Warning: %v", err)
+		}
+	}
+	return report, nil
+}
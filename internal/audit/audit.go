@@ -0,0 +1,184 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Analyzer is the part of the analyzer an audit needs
+type Analyzer interface {
+	AnalyzeWorkflows(ctx context.Context, owner, repo string, files []string) (*models.PerformanceReport, error)
+}
+
+// Auditor analyzes the same workflows across many repositories on a fixed number
+// of workers. A failing repository is recorded and skipped, never stopping the
+// others.
+type Auditor struct {
+	analyzer   Analyzer
+	workers    int
+	files      []string
+	checkpoint *Checkpoint
+	outputDir  string
+	progress   io.Writer
+
+	// onReport, when set, is called with each finished report, e.g. to upload it
+	onReport func(ctx context.Context, report *models.PerformanceReport)
+}
+
+// Option configures optional Auditor behaviour
+type Option func(*Auditor)
+
+// WithReportHook calls fn with the report of every repository audited successfully
+func WithReportHook(fn func(ctx context.Context, report *models.PerformanceReport)) Option {
+	return func(a *Auditor) {
+		a.onReport = fn
+	}
+}
+
+// New creates an auditor analyzing files in each repository with at most workers
+// analyses at once. Reports are written to outputDir as <owner>/<repo>.json.
+func New(analyzer Analyzer, workers int, files []string, checkpoint *Checkpoint, outputDir string, opts ...Option) *Auditor {
+	a := &Auditor{
+		analyzer:   analyzer,
+		workers:    max(workers, 1),
+		files:      files,
+		checkpoint: checkpoint,
+		outputDir:  outputDir,
+		progress:   os.Stdout,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Summary counts the outcome of an audit
+type Summary struct {
+	Succeeded int
+	Failed    int
+	Skipped   int // already audited according to the checkpoint
+}
+
+// Run audits repos (owner/repo) until all are done or ctx is cancelled.
+// Repositories the checkpoint lists as done, and repeated ones, are skipped.
+func (a *Auditor) Run(ctx context.Context, repos []string) (Summary, error) {
+	var summary Summary
+	var mu sync.Mutex
+	queue := make(chan string)
+
+	var wg sync.WaitGroup
+	for i := 0; i < a.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range queue {
+				// Progress is buffered per repository and printed once it is
+				// done, so the stage groups of concurrent analyses never interleave
+				var progress bytes.Buffer
+				result := a.audit(analyzer.ContextWithProgress(ctx, &progress), repo)
+				mu.Lock()
+				fmt.Fprintf(a.progress, "Progress of %s:\n", repo)
+				progress.WriteTo(a.progress)
+				mu.Unlock()
+				// A cancelled audit resumes with this repository instead of recording it as failed
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := a.checkpoint.Record(repo, result); err != nil {
+					log.Printf("Warning: %v", err)
+				}
+				mu.Lock()
+				if result.Status == StatusSucceeded {
+					summary.Succeeded++
+					log.Printf("Audited %s: %d findings", repo, result.Findings)
+				} else {
+					summary.Failed++
+					log.Printf("Warning: audit of %s failed: %s", repo, result.Error)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		if seen[repo] {
+			continue
+		}
+		seen[repo] = true
+		if a.checkpoint.Done(repo) {
+			summary.Skipped++
+			continue
+		}
+		select {
+		case queue <- repo:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return summary, ctx.Err()
+}
+
+// audit analyzes one repository, turning errors and panics into a failed result
+func (a *Auditor) audit(ctx context.Context, repository string) (result Result) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Panic auditing %s: %v\n%s", repository, r, debug.Stack())
+			result = Result{Status: StatusFailed, Error: fmt.Sprintf("panic: %v", r), FinishedAt: time.Now()}
+		}
+	}()
+
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" {
+		return Result{Status: StatusFailed, Error: fmt.Sprintf("invalid repository %q, expected owner/repo", repository), FinishedAt: time.Now()}
+	}
+
+	report, err := a.analyzer.AnalyzeWorkflows(ctx, owner, repo, a.files)
+	if err != nil {
+		return Result{Status: StatusFailed, Error: err.Error(), FinishedAt: time.Now()}
+	}
+
+	path, err := a.save(owner, repo, report)
+	if err != nil {
+		return Result{Status: StatusFailed, Error: err.Error(), FinishedAt: time.Now()}
+	}
+	if a.onReport != nil {
+		a.onReport(ctx, report)
+	}
+	return Result{Status: StatusSucceeded, Report: path, Findings: len(report.Findings), FinishedAt: time.Now()}
+}
+
+// save writes a repository's report as JSON under the output directory
+func (a *Auditor) save(owner, repo string, report *models.PerformanceReport) (string, error) {
+	dir := filepath.Join(a.outputDir, owner)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", dir, err)
+	}
+	path := filepath.Join(dir, repo+".json")
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to write report: %v", err)
+	}
+	defer f.Close()
+	if err := (models.JSONRenderer{Indent: true}).Render(f, report); err != nil {
+		return "", fmt.Errorf("failed to write report: %v", err)
+	}
+	return path, nil
+}
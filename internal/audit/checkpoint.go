@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Result statuses recorded in the checkpoint
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Result is the outcome of auditing one repository
+type Result struct {
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	Report     string    `json:"report,omitempty"` // path of the JSON report
+	Findings   int       `json:"findings"`
+	FinishedAt time.Time `json:"finished_at"`
+}
+
+// Checkpoint records the repositories an audit has finished, so a crashed or
+// cancelled audit resumes with the repositories it hadn't finished yet. It is
+// rewritten after every repository.
+type Checkpoint struct {
+	path string
+	mu   sync.Mutex
+
+	Repositories map[string]Result `json:"repositories"`
+}
+
+// LoadCheckpoint reads the checkpoint at path; a missing file starts an empty one
+func LoadCheckpoint(path string) (*Checkpoint, error) {
+	c := &Checkpoint{path: path, Repositories: make(map[string]Result)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %v", err)
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint %s: %v", path, err)
+	}
+	if c.Repositories == nil {
+		c.Repositories = make(map[string]Result)
+	}
+	return c, nil
+}
+
+// Done reports whether repo was already audited successfully; failed
+// repositories are retried
+func (c *Checkpoint) Done(repo string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.Repositories[repo].Status == StatusSucceeded
+}
+
+// Record stores a repository's result and saves the checkpoint
+func (c *Checkpoint) Record(repo string, result Result) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Repositories[repo] = result
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash never leaves a truncated checkpoint
+	if dir := filepath.Dir(c.path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create %s: %v", dir, err)
+		}
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %v", err)
+	}
+	return nil
+}
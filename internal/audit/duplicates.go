@@ -0,0 +1,21 @@
+package audit
+
+import (
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// WriteDuplicates finds near-identical workflows across the repositories the
+// checkpoint lists and writes them, with a plan to consolidate each cluster into
+// a reusable workflow, to duplicates.json in the output directory. It returns
+// the path written, or "" when no workflow has a copy in another repository.
+func (a *Auditor) WriteDuplicates() (string, error) {
+	reports, err := a.reports()
+	if err != nil {
+		return "", err
+	}
+	clusters := models.DuplicateClusters(reports)
+	if len(clusters) == 0 {
+		return "", nil
+	}
+	return a.writeJSON("duplicates.json", clusters)
+}
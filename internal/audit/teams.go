@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// WriteTeams groups the findings and cost of every report the checkpoint lists,
+// including those of earlier runs of the audit, by the teams CODEOWNERS assigns
+// them to, and writes the summary to teams.json in the output directory. It
+// returns the path written, or "" when no repository has a CODEOWNERS file.
+func (a *Auditor) WriteTeams() (string, error) {
+	reports, err := a.reports()
+	if err != nil {
+		return "", err
+	}
+	teams := models.TeamSummaries(reports)
+	if teams == nil {
+		return "", nil
+	}
+	return a.writeJSON("teams.json", teams)
+}
+
+// reports reads the reports of every repository the checkpoint lists as audited
+func (a *Auditor) reports() ([]*models.PerformanceReport, error) {
+	a.checkpoint.mu.Lock()
+	var paths []string
+	for _, result := range a.checkpoint.Repositories {
+		if result.Status == StatusSucceeded && result.Report != "" {
+			paths = append(paths, result.Report)
+		}
+	}
+	a.checkpoint.mu.Unlock()
+	sort.Strings(paths)
+
+	var reports []*models.PerformanceReport
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report: %v", err)
+		}
+		var report models.PerformanceReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			return nil, fmt.Errorf("failed to parse report %s: %v", path, err)
+		}
+		reports = append(reports, &report)
+	}
+	return reports, nil
+}
+
+// writeJSON writes v as indented JSON to name in the output directory
+func (a *Auditor) writeJSON(name string, v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(a.outputDir, name)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", name, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+)
+
+// analysisDepth validates the analysis_depth input, zero when unset
+func (s *inputSet) analysisDepth() int {
+	v := s.get("analysis_depth")
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		s.invalid("analysis_depth", "must be a positive integer, got %q", v)
+	}
+	return n
+}
+
+// timeout validates the timeout input given in minutes, zero when unset
+func (s *inputSet) timeout() time.Duration {
+	v := s.get("timeout")
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		s.invalid("timeout", "must be a positive number of minutes, got %q", v)
+	}
+	return time.Duration(n) * time.Minute
+}
+
+// mode validates the mode input
+func (s *inputSet) mode() analyzer.Mode {
+	mode, err := analyzer.ParseMode(s.get("mode"))
+	if err != nil {
+		s.invalid("mode", "must be survey or deep, got %q", s.get("mode"))
+	}
+	return mode
+}
+
+// lang validates the lang input
+func (s *inputSet) lang() i18n.Lang {
+	lang, err := i18n.ParseLang(s.get("lang"))
+	if err != nil {
+		s.invalid("lang", "must be en, ko or ja, got %q", s.get("lang"))
+	}
+	return lang
+}
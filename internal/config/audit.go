@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+	"github.com/somaz94/github-action-analyzer/internal/storage"
+)
+
+const (
+	// defaultAuditWorkers is the number of repositories audited at once
+	defaultAuditWorkers = 4
+	// defaultRateLimit stays under the 5,000 requests per hour of a token,
+	// leaving room for other tools sharing it
+	defaultRateLimit = 4000
+	// defaultCheckpoint is where audit progress is kept when checkpoint is unset
+	defaultCheckpoint = "audit-checkpoint.json"
+	// defaultAuditOutput is where audit reports are written when output_dir is unset
+	defaultAuditOutput = "audit-reports"
+)
+
+// AuditConfig holds the validated inputs of audit mode
+type AuditConfig struct {
+	Token         string
+	Repositories  []string
+	Organization  string
+	WorkflowFiles []string
+	Workers       int
+	RateLimit     int // requests per hour across all workers
+	Checkpoint    string
+	OutputDir     string
+	Upload        *storage.Location
+	Debug         bool
+	AnalysisDepth int
+	Timeout       time.Duration
+	Mode          analyzer.Mode
+	Lang          i18n.Lang
+	APIURL        string
+	CABundle      string
+}
+
+// auditInputs lists the inputs of audit mode; analysis settings apply to every repository
+func auditInputs() []*input {
+	return append([]*input{
+		{name: "github_token", usage: "GitHub token for API access", fallback: "GITHUB_TOKEN"},
+		{name: "repositories", usage: "comma-separated repositories to audit (owner/repo)"},
+		{name: "organization", usage: "audit every unarchived repository of this organization"},
+		{name: "workflow_file", usage: "workflow file to analyze in each repository, or a comma-separated list"},
+		{name: "workers", usage: "number of repositories to audit at once"},
+		{name: "rate_limit", usage: "GitHub API requests per hour across all workers"},
+		{name: "checkpoint", usage: "file recording finished repositories, to resume an interrupted audit"},
+		{name: "output_dir", usage: "directory for the JSON report of each repository"},
+		{name: "upload_url", usage: "s3:// or gs:// bucket URL to also upload each report to"},
+		{name: "debug", usage: "enable debug mode (true/false)", fallback: "DEBUG"},
+		{name: "analysis_depth", usage: "number of workflow runs to analyze"},
+		{name: "timeout", usage: "analysis timeout per repository in minutes", fallback: "TIMEOUT"},
+		{name: "mode", usage: "analysis mode: survey or deep"},
+		{name: "lang", usage: "report language: en, ko or ja"},
+	}, networkInputs()...)
+}
+
+// LoadAudit reads and validates the inputs of audit mode like Load
+func LoadAudit(args []string) (*AuditConfig, error) {
+	s, err := parseInputs("analyzer audit", auditInputs(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &AuditConfig{
+		Token:        s.get("github_token"),
+		Organization: s.get("organization"),
+		Workers:      defaultAuditWorkers,
+		RateLimit:    defaultRateLimit,
+		Checkpoint:   s.get("checkpoint"),
+		OutputDir:    s.get("output_dir"),
+		Debug:        s.boolean("debug"),
+		APIURL:       s.apiURL(),
+		CABundle:     s.caBundle(),
+	}
+	if cfg.Token == "" {
+		s.invalid("github_token", "is required (set GITHUB_TOKEN or pass -github-token)")
+	}
+	if cfg.Checkpoint == "" {
+		cfg.Checkpoint = defaultCheckpoint
+	}
+	if cfg.OutputDir == "" {
+		cfg.OutputDir = defaultAuditOutput
+	}
+
+	for _, repository := range strings.Split(s.get("repositories"), ",") {
+		repository = strings.TrimSpace(repository)
+		if repository == "" {
+			continue
+		}
+		if parts := strings.Split(repository, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			s.invalid("repositories", "must be comma-separated owner/repo names, got %q", repository)
+			continue
+		}
+		cfg.Repositories = append(cfg.Repositories, repository)
+	}
+	if len(cfg.Repositories) == 0 && cfg.Organization == "" {
+		s.invalid("repositories", "is required unless organization is set")
+	}
+
+	for _, file := range strings.Split(s.get("workflow_file"), ",") {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		if !strings.HasSuffix(file, ".yml") && !strings.HasSuffix(file, ".yaml") {
+			s.invalid("workflow_file", "must be a .yml or .yaml file, got %q", file)
+			continue
+		}
+		cfg.WorkflowFiles = append(cfg.WorkflowFiles, file)
+	}
+	if len(cfg.WorkflowFiles) == 0 {
+		s.invalid("workflow_file", "is required (e.g. ci.yml)")
+	}
+
+	if v := s.get("workers"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			s.invalid("workers", "must be a positive integer, got %q", v)
+		}
+		cfg.Workers = n
+	}
+
+	if v := s.get("rate_limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			s.invalid("rate_limit", "must be a positive number of requests per hour, got %q", v)
+		}
+		cfg.RateLimit = n
+	}
+
+	if v := s.get("upload_url"); v != "" {
+		loc, err := storage.ParseLocation(v)
+		if err != nil {
+			s.invalid("upload_url", "must be an s3://bucket/prefix or gs://bucket/prefix URL, got %q (%v)", v, err)
+		}
+		cfg.Upload = loc
+	}
+
+	cfg.AnalysisDepth = s.analysisDepth()
+	cfg.Timeout = s.timeout()
+	cfg.Mode = s.mode()
+	cfg.Lang = s.lang()
+
+	if len(s.errs) > 0 {
+		return nil, errors.Join(s.errs...)
+	}
+	return cfg, nil
+}
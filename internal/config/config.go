@@ -0,0 +1,439 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/policy"
+	"github.com/somaz94/github-action-analyzer/internal/pricing"
+	"github.com/somaz94/github-action-analyzer/internal/storage"
+	"github.com/somaz94/github-action-analyzer/internal/telemetry"
+)
+
+// DefaultLogCacheDir is where job logs are kept when the analyzer runs outside
+// GitHub Actions
+const DefaultLogCacheDir = ".analyzer"
+
+// Config holds the validated action inputs
+type Config struct {
+	Token            string
+	Owner            string
+	Repo             string
+	WorkflowFile     string
+	WorkflowFiles    []string // workflow_file split on commas
+	Debug            bool
+	AnalysisDepth    int
+	AnalyzeDepth     int
+	Timeout          time.Duration
+	StageTimeouts    analyzer.StageBudgets
+	Sample           analyzer.Sampling
+	Mode             analyzer.Mode
+	DryRun           bool
+	Lang             i18n.Lang
+	PlainOutput      bool
+	Audience         string
+	DiffMode         bool
+	Sustainability   bool
+	StyleChecks      bool
+	MinConfidence    float64
+	MaskedRate       float64 // masked_failure_rate
+	VersionChannel   analyzer.VersionChannel
+	VersionSource    analyzer.VersionSource
+	Policy           *policy.Policy
+	RegoPolicies     *policy.Rego
+	Pricing          *pricing.Pricing
+	FailOnPolicy     bool
+	CarbonIntensity  float64
+	CacheDir         string
+	CacheResults     bool
+	Digest           bool
+	TrackAdoption    bool
+	LogCacheDir      string
+	DeployWorkflows  []string
+	Upload           *storage.Location
+	Exporters        []string // otlp, datadog or honeycomb
+	OTLP             *telemetry.Endpoint
+	DatadogAPIKey    string
+	DatadogSite      string
+	HoneycombAPIKey  string
+	HoneycombDataset string
+	Outputs          []models.Target
+	OutputEncoding   string
+	APIURL           string
+	CABundle         string
+}
+
+// InputError describes a single missing or invalid input
+type InputError struct {
+	Input   string
+	Message string
+}
+
+func (e *InputError) Error() string {
+	return fmt.Sprintf("input %q %s", e.Input, e.Message)
+}
+
+// input is one action input, readable from INPUT_<NAME> or a -<name> flag
+type input struct {
+	name     string
+	usage    string
+	fallback string // plain env var used when INPUT_<NAME> is unset
+	value    string
+}
+
+// inputs lists every supported input in action.yml order
+func inputs() []*input {
+	return append([]*input{
+		{name: "github_token", usage: "GitHub token for API access", fallback: "GITHUB_TOKEN"},
+		{name: "workflow_file", usage: "workflow file to analyze, e.g. ci.yml, or a comma-separated list"},
+		{name: "repository", usage: "repository to analyze (owner/repo)", fallback: "GITHUB_REPOSITORY"},
+		{name: "debug", usage: "enable debug mode (true/false)", fallback: "DEBUG"},
+		{name: "analysis_depth", usage: "number of workflow runs to analyze"},
+		{name: "analyze_depth", usage: "levels of called reusable workflows and actions to resolve and check, 0 to 5"},
+		{name: "sample", usage: "runs to analyze in depth: latest:N, random:N or per-branch:N"},
+		{name: "timeout", usage: "analysis timeout in minutes", fallback: "TIMEOUT"},
+		{name: "stage_timeouts", usage: "per-stage budgets in minutes, e.g. runs=10,logs=30,files=5"},
+		{name: "mode", usage: "analysis mode: survey or deep"},
+		{name: "dry_run", usage: "print the planned API calls only (true/false)"},
+		{name: "lang", usage: "report language: en, ko or ja"},
+		{name: "plain_output", usage: "render the report without emoji (true/false)"},
+		{name: "audience", usage: "who the report is for: developer, manager or security"},
+		{name: "diff_mode", usage: "review only workflow files changed by the pull request (true/false)"},
+		{name: "sustainability", usage: "add an energy and CO2 estimate (true/false)"},
+		{name: "style_checks", usage: "add the optional style rules (true/false)"},
+		{name: "version_channel", usage: "release line of suggested language versions: lts or latest"},
+		{name: "version_source", usage: "where language versions come from: github or endoflife (endoflife.date)"},
+		{name: "policy_file", usage: "YAML policy of allowed runner labels, actions and permissions to enforce"},
+		{name: "policy_dir", usage: "directory of custom Rego policies (package analyzer) to evaluate"},
+		{name: "fail_on_policy_violation", usage: "exit non-zero when the policy is violated (true/false)"},
+		{name: "carbon_intensity", usage: "grid carbon intensity in gCO2e/kWh"},
+		{name: "min_confidence", usage: "drop findings drawn from job logs below this confidence, from 0 to 1"},
+		{name: "masked_failure_rate", usage: "share of successful runs a continue-on-error step must fail in to be reported, from 0 to 1"},
+		{name: "pricing_file", usage: "YAML table of per-minute runner prices for the cost estimate, e.g. for self-hosted runners"},
+		{name: "cache_dir", usage: "directory for the API response cache kept between runs"},
+		{name: "cache_results", usage: "reuse the last report while the workflow and its runs are unchanged (true/false)"},
+		{name: "digest", usage: "report only the changes since the previous analysis recorded in cache_dir (true/false)"},
+		{name: "track_adoption", usage: "report the recommendations adopted since the previous analysis recorded in cache_dir (true/false)"},
+		{name: "log_cache_dir", usage: "directory to keep downloaded job logs in, or off (default: " + DefaultLogCacheDir + " outside GitHub Actions)"},
+		{name: "deploy_workflows", usage: "comma-separated deploy workflow files for the DORA metrics"},
+		{name: "upload_url", usage: "s3:// or gs:// bucket URL to upload the JSON and text reports to"},
+		{name: "exporters", usage: "comma-separated backends to export the analyzed runs to: otlp, datadog or honeycomb"},
+		{name: "otlp_endpoint", usage: "OTLP/HTTP collector URL to export the analyzed runs to as traces", fallback: "OTEL_EXPORTER_OTLP_ENDPOINT"},
+		{name: "otlp_headers", usage: "comma-separated key=value headers for the OTLP collector, e.g. an API key", fallback: "OTEL_EXPORTER_OTLP_HEADERS"},
+		{name: "datadog_api_key", usage: "Datadog API key for the datadog exporter", fallback: "DD_API_KEY"},
+		{name: "datadog_site", usage: "Datadog site for the datadog exporter, e.g. datadoghq.eu (default: " + telemetry.DefaultDatadogSite + ")", fallback: "DD_SITE"},
+		{name: "honeycomb_api_key", usage: "Honeycomb API key for the honeycomb exporter", fallback: "HONEYCOMB_API_KEY"},
+		{name: "honeycomb_dataset", usage: "Honeycomb dataset for the honeycomb exporter (default: " + telemetry.DefaultHoneycombDataset + ")"},
+		{name: "report_outputs", usage: "comma-separated report outputs as format or format:path, e.g. console,markdown:report.md"},
+		{name: "output_encoding", usage: "step output encoding: raw or base64"},
+	}, networkInputs()...)
+}
+
+// inputSet holds parsed input values and the problems found validating them
+type inputSet struct {
+	values map[string]*input
+	args   []string // arguments left after the flags
+	errs   []error
+}
+
+// parseInputs reads inputs from INPUT_* environment variables and lets
+// command-line flags override them
+func parseInputs(name string, list []*input, args []string) (*inputSet, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	s := &inputSet{values: make(map[string]*input)}
+	for _, in := range list {
+		in.value = os.Getenv("INPUT_" + strings.ToUpper(in.name))
+		if in.value == "" && in.fallback != "" {
+			in.value = os.Getenv(in.fallback)
+		}
+		fs.StringVar(&in.value, strings.ReplaceAll(in.name, "_", "-"), in.value, in.usage)
+		s.values[in.name] = in
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	s.args = fs.Args()
+	return s, nil
+}
+
+// get returns an input's trimmed value
+func (s *inputSet) get(name string) string {
+	return strings.TrimSpace(s.values[name].value)
+}
+
+// invalid records a problem with an input
+func (s *inputSet) invalid(name, format string, args ...interface{}) {
+	s.errs = append(s.errs, &InputError{Input: name, Message: fmt.Sprintf(format, args...)})
+}
+
+// boolean parses a true/false input, defaulting to false
+func (s *inputSet) boolean(name string) bool {
+	v := s.get(name)
+	if v == "" {
+		return false
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		s.invalid(name, "must be true or false, got %q", v)
+	}
+	return b
+}
+
+// Load reads inputs from INPUT_* environment variables, lets command-line flags
+// override them and validates the result. All problems are reported together.
+func Load(args []string) (*Config, error) {
+	s, err := parseInputs("analyzer", inputs(), args)
+	if err != nil {
+		return nil, err
+	}
+	get, invalid, boolean := s.get, s.invalid, s.boolean
+
+	cfg := &Config{
+		Token:          get("github_token"),
+		WorkflowFile:   get("workflow_file"),
+		Debug:          boolean("debug"),
+		DryRun:         boolean("dry_run"),
+		PlainOutput:    boolean("plain_output"),
+		DiffMode:       boolean("diff_mode"),
+		Sustainability: boolean("sustainability"),
+		StyleChecks:    boolean("style_checks"),
+		FailOnPolicy:   boolean("fail_on_policy_violation"),
+		CacheDir:       get("cache_dir"),
+		CacheResults:   boolean("cache_results"),
+		Digest:         boolean("digest"),
+		TrackAdoption:  boolean("track_adoption"),
+		APIURL:         s.apiURL(),
+		CABundle:       s.caBundle(),
+		AnalyzeDepth:   analyzer.DefaultAnalyzeDepth,
+		MaskedRate:     analyzer.DefaultMaskedFailureRate,
+	}
+
+	// Public repositories can be analyzed anonymously, but reviews need a token
+	if cfg.Token == "" && cfg.DiffMode {
+		invalid("github_token", "is required with diff_mode to review the pull request (pass ${{ secrets.GITHUB_TOKEN }} or a personal access token)")
+	}
+
+	if repository := get("repository"); repository == "" {
+		invalid("repository", "is required (format: owner/repo)")
+	} else if parts := strings.Split(repository, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		invalid("repository", "must have the format owner/repo, got %q", repository)
+	} else {
+		cfg.Owner, cfg.Repo = parts[0], parts[1]
+	}
+
+	if cfg.WorkflowFile == "" && !cfg.DiffMode {
+		invalid("workflow_file", "is required unless diff_mode is true (e.g. ci.yml or .github/workflows/ci.yml)")
+	} else if cfg.WorkflowFile != "" {
+		for _, file := range strings.Split(cfg.WorkflowFile, ",") {
+			file = strings.TrimSpace(file)
+			if !strings.HasSuffix(file, ".yml") && !strings.HasSuffix(file, ".yaml") {
+				invalid("workflow_file", "must be a .yml or .yaml file, got %q", file)
+				continue
+			}
+			cfg.WorkflowFiles = append(cfg.WorkflowFiles, file)
+		}
+		if len(cfg.WorkflowFiles) > 1 && cfg.DryRun {
+			invalid("workflow_file", "must be a single file with dry_run, got %q", cfg.WorkflowFile)
+		}
+	}
+
+	cfg.AnalysisDepth = s.analysisDepth()
+	if v := get("analyze_depth"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 || n > analyzer.MaxAnalyzeDepth {
+			invalid("analyze_depth", "must be an integer from 0 to %d, got %q", analyzer.MaxAnalyzeDepth, v)
+		}
+		cfg.AnalyzeDepth = n
+	}
+
+	cfg.Timeout = s.timeout()
+
+	sample, err := analyzer.ParseSampling(get("sample"))
+	if err != nil {
+		invalid("sample", "must be latest:N, random:N or per-branch:N with a positive N (e.g. random:20), got %q", get("sample"))
+	}
+	cfg.Sample = sample
+
+	budgets, err := analyzer.ParseStageBudgets(get("stage_timeouts"))
+	if err != nil {
+		invalid("stage_timeouts", "must be comma-separated group=minutes pairs for runs, logs and files (e.g. runs=10,logs=30,files=5), got %q", get("stage_timeouts"))
+	}
+	cfg.StageTimeouts = budgets
+
+	cfg.Mode = s.mode()
+
+	channel, err := analyzer.ParseVersionChannel(get("version_channel"))
+	if err != nil {
+		invalid("version_channel", "must be lts or latest, got %q", get("version_channel"))
+	}
+	cfg.VersionChannel = channel
+
+	source, err := analyzer.ParseVersionSource(get("version_source"))
+	if err != nil {
+		invalid("version_source", "must be github or endoflife, got %q", get("version_source"))
+	}
+	cfg.VersionSource = source
+
+	cfg.Lang = s.lang()
+
+	switch v := get("audience"); v {
+	case "", models.AudienceDeveloper, models.AudienceManager, models.AudienceSecurity:
+		cfg.Audience = v
+	default:
+		invalid("audience", "must be %s, got %q", strings.Join(models.Audiences(), ", "), v)
+	}
+
+	if v := get("policy_file"); v != "" {
+		p, err := policy.Load(v)
+		if err != nil {
+			invalid("policy_file", "must be a readable policy file, got %q (%v)", v, err)
+		}
+		cfg.Policy = p
+	}
+	if v := get("policy_dir"); v != "" {
+		r, err := policy.LoadRego(context.Background(), v)
+		if err != nil {
+			invalid("policy_dir", "must be a directory of valid Rego policies, got %q (%v)", v, err)
+		}
+		cfg.RegoPolicies = r
+	}
+	if cfg.FailOnPolicy && cfg.Policy == nil && cfg.RegoPolicies == nil {
+		invalid("fail_on_policy_violation", "requires policy_file or policy_dir")
+	}
+
+	if v := get("pricing_file"); v != "" {
+		p, err := pricing.Load(v)
+		if err != nil {
+			invalid("pricing_file", "must be a readable pricing file, got %q (%v)", v, err)
+		}
+		cfg.Pricing = p
+	}
+
+	if cfg.CacheResults && cfg.CacheDir == "" {
+		invalid("cache_results", "requires cache_dir to keep the reports in")
+	}
+	if cfg.Digest && cfg.CacheDir == "" {
+		invalid("digest", "requires cache_dir to keep the previous analysis in")
+	}
+	if cfg.TrackAdoption && cfg.CacheDir == "" {
+		invalid("track_adoption", "requires cache_dir to keep the previous findings in")
+	}
+
+	// Local runs keep job logs, so analyzing again with other settings doesn't
+	// download them again; on GitHub's runners the workspace is thrown away
+	switch v := get("log_cache_dir"); {
+	case v == "off":
+	case v != "":
+		cfg.LogCacheDir = v
+	case os.Getenv("GITHUB_ACTIONS") != "true":
+		cfg.LogCacheDir = DefaultLogCacheDir
+	}
+
+	if v := get("carbon_intensity"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f <= 0 {
+			invalid("carbon_intensity", "must be a positive number of gCO2e/kWh, got %q", v)
+		}
+		cfg.CarbonIntensity = f
+	}
+
+	if v := get("min_confidence"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f > 1 {
+			invalid("min_confidence", "must be a number from 0 to 1, got %q", v)
+		}
+		cfg.MinConfidence = f
+	}
+
+	if v := get("masked_failure_rate"); v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 || f >= 1 {
+			invalid("masked_failure_rate", "must be a number from 0 up to but not including 1, got %q", v)
+		}
+		cfg.MaskedRate = f
+	}
+
+	for _, file := range strings.Split(get("deploy_workflows"), ",") {
+		if file = strings.TrimSpace(file); file == "" {
+			continue
+		}
+		if !strings.HasSuffix(file, ".yml") && !strings.HasSuffix(file, ".yaml") {
+			invalid("deploy_workflows", "must list .yml or .yaml files, got %q", file)
+		}
+		cfg.DeployWorkflows = append(cfg.DeployWorkflows, file)
+	}
+
+	if v := get("upload_url"); v != "" {
+		loc, err := storage.ParseLocation(v)
+		if err != nil {
+			invalid("upload_url", "must be an s3://bucket/prefix or gs://bucket/prefix URL, got %q (%v)", v, err)
+		}
+		cfg.Upload = loc
+	}
+
+	if v := get("otlp_endpoint"); v != "" {
+		endpoint, err := telemetry.ParseEndpoint(v, get("otlp_headers"))
+		if err != nil {
+			invalid("otlp_endpoint", "must be an OTLP/HTTP collector URL with key=value otlp_headers, got %q (%v)", v, err)
+		}
+		cfg.OTLP = endpoint
+	}
+	cfg.DatadogAPIKey, cfg.DatadogSite = get("datadog_api_key"), get("datadog_site")
+	cfg.HoneycombAPIKey, cfg.HoneycombDataset = get("honeycomb_api_key"), get("honeycomb_dataset")
+
+	// An OTLP endpoint alone turns on its exporter
+	exporters := get("exporters")
+	if exporters == "" && cfg.OTLP != nil {
+		exporters = "otlp"
+	}
+	for _, name := range strings.Split(exporters, ",") {
+		switch name = strings.ToLower(strings.TrimSpace(name)); name {
+		case "":
+			continue
+		case "otlp":
+			if cfg.OTLP == nil {
+				invalid("exporters", "lists otlp, which requires otlp_endpoint")
+			}
+		case "datadog":
+			if cfg.DatadogAPIKey == "" {
+				invalid("exporters", "lists datadog, which requires datadog_api_key")
+			}
+		case "honeycomb":
+			if cfg.HoneycombAPIKey == "" {
+				invalid("exporters", "lists honeycomb, which requires honeycomb_api_key")
+			}
+		default:
+			invalid("exporters", "must list otlp, datadog or honeycomb, got %q", name)
+		}
+		cfg.Exporters = append(cfg.Exporters, name)
+	}
+
+	if v := get("report_outputs"); v != "" {
+		targets, err := models.ParseTargets(v)
+		if err != nil {
+			invalid("report_outputs", "must list %s outputs, optionally followed by :path, got %q (%v)", strings.Join(models.Formats(), ", "), v, err)
+		}
+		cfg.Outputs = targets
+	}
+
+	switch v := get("output_encoding"); v {
+	case "", models.EncodingRaw, models.EncodingBase64:
+		cfg.OutputEncoding = v
+	default:
+		invalid("output_encoding", "must be raw or base64, got %q", v)
+	}
+
+	if len(s.errs) > 0 {
+		return nil, errors.Join(s.errs...)
+	}
+	return cfg, nil
+}
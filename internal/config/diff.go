@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// DiffConfig holds the validated inputs of diff mode
+type DiffConfig struct {
+	Before      string // JSON report to compare against
+	After       string // JSON report compared
+	Format      string
+	FailOn      string  // severity of new findings that fails the comparison; empty never fails
+	MaxSlowdown float64 // percent the average successful run may slow down by; 0 doesn't check
+	Lang        i18n.Lang
+	PlainOutput bool
+}
+
+// diffInputs lists the inputs of diff mode. The reports can also be given as
+// the two arguments, before and after.
+func diffInputs() []*input {
+	return []*input{
+		{name: "before", usage: "JSON report to compare against, e.g. from the base branch"},
+		{name: "after", usage: "JSON report to compare, e.g. from the pull request"},
+		{name: "format", usage: "comparison format: console, markdown or json"},
+		{name: "fail_on", usage: "exit non-zero on new findings of this severity or higher: info, warning or critical"},
+		{name: "max_slowdown", usage: "exit non-zero when the average successful run got slower by more than this percentage"},
+		{name: "lang", usage: "report language: en, ko or ja"},
+		{name: "plain_output", usage: "render the comparison without emoji (true/false)"},
+	}
+}
+
+// LoadDiff reads and validates the inputs of diff mode like Load
+func LoadDiff(args []string) (*DiffConfig, error) {
+	s, err := parseInputs("analyzer diff", diffInputs(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &DiffConfig{
+		Before:      s.get("before"),
+		After:       s.get("after"),
+		Format:      s.get("format"),
+		FailOn:      s.get("fail_on"),
+		PlainOutput: s.boolean("plain_output"),
+	}
+	switch len(s.args) {
+	case 0:
+	case 2:
+		cfg.Before, cfg.After = s.args[0], s.args[1]
+	default:
+		s.invalid("before", "expected two report files, before and after, got %d", len(s.args))
+	}
+	if len(s.args) == 0 && (cfg.Before == "" || cfg.After == "") {
+		s.invalid("before", "both reports are required (analyzer diff before.json after.json)")
+	}
+
+	switch cfg.Format {
+	case "":
+		cfg.Format = models.FormatConsole
+	case models.FormatConsole, models.FormatMarkdown, models.FormatJSON:
+	default:
+		s.invalid("format", "must be console, markdown or json, got %q", cfg.Format)
+	}
+
+	switch cfg.FailOn {
+	case "", models.SeverityInfo, models.SeverityWarning, models.SeverityCritical:
+	default:
+		s.invalid("fail_on", "must be info, warning or critical, got %q", cfg.FailOn)
+	}
+
+	if v := s.get("max_slowdown"); v != "" {
+		pct, err := strconv.ParseFloat(v, 64)
+		if err != nil || pct <= 0 {
+			s.invalid("max_slowdown", "must be a positive percentage, got %q", v)
+		}
+		cfg.MaxSlowdown = pct
+	}
+
+	lang, err := i18n.ParseLang(s.get("lang"))
+	if err != nil {
+		s.invalid("lang", "must be en, ko or ja, got %q", s.get("lang"))
+	}
+	cfg.Lang = lang
+
+	if len(s.errs) > 0 {
+		return nil, errors.Join(s.errs...)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+)
+
+// GenerateConfig holds the validated inputs of generate mode
+type GenerateConfig struct {
+	Token        string
+	Repository   string
+	WorkflowFile string
+	Output       string   // file the rewritten workflow is written to
+	Fixes        []string // empty for every fix
+	Debug        bool
+	APIURL       string
+	CABundle     string
+}
+
+// generateInputs lists the inputs of generate mode
+func generateInputs() []*input {
+	return append([]*input{
+		{name: "github_token", usage: "GitHub token for API access", fallback: "GITHUB_TOKEN"},
+		{name: "repository", usage: "repository of the workflow (owner/repo)", fallback: "GITHUB_REPOSITORY"},
+		{name: "workflow_file", usage: "workflow file to rewrite, e.g. ci.yml"},
+		{name: "output", usage: "file to write the rewritten workflow to (default: <name>.optimized.yml)"},
+		{name: "fixes", usage: "comma-separated fixes to make: " + strings.Join(analyzer.Fixes(), ", ") + " (default: all)"},
+		{name: "debug", usage: "enable debug mode (true/false)", fallback: "DEBUG"},
+	}, networkInputs()...)
+}
+
+// LoadGenerate reads and validates the inputs of generate mode like Load
+func LoadGenerate(args []string) (*GenerateConfig, error) {
+	s, err := parseInputs("analyzer generate", generateInputs(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &GenerateConfig{
+		Token:        s.get("github_token"),
+		Repository:   s.get("repository"),
+		WorkflowFile: s.get("workflow_file"),
+		Output:       s.get("output"),
+		Debug:        s.boolean("debug"),
+		APIURL:       s.apiURL(),
+		CABundle:     s.caBundle(),
+	}
+	if parts := strings.Split(cfg.Repository, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		s.invalid("repository", "must have the format owner/repo, got %q", cfg.Repository)
+	}
+
+	switch file := cfg.WorkflowFile; {
+	case file == "":
+		s.invalid("workflow_file", "is required (e.g. ci.yml)")
+	case !strings.HasSuffix(file, ".yml") && !strings.HasSuffix(file, ".yaml"):
+		s.invalid("workflow_file", "must be a .yml or .yaml file, got %q", file)
+	case cfg.Output == "":
+		base := path.Base(file)
+		cfg.Output = strings.TrimSuffix(base, path.Ext(base)) + ".optimized" + path.Ext(base)
+	}
+
+	known := make(map[string]bool)
+	for _, fix := range analyzer.Fixes() {
+		known[fix] = true
+	}
+	for _, fix := range strings.Split(s.get("fixes"), ",") {
+		fix = strings.TrimSpace(fix)
+		if fix == "" {
+			continue
+		}
+		if !known[fix] {
+			s.invalid("fixes", "must list %s, got %q", strings.Join(analyzer.Fixes(), ", "), fix)
+			continue
+		}
+		cfg.Fixes = append(cfg.Fixes, fix)
+	}
+
+	if len(s.errs) > 0 {
+		return nil, errors.Join(s.errs...)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"net/url"
+	"os"
+)
+
+// networkInputs are the inputs for restricted networks shared by every mode.
+// Proxies are read from HTTPS_PROXY, HTTP_PROXY and NO_PROXY as usual.
+func networkInputs() []*input {
+	return []*input{
+		{name: "api_url", usage: "GitHub API URL, for GitHub Enterprise Server", fallback: "GITHUB_API_URL"},
+		{name: "ca_bundle", usage: "PEM file of extra CA certificates to trust, e.g. of a GHES instance or proxy"},
+	}
+}
+
+// apiURL validates the api_url input
+func (s *inputSet) apiURL() string {
+	v := s.get("api_url")
+	if v == "" {
+		return ""
+	}
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		s.invalid("api_url", "must be an http(s) URL, got %q", v)
+	}
+	return v
+}
+
+// caBundle validates that the ca_bundle input names a readable file
+func (s *inputSet) caBundle() string {
+	v := s.get("ca_bundle")
+	if v == "" {
+		return ""
+	}
+	if _, err := os.Stat(v); err != nil {
+		s.invalid("ca_bundle", "must be a readable PEM file, got %q (%v)", v, err)
+	}
+	return v
+}
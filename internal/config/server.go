@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+)
+
+// defaultListenAddr is where serve mode listens when listen_addr is unset
+const defaultListenAddr = ":8080"
+
+// defaultWorkers is the number of analyses serve mode runs at once
+const defaultWorkers = 2
+
+// ServerConfig holds the validated inputs of serve mode
+type ServerConfig struct {
+	Token         string
+	APIToken      string
+	Addr          string
+	Workers       int
+	WebhookSecret string
+	Database      string
+	Debug         bool
+	AnalysisDepth int
+	Timeout       time.Duration
+	Mode          analyzer.Mode
+	Lang          i18n.Lang
+	APIURL        string
+	CABundle      string
+}
+
+// serverInputs lists the inputs of serve mode; analysis settings apply to every job
+func serverInputs() []*input {
+	return append([]*input{
+		{name: "github_token", usage: "GitHub token for API access", fallback: "GITHUB_TOKEN"},
+		{name: "api_token", usage: "bearer token clients must send to the API", fallback: "API_TOKEN"},
+		{name: "listen_addr", usage: "address to serve the HTTP API on", fallback: "LISTEN_ADDR"},
+		{name: "workers", usage: "number of analyses to run at once"},
+		{name: "webhook_secret", usage: "secret GitHub signs webhook deliveries with", fallback: "WEBHOOK_SECRET"},
+		{name: "database", usage: "SQLite file to persist runs, jobs, analyses and findings in", fallback: "DATABASE"},
+		{name: "debug", usage: "enable debug mode (true/false)", fallback: "DEBUG"},
+		{name: "analysis_depth", usage: "number of workflow runs to analyze"},
+		{name: "timeout", usage: "analysis timeout in minutes", fallback: "TIMEOUT"},
+		{name: "mode", usage: "analysis mode: survey or deep"},
+		{name: "lang", usage: "report language: en, ko or ja"},
+	}, networkInputs()...)
+}
+
+// LoadServer reads and validates the inputs of serve mode like Load
+func LoadServer(args []string) (*ServerConfig, error) {
+	s, err := parseInputs("analyzer serve", serverInputs(), args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &ServerConfig{
+		Token:         s.get("github_token"),
+		APIToken:      s.get("api_token"),
+		Addr:          s.get("listen_addr"),
+		Workers:       defaultWorkers,
+		WebhookSecret: s.get("webhook_secret"),
+		Database:      s.get("database"),
+		Debug:         s.boolean("debug"),
+		APIURL:        s.apiURL(),
+		CABundle:      s.caBundle(),
+	}
+	if cfg.Token == "" {
+		s.invalid("github_token", "is required (set GITHUB_TOKEN or pass -github-token)")
+	}
+	if cfg.APIToken == "" {
+		s.invalid("api_token", "is required, since the API spends the GitHub token and serves its reports (set API_TOKEN or pass -api-token)")
+	}
+	if cfg.Addr == "" {
+		cfg.Addr = defaultListenAddr
+	}
+
+	if v := s.get("workers"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			s.invalid("workers", "must be a positive integer, got %q", v)
+		}
+		cfg.Workers = n
+	}
+
+	cfg.AnalysisDepth = s.analysisDepth()
+	cfg.Timeout = s.timeout()
+	cfg.Mode = s.mode()
+	cfg.Lang = s.lang()
+
+	if len(s.errs) > 0 {
+		return nil, errors.Join(s.errs...)
+	}
+	return cfg, nil
+}
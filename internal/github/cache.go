@@ -0,0 +1,365 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+// cacheFile is the name of the response cache inside the cache directory
+const cacheFile = "github-api-cache.json"
+
+// maxCachedRuns matches the runs listed without a cache
+const maxCachedRuns = MaxRuns
+
+// cachedRuns is the run listing of one workflow. ETag belongs to the first page of
+// the delta listing created on or after Since.
+type cachedRuns struct {
+	Runs  []*gh.WorkflowRun `json:"runs"`
+	Since time.Time         `json:"since,omitempty"`
+	ETag  string            `json:"etag,omitempty"`
+}
+
+// cachedFile is the content of a file at the repository's head commit
+type cachedFile struct {
+	HeadSHA string `json:"head_sha"`
+	ETag    string `json:"etag,omitempty"`
+	Content string `json:"content"`
+}
+
+// responseCache is the on-disk form of the cache
+type responseCache struct {
+	Runs  map[string]*cachedRuns       `json:"runs"`  // owner/repo/workflow
+	Jobs  map[string][]*gh.WorkflowJob `json:"jobs"`  // completed runs only, by run ID and attempt
+	Files map[string]*cachedFile       `json:"files"` // owner/repo/path
+	Heads map[string]string            `json:"heads"` // owner/repo -> head SHA
+}
+
+// CachedClient wraps Client with a response cache persisted between runs, e.g. through
+// actions/cache. Completed runs and their jobs never change, so only newer runs are
+// fetched; file contents are reused while the repository's head commit is unchanged.
+// Run listings and files are revalidated with ETags, so unchanged responses come back
+// as 304 Not Modified without using rate limit.
+type CachedClient struct {
+	*Client
+
+	dir    string
+	mu     sync.Mutex
+	data   responseCache
+	heads  map[string]string // head SHAs verified during this process
+	hits   int
+	misses int
+}
+
+// NewCachedClient loads the cache from dir, starting empty if it doesn't exist yet
+func NewCachedClient(client *Client, dir string) (*CachedClient, error) {
+	c := &CachedClient{
+		Client: client,
+		dir:    dir,
+		heads:  make(map[string]string),
+		data: responseCache{
+			Runs:  make(map[string]*cachedRuns),
+			Jobs:  make(map[string][]*gh.WorkflowJob),
+			Files: make(map[string]*cachedFile),
+			Heads: make(map[string]string),
+		},
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, cacheFile))
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read API cache: %v", err)
+	}
+	if err := json.Unmarshal(raw, &c.data); err != nil {
+		// A corrupt cache is only a lost optimization
+		return c, nil
+	}
+	return c, nil
+}
+
+// jobsKey identifies the jobs of one attempt of a run in the cache
+func jobsKey(runID int64, attempt int) string {
+	return fmt.Sprintf("%d/%d", runID, attempt)
+}
+
+// Save writes the cache back to disk, dropping jobs of runs that fell out of
+// every run listing and of attempts a re-run replaced
+func (c *CachedClient) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	listed := make(map[int64]int) // latest attempt of each listed run
+	for _, entry := range c.data.Runs {
+		for _, run := range entry.Runs {
+			listed[run.GetID()] = max(listed[run.GetID()], run.GetRunAttempt())
+		}
+	}
+	for key := range c.data.Jobs {
+		id, attempt, _ := strings.Cut(key, "/")
+		runID, err := strconv.ParseInt(id, 10, 64)
+		n, ok := listed[runID]
+		if err != nil || !ok || strconv.Itoa(n) != attempt {
+			delete(c.data.Jobs, key)
+		}
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %v", err)
+	}
+	raw, err := json.Marshal(c.data)
+	if err != nil {
+		return fmt.Errorf("failed to encode API cache: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, cacheFile), raw, 0644); err != nil {
+		return fmt.Errorf("failed to write API cache: %v", err)
+	}
+	return nil
+}
+
+// Stats returns the number of cache hits and misses so far
+func (c *CachedClient) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+func (c *CachedClient) record(hit bool) {
+	if hit {
+		c.hits++
+	} else {
+		c.misses++
+	}
+}
+
+// GetWorkflowRuns fetches only runs created since the newest cached run, or since
+// the oldest cached run that hadn't completed yet, and merges them with the cache
+func (c *CachedClient) GetWorkflowRuns(ctx context.Context, owner, repo, workflowFile string) ([]*gh.WorkflowRun, error) {
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, workflowFile)
+
+	c.mu.Lock()
+	entry := c.data.Runs[key]
+	c.mu.Unlock()
+	if entry == nil || len(entry.Runs) == 0 {
+		runs, err := c.Client.GetWorkflowRuns(ctx, owner, repo, workflowFile)
+		if err != nil {
+			return nil, err
+		}
+		c.mu.Lock()
+		c.record(false)
+		c.data.Runs[key] = &cachedRuns{Runs: runs}
+		c.mu.Unlock()
+		return runs, nil
+	}
+
+	since := runsRefreshPoint(entry.Runs)
+	etag := ""
+	if entry.Since.Equal(since) {
+		etag = entry.ETag
+	}
+	fresh, result, err := c.listRunsSince(ctx, owner, repo, workflowFile, since, etag)
+	if err != nil {
+		return nil, err
+	}
+	if result.NotModified {
+		// Nothing new since the last analysis
+		c.mu.Lock()
+		c.record(true)
+		c.mu.Unlock()
+		return entry.Runs, nil
+	}
+
+	merged := fresh
+	seen := make(map[int64]bool, len(fresh))
+	for _, run := range fresh {
+		seen[run.GetID()] = true
+	}
+	for _, run := range entry.Runs {
+		if !seen[run.GetID()] && run.GetCreatedAt().Before(since) {
+			merged = append(merged, run)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].GetCreatedAt().After(merged[j].GetCreatedAt().Time)
+	})
+	if len(merged) > maxCachedRuns {
+		merged = merged[:maxCachedRuns]
+	}
+
+	// The next analysis revalidates the same delta listing if no run was added
+	entry = &cachedRuns{Runs: merged}
+	if runsRefreshPoint(merged).Equal(since) {
+		entry.Since, entry.ETag = since, result.ETag
+	}
+
+	c.mu.Lock()
+	c.record(true)
+	c.data.Runs[key] = entry
+	c.mu.Unlock()
+	return merged, nil
+}
+
+// runsRefreshPoint returns the creation time from which runs must be fetched again
+func runsRefreshPoint(runs []*gh.WorkflowRun) time.Time {
+	var since time.Time
+	for _, run := range runs {
+		if created := run.GetCreatedAt().Time; created.After(since) {
+			since = created
+		}
+	}
+	for _, run := range runs {
+		if created := run.GetCreatedAt().Time; run.GetStatus() != "completed" && created.Before(since) {
+			since = created
+		}
+	}
+	return since
+}
+
+// listRunsSince lists a workflow's runs created at or after since. The first page is
+// requested conditionally on etag; the result reports whether it was unchanged.
+func (c *CachedClient) listRunsSince(ctx context.Context, owner, repo, workflowFile string, since time.Time, etag string) ([]*gh.WorkflowRun, *ConditionalResult, error) {
+	var all []*gh.WorkflowRun
+	var first *ConditionalResult
+	opts := &gh.ListWorkflowRunsOptions{
+		Created:     ">=" + since.UTC().Format(time.RFC3339),
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	for {
+		runs, result, resp, err := c.ListWorkflowRunsIfChanged(ctx, owner, repo, workflowFile, opts, etag)
+		if err != nil {
+			return nil, nil, err
+		}
+		if first == nil {
+			first = result
+			if result.NotModified {
+				return nil, result, nil
+			}
+		}
+		all = append(all, runs...)
+		if resp.NextPage == 0 || len(all) >= maxCachedRuns {
+			break
+		}
+		opts.Page = resp.NextPage
+		etag = ""
+	}
+	return all, first, nil
+}
+
+// GetWorkflowJobs serves jobs of completed run attempts from the cache. The
+// latest attempt, 0, is never cached, since a re-run changes it.
+func (c *CachedClient) GetWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*gh.WorkflowJob, error) {
+	key := jobsKey(runID, attempt)
+	c.mu.Lock()
+	jobs, ok := c.data.Jobs[key]
+	c.record(ok)
+	c.mu.Unlock()
+	if ok {
+		return jobs, nil
+	}
+
+	jobs, err := c.Client.GetWorkflowJobs(ctx, owner, repo, runID, attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt > 0 && jobsCompleted(jobs) {
+		c.mu.Lock()
+		c.data.Jobs[key] = jobs
+		c.mu.Unlock()
+	}
+	return jobs, nil
+}
+
+func jobsCompleted(jobs []*gh.WorkflowJob) bool {
+	if len(jobs) == 0 {
+		return false
+	}
+	for _, job := range jobs {
+		if job.GetStatus() != "completed" {
+			return false
+		}
+	}
+	return true
+}
+
+// GetFileContent serves files from the cache while the head commit is unchanged.
+// After the head moves, cached files are revalidated with their ETag.
+func (c *CachedClient) GetFileContent(ctx context.Context, owner, repo, path string) (string, error) {
+	head, err := c.headSHA(ctx, owner, repo)
+	if err != nil {
+		return c.Client.GetFileContent(ctx, owner, repo, path)
+	}
+
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, path)
+	c.mu.Lock()
+	file := c.data.Files[key]
+	c.mu.Unlock()
+	if file != nil && file.HeadSHA == head {
+		c.mu.Lock()
+		c.record(true)
+		c.mu.Unlock()
+		return file.Content, nil
+	}
+
+	etag := ""
+	if file != nil {
+		etag = file.ETag
+	}
+	content, result, err := c.GetFileContentIfChanged(ctx, owner, repo, path, etag)
+	if err != nil {
+		c.mu.Lock()
+		c.record(false)
+		delete(c.data.Files, key)
+		c.mu.Unlock()
+		return "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.record(result.NotModified)
+	if result.NotModified {
+		file.HeadSHA = head
+		return file.Content, nil
+	}
+	c.data.Files[key] = &cachedFile{HeadSHA: head, ETag: result.ETag, Content: content}
+	return content, nil
+}
+
+// headSHA resolves the repository's head commit once per process. The request is
+// conditional on the cached SHA, so an unchanged head doesn't count against the rate limit.
+func (c *CachedClient) headSHA(ctx context.Context, owner, repo string) (string, error) {
+	key := owner + "/" + repo
+
+	c.mu.Lock()
+	head, verified := c.heads[key]
+	cached := c.data.Heads[key]
+	c.mu.Unlock()
+	if verified {
+		return head, nil
+	}
+
+	sha, resp, err := c.client.Repositories.GetCommitSHA1(ctx, owner, repo, "HEAD", cached)
+	switch {
+	case resp != nil && resp.StatusCode == http.StatusNotModified:
+		sha = cached
+	case err != nil:
+		return "", fmt.Errorf("failed to resolve head commit: %v", err)
+	}
+
+	c.mu.Lock()
+	c.heads[key] = sha
+	c.data.Heads[key] = sha
+	c.mu.Unlock()
+	return sha, nil
+}
@@ -3,57 +3,194 @@ package github
 import (
 	"context"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
 	"golang.org/x/oauth2"
 )
 
+// MaxRuns bounds the runs listed per workflow, five pages of the API
+const MaxRuns = 500
+
+// DefaultRequestTimeout bounds each HTTP request, including reading the body;
+// job logs of long builds take a while to download
+const DefaultRequestTimeout = 2 * time.Minute
+
+// Client calls the GitHub API. Every method uses the context it is given, so
+// cancelling an analysis stops its requests.
 type Client struct {
 	client *gh.Client
-	ctx    context.Context
+	// download fetches the pre-signed log URLs the API redirects to, which must not get the token
+	download *http.Client
+	// logCache is the directory job logs are kept in, if any
+	logCache string
+	// quota tracks the API rate limit the responses report
+	quota *quotaTransport
+	// anonymous is set without a token, which only reaches public repositories
+	anonymous bool
+}
+
+// clientOptions collects the ClientOptions passed to NewClient
+type clientOptions struct {
+	httpClient *http.Client
+	timeout    time.Duration
+	limiter    *RateLimiter
+	baseURL    string
+	logCache   string
 }
 
-func NewClient(token string) *Client {
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: token},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+// ClientOption configures optional Client behaviour
+type ClientOption func(*clientOptions)
+
+// WithHTTPClient sends requests through hc's transport, e.g. one with a proxy or
+// a custom CA. The token is added on top of it.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(o *clientOptions) {
+		o.httpClient = hc
+	}
+}
+
+// WithRequestTimeout bounds each request; zero or less keeps DefaultRequestTimeout
+func WithRequestTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		if d > 0 {
+			o.timeout = d
+		}
+	}
+}
+
+func NewClient(token string, opts ...ClientOption) *Client {
+	o := clientOptions{timeout: DefaultRequestTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var transport http.RoundTripper = http.DefaultTransport
+	if o.httpClient != nil && o.httpClient.Transport != nil {
+		transport = o.httpClient.Transport
+	}
+	api := transport
+	if o.limiter != nil {
+		api = &limitedTransport{base: transport, limiter: o.limiter}
+	}
+	quota := &quotaTransport{base: api}
+	auth := &http.Client{Transport: quota, Timeout: o.timeout}
+	// Without a token, requests are anonymous and limited to public repositories
+	if token != "" {
+		auth.Transport = &oauth2.Transport{
+			Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			Base:   quota,
+		}
+	}
+
+	client := gh.NewClient(auth)
+	if o.baseURL != "" {
+		// The URL is validated with the inputs, so an error leaves GitHub.com in place
+		if enterprise, err := gh.NewEnterpriseClient(o.baseURL, o.baseURL, auth); err == nil {
+			client = enterprise
+		}
+	}
 
 	return &Client{
-		client: gh.NewClient(tc),
-		ctx:    ctx,
+		client:    client,
+		download:  &http.Client{Transport: transport, Timeout: o.timeout},
+		logCache:  o.logCache,
+		quota:     quota,
+		anonymous: token == "",
 	}
 }
 
+// GetWorkflowRuns lists a workflow's latest runs, newest first, page by page up
+// to MaxRuns. Each page is retried before the last error is returned.
 func (c *Client) GetWorkflowRuns(ctx context.Context, owner, repo, workflowFile string) ([]*gh.WorkflowRun, error) {
-	var allRuns []*gh.WorkflowRun
+	// 실행 기록이 없어도 빈 슬라이스 반환
+	allRuns := []*gh.WorkflowRun{}
 	opts := &gh.ListWorkflowRunsOptions{
 		ListOptions: gh.ListOptions{
 			PerPage: 100,
 		},
 	}
 
-	// Add retry logic
-	for retries := 3; retries > 0; retries-- {
-		runs, _, err := c.client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowFile, opts)
-		if err == nil {
-			if runs != nil && runs.WorkflowRuns != nil {
-				allRuns = append(allRuns, runs.WorkflowRuns...)
+	for {
+		var runs *gh.WorkflowRuns
+		var resp *gh.Response
+		var err error
+		// Add retry logic
+		for retries := 3; retries > 0; retries-- {
+			if runs, resp, err = c.client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowFile, opts); err == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(2 * time.Second):
 			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list runs of %s: %v", workflowFile, err)
+		}
+		if runs != nil {
+			allRuns = append(allRuns, runs.WorkflowRuns...)
+		}
+		if resp.NextPage == 0 || len(allRuns) >= MaxRuns {
 			break
 		}
-		time.Sleep(time.Second * 2)
+		opts.Page = resp.NextPage
+	}
+	if len(allRuns) > MaxRuns {
+		allRuns = allRuns[:MaxRuns]
 	}
-
-	// 실행 기록이 없어도 빈 슬라이스 반환
 	return allRuns, nil
 }
 
+// GetWorkflowJobs lists the jobs of one attempt of a run, or of its latest
+// attempt when attempt is 0. A re-run replaces the jobs the API lists for a run.
+func (c *Client) GetWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*gh.WorkflowJob, error) {
+	allJobs := []*gh.WorkflowJob{}
+	page := 1
+	for {
+		var jobs *gh.Jobs
+		var resp *gh.Response
+		var err error
+		if attempt == 0 {
+			jobs, resp, err = c.client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &gh.ListWorkflowJobsOptions{
+				ListOptions: gh.ListOptions{
+					PerPage: 100,
+					Page:    page,
+				},
+			})
+		} else {
+			var req *http.Request
+			req, err = c.client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/actions/runs/%d/attempts/%d/jobs?per_page=100&page=%d", owner, repo, runID, attempt, page), nil)
+			if err != nil {
+				return nil, err
+			}
+			jobs = new(gh.Jobs)
+			resp, err = c.client.Do(ctx, req, jobs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflow jobs: %v", err)
+		}
+		allJobs = append(allJobs, jobs.Jobs...)
+		if resp.NextPage == 0 {
+			return allJobs, nil
+		}
+		page = resp.NextPage
+	}
+}
+
 func (c *Client) GetWorkflowJobLogs(ctx context.Context, owner, repo string, runID int64) (string, error) {
+	// GitHub only serves job logs to signed-in users, so anonymous analyses
+	// rely on job metadata and don't spend their small rate limit on them
+	if c.anonymous {
+		return "", nil
+	}
 	jobs, _, err := c.client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, &gh.ListWorkflowJobsOptions{})
 	if err != nil {
 		return "", fmt.Errorf("failed to list workflow jobs: %v", err)
@@ -61,34 +198,244 @@ func (c *Client) GetWorkflowJobLogs(ctx context.Context, owner, repo string, run
 
 	var logs string
 	for _, job := range jobs.Jobs {
-		// Get raw logs URL
-		rawLogsURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/actions/jobs/%d/logs", owner, repo, job.GetID())
-
-		req, err := http.NewRequestWithContext(ctx, "GET", rawLogsURL, nil)
+		// Stop between jobs as soon as the analysis is cancelled
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		// Logs that expired or were deleted are skipped; the other jobs still count
+		content, err := c.cachedJobLogs(ctx, owner, repo, job)
 		if err != nil {
+			if ctx.Err() != nil {
+				return "", ctx.Err()
+			}
 			continue
 		}
+		logs += content
+	}
+
+	return logs, nil
+}
+
+// jobLogs downloads one job's logs from the URL the API redirects to
+func (c *Client) jobLogs(ctx context.Context, owner, repo string, jobID int64) (string, error) {
+	u, _, err := c.client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, true)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs URL of job %d: %v", jobID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.download.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to download logs of job %d: %v", jobID, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download logs of job %d: %s", jobID, resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs of job %d: %v", jobID, err)
+	}
+	return string(content), nil
+}
+
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, path string) (string, error) {
+	fileContent, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to get file content: %v", err)
+	}
+
+	content, err := fileContent.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode content: %v", err)
+	}
+
+	return content, nil
+}
+
+// FileExists reports whether path exists in the repository's default branch. Unlike
+// GetFileContent it works for files too large for the contents API to return inline.
+func (c *Client) FileExists(ctx context.Context, owner, repo, path string) (bool, error) {
+	_, _, resp, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check %s: %v", path, err)
+	}
+	return true, nil
+}
+
+// GetTree lists the paths of all files in the repository's default branch
+func (c *Client) GetTree(ctx context.Context, owner, repo string) ([]string, error) {
+	tree, _, err := c.client.Git.GetTree(ctx, owner, repo, "HEAD", true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository tree: %v", err)
+	}
+
+	var paths []string
+	for _, entry := range tree.Entries {
+		if entry.GetType() == "blob" {
+			paths = append(paths, entry.GetPath())
+		}
+	}
+	return paths, nil
+}
+
+// ConditionalResult is the outcome of a request made with If-None-Match
+type ConditionalResult struct {
+	ETag        string
+	NotModified bool
+}
+
+// conditionalGet fetches u into v unless it still matches etag. GitHub doesn't count
+// 304 Not Modified responses against the rate limit.
+func (c *Client) conditionalGet(ctx context.Context, u, etag string, v interface{}) (*ConditionalResult, *gh.Response, error) {
+	req, err := c.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := c.client.Do(ctx, req, v)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return &ConditionalResult{ETag: etag, NotModified: true}, resp, nil
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	return &ConditionalResult{ETag: resp.Header.Get("ETag")}, resp, nil
+}
+
+// GetFileContentIfChanged fetches a file unless it still matches etag. content is
+// empty when the result is NotModified.
+func (c *Client) GetFileContentIfChanged(ctx context.Context, owner, repo, path, etag string) (string, *ConditionalResult, error) {
+	u := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, (&url.URL{Path: path}).EscapedPath())
+	var file gh.RepositoryContent
+	result, _, err := c.conditionalGet(ctx, u, etag, &file)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get file content: %v", err)
+	}
+	if result.NotModified {
+		return "", result, nil
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to decode content: %v", err)
+	}
+	return content, result, nil
+}
+
+// ListWorkflowRunsIfChanged lists one page of a workflow's runs unless the page still
+// matches etag. runs is nil when the result is NotModified.
+func (c *Client) ListWorkflowRunsIfChanged(ctx context.Context, owner, repo, workflowFile string, opts *gh.ListWorkflowRunsOptions, etag string) ([]*gh.WorkflowRun, *ConditionalResult, *gh.Response, error) {
+	query := url.Values{}
+	if opts.Created != "" {
+		query.Set("created", opts.Created)
+	}
+	if opts.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	if opts.Page > 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+	u := fmt.Sprintf("repos/%s/%s/actions/workflows/%s/runs?%s", owner, repo, url.PathEscape(workflowFile), query.Encode())
+
+	var runs gh.WorkflowRuns
+	result, resp, err := c.conditionalGet(ctx, u, etag, &runs)
+	if err != nil {
+		return nil, nil, resp, fmt.Errorf("failed to list workflow runs: %v", err)
+	}
+	return runs.WorkflowRuns, result, resp, nil
+}
+
+// GetCacheUsage returns the total size and number of a repository's active Actions caches
+func (c *Client) GetCacheUsage(ctx context.Context, owner, repo string) (int64, int, error) {
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/actions/cache/usage", owner, repo), nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	var usage struct {
+		Bytes int64 `json:"active_caches_size_in_bytes"`
+		Count int   `json:"active_caches_count"`
+	}
+	if _, err := c.client.Do(ctx, req, &usage); err != nil {
+		return 0, 0, fmt.Errorf("failed to get cache usage: %v", err)
+	}
+	return usage.Bytes, usage.Count, nil
+}
 
-		resp, err := http.DefaultClient.Do(req)
+// ListCaches lists up to limit Actions caches of a repository, most recently used first
+func (c *Client) ListCaches(ctx context.Context, owner, repo string, limit int) ([]models.ActionsCache, error) {
+	var all []models.ActionsCache
+	for page := 1; len(all) < limit; page++ {
+		u := fmt.Sprintf("repos/%s/%s/actions/caches?sort=last_accessed_at&direction=desc&per_page=100&page=%d", owner, repo, page)
+		req, err := c.client.NewRequest("GET", u, nil)
 		if err != nil {
-			continue
+			return nil, err
+		}
+		var caches struct {
+			Caches []models.ActionsCache `json:"actions_caches"`
 		}
-		defer resp.Body.Close()
+		resp, err := c.client.Do(ctx, req, &caches)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list caches: %v", err)
+		}
+		all = append(all, caches.Caches...)
+		if resp.NextPage == 0 {
+			break
+		}
+	}
+	if len(all) > limit {
+		all = all[:limit]
+	}
+	return all, nil
+}
 
-		logContent, err := ioutil.ReadAll(resp.Body)
+// ListWorkflows returns the workflows registered in a repository with their state,
+// e.g. active or disabled_inactivity
+func (c *Client) ListWorkflows(ctx context.Context, owner, repo string) ([]*gh.Workflow, error) {
+	var all []*gh.Workflow
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		workflows, resp, err := c.client.Actions.ListWorkflows(ctx, owner, repo, opts)
 		if err != nil {
-			continue
+			return nil, fmt.Errorf("failed to list workflows of %s/%s: %v", owner, repo, err)
+		}
+		all = append(all, workflows.Workflows...)
+		if resp.NextPage == 0 {
+			break
 		}
-		logs += string(logContent)
+		opts.Page = resp.NextPage
 	}
+	return all, nil
+}
 
-	return logs, nil
+// CountWorkflowRuns returns the number of runs of a workflow created since a day
+func (c *Client) CountWorkflowRuns(ctx context.Context, owner, repo, workflowFile string, since time.Time) (int, error) {
+	opts := &gh.ListWorkflowRunsOptions{
+		Created:     ">=" + since.UTC().Format("2006-01-02"),
+		ListOptions: gh.ListOptions{PerPage: 1},
+	}
+	runs, _, err := c.client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflowFile, opts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count runs of %s: %v", workflowFile, err)
+	}
+	return runs.GetTotalCount(), nil
 }
 
-func (c *Client) GetFileContent(ctx context.Context, owner, repo, path string) (string, error) {
-	fileContent, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, nil)
+func (c *Client) GetFileContentAtRef(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	opts := &gh.RepositoryContentGetOptions{Ref: ref}
+	fileContent, _, _, err := c.client.Repositories.GetContents(ctx, owner, repo, path, opts)
 	if err != nil {
-		return "", fmt.Errorf("failed to get file content: %v", err)
+		return "", fmt.Errorf("failed to get file content at %s: %v", ref, err)
 	}
 
 	content, err := fileContent.GetContent()
@@ -99,6 +446,39 @@ func (c *Client) GetFileContent(ctx context.Context, owner, repo, path string) (
 	return content, nil
 }
 
+func (c *Client) GetPullRequest(ctx context.Context, owner, repo string, number int) (*gh.PullRequest, error) {
+	pr, _, err := c.client.PullRequests.Get(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request #%d: %v", number, err)
+	}
+	return pr, nil
+}
+
+func (c *Client) ListPullRequestFiles(ctx context.Context, owner, repo string, number int) ([]*gh.CommitFile, error) {
+	var allFiles []*gh.CommitFile
+	opts := &gh.ListOptions{PerPage: 100}
+	for {
+		files, resp, err := c.client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull request files: %v", err)
+		}
+		allFiles = append(allFiles, files...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return allFiles, nil
+}
+
+func (c *Client) CreateReview(ctx context.Context, owner, repo string, number int, review *gh.PullRequestReviewRequest) error {
+	_, _, err := c.client.PullRequests.CreateReview(ctx, owner, repo, number, review)
+	if err != nil {
+		return fmt.Errorf("failed to create review on pull request #%d: %v", number, err)
+	}
+	return nil
+}
+
 func (c *Client) GetLatestRelease(ctx context.Context, owner, repo string) (*gh.RepositoryRelease, error) {
 	release, _, err := c.client.Repositories.GetLatestRelease(ctx, owner, repo)
 	if err != nil {
@@ -106,3 +486,146 @@ func (c *Client) GetLatestRelease(ctx context.Context, owner, repo string) (*gh.
 	}
 	return release, nil
 }
+
+// ListReleases returns up to limit releases of a repository, newest first
+func (c *Client) ListReleases(ctx context.Context, owner, repo string, limit int) ([]*gh.RepositoryRelease, error) {
+	releases, _, err := c.client.Repositories.ListReleases(ctx, owner, repo, &gh.ListOptions{PerPage: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list releases of %s/%s: %v", owner, repo, err)
+	}
+	return releases, nil
+}
+
+// ListTagNames returns the names of a repository's tags starting with prefix,
+// for projects that tag their versions without publishing GitHub releases
+func (c *Client) ListTagNames(ctx context.Context, owner, repo, prefix string) ([]string, error) {
+	var names []string
+	opts := &gh.ReferenceListOptions{Ref: "tags/" + prefix, ListOptions: gh.ListOptions{PerPage: 100}}
+	for {
+		refs, resp, err := c.client.Git.ListMatchingRefs(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags of %s/%s: %v", owner, repo, err)
+		}
+		for _, ref := range refs {
+			names = append(names, strings.TrimPrefix(ref.GetRef(), "refs/tags/"))
+		}
+		if resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetRepository returns a repository's metadata, e.g. whether it is archived
+func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*gh.Repository, error) {
+	repository, _, err := c.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository %s/%s: %v", owner, repo, err)
+	}
+	return repository, nil
+}
+
+// ListContributors returns up to limit contributors of a repository, most active first
+func (c *Client) ListContributors(ctx context.Context, owner, repo string, limit int) ([]*gh.Contributor, error) {
+	contributors, _, err := c.client.Repositories.ListContributors(ctx, owner, repo, &gh.ListContributorsOptions{
+		ListOptions: gh.ListOptions{PerPage: limit},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contributors of %s/%s: %v", owner, repo, err)
+	}
+	return contributors, nil
+}
+
+// branchRule is a rule of the rulesets that apply to a branch
+type branchRule struct {
+	Type       string `json:"type"`
+	Parameters struct {
+		RequiredStatusChecks []struct {
+			Context       string `json:"context"`
+			IntegrationID *int64 `json:"integration_id,omitempty"`
+		} `json:"required_status_checks"`
+	} `json:"parameters"`
+}
+
+// GetRequiredStatusChecks returns the status checks a branch requires, from its
+// branch protection and the rulesets that apply to it. Reading branch protection
+// takes admin access, so without it only the rulesets' checks are returned.
+func (c *Client) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) ([]*gh.RequiredStatusCheck, error) {
+	var checks []*gh.RequiredStatusCheck
+	seen := make(map[string]bool)
+	add := func(check *gh.RequiredStatusCheck) {
+		if !seen[check.Context] {
+			seen[check.Context] = true
+			checks = append(checks, check)
+		}
+	}
+	unavailable := func(resp *gh.Response) bool {
+		return resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden)
+	}
+
+	protection, resp, err := c.client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+	switch {
+	case err == nil:
+		for _, check := range protection.Checks {
+			add(check)
+		}
+		for _, name := range protection.Contexts {
+			add(&gh.RequiredStatusCheck{Context: name})
+		}
+	case !unavailable(resp):
+		return nil, fmt.Errorf("failed to get required status checks of %s: %v", branch, err)
+	}
+
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("repos/%s/%s/rules/branches/%s", owner, repo, url.PathEscape(branch)), nil)
+	if err != nil {
+		return nil, err
+	}
+	var rules []branchRule
+	if resp, err := c.client.Do(ctx, req, &rules); err != nil {
+		// GitHub Enterprise Server releases before rulesets don't have the endpoint
+		if unavailable(resp) {
+			return checks, nil
+		}
+		return nil, fmt.Errorf("failed to get rules of %s: %v", branch, err)
+	}
+	for _, rule := range rules {
+		if rule.Type != "required_status_checks" {
+			continue
+		}
+		for _, check := range rule.Parameters.RequiredStatusChecks {
+			add(&gh.RequiredStatusCheck{Context: check.Context, AppID: check.IntegrationID})
+		}
+	}
+	return checks, nil
+}
+
+func (c *Client) GetRateLimit(ctx context.Context) (*gh.Rate, error) {
+	limits, _, err := c.client.RateLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rate limits: %v", err)
+	}
+	return limits.GetCore(), nil
+}
+
+// ListOrgRepositories returns the owner/repo names of an organization's repositories,
+// skipping archived ones
+func (c *Client) ListOrgRepositories(ctx context.Context, org string) ([]string, error) {
+	var names []string
+	opts := &gh.RepositoryListByOrgOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	for {
+		repos, resp, err := c.client.Repositories.ListByOrg(ctx, org, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories of %s: %v", org, err)
+		}
+		for _, repo := range repos {
+			if !repo.GetArchived() {
+				names = append(names, repo.GetFullName())
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return names, nil
+}
@@ -0,0 +1,100 @@
+package github
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+// logCacheDir is the directory of cached job logs inside the log cache directory
+const logCacheDir = "logs"
+
+// WithLogCache keeps the downloaded logs of completed jobs in dir, keyed by job
+// ID, so analyzing the same runs again reads them from disk instead of
+// downloading them. Logs are stored gzipped.
+func WithLogCache(dir string) ClientOption {
+	return func(o *clientOptions) {
+		o.logCache = dir
+	}
+}
+
+// logPath returns the file a job's cached logs are kept in
+func (c *Client) logPath(jobID int64) string {
+	return filepath.Join(c.logCache, logCacheDir, strconv.FormatInt(jobID, 10)+".log.gz")
+}
+
+// cachedJobLogs returns a job's logs from the log cache, downloading and storing
+// them when they aren't cached yet. Logs of jobs still running can change, so
+// only completed jobs are cached.
+func (c *Client) cachedJobLogs(ctx context.Context, owner, repo string, job *gh.WorkflowJob) (string, error) {
+	if c.logCache == "" || job.GetStatus() != "completed" {
+		return c.jobLogs(ctx, owner, repo, job.GetID())
+	}
+	if content, err := c.readLog(job.GetID()); err == nil {
+		return content, nil
+	}
+
+	content, err := c.jobLogs(ctx, owner, repo, job.GetID())
+	if err != nil {
+		return "", err
+	}
+	// A log that can't be cached is still analyzed
+	_ = c.storeLog(job.GetID(), content)
+	return content, nil
+}
+
+// readLog reads a job's logs from the log cache
+func (c *Client) readLog(jobID int64) (string, error) {
+	f, err := os.Open(c.logPath(jobID))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	content, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// storeLog writes a job's logs to the log cache. The file is written under a
+// temporary name first, so an interrupted analysis never leaves a truncated log.
+func (c *Client) storeLog(jobID int64, content string) error {
+	path := c.logPath(jobID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create log cache directory: %v", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".log-*")
+	if err != nil {
+		return fmt.Errorf("failed to write cached log: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := gzip.NewWriter(tmp)
+	if _, err := io.WriteString(zw, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cached log: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cached log: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cached log: %v", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cached log: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimiter is a token bucket shared by every client it is passed to, so
+// concurrent analyses together stay under one request budget
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter allows perHour requests per hour on average, and up to burst at once
+func NewRateLimiter(perHour, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:   float64(perHour) / time.Hour.Seconds(),
+		burst:  float64(max(burst, 1)),
+		tokens: float64(max(burst, 1)),
+		last:   time.Now(),
+	}
+}
+
+// Wait blocks until a request may be sent or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// limitedTransport waits for the rate limiter before each request
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// WithRateLimiter makes every API request wait for limiter. Log downloads from
+// the pre-signed URLs the API redirects to don't count against the rate limit.
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(o *clientOptions) {
+		o.limiter = limiter
+	}
+}
+
+// quotaTransport notes when GitHub reports the core API rate limit as used up,
+// so the analysis can stop sending requests that would fail until it resets
+type quotaTransport struct {
+	base  http.RoundTripper
+	reset atomic.Int64 // Unix time the used up limit resets at, 0 while requests remain
+}
+
+func (t *quotaTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return resp, err
+	}
+	if resource := resp.Header.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return resp, err
+	}
+	reset, perr := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if perr != nil {
+		reset = time.Now().Add(time.Hour).Unix()
+	}
+	t.reset.Store(reset)
+	return resp, err
+}
+
+// exhausted reports whether the rate limit is used up and hasn't reset yet
+func (t *quotaTransport) exhausted() bool {
+	reset := t.reset.Load()
+	return reset != 0 && time.Now().Unix() < reset
+}
+
+// RateLimited reports whether the client used up its API rate limit, e.g. the 60
+// requests an hour of anonymous clients, so further requests fail until it resets
+func (c *Client) RateLimited() bool {
+	return c.quota.exhausted()
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// NewTransport returns an HTTP transport for restricted networks. It uses the
+// proxy from HTTPS_PROXY, HTTP_PROXY and NO_PROXY, and trusts the CA certificates
+// in the PEM file caBundle in addition to the system ones, e.g. the private CA of
+// a GitHub Enterprise Server or of a TLS-inspecting proxy.
+func NewTransport(caBundle string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if caBundle == "" {
+		return transport, nil
+	}
+
+	pem, err := os.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %v", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", caBundle)
+	}
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	return transport, nil
+}
+
+// WithBaseURL points the client at a GitHub Enterprise Server API, e.g. the
+// GITHUB_API_URL of its runners. An empty URL or https://api.github.com keeps GitHub.com.
+func WithBaseURL(apiURL string) ClientOption {
+	return func(o *clientOptions) {
+		if apiURL != "" && strings.TrimSuffix(apiURL, "/") != "https://api.github.com" {
+			o.baseURL = apiURL
+		}
+	}
+}
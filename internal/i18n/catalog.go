@@ -0,0 +1,1493 @@
+package i18n
+
+// catalog maps English source messages to their translations
+var catalog = map[Lang]map[string]string{
+	Korean: {
+		// Report headings
+		"Workflow Analysis Report":    "워크플로 분석 보고서",
+		"End of Analysis Report":      "분석 보고서 끝",
+		"Overview":                    "개요",
+		"Repository":                  "저장소",
+		"Workflow":                    "워크플로",
+		"Total Execution Time":        "총 실행 시간",
+		"Slow Steps Detected":         "느린 단계 감지",
+		"Duration":                    "소요 시간",
+		"Cache Optimization Tips":     "캐시 최적화 팁",
+		"What":                        "내용",
+		"Impact":                      "효과",
+		"Example":                     "예시",
+		"Docker Optimization Tips":    "Docker 최적화 팁",
+		"Issue":                       "문제",
+		"Solution":                    "해결 방법",
+		"Expected Improvement":        "예상 개선 효과",
+		"Cost Saving Opportunities":   "비용 절감 기회",
+		"Workflow Structure Analysis": "워크플로 구조 분석",
+		"General Recommendations":     "일반 권장 사항",
+		"Runner Optimizations":        "러너 최적화",
+		"Security Recommendations":    "보안 권장 사항",
+
+		// Dry run plan
+		"Analysis Dry Run Plan":                "분석 드라이런 계획",
+		"Mode":                                 "모드",
+		"Runs: %d to analyze (%d available)":   "실행: %d개 분석 예정 (%d개 사용 가능)",
+		"Planned API Calls":                    "예정된 API 호출",
+		"Quota Estimate":                       "할당량 예상",
+		"Estimated requests: %d":               "예상 요청 수: %d",
+		"Remaining rate limit: %d (resets %s)": "남은 요청 한도: %d (%s에 초기화)",
+		"The estimate exceeds the remaining quota; lower analysis_depth or use survey mode": "예상 요청 수가 남은 한도를 초과합니다. analysis_depth를 낮추거나 survey 모드를 사용하세요",
+		"List workflow runs":                              "워크플로 실행 목록 조회",
+		"Fetch job and step timings for each run":         "각 실행의 작업 및 단계 시간 조회",
+		"List jobs of each sampled run":                   "샘플 실행별 작업 목록 조회",
+		"Download job logs of each sampled run":           "샘플 실행별 작업 로그 다운로드",
+		"Assumes %d jobs per run based on the latest run": "최근 실행 기준으로 실행당 작업 %d개로 가정",
+		"Fetch the workflow file (three times), the Dockerfile and GitLab CI/CircleCI configs": "워크플로 파일(3회), Dockerfile, GitLab CI/CircleCI 설정 조회",
+		"Look up the latest version of each detected language":                                 "감지된 언어별 최신 버전 조회",
+		"Upper bound; only detected languages are queried":                                     "최대값이며 감지된 언어만 조회합니다",
+
+		// Cost saving tips
+		"Consider using GitHub Actions cache to speed up dependencies installation": "GitHub Actions 캐시를 사용해 의존성 설치 속도를 높이세요",
+		"Use matrix builds for parallel execution":                                  "매트릭스 빌드로 병렬 실행하세요",
+		"Implement proper Docker layer caching":                                     "Docker 레이어 캐싱을 적절히 구성하세요",
+		"Total execution time: %v - Consider optimizing long-running steps":         "총 실행 시간: %v - 오래 걸리는 단계의 최적화를 검토하세요",
+
+		// Docker optimizations
+		"No multi-stage build detected":                                  "멀티 스테이지 빌드가 감지되지 않았습니다",
+		"Consider using multi-stage builds to reduce final image size":   "멀티 스테이지 빌드로 최종 이미지 크기를 줄이세요",
+		"Can reduce image size by up to 50%":                             "이미지 크기를 최대 50%까지 줄일 수 있습니다",
+		"No layer caching strategy detected":                             "레이어 캐싱 전략이 감지되지 않았습니다",
+		"Implement proper layer caching by copying only necessary files": "필요한 파일만 복사하여 레이어 캐싱을 적절히 구성하세요",
+		"Can improve build time significantly":                           "빌드 시간을 크게 개선할 수 있습니다",
+
+		// Workflow structure
+		"Consider using matrix strategy for parallel testing/building across different versions/platforms": "여러 버전/플랫폼에 걸친 병렬 테스트/빌드를 위해 매트릭스 전략을 고려하세요",
+		"Review job dependencies to ensure optimal parallel execution":                                     "최적의 병렬 실행을 위해 작업 의존성을 검토하세요",
+		"Consider using specific Ubuntu version instead of 'latest' for better reproducibility":            "재현성을 위해 'latest' 대신 특정 Ubuntu 버전을 사용하세요",
+		"Add explicit permissions to improve workflow security":                                            "워크플로 보안을 위해 permissions를 명시하세요",
+		"Consider using environments for better secret management and deployment control":                  "시크릿 관리와 배포 제어를 위해 environments 사용을 고려하세요",
+
+		// Cache strategies
+		"Cache Go build artifacts and modules":                                                     "Go 빌드 결과물과 모듈 캐시",
+		"Can reduce build time and dependency download time significantly":                         "빌드 시간과 의존성 다운로드 시간을 크게 줄일 수 있습니다",
+		"Cache npm dependencies":                                                                   "npm 의존성 캐시",
+		"Can reduce npm install time by up to 50%":                                                 "npm install 시간을 최대 50%까지 줄일 수 있습니다",
+		"Cache node_modules directory":                                                             "node_modules 디렉터리 캐시",
+		"Can significantly reduce installation time for large projects":                            "대규모 프로젝트의 설치 시간을 크게 줄일 수 있습니다",
+		"Cache pip dependencies":                                                                   "pip 의존성 캐시",
+		"Can reduce pip install time significantly":                                                "pip install 시간을 크게 줄일 수 있습니다",
+		"Cache Maven dependencies":                                                                 "Maven 의존성 캐시",
+		"Can significantly reduce build time by caching Maven dependencies":                        "Maven 의존성을 캐시하여 빌드 시간을 크게 줄일 수 있습니다",
+		"Cache Gradle dependencies and wrapper":                                                    "Gradle 의존성과 wrapper 캐시",
+		"Can significantly reduce build time by caching Gradle dependencies":                       "Gradle 의존성을 캐시하여 빌드 시간을 크게 줄일 수 있습니다",
+		"Cache Ruby gems using Bundler":                                                            "Bundler로 Ruby gem 캐시",
+		"Can reduce gem installation time significantly":                                           "gem 설치 시간을 크게 줄일 수 있습니다",
+		"Cache Rust dependencies and build artifacts":                                              "Rust 의존성과 빌드 결과물 캐시",
+		"Can significantly reduce build time by caching Cargo dependencies and compiled artifacts": "Cargo 의존성과 컴파일 결과물을 캐시하여 빌드 시간을 크게 줄일 수 있습니다",
+		"Cache .NET SDK installation":                                                              ".NET SDK 설치 캐시",
+		"Can significantly reduce setup time by caching the .NET SDK":                              ".NET SDK를 캐시하여 설정 시간을 크게 줄일 수 있습니다",
+
+		// Workflow findings
+		"Pull Request":      "풀 리퀘스트",
+		"Workflow Findings": "워크플로 검사 결과",
+		"Runner label %s floats to the newest image":                                       "러너 레이블 %s는 항상 최신 이미지를 따라갑니다",
+		"No permissions block found; the workflow runs with the default token permissions": "permissions 블록이 없어 워크플로가 기본 토큰 권한으로 실행됩니다",
+		"Action %s is not pinned to a version":                                             "액션 %s의 버전이 고정되어 있지 않습니다",
+		"Action %s tracks the %s branch":                                                   "액션 %s가 %s 브랜치를 따라갑니다",
+		"Pin actions to a release tag or full commit SHA":                                  "액션을 릴리스 태그나 전체 커밋 SHA로 고정하세요",
+		"Workflow analyzer found %d issue(s) in the changed lines.":                        "워크플로 분석기가 변경된 줄에서 %d개의 문제를 발견했습니다.",
+
+		// Workflow validation
+		"Invalid workflow YAML: %v":                      "잘못된 워크플로 YAML: %v",
+		"Unknown workflow key %q":                        "알 수 없는 워크플로 키 %q",
+		"Workflow has no \"on\" trigger":                 "워크플로에 \"on\" 트리거가 없습니다",
+		"Workflow defines no jobs":                       "워크플로에 정의된 작업이 없습니다",
+		"Unknown key %q in job %s":                       "작업 %[2]s에 알 수 없는 키 %[1]q",
+		"Job %s has no runs-on":                          "작업 %s에 runs-on이 없습니다",
+		"Job %s has no steps":                            "작업 %s에 단계가 없습니다",
+		"Job %s needs unknown job %q":                    "작업 %s가 존재하지 않는 작업 %q에 의존합니다",
+		"Unknown key %q in step %q":                      "단계 %[2]q에 알 수 없는 키 %[1]q",
+		"Step %q must define exactly one of uses or run": "단계 %q는 uses와 run 중 정확히 하나만 정의해야 합니다",
+		"Unknown context %q in expression ${{%s}}":       "표현식 ${{%[2]s}}에 알 수 없는 컨텍스트 %[1]q",
+		"shellcheck SC%d in step %q: %s":                 "단계 %[2]q의 shellcheck SC%[1]d: %[3]s",
+
+		// OIDC recommendations
+		"Switch to OIDC federation so no long-lived %s credentials are stored as secrets": "OIDC 페더레이션으로 전환하여 %s 장기 자격 증명을 시크릿에 저장하지 않도록 하세요",
+		"The job also needs the id-token: write permission.":                              "작업에 id-token: write 권한도 필요합니다.",
+		"Job %s authenticates to %s with static credentials (%s)":                         "작업 %s가 정적 자격 증명(%[3]s)으로 %[2]s에 인증합니다",
+
+		// Artifact passing
+		"Artifact %q is uploaded by job %s and only consumed by job %s":                                                                            "아티팩트 %q는 작업 %s에서 업로드되어 작업 %s에서만 사용됩니다",
+		"If the artifact isn't needed after the run, consider merging the two jobs or passing the files through actions/cache keyed on github.sha": "실행 후 아티팩트가 필요 없다면 두 작업을 합치거나 github.sha를 키로 하는 actions/cache로 파일을 전달하는 것을 고려하세요",
+		"(upload %v + download %v per run)": "(실행당 업로드 %v + 다운로드 %v)",
+		"Job %s takes %v on average, less than twice the transfer; merging it into job %s avoids the round trip":                    "작업 %s는 평균 %v로 전송 시간의 두 배보다 짧습니다. 작업 %s에 합치면 왕복 전송을 피할 수 있습니다",
+		"Pass the files through actions/cache keyed on github.sha, which restores faster than artifact downloads for large outputs": "github.sha를 키로 하는 actions/cache로 파일을 전달하세요. 큰 출력물은 아티팩트 다운로드보다 빠르게 복원됩니다",
+
+		// Multi-arch builds
+		"Job %s builds %s in a single buildx invocation, emulating %s with QEMU": "작업 %s가 하나의 buildx 실행에서 %s를 빌드하며 %s를 QEMU로 에뮬레이션합니다",
+		"Build each platform on a native runner (e.g. ubuntu-24.04-arm for linux/arm64) in a matrix and merge the digests with docker buildx imagetools create, or use a remote native builder": "매트릭스로 각 플랫폼을 네이티브 러너(예: linux/arm64는 ubuntu-24.04-arm)에서 빌드하고 docker buildx imagetools create로 다이제스트를 병합하거나 원격 네이티브 빌더를 사용하세요",
+		"(average build time per run: %s)": "(실행당 평균 빌드 시간: %s)",
+
+		// Estimated savings
+		"Estimated Savings": "예상 절감 효과",
+		"%s averages %s across %d runs; caching typically saves ~%d%% → ~%s per run, ~%s/month": "%s는 %[3]d회 실행에서 평균 %[2]s 소요됩니다. 캐싱은 보통 ~%[4]d%% 절감 → 실행당 ~%[5]s, 월 ~%[6]s",
+
+		// Projected savings
+		"~%s per run, ~%s/month based on %d runs": "%[3]d회 실행 기준 실행당 ~%[1]s, 월 ~%[2]s",
+
+		// Sustainability
+		"Sustainability Estimate":                            "지속 가능성 추정",
+		"Total over %d runs: %.0f min, %.3f kWh, %.0f gCO2e": "%d회 실행 합계: %.0f분, %.3f kWh, %.0f gCO2e",
+		"Projected per month: %.2f kWh, %.1f kgCO2e":         "월간 예상: %.2f kWh, %.1f kgCO2e",
+		"Assumptions": "가정",
+		"%.2fW per vCPU, %.3fW per GB memory, PUE %.3f, %.0f gCO2e/kWh grid intensity": "vCPU당 %.2fW, 메모리 GB당 %.3fW, PUE %.3f, 전력망 탄소 집약도 %.0f gCO2e/kWh",
+
+		// CI migration advice
+		"CI Migration Advice": "CI 마이그레이션 안내",
+		"%d jobs":             "작업 %d개",
+		"Carries over":        "그대로 이전 가능",
+		"Needs manual rework": "수동 재작업 필요",
+		"Stages become needs: chains between jobs":                                                    "스테이지는 작업 간 needs: 체인이 됩니다",
+		"Run the job in the same image, or use runs-on with setup-* actions":                          "같은 이미지에서 작업을 실행하거나 runs-on과 setup-* 액션을 사용하세요",
+		"Service containers map one to one":                                                           "서비스 컨테이너는 일대일로 대응됩니다",
+		"Each script line becomes part of a run step":                                                 "각 script 줄은 run 단계의 일부가 됩니다",
+		"Prepend as a separate run step":                                                              "별도의 run 단계로 앞에 추가하세요",
+		"Cache key and paths carry over; use hashFiles() for lockfile keys":                           "캐시 키와 경로는 그대로 이전됩니다. 잠금 파일 키에는 hashFiles()를 사용하세요",
+		"Use upload-artifact/download-artifact between jobs":                                          "작업 간에 upload-artifact/download-artifact를 사용하세요",
+		"DAG dependencies carry over directly":                                                        "DAG 의존성은 그대로 이전됩니다",
+		"Download only the artifacts the job needs":                                                   "작업에 필요한 아티팩트만 다운로드하세요",
+		"parallel:matrix maps to a matrix; numeric parallel needs manual test splitting":              "parallel:matrix는 matrix에 대응되며, 숫자 parallel은 수동 테스트 분할이 필요합니다",
+		"Pipeline rules split into workflow triggers and job if: conditions":                          "파이프라인 규칙은 워크플로 트리거와 작업 if: 조건으로 나뉩니다",
+		"Branch filters become on.push.branches or if: conditions":                                    "브랜치 필터는 on.push.branches 또는 if: 조건이 됩니다",
+		"Use branches-ignore or negated if: conditions":                                               "branches-ignore 또는 부정 if: 조건을 사용하세요",
+		"Runner tags become self-hosted runner labels":                                                "러너 태그는 셀프 호스팅 러너 레이블이 됩니다",
+		"Job variables become env:":                                                                   "작업 변수는 env:가 됩니다",
+		"Use GitHub environments for protection rules":                                                "보호 규칙에는 GitHub environments를 사용하세요",
+		"manual jobs need workflow_dispatch or environment approvals":                                 "수동 작업에는 workflow_dispatch 또는 environment 승인이 필요합니다",
+		"No built-in job retry; use a retry action or re-run failed jobs":                             "기본 작업 재시도가 없으므로 재시도 액션을 사용하거나 실패한 작업을 다시 실행하세요",
+		"Convert the duration to minutes":                                                             "기간을 분 단위로 변환하세요",
+		"Templates become reusable workflows or composite actions":                                    "템플릿은 재사용 워크플로 또는 복합 액션이 됩니다",
+		"The primary image becomes the job container; extra images become services":                   "기본 이미지는 작업 컨테이너가 되고 추가 이미지는 services가 됩니다",
+		"Machine executors map to hosted VM runners":                                                  "machine 실행기는 호스팅 VM 러너에 대응됩니다",
+		"Pick the matching macOS runner image":                                                        "일치하는 macOS 러너 이미지를 선택하세요",
+		"Map the class to a larger runner size":                                                       "클래스를 더 큰 러너 크기에 대응시키세요",
+		"Shard tests with a matrix index; CircleCI timing-based splitting has no built-in equivalent": "matrix 인덱스로 테스트를 분할하세요. CircleCI의 시간 기반 분할에 해당하는 기본 기능은 없습니다",
+		"Direct equivalent": "직접 대응됩니다",
+		"Keys carry over; {{ checksum \"file\" }} becomes hashFiles('file')":  "키는 그대로 이전되며 {{ checksum \"file\" }}은 hashFiles('file')이 됩니다",
+		"actions/cache saves automatically in its post step":                  "actions/cache는 post 단계에서 자동으로 저장합니다",
+		"Workspaces become artifacts":                                         "워크스페이스는 아티팩트가 됩니다",
+		"Use a JUnit reporter action or the job summary":                      "JUnit 리포터 액션이나 작업 요약을 사용하세요",
+		"Docker is available on hosted runners; add Buildx for layer caching": "호스팅 러너에는 Docker가 있으며 레이어 캐싱을 위해 Buildx를 추가하세요",
+		"Replace each orb with an equivalent action":                          "각 orb를 동등한 액션으로 교체하세요",
+		"Workflow requires become needs:":                                     "워크플로 requires는 needs:가 됩니다",
+
+		// Partial results
+		"Partial results: the analysis ran out of time or API rate limit before finishing (skipped: %s)": "부분 결과: 분석이 완료되기 전에 시간 또는 API 요청 한도가 소진되었습니다 (건너뛴 단계: %s)",
+
+		// Run sampling
+		"Sampling":                   "샘플링",
+		"all (%d runs, survey mode)": "전체 (%d개 실행, survey 모드)",
+		"%s:%d (%d of %d runs)":      "%s:%d (%[4]d개 실행 중 %[3]d개)",
+
+		// Node.js package managers
+		"Check for Node.js and Python lockfiles":               "Node.js 및 Python 잠금 파일 확인",
+		"Upper bound; only when Node.js or Python is detected": "최대값이며 Node.js 또는 Python이 감지된 경우에만 조회합니다",
+		"Cache the pnpm store":                                 "pnpm 스토어 캐시",
+		"Can reduce pnpm install time by up to 60%":            "pnpm install 시간을 최대 60%까지 줄일 수 있습니다",
+		"Cache yarn dependencies":                              "yarn 의존성 캐시",
+		"Can reduce yarn install time by up to 50%":            "yarn install 시간을 최대 50%까지 줄일 수 있습니다",
+		"Cache bun dependencies":                               "bun 의존성 캐시",
+		"Avoids re-downloading packages on every bun install":  "bun install마다 패키지를 다시 다운로드하지 않습니다",
+
+		// Python tooling
+		"Cache the uv cache directory":                       "uv 캐시 디렉터리 캐시",
+		"Can reduce uv sync time to seconds on a warm cache": "캐시가 있으면 uv sync 시간을 몇 초로 줄일 수 있습니다",
+		"Cache Poetry dependencies and virtualenvs":          "Poetry 의존성과 가상 환경 캐시",
+		"Can reduce poetry install time significantly":       "poetry install 시간을 크게 줄일 수 있습니다",
+		"Cache Pipenv virtualenvs":                           "Pipenv 가상 환경 캐시",
+		"Can reduce pipenv install time significantly":       "pipenv install 시간을 크게 줄일 수 있습니다",
+		"Cache conda packages":                               "conda 패키지 캐시",
+		"Avoids re-downloading conda packages on every run":  "실행할 때마다 conda 패키지를 다시 다운로드하지 않습니다",
+
+		// Java build tools
+		"List repository files to find subprojects and Java build tools":                                        "하위 프로젝트와 Java 빌드 도구를 찾기 위한 저장소 파일 목록 조회",
+		"Gradle projects also fetch gradle.properties, and Python projects without a lockfile requirements.txt": "Gradle 프로젝트는 gradle.properties를, 잠금 파일이 없는 Python 프로젝트는 requirements.txt를 추가로 조회합니다",
+		"Enable the Gradle build cache": "Gradle 빌드 캐시 활성화",
+		"Reuses task outputs from earlier builds instead of re-running unchanged tasks": "변경되지 않은 태스크를 다시 실행하지 않고 이전 빌드의 태스크 출력을 재사용합니다",
+		"Enable the Gradle configuration cache":                                         "Gradle 구성 캐시 활성화",
+		"Skips the configuration phase when build scripts haven't changed":              "빌드 스크립트가 변경되지 않으면 구성 단계를 건너뜁니다",
+
+		// Go build analysis
+		"Job %s builds Go code without restoring the build cache (GOCACHE)":                                                                          "%s 작업은 빌드 캐시(GOCACHE)를 복원하지 않고 Go 코드를 빌드합니다",
+		"Use actions/setup-go v4 or later with caching enabled (the default), which restores ~/.cache/go-build and the module cache keyed on go.sum": "캐시가 활성화된(기본값) actions/setup-go v4 이상을 사용하세요. go.sum을 키로 ~/.cache/go-build와 모듈 캐시를 복원합니다",
+		"(no test result was cached in %d sampled runs)":                                                                                             "(샘플링된 실행 %d개에서 캐시된 테스트 결과 없음)",
+		"Job %s restores the Go build cache, but no test result was cached in %d sampled runs":                                                       "%s 작업은 Go 빌드 캐시를 복원하지만 샘플링된 실행 %d개에서 캐시된 테스트 결과가 없습니다",
+		"Test results are only reused when the package, its environment variables and the files it reads are unchanged; check that cache-dependency-path covers every go.sum and that tests don't depend on per-run values such as timestamps or temporary paths": "테스트 결과는 패키지, 환경 변수, 읽는 파일이 바뀌지 않았을 때만 재사용됩니다. cache-dependency-path가 모든 go.sum을 포함하는지, 테스트가 타임스탬프나 임시 경로 같은 실행마다 달라지는 값에 의존하지 않는지 확인하세요",
+		"Step %q in job %s may rewrite go.mod and go.sum during the build": "%[2]s 작업의 %[1]q 단계가 빌드 중 go.mod와 go.sum을 수정할 수 있습니다",
+		"Set GOFLAGS=-mod=readonly in the workflow env so builds fail when go.mod is out of date instead of silently updating it, and check tidiness with go mod tidy -diff (Go 1.23+)": "워크플로 env에 GOFLAGS=-mod=readonly를 설정해 go.mod가 오래되었을 때 조용히 수정하지 않고 빌드가 실패하도록 하고, go mod tidy -diff(Go 1.23+)로 정리 상태를 확인하세요",
+		"go mod download in job %s takes %v per run on average": "%s 작업의 go mod download는 실행당 평균 %v 걸립니다",
+		"Modules are still downloaded with the module cache restored. Check that cache-dependency-path covers every go.sum; vendoring (go mod vendor with -mod=vendor) removes the download from proxy.golang.org entirely, at the cost of a larger repository and noisier dependency diffs": "모듈 캐시를 복원해도 모듈을 계속 다운로드합니다. cache-dependency-path가 모든 go.sum을 포함하는지 확인하세요. 벤더링(go mod vendor와 -mod=vendor)은 proxy.golang.org 다운로드를 완전히 없애지만 저장소가 커지고 의존성 diff가 늘어납니다",
+		"Restore the module cache with actions/setup-go so modules come from the cache instead of proxy.golang.org on every run; vendoring is the alternative when the proxy is slow or unreachable, at the cost of a larger repository":                                                     "actions/setup-go로 모듈 캐시를 복원해 매 실행마다 proxy.golang.org 대신 캐시에서 모듈을 가져오세요. 프록시가 느리거나 접근할 수 없다면 저장소가 커지는 대신 벤더링을 사용할 수 있습니다",
+		"Go tests take %v per run on average; slowest packages: %s": "Go 테스트는 실행당 평균 %v 걸립니다. 가장 느린 패키지: %s",
+		"Give slow packages their own matrix job, skip long tests on pull requests with testing.Short(), and check that independent tests call t.Parallel()": "느린 패키지는 별도 매트릭스 작업으로 분리하고, 풀 리퀘스트에서는 testing.Short()로 긴 테스트를 건너뛰며, 독립적인 테스트가 t.Parallel()을 호출하는지 확인하세요",
+		"Job %s runs go test with -race, which typically makes tests 2-20x slower and uses 5-10x more memory":                                                "%s 작업은 -race로 go test를 실행하며, 이는 보통 테스트를 2-20배 느리게 하고 메모리를 5-10배 더 사용합니다",
+		"Job %s runs go test with -race in every matrix entry; the race detector typically makes tests 2-20x slower and uses 5-10x more memory":              "%s 작업은 모든 매트릭스 항목에서 -race로 go test를 실행합니다. 레이스 감지기는 보통 테스트를 2-20배 느리게 하고 메모리를 5-10배 더 사용합니다",
+		"(measured %v per run)": "(측정값: 실행당 %v)",
+		"Run the race detector in a single matrix entry, or on pushes to the default branch and a nightly schedule, and keep pull request tests without -race": "레이스 감지기는 매트릭스 항목 하나에서만, 또는 기본 브랜치 푸시와 야간 스케줄에서 실행하고 풀 리퀘스트 테스트는 -race 없이 유지하세요",
+
+		// Monorepo subprojects
+		"Directory": "디렉터리",
+
+		// Supply-chain checks
+		"its repository is archived":           "저장소가 보관(archived) 처리됨",
+		"no release and no push in %d months":  "%d개월 동안 릴리스와 푸시 없음",
+		"no release in %d months":              "%d개월 동안 릴리스 없음",
+		"a single contributor":                 "기여자가 한 명뿐",
+		"Action %s is a supply-chain risk: %s": "액션 %s는 공급망 위험이 있습니다: %s",
+		"Pin it to a full commit SHA and review its code, or replace it with a maintained action or an inline script": "전체 커밋 SHA로 고정하고 코드를 검토하거나, 유지 관리되는 액션 또는 인라인 스크립트로 교체하세요",
+		"Replace it with %s": "%s(으)로 교체하세요",
+		"Check whether third-party action repositories are archived":  "서드파티 액션 저장소의 보관 여부 확인",
+		"Check when third-party actions last released":                "서드파티 액션의 마지막 릴리스 시점 확인",
+		"Check whether third-party actions have a single contributor": "서드파티 액션의 기여자가 한 명뿐인지 확인",
+		"Upper bound; once per action repository":                     "상한값이며 액션 저장소마다 한 번 조회합니다",
+
+		// Workflow patches
+		"Suggested Patches": "제안 패치",
+		"Save as a file and apply with git apply after review:":                 "파일로 저장한 뒤 검토 후 git apply로 적용하세요:",
+		"Look up release tags to pin unpinned actions in the suggested patches": "제안 패치에서 고정되지 않은 액션을 고정할 릴리스 태그 조회",
+		"Upper bound; only actions without a version or tracking a branch":      "상한값이며 버전이 없거나 브랜치를 추적하는 액션만 조회합니다",
+
+		// Actions cache usage
+		"Actions Cache Usage":                      "Actions 캐시 사용량",
+		"%s of %s in %d caches":                    "캐시 %[3]d개, %[1]s / %[2]s",
+		"Stale (unused for 3 days): %d caches, %s": "오래됨(3일간 미사용): 캐시 %d개, %s",
+		"Saved by pull requests: %s":               "풀 리퀘스트가 저장한 캐시: %s",
+		"Cache usage is at %.0f%% of the 10 GB limit. Beyond it, GitHub evicts the least recently used caches, which can include ones every run restores":                                                                 "캐시 사용량이 10 GB 한도의 %.0f%%입니다. 한도를 넘으면 GitHub가 가장 오래 사용되지 않은 캐시부터 삭제하며, 매 실행마다 복원하는 캐시도 포함될 수 있습니다",
+		"%d caches (%s) haven't been used in 3 days. Delete them with gh cache delete or a scheduled cleanup workflow so they don't push out caches in active use":                                                        "캐시 %d개(%s)가 3일 동안 사용되지 않았습니다. 사용 중인 캐시가 밀려나지 않도록 gh cache delete 또는 예약된 정리 워크플로로 삭제하세요",
+		"Pull request caches take %s but can only be restored by the pull request that saved them. Save caches on the default branch only, using actions/cache/restore in pull requests and actions/cache/save on pushes": "풀 리퀘스트 캐시가 %s를 차지하지만 저장한 풀 리퀘스트에서만 복원할 수 있습니다. 풀 리퀘스트에서는 actions/cache/restore를, 푸시에서는 actions/cache/save를 사용해 기본 브랜치에서만 캐시를 저장하세요",
+		"Key %q is saved in %d variants (%s). Drop per-run values such as github.sha or github.run_id from the key and rely on restore-keys":                                                                              "키 %q가 %d가지 변형(%s)으로 저장되어 있습니다. 키에서 github.sha나 github.run_id 같은 실행별 값을 빼고 restore-keys를 활용하세요",
+		"Get the total size of the repository's Actions caches":                     "저장소 Actions 캐시의 전체 크기 조회",
+		"List Actions caches for the stale, pull request and key variant breakdown": "오래된 캐시, 풀 리퀘스트 캐시, 키 변형 분석을 위한 Actions 캐시 목록 조회",
+		"Upper bound; 100 caches per page, only when caches exist":                  "상한값이며 페이지당 캐시 100개, 캐시가 있을 때만 조회합니다",
+
+		// Workflow chains
+		"Workflow Chain":                                               "워크플로 체인",
+		"%v on average over %d runs":                                   "실행 %[2]d회 평균 %[1]v",
+		"starts %v after the triggering run":                           "트리거한 실행 완료 %v 후 시작",
+		"End-to-end lead time: %v on average, %v at most over %d runs": "전체 리드 타임: 실행 %[3]d회 기준 평균 %[1]v, 최대 %[2]v",
+		"Fetch the other workflow files to find workflow_run chains and deploy workflows": "workflow_run 체인과 배포 워크플로를 찾기 위해 다른 워크플로 파일 조회",
+		"Upper bound; also lists the repository tree once":                                "상한값이며 저장소 트리도 한 번 조회합니다",
+		"List runs of the workflows chained by workflow_run":                              "workflow_run으로 연결된 워크플로의 실행 목록 조회",
+		"Upper bound; only workflows linked to the analyzed one":                          "상한값이며 분석 대상과 연결된 워크플로만 조회합니다",
+
+		// DORA metrics
+		"DORA Metrics":                                           "DORA 지표",
+		"Deploy workflows":                                       "배포 워크플로",
+		"Deployment frequency: %.1f per week":                    "배포 빈도: 주당 %.1f회",
+		"Lead time for changes: %v median":                       "변경 리드 타임: 중앙값 %v",
+		"Change failure rate: %.0f%% (%d of %d deployments)":     "변경 실패율: %.0f%% (배포 %[3]d회 중 %[2]d회)",
+		"Time to restore: %v median over %d recoveries":          "복구 시간: 복구 %[2]d회 중앙값 %[1]v",
+		"Measured over the last %d days of deploy workflow runs": "최근 %d일간의 배포 워크플로 실행 기준",
+		"Elite":  "엘리트",
+		"High":   "높음",
+		"Medium": "중간",
+		"Low":    "낮음",
+		"List runs of the deploy workflows for the DORA metrics":    "DORA 지표를 위해 배포 워크플로의 실행 목록 조회",
+		"Upper bound; only configured or detected deploy workflows": "상한값이며 지정되었거나 감지된 배포 워크플로만 조회합니다",
+
+		// Markdown report
+		"Step":     "스텝",
+		"Severity": "심각도",
+		"Location": "위치",
+		"Finding":  "검사 결과",
+
+		// Shell checks
+		"Job %s runs on Windows and other platforms without a default shell, so its run steps use PowerShell on Windows and bash elsewhere":                           "%s 작업은 기본 셸 없이 Windows와 다른 플랫폼에서 실행되므로 run 단계이 Windows에서는 PowerShell, 그 외에서는 bash로 실행됩니다",
+		"Set defaults.run.shell so every matrix entry runs the same shell; Git Bash is installed on GitHub-hosted Windows runners":                                    "모든 매트릭스 항목이 같은 셸을 쓰도록 defaults.run.shell을 설정하세요. GitHub 호스팅 Windows 러너에는 Git Bash가 설치되어 있습니다",
+		"Step %q in job %s is written for bash but runs with PowerShell, the default shell on Windows":                                                                "%[2]s 작업의 %[1]q 단계은 bash용으로 작성되었지만 Windows 기본 셸인 PowerShell로 실행됩니다",
+		"Set shell: bash on the step, or defaults.run.shell for the job; Git Bash is installed on GitHub-hosted Windows runners":                                      "단계에 shell: bash를 지정하거나 작업에 defaults.run.shell을 설정하세요. GitHub 호스팅 Windows 러너에는 Git Bash가 설치되어 있습니다",
+		"Step %q in job %s pipes commands with the implicit default shell, which doesn't set pipefail, so a failure before the last command of a pipeline is ignored": "%[2]s 작업의 %[1]q 단계은 pipefail을 설정하지 않는 암묵적 기본 셸에서 파이프를 사용하므로 파이프라인 마지막 명령 이전의 실패가 무시됩니다",
+		"Set defaults.run.shell to bash, which runs scripts with -eo pipefail":                                                                                        "defaults.run.shell을 bash로 설정하면 스크립트가 -eo pipefail로 실행됩니다",
+		"Step %q in job %s runs with shell %q, which keeps going after a command fails":                                                                               "%[2]s 작업의 %[1]q 단계은 명령이 실패해도 계속 진행하는 셸 %[3]q로 실행됩니다",
+		"Add -e to the shell command (e.g. bash -eo pipefail {0}) or start the script with set -e":                                                                    "셸 명령에 -e를 추가하거나(예: bash -eo pipefail {0}) 스크립트를 set -e로 시작하세요",
+		"Step %q in job %s runs a multi-line PowerShell script; a failing native command other than the last one doesn't fail the step":                               "%[2]s 작업의 %[1]q 단계은 여러 줄 PowerShell 스크립트를 실행하며, 마지막이 아닌 네이티브 명령이 실패해도 단계은 실패하지 않습니다",
+		"Set $PSNativeCommandUseErrorActionPreference = $true at the top of the script (PowerShell 7.3+), or check $LASTEXITCODE after each command":                  "스크립트 맨 위에 $PSNativeCommandUseErrorActionPreference = $true를 설정하거나(PowerShell 7.3+) 각 명령 뒤에 $LASTEXITCODE를 확인하세요",
+		"Step %q in job %s runs a multi-line cmd script; only the last command's exit code fails the step":                                                            "%[2]s 작업의 %[1]q 단계은 여러 줄 cmd 스크립트를 실행하며, 마지막 명령의 종료 코드만 단계을 실패시킵니다",
+		"Append || exit /b to each command, or use shell: pwsh or bash":                                                                                               "각 명령 뒤에 || exit /b를 붙이거나 shell: pwsh 또는 bash를 사용하세요",
+
+		// OS package installs
+		"Step %q in job %s installs %s packages (%s) on every run, taking %v on average":                                                                                                                     "%[2]s 작업의 %[1]q 단계는 매 실행마다 %[3]s 패키지(%[4]s)를 설치하며 평균 %[5]v가 걸립니다",
+		"%s already ship with the GitHub-hosted runner images, so installing them again can usually be dropped":                                                                                              "%s은(는) GitHub 호스팅 러너 이미지에 이미 포함되어 있으므로 대개 설치를 생략할 수 있습니다",
+		"Cache the packages with awalsh128/cache-apt-pkgs-action, or run the job in a container image with them baked in":                                                                                    "awalsh128/cache-apt-pkgs-action으로 패키지를 캐시하거나, 패키지가 미리 설치된 컨테이너 이미지에서 작업을 실행하세요",
+		"Pass --no-install-recommends so apt only installs what the packages require":                                                                                                                        "apt가 필요한 패키지만 설치하도록 --no-install-recommends를 지정하세요",
+		"Set HOMEBREW_NO_AUTO_UPDATE and HOMEBREW_NO_INSTALL_CLEANUP so brew install doesn't update Homebrew itself first, which often takes most of the step; prefer tools preinstalled on the macOS image": "brew install이 먼저 Homebrew 자체를 업데이트하지 않도록 HOMEBREW_NO_AUTO_UPDATE와 HOMEBREW_NO_INSTALL_CLEANUP을 설정하세요. 이 업데이트가 단계 시간의 대부분을 차지하는 경우가 많습니다. macOS 이미지에 미리 설치된 도구를 우선 사용하세요",
+		"Pass --no-progress to choco install, cache the Chocolatey package cache with actions/cache, or use the tools preinstalled on the Windows image":                                                     "choco install에 --no-progress를 지정하고 actions/cache로 Chocolatey 패키지 캐시를 캐시하거나, Windows 이미지에 미리 설치된 도구를 사용하세요",
+
+		// Container image pulls
+		"Job %s spends %v per run on average starting its containers (%s)": "%[1]s 작업은 컨테이너(%[3]s)를 시작하는 데 실행당 평균 %[2]v를 사용합니다",
+		"image pulls: %s": "이미지 풀: %s",
+		"Mirror Docker Hub images to ghcr.io, which is closer to GitHub-hosted runners and not subject to Docker Hub's pull rate limits":                                                       "Docker Hub 이미지를 ghcr.io로 미러링하세요. GitHub 호스팅 러너와 더 가깝고 Docker Hub의 풀 속도 제한을 받지 않습니다",
+		"Use smaller variants such as -slim or -alpine tags, which have fewer and smaller layers to download":                                                                                  "-slim이나 -alpine 태그 같은 더 작은 변형을 사용하세요. 내려받을 레이어가 더 적고 작습니다",
+		"On self-hosted runners, bake the images into the runner or use a registry pull-through cache so they're pulled once per runner instead of once per job":                               "셀프 호스팅 러너에서는 이미지를 러너에 미리 넣어 두거나 레지스트리 pull-through 캐시를 사용해 작업마다가 아니라 러너마다 한 번만 풀하도록 하세요",
+		"GitHub-hosted runners start without cached images, so every job pulls them again; caching images at the runner level needs self-hosted runners or larger runners with a custom image": "GitHub 호스팅 러너는 캐시된 이미지 없이 시작하므로 모든 작업이 이미지를 다시 풀합니다. 러너 수준의 이미지 캐시에는 셀프 호스팅 러너나 커스텀 이미지를 쓰는 라지 러너가 필요합니다",
+
+		// Style checks
+		"Job ID %s doesn't follow the %s naming used by the other jobs":                                   "작업 ID %s는 다른 작업이 사용하는 %s 명명 규칙을 따르지 않습니다",
+		"Rename the job to match; remember to update needs: and required status checks that refer to it":  "규칙에 맞게 작업 이름을 바꾸세요. 이 작업을 참조하는 needs:와 필수 상태 검사도 함께 수정해야 합니다",
+		"Step %d of job %s runs a multi-line script without a name, so the log only shows its first line": "%[2]s 작업의 %[1]d번째 단계는 이름 없이 여러 줄 스크립트를 실행하므로 로그에는 첫 줄만 표시됩니다",
+		"Add a name: that says what the step does":                                                        "단계가 하는 일을 나타내는 name:을 추가하세요",
+		"Action %s is referenced as @%s here but as @%s earlier in the workflow":                          "%s 액션은 여기서는 @%s로, 워크플로 앞부분에서는 @%s로 참조됩니다",
+		"Use the same version of an action everywhere in a workflow so upgrades update every step":        "업그레이드가 모든 단계에 적용되도록 워크플로 전체에서 같은 액션 버전을 사용하세요",
+		"%d of %d step names start with a lowercase letter, unlike the rest":                              "단계 이름 %[2]d개 중 %[1]d개가 나머지와 달리 소문자로 시작합니다",
+		"Capitalize step names consistently so the log reads evenly":                                      "로그가 고르게 읽히도록 단계 이름의 대소문자를 일관되게 맞추세요",
+		"Most actions are pinned by %s, unlike %s":                                                        "대부분의 액션은 %s로 고정되어 있지만 %s는 다릅니다",
+		"commit SHA":   "커밋 SHA",
+		"major tag":    "메이저 태그",
+		"full version": "전체 버전",
+		"branch":       "브랜치",
+		"Pin every action the same way; commit SHAs with a version comment are the most robust":                                                          "모든 액션을 같은 방식으로 고정하세요. 버전 주석을 단 커밋 SHA가 가장 견고합니다",
+		"Job %s is the only job of the workflow and has %d steps":                                                                                        "%s 작업은 워크플로의 유일한 작업이며 단계가 %d개입니다",
+		"Split it into jobs such as lint, test and build linked with needs:, so independent parts run in parallel and a failure points at the right job": "lint, test, build 같은 작업으로 나누고 needs:로 연결하세요. 독립적인 부분이 병렬로 실행되고 실패한 위치도 바로 드러납니다",
+
+		// Combined reports
+		"Also found in %d more places": "그 밖에 %d곳에서도 발견됨",
+
+		// Run timings by conclusion
+		"Successful runs: %d, %v on average, %v at most":          "성공한 실행: %d회, 평균 %v, 최대 %v",
+		"Failed runs: %d, %v on average, %v at most":              "실패한 실행: %d회, 평균 %v, 최대 %v",
+		"Left out of the timings: %d runs in progress or skipped": "시간 통계에서 제외: 진행 중이거나 건너뛴 실행 %d회",
+
+		// Cancelled runs
+		"Cancelled runs: %d, %d of them superseded by a newer run of the same branch, avoiding about %v of duplicate work": "취소된 실행: %d회, 그중 %d회는 같은 브랜치의 새 실행으로 대체되어 약 %v의 중복 작업을 피함",
+		"Cancelled runs: %d, left out of the timings": "취소된 실행: %d회, 시간 통계에서 제외",
+
+		// Cache key entropy
+		"Cache key %q in job %s changes on every run, so the cache is never restored":                                                                                     "%[2]s 작업의 캐시 키 %[1]q는 실행마다 바뀌므로 캐시가 복원되지 않습니다",
+		"Key the cache on the hash of the lockfile instead of run IDs, timestamps or commits, and add restore-keys so a changed lockfile still restores the latest cache": "실행 ID, 타임스탬프, 커밋 대신 잠금 파일의 해시를 캐시 키로 사용하고, 잠금 파일이 바뀌어도 최신 캐시가 복원되도록 restore-keys를 추가하세요",
+		"it missed in %d lookups and was never restored":                                                                                                                  "%d회 조회에서 모두 적중하지 않았고 한 번도 복원되지 않았습니다",
+		"Cache key %q in job %s doesn't change with the cached content, so it keeps restoring stale data":                                                                 "%[2]s 작업의 캐시 키 %[1]q는 캐시 내용이 바뀌어도 변하지 않아 오래된 데이터를 계속 복원합니다",
+		"A saved cache key is never overwritten, so add the hash of the lockfile to the key to save a fresh cache whenever dependencies change":                           "저장된 캐시 키는 덮어쓰이지 않으므로, 의존성이 바뀔 때마다 새 캐시가 저장되도록 키에 잠금 파일의 해시를 추가하세요",
+		"it was restored in %d lookups and never saved again":                                                                                                             "%d회 조회에서 복원되었고 다시 저장된 적이 없습니다",
+
+		// Disk space
+		"A job of this workflow": "이 워크플로의 작업",
+		"Job %s":                 "%s 작업",
+		"%s ran out of disk space in %d of %d runs": "%s에서 실행 %[3]d회 중 %[2]d회 디스크 공간이 부족했습니다",
+		"%s ran low on disk space in %d of %d runs": "%s에서 실행 %[3]d회 중 %[2]d회 디스크 공간이 부족해졌습니다",
+		"%s filled the root filesystem to %d%%":     "%s에서 루트 파일 시스템을 %d%%까지 채웠습니다",
+		"as little as %d MB was left":               "남은 공간이 %d MB까지 줄었습니다",
+		"df showed the root filesystem %d%% full":   "df 기준 루트 파일 시스템 사용률 %d%%",
+		"Free about 30 GB at the start of the job by removing the preinstalled toolchains it doesn't use, or move it to a larger runner with more disk space":                                                   "작업 시작 시 사용하지 않는 사전 설치 도구 체인을 삭제해 약 30 GB를 확보하거나, 디스크가 더 큰 라지 러너로 옮기세요",
+		"Clean the workspace and prune Docker images and build cache on the self-hosted runner between jobs, or give it a larger disk":                                                                          "작업 사이에 셀프 호스티드 러너의 작업 공간을 정리하고 Docker 이미지와 빌드 캐시를 정리하거나, 더 큰 디스크를 할당하세요",
+		"Image builds keep every layer and the build cache on disk; prune them between builds with docker builder prune, or push with docker/build-push-action instead of loading images into the local daemon": "이미지 빌드는 모든 레이어와 빌드 캐시를 디스크에 남기므로, 빌드 사이에 docker builder prune으로 정리하거나 로컬 데몬에 로드하지 말고 docker/build-push-action으로 바로 푸시하세요",
+
+		// Network flakiness
+		"timeout":          "시간 초과",
+		"connection reset": "연결 재설정",
+		"DNS":              "DNS",
+		"TLS handshake":    "TLS 핸드셰이크",
+		"server error":     "서버 오류",
+		"Transient network errors reaching %s in %d of %d runs (%s)": "실행 %[3]d회 중 %[2]d회에서 %[1]s 접속 중 일시적인 네트워크 오류 발생 (%[4]s)",
+		"%d of those runs failed":                                    "그중 %d회는 실패했습니다",
+		"Install from the lockfile with npm ci, yarn install --frozen-lockfile or pnpm install --frozen-lockfile, cache the package store, and raise npm's fetch-retries": "npm ci, yarn install --frozen-lockfile, pnpm install --frozen-lockfile로 잠금 파일 기준 설치를 하고, 패키지 저장소를 캐시하며, npm의 fetch-retries를 늘리세요",
+		"Install from a pinned requirements or lock file, cache pip's download cache, and pass --retries 5 --timeout 60 to pip":                                           "버전이 고정된 requirements 또는 잠금 파일로 설치하고, pip 다운로드 캐시를 캐시하며, pip에 --retries 5 --timeout 60을 전달하세요",
+		"Retry image pulls, and mirror the images to a registry close to the runners such as ghcr.io or a pull-through cache":                                             "이미지 풀을 재시도하고, ghcr.io나 풀스루 캐시처럼 러너에 가까운 레지스트리로 이미지를 미러링하세요",
+		"Let apt retry with -o Acquire::Retries=3, or bake the packages into a container image so jobs don't install them on every run":                                   "-o Acquire::Retries=3으로 apt가 재시도하게 하거나, 패키지를 컨테이너 이미지에 포함해 작업마다 설치하지 않도록 하세요",
+		"Cache ~/.m2 or ~/.gradle keyed on the build files, and resolve dependencies through a repository mirror with retries":                                            "빌드 파일을 키로 ~/.m2 또는 ~/.gradle을 캐시하고, 재시도가 설정된 저장소 미러를 통해 의존성을 받으세요",
+		"Cache the Go module cache keyed on go.sum so modules are downloaded only when dependencies change":                                                               "go.sum을 키로 Go 모듈 캐시를 캐시해 의존성이 바뀔 때만 모듈을 다운로드하세요",
+		"Retry downloads with backoff, e.g. curl --retry 5 --retry-all-errors, and cache downloaded tools with actions/cache or a setup action":                           "curl --retry 5 --retry-all-errors처럼 백오프로 다운로드를 재시도하고, 다운로드한 도구는 actions/cache나 setup 액션으로 캐시하세요",
+		"Retry the step with backoff, e.g. with nick-fields/retry, and fetch through a mirror or caching proxy closer to the runners":                                     "nick-fields/retry 등으로 단계를 백오프 재시도하고, 러너에 가까운 미러나 캐싱 프록시를 통해 받으세요",
+
+		// github-script
+		"Step %q in job %s runs a %d-line inline github-script": "%[2]s 작업의 단계 %[1]q는 %[3]d줄짜리 인라인 github-script를 실행합니다",
+		"Move the script into a versioned file under .github/scripts that exports a function, so it can be linted and unit tested, or into a custom JavaScript action when several workflows share it; the job must check out the repository first": "스크립트를 함수를 내보내는 .github/scripts 아래의 버전 관리 파일로 옮겨 린트와 단위 테스트가 가능하게 하거나, 여러 워크플로가 공유한다면 커스텀 JavaScript 액션으로 만드세요. 작업은 먼저 저장소를 체크아웃해야 합니다",
+		"github-script step %q in job %s relies on the default token permissions for its API calls":                                                       "%[2]s 작업의 github-script 단계 %[1]q는 API 호출에 기본 토큰 권한을 사용합니다",
+		"Declare the permissions the script needs on the job, so it keeps working when the default token permissions are read-only and gets nothing more": "스크립트에 필요한 권한을 작업에 선언해, 기본 토큰 권한이 읽기 전용이어도 동작하고 그 이상의 권한은 받지 않도록 하세요",
+		"github-script step %q in job %s calls APIs needing %s, which the job's permissions don't grant":                                                  "%[2]s 작업의 github-script 단계 %[1]q는 %[3]s 권한이 필요한 API를 호출하지만 작업 권한에 없습니다",
+		"Grant the missing permissions on the job, or the API calls fail with 403 Resource not accessible by integration":                                 "작업에 누락된 권한을 부여하세요. 그렇지 않으면 API 호출이 403 Resource not accessible by integration으로 실패합니다",
+
+		// Policy enforcement
+		"The workflow": "워크플로",
+		"%s grants write-all permissions, which the policy forbids":                                "%s에 정책이 금지하는 write-all 권한이 부여되어 있습니다",
+		"Grant only the scopes the jobs need, e.g. contents: read":                                 "contents: read처럼 작업에 필요한 범위만 부여하세요",
+		"%s grants %s: %s, but the policy allows at most %s":                                       "%[1]s에 %[2]s: %[3]s 권한이 부여되어 있지만 정책은 최대 %[4]s까지 허용합니다",
+		"Lower %s to %s, or ask for an exception to the policy":                                    "%s 권한을 %s로 낮추거나 정책 예외를 요청하세요",
+		"%s runs on %q, which isn't an allowed runner label":                                       "%s이(가) 허용되지 않은 러너 레이블 %q에서 실행됩니다",
+		"Use one of the allowed runner labels: %s":                                                 "허용된 러너 레이블 중 하나를 사용하세요: %s",
+		"%s calls the reusable workflow %s, which the policy doesn't allow":                        "%s이(가) 정책에서 허용하지 않는 재사용 워크플로 %s을(를) 호출합니다",
+		"%s uses %s, which the policy doesn't allow":                                               "%s이(가) 정책에서 허용하지 않는 %s을(를) 사용합니다",
+		"Replace it with an approved action or workflow, or ask for it to be added to the policy":  "승인된 액션이나 워크플로로 교체하거나 정책에 추가해 달라고 요청하세요",
+		"%s doesn't declare permissions, so it gets the default token permissions":                 "%s이(가) 권한을 선언하지 않아 기본 토큰 권한을 받습니다",
+		"Declare permissions on the workflow or the job; the policy requires explicit permissions": "워크플로나 작업에 권한을 선언하세요. 정책상 명시적인 권한이 필요합니다",
+
+		// Custom Rego policies
+		"Custom policies could not be evaluated: %v":                                                              "사용자 정의 정책을 평가하지 못했습니다: %v",
+		"Fix the policy error; test policies locally with opa eval -d <policy_dir> -i <input.json> data.analyzer": "정책 오류를 수정하세요. opa eval -d <policy_dir> -i <input.json> data.analyzer로 로컬에서 정책을 테스트할 수 있습니다",
+
+		// Cached reports
+		"No changes: the workflow file and its runs are unchanged since the analysis at %s, so its report is reused": "변경 없음: %s 분석 이후 워크플로 파일과 실행 기록이 바뀌지 않아 그 보고서를 재사용합니다",
+
+		// Cost, trend and grade
+		"Workflow Grade":                             "워크플로 등급",
+		"Cost Estimate":                              "비용 추정",
+		"%d billable min × $%.3f = $%.2f":            "과금 %d분 × $%.3f = $%.2f",
+		"Total over %d runs: %d billable min, $%.2f": "%d회 실행 합계: 과금 %d분, $%.2f",
+		"Projected per month: $%.2f":                 "월 예상: $%.2f",
+		"GitHub's list prices for private repositories; standard runners are free for public repositories and self-hosted runners aren't billed": "비공개 저장소 기준 GitHub 정가입니다. 공개 저장소의 표준 러너는 무료이며 셀프 호스티드 러너는 과금되지 않습니다",
+		"Trend: recent successful runs are %d%% slower, %v on average against %v before":                                                         "추세: 최근 성공한 실행이 %d%% 느려졌습니다. 평균 %v (이전 %v)",
+		"Trend: recent successful runs are %d%% faster, %v on average against %v before":                                                         "추세: 최근 성공한 실행이 %d%% 빨라졌습니다. 평균 %v (이전 %v)",
+		"Trend: run times are stable":     "추세: 실행 시간이 안정적입니다",
+		"failure rate %.0f%%, was %.0f%%": "실패율 %.0f%% (이전 %.0f%%)",
+
+		// Built-in setup-* caches
+		"Job %s installs dependencies with %s, but %s doesn't cache them":                                                                                             "작업 %[1]s는 %[2]s로 의존성을 설치하지만 %[3]s가 이를 캐시하지 않습니다",
+		"Add cache: %s to the step's inputs; it restores and saves the package manager's download cache keyed on the lockfile, without a separate actions/cache step": "단계 입력에 cache: %s를 추가하세요. 별도의 actions/cache 단계 없이 lockfile을 키로 패키지 매니저의 다운로드 캐시를 복원하고 저장합니다",
+		"Turn on the cache input of actions/setup-go v3, or upgrade to v4 or later, which caches by default":                                                          "actions/setup-go v3의 cache 입력을 켜거나, 기본으로 캐시하는 v4 이상으로 업그레이드하세요",
+
+		// Digest mode
+		"Workflow Digest":        "워크플로 다이제스트",
+		"Metric Changes":         "지표 변화",
+		"New Findings":           "새 발견 사항",
+		"Resolved Findings":      "해결된 발견 사항",
+		"Metric":                 "지표",
+		"Before":                 "이전",
+		"After":                  "현재",
+		"Average successful run": "성공한 실행 평균 시간",
+		"Failure rate":           "실패율",
+		"Estimated monthly cost": "예상 월 비용",
+		"First digest: this analysis is the baseline for the next one": "첫 다이제스트: 이번 분석이 다음 다이제스트의 기준이 됩니다",
+		"No changes since %s": "%s 이후 변경 사항 없음",
+		"Changes since %s":    "%s 이후 변경 사항",
+
+		// Called workflows and actions
+		"Fetch the reusable workflows and actions called, %d levels deep": "호출된 재사용 워크플로와 액션을 %d단계 깊이까지 가져오기",
+		"Upper bound; once per called workflow or action":                 "상한값. 호출된 워크플로나 액션마다 한 번",
+
+		// Custom action linting
+		"Input %s of the action has no description":                                                                                                      "액션의 입력 %s에 설명이 없습니다",
+		"Describe every input; the description is what users see in the Marketplace and in editor completions":                                           "모든 입력에 설명을 추가하세요. 설명은 Marketplace와 에디터 자동 완성에 표시됩니다",
+		"The action runs on %s, which is end of life and deprecated on GitHub's runners":                                                                 "액션이 %s에서 실행되며, 이는 지원이 종료되어 GitHub 러너에서 더 이상 권장되지 않습니다",
+		"Move to a supported runtime and test the action on it; callers get warnings, and the runner may force the newer runtime anyway":                 "지원되는 런타임으로 옮기고 그 위에서 액션을 테스트하세요. 호출하는 쪽에 경고가 표시되며, 러너가 새 런타임을 강제할 수도 있습니다",
+		"Composite step uses %s, which isn't pinned to a commit SHA":                                                                                     "컴포지트 단계가 커밋 SHA로 고정되지 않은 %s를 사용합니다",
+		"Pin actions inside a composite action to a full commit SHA with the version in a comment; every workflow using the action inherits a moved tag": "컴포지트 액션 안의 액션은 전체 커밋 SHA로 고정하고 버전은 주석으로 남기세요. 태그가 옮겨지면 이 액션을 쓰는 모든 워크플로가 영향을 받습니다",
+		"The action has no branding":                                        "액션에 branding이 없습니다",
+		"Add an icon and color so the action stands out in the Marketplace": "Marketplace에서 눈에 띄도록 아이콘과 색상을 추가하세요",
+		"The action's image %s isn't pinned by digest":                      "액션의 이미지 %s가 다이제스트로 고정되지 않았습니다",
+		"Pin the image by digest, e.g. image:tag@sha256:..., so every run of the action uses the same image; update the digest with Dependabot or Renovate": "액션의 모든 실행이 같은 이미지를 쓰도록 image:tag@sha256:... 처럼 다이제스트로 고정하고, Dependabot이나 Renovate로 다이제스트를 갱신하세요",
+		"The action's image %s floats on the latest tag":                                         "액션의 이미지 %s가 latest 태그를 따라갑니다",
+		"Fetch the repository's own action.yml files and Docker action Dockerfiles to lint them": "저장소 자체의 action.yml 파일과 Docker 액션 Dockerfile을 가져와 검사",
+		"Upper bound; only when the repository has actions":                                      "상한값. 저장소에 액션이 있을 때만",
+
+		// Unused workflows
+		"disabled manually": "수동으로 비활성화되었습니다",
+		"disabled after 60 days without repository activity":                                                     "저장소 활동이 60일간 없어 비활성화되었습니다",
+		"Workflow %s is triggered by %s, which isn't a GitHub Actions event":                                     "워크플로 %s의 트리거 %s는 GitHub Actions 이벤트가 아닙니다",
+		"Check the spelling against the events GitHub documents; a misspelled trigger never starts the workflow": "GitHub 문서의 이벤트 이름과 철자를 비교해 보세요. 철자가 틀린 트리거는 워크플로를 시작하지 않습니다",
+		"Workflow %s is %s": "워크플로 %s: %s",
+		"Delete the workflow file if it's no longer needed, or enable it again; a disabled workflow still reads as live to anyone browsing the repository": "더 이상 필요 없다면 워크플로 파일을 삭제하고, 아니면 다시 활성화하세요. 비활성화된 워크플로도 저장소를 보는 사람에게는 사용 중인 것처럼 보입니다",
+		"Workflow %s hasn't run in the last %d days": "워크플로 %s가 최근 %d일 동안 실행되지 않았습니다",
+		"Delete the workflow if it's dead, or check its triggers: branch and path filters that no longer match keep it from ever starting": "사용하지 않는 워크플로라면 삭제하고, 아니면 트리거를 확인하세요. 더 이상 일치하지 않는 브랜치와 경로 필터 때문에 시작되지 않을 수 있습니다",
+		"List the repository's workflows to find disabled ones":                                                                            "비활성화된 워크플로를 찾기 위해 저장소의 워크플로 목록 조회",
+		"Count each workflow's runs in the last %d days to find workflows that never run":                                                  "실행되지 않는 워크플로를 찾기 위해 최근 %d일간 각 워크플로의 실행 수 집계",
+		"Upper bound; once per workflow file":                                                                                              "상한값. 워크플로 파일마다 한 번",
+
+		// Toolchain end of life
+		"Job %s installs %s %s, which has reached end of life":                             "작업 %s가 지원이 종료된 %s %s를 설치합니다",
+		"Job %s installs %s %s, which reached end of life on %s":                           "작업 %s가 %[4]s에 지원이 종료된 %[2]s %[3]s를 설치합니다",
+		"Move to a supported version; end-of-life toolchains no longer get security fixes": "지원되는 버전으로 옮기세요. 지원이 종료된 툴체인은 더 이상 보안 수정을 받지 못합니다",
+
+		// Hardcoded credentials
+		"%s looks like a hardcoded %s": "%s는 하드코딩된 %s로 보입니다",
+		"Revoke the credential now, as anyone who can read the repository or its history has it, then store the new one as an encrypted secret": "저장소나 그 이력을 읽을 수 있는 누구나 이 자격 증명을 갖고 있으므로 지금 바로 폐기하고, 새 자격 증명은 암호화된 시크릿으로 저장하세요",
+		"AWS access key ID": "AWS 액세스 키 ID",
+		"GitHub token":      "GitHub 토큰",
+		"Slack token":       "Slack 토큰",
+		"Google API key":    "Google API 키",
+		"Stripe live key":   "Stripe 라이브 키",
+		"private key":       "개인 키",
+		"curl password":     "curl 비밀번호",
+		"password in a URL": "URL 속 비밀번호",
+		"credential":        "자격 증명",
+
+		// Fork pull request exposure
+		"Fork Pull Request Exposure": "포크 풀 리퀘스트 노출",
+		"Rating":                     "등급",
+		"none":                       "없음",
+		"low":                        "낮음",
+		"medium":                     "보통",
+		"high":                       "높음",
+		"No workflow runs with the repository's privileges for pull requests from forks":                    "포크의 풀 리퀘스트에 대해 저장소 권한으로 실행되는 워크플로가 없습니다",
+		"Checks out or downloads code built from the pull request":                                          "풀 리퀘스트에서 빌드된 코드를 체크아웃하거나 다운로드합니다",
+		"Checks out the pull request's code":                                                                "풀 리퀘스트의 코드를 체크아웃합니다",
+		"Interpolates %s into scripts":                                                                      "%s을(를) 스크립트에 삽입합니다",
+		"Uses secrets %s":                                                                                   "시크릿 %s을(를) 사용합니다",
+		"Passes every secret to a reusable workflow with secrets: inherit":                                  "secrets: inherit로 재사용 워크플로에 모든 시크릿을 전달합니다",
+		"Grants the GITHUB_TOKEN write access to %s":                                                        "GITHUB_TOKEN에 %s 쓰기 권한을 부여합니다",
+		"Leaves the GITHUB_TOKEN with the repository's default permissions, which may include write access": "GITHUB_TOKEN이 쓰기 권한을 포함할 수 있는 저장소 기본 권한을 그대로 사용합니다",
+		"Workflow %s runs a fork's code or input from %s":                                                   "워크플로 %s이(가) %s에서 포크의 코드나 입력을 실행합니다",
+		"Workflow %s runs a fork's code or input from %s with secrets or a write token":                     "워크플로 %s이(가) %s에서 시크릿이나 쓰기 토큰과 함께 포크의 코드나 입력을 실행합니다",
+		"Split the workflow in two: build and test the pull request under pull_request, without secrets, and act on its uploaded results in a workflow_run workflow that never executes them": "워크플로를 둘로 나누세요: 풀 리퀘스트는 시크릿 없이 pull_request에서 빌드하고 테스트한 뒤, 업로드된 결과는 이를 절대 실행하지 않는 workflow_run 워크플로에서 처리합니다",
+		"Treat the triggering run's code, artifacts and branch names as untrusted: never execute them, and pass event values to scripts through env variables":                                "트리거한 실행의 코드, 아티팩트, 브랜치 이름을 신뢰하지 마세요: 절대 실행하지 말고, 이벤트 값은 env 변수로 스크립트에 전달합니다",
+
+		// continue-on-error audit
+		"Step %s of job %s failed in %d of %d successful runs, hidden by continue-on-error":                                                 "작업 %[2]s의 단계 %[1]s이(가) 성공한 실행 %[4]d회 중 %[3]d회 실패했지만 continue-on-error로 가려졌습니다",
+		"Fix the step or remove it; if its failures are expected, check its outcome in a later step so they're reported instead of ignored": "단계를 고치거나 제거하세요. 실패가 예상된 것이라면 이후 단계에서 outcome을 확인해 무시되지 않고 보고되도록 하세요",
+
+		// HTML report
+		"Run Timeline": "실행 타임라인",
+		"Run #%d":      "실행 #%d",
+		"Dashed lines are time spent waiting for a runner or for needed jobs; click a job to show its steps.": "점선은 러너나 선행 작업을 기다린 시간입니다. 작업을 클릭하면 단계가 표시됩니다.",
+		"Waited %s": "%s 대기",
+
+		// Mermaid diagrams
+		"Job Graph":     "작업 그래프",
+		"%v on average": "평균 %v",
+
+		// Adoption tracking
+		"Adopted Since Last Run":                             "지난 실행 이후 반영된 권장 사항",
+		"%d recommendations adopted since %s, %d still open": "%[2]s 이후 권장 사항 %[1]d개 반영, %[3]d개 남음",
+
+		// Cost attribution
+		"Most expensive branches and pull requests": "가장 비용이 큰 브랜치와 풀 리퀘스트",
+		"%d runs, %d billable min, $%.2f":           "실행 %d회, 과금 %d분, $%.2f",
+		"Branch or pull request":                    "브랜치 또는 풀 리퀘스트",
+		"Runs":                                      "실행",
+		"Billable minutes":                          "과금 시간(분)",
+		"Cost":                                      "비용",
+		"bot":                                       "봇",
+
+		// Custom pricing
+		"Prices from %s; other runners at GitHub's list prices less %.0f%%, self-hosted ones free": "%s의 가격입니다. 그 외 러너는 GitHub 정가에서 %.0f%% 할인된 가격이며 셀프 호스티드 러너는 무료입니다",
+		"Prices from %s; other runners at GitHub's list prices, self-hosted ones free":             "%s의 가격입니다. 그 외 러너는 GitHub 정가이며 셀프 호스티드 러너는 무료입니다",
+
+		// Required status checks
+		"Required Status Checks":          "필수 상태 검사",
+		"Branch":                          "브랜치",
+		"Check":                           "검사",
+		"Reported by":                     "보고하는 작업",
+		"reported outside GitHub Actions": "GitHub Actions 외부에서 보고됨",
+		"no workflow job reports it":      "보고하는 워크플로 작업 없음",
+		"Required check %q of %s isn't reported by any workflow job, so pull requests wait for it and can't merge":                                                                                        "%[2]s의 필수 검사 %[1]q를 보고하는 워크플로 작업이 없어 풀 리퀘스트가 이를 기다리며 병합할 수 없습니다",
+		"Update the branch protection or ruleset to the job's current check name, or remove the check; renaming a job, adding a matrix or calling a reusable workflow changes the name its check reports": "브랜치 보호 규칙이나 룰셋을 작업의 현재 검사 이름으로 수정하거나 검사를 제거하세요. 작업 이름 변경, 매트릭스 추가, 재사용 워크플로 호출은 검사 이름을 바꿉니다",
+		"Required check %q takes %v on average, and every pull request to %s waits for it before merging":                                                                                                 "필수 검사 %q는 평균 %v가 걸리며 %s로 향하는 모든 풀 리퀘스트가 병합 전에 이를 기다립니다",
+		"Shard its tests across parallel jobs and cache its dependencies, or move the slowest part out of the required checks, e.g. to run on push to the default branch or in the merge queue":           "테스트를 병렬 작업으로 분할하고 의존성을 캐시하거나, 가장 느린 부분을 필수 검사에서 빼서 기본 브랜치 push나 병합 큐에서 실행하세요",
+		"Jobs %s run on pull requests but aren't required checks of %s, so pull requests can merge while they fail":                                                                                       "작업 %s는 풀 리퀘스트에서 실행되지만 %s의 필수 검사가 아니어서 실패해도 풀 리퀘스트가 병합될 수 있습니다",
+		"Require them in the branch protection or ruleset, or add one job that needs them all and require only that one, so renaming or adding jobs doesn't change the required checks":                   "브랜치 보호 규칙이나 룰셋에서 필수로 지정하거나, 이들 모두를 needs로 가진 작업 하나만 필수로 지정해 작업 이름 변경이나 추가가 필수 검사에 영향을 주지 않게 하세요",
+		"Get the status checks the default branch requires, with the repository and its rulesets":                                                                                                         "저장소 정보와 룰셋을 포함해 기본 브랜치의 필수 상태 검사 조회",
+
+		// Merge queue
+		"Merge Queue":                      "병합 큐",
+		"required":                         "필수",
+		"Jobs in the queue, slowest first": "큐의 작업 (느린 순)",
+		"Throughput: %.1f entries merged per day over %d days": "처리량: %[2]d일 동안 하루 평균 %.1[1]f건 병합",
+		"Latency per entry: %v on average, %v at most":         "항목당 지연 시간: 평균 %v, 최대 %v",
+		"Removed from the queue: %d of %d entries (%.0f%%)":    "큐에서 제거됨: %[2]d건 중 %[1]d건 (%.0[3]f%%)",
+		"Workflow %s reports required checks of %s but isn't triggered by merge_group, so merge queue entries wait for them until the queue times out":                                   "워크플로 %s는 %s의 필수 검사를 보고하지만 merge_group으로 트리거되지 않아 병합 큐 항목이 시간 초과될 때까지 이를 기다립니다",
+		"Trigger the workflow on merge_group as well, so its checks also report on the merge queue's temporary branches":                                                                 "merge_group에서도 워크플로를 트리거해 병합 큐의 임시 브랜치에서도 검사가 보고되게 하세요",
+		"Job %s takes %v on average in the merge queue, and every entry waits for it before merging":                                                                                     "작업 %s는 병합 큐에서 평균 %v가 걸리며 모든 항목이 병합 전에 이를 기다립니다",
+		"Run it on pull requests only and keep fast checks that catch conflicts between pull requests in the queue; a job skipped in the merge group still satisfies its required check": "풀 리퀘스트에서만 실행하고 큐에는 풀 리퀘스트 간 충돌을 잡는 빠른 검사만 남기세요. 병합 그룹에서 건너뛴 작업도 필수 검사를 충족합니다",
+
+		// Run start heatmap
+		"Run Start Heatmap":                             "실행 시작 히트맵",
+		"Busiest: %s %02d:00 UTC, %d of %d runs":        "가장 바쁜 시간: %s %02d:00 UTC, 실행 %[4]d회 중 %[3]d회",
+		"Quietest hour for scheduled jobs: %02d:00 UTC": "예약 작업에 가장 한가한 시간: %02d:00 UTC",
+		"Monday":    "월요일",
+		"Tuesday":   "화요일",
+		"Wednesday": "수요일",
+		"Thursday":  "목요일",
+		"Friday":    "금요일",
+		"Saturday":  "토요일",
+		"Sunday":    "일요일",
+
+		// Runner image lifecycle
+		"Job %s runs on %s, which GitHub removes on %s; until then, jobs on it fail during scheduled brownouts": "작업 %s은(는) %s에서 실행되며, GitHub는 %s에 이 이미지를 제거합니다. 그때까지 예정된 브라운아웃 동안 이 이미지의 작업이 실패합니다",
+		"Job %s runs on %s, which GitHub removed on %s, so the job no longer starts":                            "작업 %s은(는) %s에서 실행되지만 GitHub가 %s에 이 이미지를 제거했으므로 작업이 더 이상 시작되지 않습니다",
+		"Move to %s and check the preinstalled tool versions, which change between images":                      "%s(으)로 옮기고 이미지마다 달라지는 사전 설치 도구 버전을 확인하세요",
+		"macOS 15 runners are Apple silicon; use macos-15-intel for x64 builds":                                 "macOS 15 러너는 Apple 실리콘입니다. x64 빌드에는 macos-15-intel을 사용하세요",
+		"Windows Server 2025 runners come with Visual Studio 2022 instead of 2019":                              "Windows Server 2025 러너에는 Visual Studio 2019 대신 2022가 설치되어 있습니다",
+
+		// Windows steps and line endings
+		"Step %q in job %s writes to $%s in PowerShell, where it's an unset variable, so the value is lost":                                                                                           "작업 %[2]s의 단계 %[1]q이(가) PowerShell에서 $%[3]s에 쓰지만 PowerShell에서는 설정되지 않은 변수이므로 값이 사라집니다",
+		"Use $env:%s in PowerShell, or set shell: bash on the step":                                                                                                                                   "PowerShell에서는 $env:%s를 사용하거나 단계에 shell: bash를 설정하세요",
+		"Step %q in job %s pastes ${{ %s }} into a bash script on Windows, where the path's backslashes are read as escapes":                                                                          "작업 %[2]s의 단계 %[1]q이(가) Windows의 bash 스크립트에 ${{ %[3]s }}를 그대로 넣어 경로의 백슬래시가 이스케이프로 해석됩니다",
+		"Read the path from the quoted environment variable \"$%s\" instead, and convert it with cygpath -u for tools that expect a POSIX path":                                                       "대신 따옴표로 감싼 환경 변수 \"$%s\"에서 경로를 읽고, POSIX 경로가 필요한 도구에는 cygpath -u로 변환하세요",
+		"Job %s checks out files with CRLF line endings on Windows, and the logs of %d of %d runs show scripts or linters tripping over them":                                                         "작업 %s은(는) Windows에서 CRLF 줄 끝으로 파일을 체크아웃하며, 실행 %[3]d회 중 %[2]d회의 로그에서 스크립트나 린터가 이 때문에 실패했습니다",
+		"Git converts line endings on checkout because core.autocrlf is true on Windows runners; commit a .gitattributes with * text=auto eol=lf, or turn the conversion off before actions/checkout": "Windows 러너에서는 core.autocrlf가 true라서 Git이 체크아웃 시 줄 끝을 변환합니다. * text=auto eol=lf가 담긴 .gitattributes를 커밋하거나 actions/checkout 전에 변환을 끄세요",
+
+		// ML workloads on GPU and larger runners
+		"Step %q in ML job %s averages %s on CPU, %.0f%% of the job's %s ($%.2f per run on %s)": "ML 작업 %[2]s의 단계 %[1]q은(는) CPU에서 평균 %[3]s로, 작업 시간 %[5]s의 %.0[4]f%%를 차지합니다 (%[7]s에서 실행당 $%.2[6]f)",
+		"~%s and $%.2f per run":                        "실행당 약 %s, $%.2f",
+		"T4 GPU runner: %s, assuming a %.0fx speedup":  "T4 GPU 러너: %s (%.0f배 빨라진다고 가정)",
+		"16-core runner: %s, assuming a %.1fx speedup": "16코어 러너: %s (%.1f배 빨라진다고 가정)",
+		"Estimated on faster runners: %s. Measure the speedup on a run before switching; GPU runners need the CUDA builds of the frameworks": "더 빠른 러너에서의 추정치: %s. 전환하기 전에 실제 실행으로 속도 향상을 측정하세요. GPU 러너에는 프레임워크의 CUDA 빌드가 필요합니다",
+
+		// CODEOWNERS team ownership
+		"Owners":                    "소유자",
+		"Unowned":                   "소유자 없음",
+		"Findings and Cost by Team": "팀별 발견 사항과 비용",
+		"%d findings (%d critical, %d warnings) in %d workflows": "워크플로 %[4]d개에서 발견 사항 %[1]d건 (심각 %[2]d건, 경고 %[3]d건)",
+		"$%.2f per month": "월 $%.2f",
+
+		// Report audiences
+		"Findings Overview": "발견 사항 개요",
+		"Critical":          "심각",
+		"%d findings: %d critical, %d warnings, %d info": "발견 사항 %d건: 심각 %d건, 경고 %d건, 정보 %d건",
+		"By category: %s": "분류별: %s",
+		"Applying the recommendations saves about %v of runner time per month": "권장 사항을 적용하면 월 약 %v의 러너 시간을 절약합니다",
+		"about $%.2f": "약 $%.2f",
+
+		// Finding confidence
+		"Confidence: %.0f%%, drawn from job logs": "신뢰도: %.0f%%, 작업 로그 기반",
+
+		// Starter workflows of an organization's .github repository
+		"Starter Workflows":                      "스타터 워크플로",
+		"no findings":                            "발견 사항 없음",
+		"%d findings (%d critical, %d warnings)": "발견 사항 %d건 (심각 %d건, 경고 %d건)",
+		"Repositories created from these templates inherit their findings, so fixing a template fixes every new copy": "이 템플릿으로 만든 저장소는 발견 사항을 그대로 물려받으므로, 템플릿을 고치면 새로 만드는 모든 사본이 고쳐집니다",
+
+		// Artifact download ordering
+		"Job %s downloads artifact %q, but job %s uploads it per matrix entry as %q at line %d":                                    "%s 잡이 아티팩트 %q을(를) 다운로드하지만, %s 잡은 %[5]d번째 줄에서 매트릭스 항목마다 %[4]q(으)로 업로드합니다",
+		"Download with pattern: %s* and merge-multiple: true to get the artifacts of every matrix entry":                           "모든 매트릭스 항목의 아티팩트를 받으려면 pattern: %s*와 merge-multiple: true로 다운로드하세요",
+		"Job %s downloads artifact %q, which no job of this workflow uploads":                                                      "%s 잡이 아티팩트 %q을(를) 다운로드하지만, 이 워크플로의 어떤 잡도 업로드하지 않습니다",
+		"Check the name against the upload-artifact steps, or set run-id to download it from another workflow run":                 "upload-artifact 스텝의 이름과 대조하거나, 다른 워크플로 실행에서 받으려면 run-id를 설정하세요",
+		"Job %s downloads artifact %q before uploading it at line %d":                                                              "%s 잡이 아티팩트 %q을(를) %d번째 줄에서 업로드하기 전에 다운로드합니다",
+		"Move the download after the upload, or drop it and use the files directly":                                                "다운로드를 업로드 뒤로 옮기거나, 다운로드를 빼고 파일을 직접 사용하세요",
+		"Job %s downloads artifact %q uploaded by job %s at line %d, but doesn't need that job, so the artifact may not exist yet": "%[1]s 잡이 %[3]s 잡이 %[4]d번째 줄에서 업로드하는 아티팩트 %[2]q을(를) 다운로드하지만 그 잡을 needs에 두지 않아, 아티팩트가 아직 없을 수 있습니다",
+		"Add %s to the needs of job %s": "%[2]s 잡의 needs에 %[1]s을(를) 추가하세요",
+
+		// Repeated and complex expressions
+		"Expression ${{ %s }} is repeated in %d steps of job %s (lines %s)":                                                 "표현식 ${{ %s }}이(가) %[3]s 잡의 스텝 %[2]d개에서 반복됩니다 (%[4]s번째 줄)",
+		"Compute it once in a step that writes it to $GITHUB_OUTPUT and refer to that output in the other steps":            "한 스텝에서 한 번 계산해 $GITHUB_OUTPUT에 쓰고, 다른 스텝에서는 그 출력을 참조하세요",
+		"Hoist it into the job's env: under a descriptive name and refer to that variable in the steps":                     "의미 있는 이름으로 잡의 env:에 올리고 스텝에서는 그 변수를 참조하세요",
+		"Step %d of job %s has an expression of %d characters with %d conditions":                                           "%[2]s 잡의 스텝 %[1]d에 조건 %[4]d개로 된 %[3]d자 표현식이 있습니다",
+		"Split it into named parts in the job's env: or a step output, so each condition can be read and reused on its own": "잡의 env:나 스텝 출력으로 이름 붙은 부분으로 나누어 각 조건을 따로 읽고 재사용할 수 있게 하세요",
+
+		// Cache save and restore time
+		"Saving cache %q in job %s took %v across %d runs, more than the %v its %d restores saved":                                                                             "%[2]s 잡의 캐시 %[1]q 저장에 실행 %[4]d회 동안 %[3]v가 걸려, 복원 %[6]d회로 절약한 %[5]v보다 깁니다",
+		"the job averages %v when the cache misses and %v when it's restored, %v of which is the restore":                                                                      "잡은 캐시 미스 시 평균 %v, 복원 시 평균 %v가 걸리며 그중 복원이 %v입니다",
+		"Cache the package manager's download cache, e.g. ~/.npm, instead of node_modules; it's smaller to save and restore, and npm ci rebuilds node_modules from it quickly": "node_modules 대신 ~/.npm 같은 패키지 매니저의 다운로드 캐시를 캐시하세요. 저장과 복원이 작고 빠르며, npm ci가 그로부터 node_modules를 빠르게 다시 만듭니다",
+		"Cache fewer files: narrow path to downloaded dependencies rather than build outputs, and key it on the lockfile hash so it's only saved when dependencies change":     "캐시할 파일을 줄이세요. path를 빌드 결과물이 아닌 다운로드한 의존성으로 좁히고, 의존성이 바뀔 때만 저장되도록 키를 락파일 해시로 지정하세요",
+
+		// QEMU and Buildx setup
+		"Job %s sets up QEMU but only builds for the platform of its runner":                                         "%s 잡이 QEMU를 설정하지만 러너 자체 플랫폼용으로만 빌드합니다",
+		"Remove the setup-qemu-action step; QEMU is only needed to build or run images for another CPU architecture": "setup-qemu-action 스텝을 제거하세요. QEMU는 다른 CPU 아키텍처용 이미지를 빌드하거나 실행할 때만 필요합니다",
+		"(%v per run)": "(실행당 %v)",
+		"Setting up Buildx in job %s takes %v per run": "%s 잡의 Buildx 설정에 실행당 %v가 걸립니다",
+		"Create a persistent builder on the runner once (docker buildx create --name ci --driver docker-container --bootstrap) and select it with name: ci, keep-state: true and cleanup: false, so the builder and its layer cache survive between jobs": "러너에 영구 빌더를 한 번 만들고(docker buildx create --name ci --driver docker-container --bootstrap) name: ci, keep-state: true, cleanup: false로 선택해 빌더와 레이어 캐시가 잡 사이에 유지되게 하세요",
+		"Builds without multi-platform output or cache export can use driver: docker, which needs no BuildKit container; otherwise pin version: so the buildx binary is reused from the tool cache":                                                       "멀티 플랫폼 출력이나 캐시 내보내기가 없는 빌드는 BuildKit 컨테이너가 필요 없는 driver: docker를 쓸 수 있습니다. 그렇지 않다면 version:을 고정해 buildx 바이너리를 도구 캐시에서 재사용하세요",
+
+		// Container registry logins
+		"Job %s logs in to %s again after the login at line %d":                                                                   "%s 잡이 %[3]d번째 줄의 로그인 후 %[2]s에 다시 로그인합니다",
+		"Remove the repeated login; a login lasts for the rest of the job":                                                        "반복된 로그인을 제거하세요. 로그인은 잡이 끝날 때까지 유지됩니다",
+		"Job %s logs in to a container registry but never pushes":                                                                 "%s 잡이 컨테이너 레지스트리에 로그인하지만 푸시하지 않습니다",
+		"Remove the login unless the job pulls private images; every login puts a registry token within reach of the job's steps": "잡이 비공개 이미지를 풀하지 않는다면 로그인을 제거하세요. 로그인할 때마다 레지스트리 토큰이 잡의 스텝에 노출됩니다",
+		"Job %s leaves its %s credentials in the Docker config of a self-hosted runner":                                           "%s 잡이 %s 자격 증명을 셀프 호스티드 러너의 Docker 설정에 남깁니다",
+		"Add a final step with if: always() running docker logout, or use docker/login-action, which logs out when the job ends":  "if: always()로 docker logout을 실행하는 마지막 스텝을 추가하거나, 잡이 끝날 때 로그아웃하는 docker/login-action을 사용하세요",
+		"Keep the action's default logout, so the next job on the runner can't reuse the credentials":                             "러너의 다음 잡이 자격 증명을 재사용할 수 없도록 액션의 기본 로그아웃을 유지하세요",
+
+		// release hardening
+		"Release Hardening": "릴리스 강화",
+		"Trigger limited to release tags or published releases": "릴리스 태그나 게시된 릴리스로 제한된 트리거",
+		"Build provenance attestation":                          "빌드 출처 증명",
+		"Signed artifacts":                                      "서명된 아티팩트",
+		"Each release asset uploaded once":                      "각 릴리스 에셋을 한 번만 업로드",
+		"Release workflow runs on %s, which also matches tags or release events that aren't releases":                                                                          "릴리스 워크플로가 %s에서 실행되어 릴리스가 아닌 태그나 릴리스 이벤트에도 일치합니다",
+		"Filter push tags to the release pattern, e.g. v[0-9]+.[0-9]+.[0-9]+, and release events to types: [published], so test tags and edited releases don't publish":        "테스트 태그나 수정된 릴리스가 게시되지 않도록 push 태그를 v[0-9]+.[0-9]+.[0-9]+ 같은 릴리스 패턴으로, 릴리스 이벤트를 types: [published]로 제한하세요",
+		"Release asset %s is uploaded again after job %s uploaded it at line %d":                                                                                               "릴리스 에셋 %[1]s를 작업 %[2]s가 %[3]d번째 줄에서 업로드한 뒤 다시 업로드합니다",
+		"Upload each asset from one step; a second upload either fails because the asset exists or silently replaces it":                                                       "각 에셋은 한 스텝에서만 업로드하세요. 두 번째 업로드는 에셋이 이미 있어 실패하거나 조용히 덮어씁니다",
+		"Every matrix entry of job %s uploads release asset %s":                                                                                                                "작업 %s의 모든 매트릭스 항목이 릴리스 에셋 %s를 업로드합니다",
+		"Name the asset after the matrix entry, e.g. with ${{ matrix.os }}, or upload from a single job after the matrix finishes":                                             "${{ matrix.os }} 등으로 에셋 이름에 매트릭스 항목을 넣거나, 매트릭스가 끝난 뒤 단일 작업에서 업로드하세요",
+		"Release workflow publishes without a build provenance attestation":                                                                                                    "릴리스 워크플로가 빌드 출처 증명 없이 게시합니다",
+		"Attest the built artifacts with actions/attest-build-provenance, which needs id-token: write and attestations: write, or call the SLSA generator's reusable workflow": "id-token: write와 attestations: write가 필요한 actions/attest-build-provenance로 빌드 결과물을 증명하거나 SLSA generator의 재사용 워크플로를 호출하세요",
+		"Release workflow doesn't sign what it publishes":                                                                                                                      "릴리스 워크플로가 게시하는 결과물에 서명하지 않습니다",
+		"Sign images and files keylessly with cosign, which needs id-token: write, so users can verify they came from this workflow":                                           "사용자가 이 워크플로에서 나온 것인지 검증할 수 있도록 id-token: write가 필요한 cosign으로 이미지와 파일에 키 없이 서명하세요",
+
+		// rollbacks
+		"Rollbacks: %d, %.0f%% of successful deployments": "롤백: %d회, 성공한 배포의 %.0f%%",
+		"%v on average after the deployment they undo":    "되돌린 배포 후 평균 %v",
+
+		// monorepo build system caches
+		"Turborepo remote cache":                                   "Turborepo 원격 캐시",
+		"Share Turborepo task outputs through Vercel Remote Cache": "Vercel Remote Cache로 Turborepo 작업 출력을 공유",
+		"Tasks whose inputs didn't change replay their outputs instead of running again, across jobs, branches and developer machines; actions/cache on .turbo only restores one whole snapshot per key": "입력이 바뀌지 않은 작업은 다시 실행하지 않고 작업, 브랜치, 개발자 머신 간에 출력을 재생합니다. .turbo에 대한 actions/cache는 키마다 전체 스냅샷 하나만 복원합니다",
+		"Turborepo remote cache on GitHub":                                                                                     "GitHub 기반 Turborepo 원격 캐시",
+		"Serve Turborepo's remote cache from the GitHub Actions cache":                                                         "GitHub Actions 캐시로 Turborepo 원격 캐시를 제공",
+		"Gives per-task remote caching without an external account; entries count toward the repository's Actions cache quota": "외부 계정 없이 작업 단위 원격 캐시를 제공합니다. 항목은 저장소의 Actions 캐시 용량에 포함됩니다",
+		"Nx Cloud remote cache":                  "Nx Cloud 원격 캐시",
+		"Share Nx task outputs through Nx Cloud": "Nx Cloud로 Nx 작업 출력을 공유",
+		"Tasks whose inputs didn't change replay their outputs instead of running again, across jobs, branches and developer machines; actions/cache on .nx/cache only restores one whole snapshot per key": "입력이 바뀌지 않은 작업은 다시 실행하지 않고 작업, 브랜치, 개발자 머신 간에 출력을 재생합니다. .nx/cache에 대한 actions/cache는 키마다 전체 스냅샷 하나만 복원합니다",
+		"Restore the Nx local cache from the GitHub Actions cache": "GitHub Actions 캐시에서 Nx 로컬 캐시를 복원",
+		"Without a remote cache, restoring the latest cache of the branch or main still skips unchanged tasks; nx affected skips unaffected projects altogether": "원격 캐시가 없어도 브랜치나 main의 최신 캐시를 복원하면 바뀌지 않은 작업을 건너뜁니다. nx affected는 영향받지 않은 프로젝트를 통째로 건너뜁니다",
+		"Bazel remote cache": "Bazel 원격 캐시",
+		"Point Bazel at a remote cache such as BuildBuddy, EngFlow or a bazel-remote server":                                                                                           "BuildBuddy, EngFlow, bazel-remote 서버 같은 원격 캐시를 Bazel에 지정",
+		"Actions are looked up by their inputs' digest, so only changed targets rebuild, in every job; caching the output base with actions/cache is large and invalidated as a whole": "액션을 입력 다이제스트로 조회하므로 모든 작업에서 바뀐 타깃만 다시 빌드합니다. actions/cache로 output base를 캐시하면 크고 통째로 무효화됩니다",
+		"Bazel disk and repository cache": "Bazel 디스크 및 저장소 캐시",
+		"Keep Bazel's disk and repository caches in the GitHub Actions cache with setup-bazel":                                    "setup-bazel로 Bazel의 디스크 및 저장소 캐시를 GitHub Actions 캐시에 보관",
+		"Without a remote cache, the disk cache is saved per workflow and restored by digest, so unchanged targets don't rebuild": "원격 캐시가 없어도 디스크 캐시를 워크플로별로 저장하고 다이제스트로 복원하므로 바뀌지 않은 타깃은 다시 빌드하지 않습니다",
+
+		// missing lockfiles
+		"%s has no lockfile, so every install resolves dependency versions anew": "%s에 잠금 파일이 없어 설치할 때마다 의존성 버전을 새로 결정합니다",
+		"Commit the lockfile your package manager writes, such as package-lock.json, yarn.lock or pnpm-lock.yaml, and install with npm ci or --frozen-lockfile; builds become reproducible and caches keyed on the lockfile's hash start to hit": "package-lock.json, yarn.lock, pnpm-lock.yaml 등 패키지 관리자가 만드는 잠금 파일을 커밋하고 npm ci나 --frozen-lockfile로 설치하세요. 빌드가 재현 가능해지고 잠금 파일 해시를 키로 하는 캐시가 적중하기 시작합니다",
+		"%s has no go.sum, so module checksums aren't verified and the module cache can't be keyed on them":                                                                                                                                      "%s에 go.sum이 없어 모듈 체크섬을 검증하지 않고 모듈 캐시의 키로도 쓸 수 없습니다",
+		"Run go mod tidy and commit go.sum":                                                               "go mod tidy를 실행하고 go.sum을 커밋하세요",
+		"%s has no Gemfile.lock, so every install resolves gem versions anew":                             "%s에 Gemfile.lock이 없어 설치할 때마다 gem 버전을 새로 결정합니다",
+		"Run bundle lock and commit Gemfile.lock; bundler-cache keys on it":                               "bundle lock을 실행하고 Gemfile.lock을 커밋하세요. bundler-cache는 이 파일을 키로 사용합니다",
+		"(installed in %d of %d logged runs)":                                                             "(로그가 있는 실행 %[2]d개 중 %[1]d개에서 설치)",
+		"setup actions found no lockfile to key their cache on in %d of %d logged runs":                   "로그가 있는 실행 %[2]d개 중 %[1]d개에서 setup 액션이 캐시 키로 쓸 잠금 파일을 찾지 못했습니다",
+		"The go command reported go.mod or go.sum out of date in %d of %d logged runs":                    "로그가 있는 실행 %[2]d개 중 %[1]d개에서 go 명령이 go.mod나 go.sum이 최신이 아니라고 보고했습니다",
+		"Run go mod tidy and commit both files, and add a check that fails when go mod tidy changes them": "go mod tidy를 실행해 두 파일을 커밋하고, go mod tidy가 파일을 바꾸면 실패하는 검사를 추가하세요",
+		"%s has no lockfile or pinned requirements, so every install resolves dependency versions anew":   "%s에 잠금 파일이나 고정된 requirements가 없어 설치할 때마다 의존성 버전을 새로 결정합니다",
+		"Commit the lockfile of uv, Poetry or PDM, or compile pinned requirements with pip-compile or uv pip compile; builds become reproducible and caches keyed on the lockfile's hash start to hit":                  "uv, Poetry, PDM의 잠금 파일을 커밋하거나 pip-compile 또는 uv pip compile로 고정된 requirements를 만드세요. 빌드가 재현 가능해지고 잠금 파일 해시를 키로 하는 캐시가 적중하기 시작합니다",
+		"%s leaves %d of %d requirements unpinned, e.g. %s, so every install may resolve different versions":                                                                                                            "%[1]s의 requirements %[3]d개 중 %[2]d개가 고정되지 않아(예: %[4]s) 설치할 때마다 다른 버전이 선택될 수 있습니다",
+		"Keep the loose requirements in requirements.in and compile them into pinned requirements.txt with pip-compile or uv pip compile, so installs are reproducible and the pip cache keyed on the file stays valid": "느슨한 requirements는 requirements.in에 두고 pip-compile이나 uv pip compile로 고정된 requirements.txt를 만들어 설치를 재현 가능하게 하고 이 파일을 키로 하는 pip 캐시를 유효하게 유지하세요",
+
+		// write-all and read-all under a policy maximum
+		"%s grants %s, which includes %s: %s, but the policy allows at most %s": "%[1]s에 부여된 %[2]s 권한에는 %[3]s: %[4]s가 포함되지만 정책은 최대 %[5]s까지 허용합니다",
+		"Replace %s with the scopes the jobs need, granting %s at most %s":      "%[1]s 대신 작업에 필요한 범위만 부여하고 %[2]s는 최대 %[3]s로 지정하세요",
+
+		// DORA rollback workflows
+		"Rollback workflows": "롤백 워크플로",
+	},
+	Japanese: {
+		// Report headings
+		"Workflow Analysis Report":    "ワークフロー分析レポート",
+		"End of Analysis Report":      "分析レポート終了",
+		"Overview":                    "概要",
+		"Repository":                  "リポジトリ",
+		"Workflow":                    "ワークフロー",
+		"Total Execution Time":        "合計実行時間",
+		"Slow Steps Detected":         "遅いステップを検出",
+		"Duration":                    "所要時間",
+		"Cache Optimization Tips":     "キャッシュ最適化のヒント",
+		"What":                        "内容",
+		"Impact":                      "効果",
+		"Example":                     "例",
+		"Docker Optimization Tips":    "Docker 最適化のヒント",
+		"Issue":                       "問題",
+		"Solution":                    "解決策",
+		"Expected Improvement":        "期待される改善",
+		"Cost Saving Opportunities":   "コスト削減の機会",
+		"Workflow Structure Analysis": "ワークフロー構造分析",
+		"General Recommendations":     "一般的な推奨事項",
+		"Runner Optimizations":        "ランナーの最適化",
+		"Security Recommendations":    "セキュリティの推奨事項",
+
+		// Dry run plan
+		"Analysis Dry Run Plan":                "分析ドライラン計画",
+		"Mode":                                 "モード",
+		"Runs: %d to analyze (%d available)":   "実行: %d 件を分析予定 (%d 件利用可能)",
+		"Planned API Calls":                    "予定されている API 呼び出し",
+		"Quota Estimate":                       "クォータ見積もり",
+		"Estimated requests: %d":               "推定リクエスト数: %d",
+		"Remaining rate limit: %d (resets %s)": "残りレート制限: %d (%s にリセット)",
+		"The estimate exceeds the remaining quota; lower analysis_depth or use survey mode": "見積もりが残りクォータを超えています。analysis_depth を下げるか survey モードを使用してください",
+		"List workflow runs":                              "ワークフロー実行の一覧を取得",
+		"Fetch job and step timings for each run":         "各実行のジョブとステップの時間を取得",
+		"List jobs of each sampled run":                   "サンプル実行ごとのジョブ一覧を取得",
+		"Download job logs of each sampled run":           "サンプル実行ごとのジョブログをダウンロード",
+		"Assumes %d jobs per run based on the latest run": "最新の実行に基づき 1 実行あたり %d ジョブと仮定",
+		"Fetch the workflow file (three times), the Dockerfile and GitLab CI/CircleCI configs": "ワークフローファイル (3 回)、Dockerfile、GitLab CI/CircleCI 設定を取得",
+		"Look up the latest version of each detected language":                                 "検出された各言語の最新バージョンを確認",
+		"Upper bound; only detected languages are queried":                                     "上限値です。検出された言語のみ問い合わせます",
+
+		// Cost saving tips
+		"Consider using GitHub Actions cache to speed up dependencies installation": "GitHub Actions のキャッシュで依存関係のインストールを高速化しましょう",
+		"Use matrix builds for parallel execution":                                  "マトリックスビルドで並列実行しましょう",
+		"Implement proper Docker layer caching":                                     "Docker レイヤーキャッシュを適切に構成しましょう",
+		"Total execution time: %v - Consider optimizing long-running steps":         "合計実行時間: %v - 時間のかかるステップの最適化を検討してください",
+
+		// Docker optimizations
+		"No multi-stage build detected":                                  "マルチステージビルドが検出されませんでした",
+		"Consider using multi-stage builds to reduce final image size":   "マルチステージビルドで最終イメージのサイズを削減しましょう",
+		"Can reduce image size by up to 50%":                             "イメージサイズを最大 50% 削減できます",
+		"No layer caching strategy detected":                             "レイヤーキャッシュ戦略が検出されませんでした",
+		"Implement proper layer caching by copying only necessary files": "必要なファイルのみをコピーしてレイヤーキャッシュを適切に構成しましょう",
+		"Can improve build time significantly":                           "ビルド時間を大幅に改善できます",
+
+		// Workflow structure
+		"Consider using matrix strategy for parallel testing/building across different versions/platforms": "複数のバージョン/プラットフォームで並列にテスト/ビルドするためにマトリックス戦略を検討してください",
+		"Review job dependencies to ensure optimal parallel execution":                                     "最適な並列実行のためにジョブの依存関係を見直してください",
+		"Consider using specific Ubuntu version instead of 'latest' for better reproducibility":            "再現性のために 'latest' ではなく特定の Ubuntu バージョンを使用してください",
+		"Add explicit permissions to improve workflow security":                                            "ワークフローのセキュリティ向上のために permissions を明示してください",
+		"Consider using environments for better secret management and deployment control":                  "シークレット管理とデプロイ制御のために environments の利用を検討してください",
+
+		// Cache strategies
+		"Cache Go build artifacts and modules":                                                     "Go のビルド成果物とモジュールをキャッシュ",
+		"Can reduce build time and dependency download time significantly":                         "ビルド時間と依存関係のダウンロード時間を大幅に短縮できます",
+		"Cache npm dependencies":                                                                   "npm の依存関係をキャッシュ",
+		"Can reduce npm install time by up to 50%":                                                 "npm install の時間を最大 50% 短縮できます",
+		"Cache node_modules directory":                                                             "node_modules ディレクトリをキャッシュ",
+		"Can significantly reduce installation time for large projects":                            "大規模プロジェクトのインストール時間を大幅に短縮できます",
+		"Cache pip dependencies":                                                                   "pip の依存関係をキャッシュ",
+		"Can reduce pip install time significantly":                                                "pip install の時間を大幅に短縮できます",
+		"Cache Maven dependencies":                                                                 "Maven の依存関係をキャッシュ",
+		"Can significantly reduce build time by caching Maven dependencies":                        "Maven の依存関係をキャッシュしてビルド時間を大幅に短縮できます",
+		"Cache Gradle dependencies and wrapper":                                                    "Gradle の依存関係と wrapper をキャッシュ",
+		"Can significantly reduce build time by caching Gradle dependencies":                       "Gradle の依存関係をキャッシュしてビルド時間を大幅に短縮できます",
+		"Cache Ruby gems using Bundler":                                                            "Bundler で Ruby gem をキャッシュ",
+		"Can reduce gem installation time significantly":                                           "gem のインストール時間を大幅に短縮できます",
+		"Cache Rust dependencies and build artifacts":                                              "Rust の依存関係とビルド成果物をキャッシュ",
+		"Can significantly reduce build time by caching Cargo dependencies and compiled artifacts": "Cargo の依存関係とコンパイル済み成果物をキャッシュしてビルド時間を大幅に短縮できます",
+		"Cache .NET SDK installation":                                                              ".NET SDK のインストールをキャッシュ",
+		"Can significantly reduce setup time by caching the .NET SDK":                              ".NET SDK をキャッシュしてセットアップ時間を大幅に短縮できます",
+
+		// Workflow findings
+		"Pull Request":      "プルリクエスト",
+		"Workflow Findings": "ワークフローの検出事項",
+		"Runner label %s floats to the newest image":                                       "ランナーラベル %s は常に最新のイメージを指します",
+		"No permissions block found; the workflow runs with the default token permissions": "permissions ブロックがないため、ワークフローはデフォルトのトークン権限で実行されます",
+		"Action %s is not pinned to a version":                                             "アクション %s のバージョンが固定されていません",
+		"Action %s tracks the %s branch":                                                   "アクション %s は %s ブランチを追跡しています",
+		"Pin actions to a release tag or full commit SHA":                                  "アクションをリリースタグまたは完全なコミット SHA に固定してください",
+		"Workflow analyzer found %d issue(s) in the changed lines.":                        "ワークフローアナライザーが変更行で %d 件の問題を検出しました。",
+
+		// Workflow validation
+		"Invalid workflow YAML: %v":                      "無効なワークフロー YAML: %v",
+		"Unknown workflow key %q":                        "不明なワークフローキー %q",
+		"Workflow has no \"on\" trigger":                 "ワークフローに \"on\" トリガーがありません",
+		"Workflow defines no jobs":                       "ワークフローにジョブが定義されていません",
+		"Unknown key %q in job %s":                       "ジョブ %[2]s に不明なキー %[1]q",
+		"Job %s has no runs-on":                          "ジョブ %s に runs-on がありません",
+		"Job %s has no steps":                            "ジョブ %s にステップがありません",
+		"Job %s needs unknown job %q":                    "ジョブ %s が存在しないジョブ %q に依存しています",
+		"Unknown key %q in step %q":                      "ステップ %[2]q に不明なキー %[1]q",
+		"Step %q must define exactly one of uses or run": "ステップ %q は uses と run のどちらか一方のみを定義する必要があります",
+		"Unknown context %q in expression ${{%s}}":       "式 ${{%[2]s}} に不明なコンテキスト %[1]q",
+		"shellcheck SC%d in step %q: %s":                 "ステップ %[2]q の shellcheck SC%[1]d: %[3]s",
+
+		// OIDC recommendations
+		"Switch to OIDC federation so no long-lived %s credentials are stored as secrets": "OIDC フェデレーションに切り替えて、%s の長期認証情報をシークレットに保存しないようにしてください",
+		"The job also needs the id-token: write permission.":                              "ジョブには id-token: write 権限も必要です。",
+		"Job %s authenticates to %s with static credentials (%s)":                         "ジョブ %s は静的な認証情報 (%[3]s) で %[2]s に認証しています",
+
+		// Artifact passing
+		"Artifact %q is uploaded by job %s and only consumed by job %s":                                                                            "アーティファクト %q はジョブ %s でアップロードされ、ジョブ %s でのみ使用されます",
+		"If the artifact isn't needed after the run, consider merging the two jobs or passing the files through actions/cache keyed on github.sha": "実行後にアーティファクトが不要なら、2 つのジョブを統合するか、github.sha をキーにした actions/cache でファイルを受け渡すことを検討してください",
+		"(upload %v + download %v per run)": "(実行あたりアップロード %v + ダウンロード %v)",
+		"Job %s takes %v on average, less than twice the transfer; merging it into job %s avoids the round trip":                    "ジョブ %s の平均所要時間は %v で転送時間の 2 倍未満です。ジョブ %s に統合すると往復転送を回避できます",
+		"Pass the files through actions/cache keyed on github.sha, which restores faster than artifact downloads for large outputs": "github.sha をキーにした actions/cache でファイルを受け渡してください。大きな出力ではアーティファクトのダウンロードより高速に復元できます",
+
+		// Multi-arch builds
+		"Job %s builds %s in a single buildx invocation, emulating %s with QEMU": "ジョブ %s は 1 回の buildx 実行で %s をビルドし、%s を QEMU でエミュレートしています",
+		"Build each platform on a native runner (e.g. ubuntu-24.04-arm for linux/arm64) in a matrix and merge the digests with docker buildx imagetools create, or use a remote native builder": "マトリックスで各プラットフォームをネイティブランナー (例: linux/arm64 には ubuntu-24.04-arm) でビルドし、docker buildx imagetools create でダイジェストを統合するか、リモートのネイティブビルダーを使用してください",
+		"(average build time per run: %s)": "(実行あたりの平均ビルド時間: %s)",
+
+		// Estimated savings
+		"Estimated Savings": "推定削減効果",
+		"%s averages %s across %d runs; caching typically saves ~%d%% → ~%s per run, ~%s/month": "%s は %[3]d 回の実行で平均 %[2]s かかります。キャッシュで通常 ~%[4]d%% 削減 → 実行あたり ~%[5]s、月あたり ~%[6]s",
+
+		// Projected savings
+		"~%s per run, ~%s/month based on %d runs": "%[3]d 回の実行に基づき実行あたり ~%[1]s、月あたり ~%[2]s",
+
+		// Sustainability
+		"Sustainability Estimate":                            "サステナビリティ推定",
+		"Total over %d runs: %.0f min, %.3f kWh, %.0f gCO2e": "%d 回の実行の合計: %.0f 分、%.3f kWh、%.0f gCO2e",
+		"Projected per month: %.2f kWh, %.1f kgCO2e":         "月間予測: %.2f kWh、%.1f kgCO2e",
+		"Assumptions": "前提条件",
+		"%.2fW per vCPU, %.3fW per GB memory, PUE %.3f, %.0f gCO2e/kWh grid intensity": "vCPU あたり %.2fW、メモリ GB あたり %.3fW、PUE %.3f、電力網の炭素強度 %.0f gCO2e/kWh",
+
+		// CI migration advice
+		"CI Migration Advice": "CI 移行アドバイス",
+		"%d jobs":             "%d ジョブ",
+		"Carries over":        "そのまま移行可能",
+		"Needs manual rework": "手動での作り直しが必要",
+		"Stages become needs: chains between jobs":                                                    "ステージはジョブ間の needs: チェーンになります",
+		"Run the job in the same image, or use runs-on with setup-* actions":                          "同じイメージでジョブを実行するか、runs-on と setup-* アクションを使用してください",
+		"Service containers map one to one":                                                           "サービスコンテナは 1 対 1 で対応します",
+		"Each script line becomes part of a run step":                                                 "各 script 行は run ステップの一部になります",
+		"Prepend as a separate run step":                                                              "別の run ステップとして先頭に追加してください",
+		"Cache key and paths carry over; use hashFiles() for lockfile keys":                           "キャッシュキーとパスはそのまま移行できます。ロックファイルのキーには hashFiles() を使用してください",
+		"Use upload-artifact/download-artifact between jobs":                                          "ジョブ間で upload-artifact/download-artifact を使用してください",
+		"DAG dependencies carry over directly":                                                        "DAG 依存関係はそのまま移行できます",
+		"Download only the artifacts the job needs":                                                   "ジョブに必要なアーティファクトのみダウンロードしてください",
+		"parallel:matrix maps to a matrix; numeric parallel needs manual test splitting":              "parallel:matrix は matrix に対応します。数値の parallel は手動でのテスト分割が必要です",
+		"Pipeline rules split into workflow triggers and job if: conditions":                          "パイプラインのルールはワークフローのトリガーとジョブの if: 条件に分かれます",
+		"Branch filters become on.push.branches or if: conditions":                                    "ブランチフィルターは on.push.branches または if: 条件になります",
+		"Use branches-ignore or negated if: conditions":                                               "branches-ignore または否定の if: 条件を使用してください",
+		"Runner tags become self-hosted runner labels":                                                "ランナータグはセルフホストランナーのラベルになります",
+		"Job variables become env:":                                                                   "ジョブ変数は env: になります",
+		"Use GitHub environments for protection rules":                                                "保護ルールには GitHub environments を使用してください",
+		"manual jobs need workflow_dispatch or environment approvals":                                 "手動ジョブには workflow_dispatch または environment の承認が必要です",
+		"No built-in job retry; use a retry action or re-run failed jobs":                             "組み込みのジョブ再試行はありません。再試行アクションを使うか失敗したジョブを再実行してください",
+		"Convert the duration to minutes":                                                             "期間を分に変換してください",
+		"Templates become reusable workflows or composite actions":                                    "テンプレートは再利用可能なワークフローまたは複合アクションになります",
+		"The primary image becomes the job container; extra images become services":                   "プライマリイメージはジョブコンテナになり、追加のイメージは services になります",
+		"Machine executors map to hosted VM runners":                                                  "machine エグゼキューターはホスト型 VM ランナーに対応します",
+		"Pick the matching macOS runner image":                                                        "対応する macOS ランナーイメージを選択してください",
+		"Map the class to a larger runner size":                                                       "クラスをより大きなランナーサイズに対応させてください",
+		"Shard tests with a matrix index; CircleCI timing-based splitting has no built-in equivalent": "matrix のインデックスでテストを分割してください。CircleCI のタイミングベース分割に相当する組み込み機能はありません",
+		"Direct equivalent": "直接対応します",
+		"Keys carry over; {{ checksum \"file\" }} becomes hashFiles('file')":  "キーはそのまま移行でき、{{ checksum \"file\" }} は hashFiles('file') になります",
+		"actions/cache saves automatically in its post step":                  "actions/cache は post ステップで自動的に保存します",
+		"Workspaces become artifacts":                                         "ワークスペースはアーティファクトになります",
+		"Use a JUnit reporter action or the job summary":                      "JUnit レポーターアクションまたはジョブサマリーを使用してください",
+		"Docker is available on hosted runners; add Buildx for layer caching": "ホスト型ランナーでは Docker が利用できます。レイヤーキャッシュのために Buildx を追加してください",
+		"Replace each orb with an equivalent action":                          "各 orb を同等のアクションに置き換えてください",
+		"Workflow requires become needs:":                                     "ワークフローの requires は needs: になります",
+
+		// Partial results
+		"Partial results: the analysis ran out of time or API rate limit before finishing (skipped: %s)": "部分的な結果: 分析が完了する前に時間または API レート制限を使い切りました (スキップされた段階: %s)",
+
+		// Run sampling
+		"Sampling":                   "サンプリング",
+		"all (%d runs, survey mode)": "すべて (%d 回の実行、survey モード)",
+		"%s:%d (%d of %d runs)":      "%s:%d (%[4]d 回の実行のうち %[3]d 回)",
+
+		// Node.js package managers
+		"Check for Node.js and Python lockfiles":               "Node.js と Python のロックファイルを確認",
+		"Upper bound; only when Node.js or Python is detected": "上限値です。Node.js または Python が検出された場合のみ問い合わせます",
+		"Cache the pnpm store":                                 "pnpm ストアをキャッシュ",
+		"Can reduce pnpm install time by up to 60%":            "pnpm install の時間を最大 60% 短縮できます",
+		"Cache yarn dependencies":                              "yarn の依存関係をキャッシュ",
+		"Can reduce yarn install time by up to 50%":            "yarn install の時間を最大 50% 短縮できます",
+		"Cache bun dependencies":                               "bun の依存関係をキャッシュ",
+		"Avoids re-downloading packages on every bun install":  "bun install のたびにパッケージを再ダウンロードせずに済みます",
+
+		// Python tooling
+		"Cache the uv cache directory":                       "uv のキャッシュディレクトリをキャッシュ",
+		"Can reduce uv sync time to seconds on a warm cache": "キャッシュが温まっていれば uv sync を数秒に短縮できます",
+		"Cache Poetry dependencies and virtualenvs":          "Poetry の依存関係と仮想環境をキャッシュ",
+		"Can reduce poetry install time significantly":       "poetry install の時間を大幅に短縮できます",
+		"Cache Pipenv virtualenvs":                           "Pipenv の仮想環境をキャッシュ",
+		"Can reduce pipenv install time significantly":       "pipenv install の時間を大幅に短縮できます",
+		"Cache conda packages":                               "conda パッケージをキャッシュ",
+		"Avoids re-downloading conda packages on every run":  "実行のたびに conda パッケージを再ダウンロードせずに済みます",
+
+		// Java build tools
+		"List repository files to find subprojects and Java build tools":                                        "サブプロジェクトと Java ビルドツールを見つけるためにリポジトリのファイル一覧を取得",
+		"Gradle projects also fetch gradle.properties, and Python projects without a lockfile requirements.txt": "Gradle プロジェクトでは gradle.properties を、ロックファイルのない Python プロジェクトでは requirements.txt も取得します",
+		"Enable the Gradle build cache": "Gradle ビルドキャッシュを有効化",
+		"Reuses task outputs from earlier builds instead of re-running unchanged tasks": "変更のないタスクを再実行せず、以前のビルドのタスク出力を再利用します",
+		"Enable the Gradle configuration cache":                                         "Gradle 構成キャッシュを有効化",
+		"Skips the configuration phase when build scripts haven't changed":              "ビルドスクリプトに変更がなければ構成フェーズをスキップします",
+
+		// Go build analysis
+		"Job %s builds Go code without restoring the build cache (GOCACHE)":                                                                          "ジョブ %s はビルドキャッシュ (GOCACHE) を復元せずに Go コードをビルドしています",
+		"Use actions/setup-go v4 or later with caching enabled (the default), which restores ~/.cache/go-build and the module cache keyed on go.sum": "キャッシュを有効にした (デフォルト) actions/setup-go v4 以降を使用してください。go.sum をキーに ~/.cache/go-build とモジュールキャッシュを復元します",
+		"(no test result was cached in %d sampled runs)":                                                                                             "(サンプリングした %d 件の実行でキャッシュされたテスト結果なし)",
+		"Job %s restores the Go build cache, but no test result was cached in %d sampled runs":                                                       "ジョブ %s は Go ビルドキャッシュを復元していますが、サンプリングした %d 件の実行でキャッシュされたテスト結果がありません",
+		"Test results are only reused when the package, its environment variables and the files it reads are unchanged; check that cache-dependency-path covers every go.sum and that tests don't depend on per-run values such as timestamps or temporary paths": "テスト結果はパッケージ、環境変数、読み込むファイルが変わっていない場合にのみ再利用されます。cache-dependency-path がすべての go.sum を含むか、テストがタイムスタンプや一時パスなど実行ごとに変わる値に依存していないか確認してください",
+		"Step %q in job %s may rewrite go.mod and go.sum during the build": "ジョブ %[2]s のステップ %[1]q はビルド中に go.mod と go.sum を書き換える可能性があります",
+		"Set GOFLAGS=-mod=readonly in the workflow env so builds fail when go.mod is out of date instead of silently updating it, and check tidiness with go mod tidy -diff (Go 1.23+)": "ワークフローの env に GOFLAGS=-mod=readonly を設定し、go.mod が古いときに黙って更新せずビルドを失敗させ、go mod tidy -diff (Go 1.23+) で整理状態を確認してください",
+		"go mod download in job %s takes %v per run on average": "ジョブ %s の go mod download は実行あたり平均 %v かかります",
+		"Modules are still downloaded with the module cache restored. Check that cache-dependency-path covers every go.sum; vendoring (go mod vendor with -mod=vendor) removes the download from proxy.golang.org entirely, at the cost of a larger repository and noisier dependency diffs": "モジュールキャッシュを復元してもモジュールがダウンロードされています。cache-dependency-path がすべての go.sum を含むか確認してください。ベンダリング (go mod vendor と -mod=vendor) は proxy.golang.org からのダウンロードを完全になくしますが、リポジトリが大きくなり依存関係の差分が増えます",
+		"Restore the module cache with actions/setup-go so modules come from the cache instead of proxy.golang.org on every run; vendoring is the alternative when the proxy is slow or unreachable, at the cost of a larger repository":                                                     "actions/setup-go でモジュールキャッシュを復元し、毎回 proxy.golang.org ではなくキャッシュからモジュールを取得してください。プロキシが遅いか到達できない場合は、リポジトリが大きくなる代わりにベンダリングも選択肢です",
+		"Go tests take %v per run on average; slowest packages: %s": "Go テストは実行あたり平均 %v かかります。最も遅いパッケージ: %s",
+		"Give slow packages their own matrix job, skip long tests on pull requests with testing.Short(), and check that independent tests call t.Parallel()": "遅いパッケージは専用のマトリックスジョブに分け、プルリクエストでは testing.Short() で長いテストをスキップし、独立したテストが t.Parallel() を呼んでいるか確認してください",
+		"Job %s runs go test with -race, which typically makes tests 2-20x slower and uses 5-10x more memory":                                                "ジョブ %s は -race 付きで go test を実行しており、通常テストが 2-20 倍遅くなり、メモリを 5-10 倍使用します",
+		"Job %s runs go test with -race in every matrix entry; the race detector typically makes tests 2-20x slower and uses 5-10x more memory":              "ジョブ %s はすべてのマトリックスエントリで -race 付きの go test を実行しています。レース検出器は通常テストを 2-20 倍遅くし、メモリを 5-10 倍使用します",
+		"(measured %v per run)": "(実測: 実行あたり %v)",
+		"Run the race detector in a single matrix entry, or on pushes to the default branch and a nightly schedule, and keep pull request tests without -race": "レース検出器は 1 つのマトリックスエントリ、またはデフォルトブランチへのプッシュと夜間スケジュールでのみ実行し、プルリクエストのテストは -race なしにしてください",
+
+		// Monorepo subprojects
+		"Directory": "ディレクトリ",
+
+		// Supply-chain checks
+		"its repository is archived":           "リポジトリがアーカイブ済み",
+		"no release and no push in %d months":  "%d か月間リリースもプッシュもなし",
+		"no release in %d months":              "%d か月間リリースなし",
+		"a single contributor":                 "コントリビューターが 1 人のみ",
+		"Action %s is a supply-chain risk: %s": "アクション %s はサプライチェーンのリスクがあります: %s",
+		"Pin it to a full commit SHA and review its code, or replace it with a maintained action or an inline script": "完全なコミット SHA に固定してコードをレビューするか、メンテナンスされているアクションまたはインラインスクリプトに置き換えてください",
+		"Replace it with %s": "%s に置き換えてください",
+		"Check whether third-party action repositories are archived":  "サードパーティアクションのリポジトリがアーカイブされているか確認",
+		"Check when third-party actions last released":                "サードパーティアクションの最終リリース日を確認",
+		"Check whether third-party actions have a single contributor": "サードパーティアクションのコントリビューターが 1 人のみか確認",
+		"Upper bound; once per action repository":                     "上限値。アクションのリポジトリごとに 1 回問い合わせます",
+
+		// Workflow patches
+		"Suggested Patches": "提案パッチ",
+		"Save as a file and apply with git apply after review:":                 "ファイルに保存し、レビュー後に git apply で適用してください:",
+		"Look up release tags to pin unpinned actions in the suggested patches": "提案パッチで未固定のアクションを固定するためのリリースタグを取得",
+		"Upper bound; only actions without a version or tracking a branch":      "上限値。バージョン指定がないかブランチを追跡するアクションのみ問い合わせます",
+
+		// Actions cache usage
+		"Actions Cache Usage":                      "Actions キャッシュ使用量",
+		"%s of %s in %d caches":                    "キャッシュ %[3]d 件、%[1]s / %[2]s",
+		"Stale (unused for 3 days): %d caches, %s": "古いキャッシュ (3 日間未使用): %d 件、%s",
+		"Saved by pull requests: %s":               "プルリクエストが保存したキャッシュ: %s",
+		"Cache usage is at %.0f%% of the 10 GB limit. Beyond it, GitHub evicts the least recently used caches, which can include ones every run restores":                                                                 "キャッシュ使用量は 10 GB の上限の %.0f%% です。上限を超えると GitHub は最も長く使われていないキャッシュから削除し、毎回復元するキャッシュも含まれる可能性があります",
+		"%d caches (%s) haven't been used in 3 days. Delete them with gh cache delete or a scheduled cleanup workflow so they don't push out caches in active use":                                                        "%d 件のキャッシュ (%s) が 3 日間使われていません。使用中のキャッシュが追い出されないよう、gh cache delete または定期的なクリーンアップワークフローで削除してください",
+		"Pull request caches take %s but can only be restored by the pull request that saved them. Save caches on the default branch only, using actions/cache/restore in pull requests and actions/cache/save on pushes": "プルリクエストのキャッシュが %s を占めていますが、保存したプルリクエストでしか復元できません。プルリクエストでは actions/cache/restore、プッシュでは actions/cache/save を使い、デフォルトブランチでのみキャッシュを保存してください",
+		"Key %q is saved in %d variants (%s). Drop per-run values such as github.sha or github.run_id from the key and rely on restore-keys":                                                                              "キー %q が %d 種類 (%s) 保存されています。キーから github.sha や github.run_id など実行ごとの値を除き、restore-keys を活用してください",
+		"Get the total size of the repository's Actions caches":                     "リポジトリの Actions キャッシュの合計サイズを取得",
+		"List Actions caches for the stale, pull request and key variant breakdown": "古いキャッシュ、プルリクエストのキャッシュ、キーの種類の内訳のために Actions キャッシュ一覧を取得",
+		"Upper bound; 100 caches per page, only when caches exist":                  "上限値。1 ページあたり 100 件、キャッシュがある場合のみ取得します",
+
+		// Workflow chains
+		"Workflow Chain":                                               "ワークフローチェーン",
+		"%v on average over %d runs":                                   "%[2]d 回の実行で平均 %[1]v",
+		"starts %v after the triggering run":                           "トリガー元の実行完了から %v 後に開始",
+		"End-to-end lead time: %v on average, %v at most over %d runs": "エンドツーエンドのリードタイム: %[3]d 回の実行で平均 %[1]v、最大 %[2]v",
+		"Fetch the other workflow files to find workflow_run chains and deploy workflows": "workflow_run チェーンとデプロイワークフローを見つけるために他のワークフローファイルを取得",
+		"Upper bound; also lists the repository tree once":                                "上限値。リポジトリのツリーも 1 回取得します",
+		"List runs of the workflows chained by workflow_run":                              "workflow_run で連鎖するワークフローの実行一覧を取得",
+		"Upper bound; only workflows linked to the analyzed one":                          "上限値。分析対象とつながるワークフローのみ取得します",
+
+		// DORA metrics
+		"DORA Metrics":                                           "DORA メトリクス",
+		"Deploy workflows":                                       "デプロイワークフロー",
+		"Deployment frequency: %.1f per week":                    "デプロイ頻度: 週 %.1f 回",
+		"Lead time for changes: %v median":                       "変更のリードタイム: 中央値 %v",
+		"Change failure rate: %.0f%% (%d of %d deployments)":     "変更失敗率: %.0f%% (%[3]d 回のデプロイ中 %[2]d 回)",
+		"Time to restore: %v median over %d recoveries":          "復旧時間: %[2]d 回の復旧で中央値 %[1]v",
+		"Measured over the last %d days of deploy workflow runs": "直近 %d 日間のデプロイワークフローの実行に基づく",
+		"Elite":  "エリート",
+		"High":   "高",
+		"Medium": "中",
+		"Low":    "低",
+		"List runs of the deploy workflows for the DORA metrics":    "DORA メトリクスのためにデプロイワークフローの実行一覧を取得",
+		"Upper bound; only configured or detected deploy workflows": "上限値。指定または検出されたデプロイワークフローのみ取得します",
+
+		// Markdown report
+		"Step":     "ステップ",
+		"Severity": "重要度",
+		"Location": "場所",
+		"Finding":  "検出事項",
+
+		// Shell checks
+		"Job %s runs on Windows and other platforms without a default shell, so its run steps use PowerShell on Windows and bash elsewhere":                           "ジョブ %s はデフォルトシェルなしで Windows と他のプラットフォームで実行されるため、run ステップは Windows では PowerShell、それ以外では bash で実行されます",
+		"Set defaults.run.shell so every matrix entry runs the same shell; Git Bash is installed on GitHub-hosted Windows runners":                                    "すべてのマトリックスエントリが同じシェルを使うよう defaults.run.shell を設定してください。GitHub ホストの Windows ランナーには Git Bash がインストールされています",
+		"Step %q in job %s is written for bash but runs with PowerShell, the default shell on Windows":                                                                "ジョブ %[2]s のステップ %[1]q は bash 用に書かれていますが、Windows のデフォルトシェルである PowerShell で実行されます",
+		"Set shell: bash on the step, or defaults.run.shell for the job; Git Bash is installed on GitHub-hosted Windows runners":                                      "ステップに shell: bash を指定するか、ジョブに defaults.run.shell を設定してください。GitHub ホストの Windows ランナーには Git Bash がインストールされています",
+		"Step %q in job %s pipes commands with the implicit default shell, which doesn't set pipefail, so a failure before the last command of a pipeline is ignored": "ジョブ %[2]s のステップ %[1]q は pipefail を設定しない暗黙のデフォルトシェルでパイプを使うため、パイプライン最後のコマンドより前の失敗が無視されます",
+		"Set defaults.run.shell to bash, which runs scripts with -eo pipefail":                                                                                        "defaults.run.shell を bash に設定すると、スクリプトは -eo pipefail で実行されます",
+		"Step %q in job %s runs with shell %q, which keeps going after a command fails":                                                                               "ジョブ %[2]s のステップ %[1]q は、コマンドが失敗しても続行するシェル %[3]q で実行されます",
+		"Add -e to the shell command (e.g. bash -eo pipefail {0}) or start the script with set -e":                                                                    "シェルコマンドに -e を追加する(例: bash -eo pipefail {0})か、スクリプトを set -e で始めてください",
+		"Step %q in job %s runs a multi-line PowerShell script; a failing native command other than the last one doesn't fail the step":                               "ジョブ %[2]s のステップ %[1]q は複数行の PowerShell スクリプトを実行します。最後以外のネイティブコマンドが失敗してもステップは失敗しません",
+		"Set $PSNativeCommandUseErrorActionPreference = $true at the top of the script (PowerShell 7.3+), or check $LASTEXITCODE after each command":                  "スクリプトの先頭で $PSNativeCommandUseErrorActionPreference = $true を設定する(PowerShell 7.3+)か、各コマンドの後で $LASTEXITCODE を確認してください",
+		"Step %q in job %s runs a multi-line cmd script; only the last command's exit code fails the step":                                                            "ジョブ %[2]s のステップ %[1]q は複数行の cmd スクリプトを実行します。最後のコマンドの終了コードだけがステップを失敗させます",
+		"Append || exit /b to each command, or use shell: pwsh or bash":                                                                                               "各コマンドの後に || exit /b を付けるか、shell: pwsh または bash を使ってください",
+
+		// OS package installs
+		"Step %q in job %s installs %s packages (%s) on every run, taking %v on average":                                                                                                                     "ジョブ %[2]s のステップ %[1]q は毎回 %[3]s パッケージ (%[4]s) をインストールしており、平均 %[5]v かかっています",
+		"%s already ship with the GitHub-hosted runner images, so installing them again can usually be dropped":                                                                                              "%s は GitHub ホストランナーのイメージに含まれているため、通常はインストールを省略できます",
+		"Cache the packages with awalsh128/cache-apt-pkgs-action, or run the job in a container image with them baked in":                                                                                    "awalsh128/cache-apt-pkgs-action でパッケージをキャッシュするか、パッケージを組み込んだコンテナイメージでジョブを実行してください",
+		"Pass --no-install-recommends so apt only installs what the packages require":                                                                                                                        "apt が必要なパッケージだけをインストールするよう --no-install-recommends を指定してください",
+		"Set HOMEBREW_NO_AUTO_UPDATE and HOMEBREW_NO_INSTALL_CLEANUP so brew install doesn't update Homebrew itself first, which often takes most of the step; prefer tools preinstalled on the macOS image": "brew install が先に Homebrew 自体を更新しないよう HOMEBREW_NO_AUTO_UPDATE と HOMEBREW_NO_INSTALL_CLEANUP を設定してください。この更新がステップ時間の大半を占めることがよくあります。macOS イメージにプリインストールされたツールを優先してください",
+		"Pass --no-progress to choco install, cache the Chocolatey package cache with actions/cache, or use the tools preinstalled on the Windows image":                                                     "choco install に --no-progress を指定し、actions/cache で Chocolatey のパッケージキャッシュをキャッシュするか、Windows イメージにプリインストールされたツールを使ってください",
+
+		// Container image pulls
+		"Job %s spends %v per run on average starting its containers (%s)": "ジョブ %[1]s はコンテナ (%[3]s) の起動に 1 回あたり平均 %[2]v かかっています",
+		"image pulls: %s": "イメージの pull: %s",
+		"Mirror Docker Hub images to ghcr.io, which is closer to GitHub-hosted runners and not subject to Docker Hub's pull rate limits":                                                       "Docker Hub のイメージを ghcr.io にミラーしてください。GitHub ホストランナーに近く、Docker Hub の pull レート制限も受けません",
+		"Use smaller variants such as -slim or -alpine tags, which have fewer and smaller layers to download":                                                                                  "-slim や -alpine タグなどの小さいバリアントを使ってください。ダウンロードするレイヤーが少なく小さくなります",
+		"On self-hosted runners, bake the images into the runner or use a registry pull-through cache so they're pulled once per runner instead of once per job":                               "セルフホストランナーでは、イメージをランナーに組み込むかレジストリの pull-through キャッシュを使い、ジョブごとではなくランナーごとに 1 回だけ pull されるようにしてください",
+		"GitHub-hosted runners start without cached images, so every job pulls them again; caching images at the runner level needs self-hosted runners or larger runners with a custom image": "GitHub ホストランナーはキャッシュされたイメージなしで起動するため、すべてのジョブがイメージを pull し直します。ランナーレベルでイメージをキャッシュするにはセルフホストランナーか、カスタムイメージを使う larger ランナーが必要です",
+
+		// Style checks
+		"Job ID %s doesn't follow the %s naming used by the other jobs":                                   "ジョブ ID %s は他のジョブが使う %s の命名規則に従っていません",
+		"Rename the job to match; remember to update needs: and required status checks that refer to it":  "規則に合わせてジョブ名を変更してください。このジョブを参照する needs: と必須ステータスチェックも更新してください",
+		"Step %d of job %s runs a multi-line script without a name, so the log only shows its first line": "ジョブ %[2]s の %[1]d 番目のステップは名前なしで複数行のスクリプトを実行しているため、ログには 1 行目しか表示されません",
+		"Add a name: that says what the step does":                                                        "ステップの内容を表す name: を追加してください",
+		"Action %s is referenced as @%s here but as @%s earlier in the workflow":                          "アクション %s はここでは @%s、ワークフローの前の部分では @%s として参照されています",
+		"Use the same version of an action everywhere in a workflow so upgrades update every step":        "アップグレードがすべてのステップに反映されるよう、ワークフロー全体で同じバージョンのアクションを使ってください",
+		"%d of %d step names start with a lowercase letter, unlike the rest":                              "ステップ名 %[2]d 個のうち %[1]d 個が他と異なり小文字で始まっています",
+		"Capitalize step names consistently so the log reads evenly":                                      "ログが読みやすくなるよう、ステップ名の大文字・小文字を統一してください",
+		"Most actions are pinned by %s, unlike %s":                                                        "ほとんどのアクションは %s で固定されていますが、%s は違います",
+		"commit SHA":   "コミット SHA",
+		"major tag":    "メジャータグ",
+		"full version": "完全なバージョン",
+		"branch":       "ブランチ",
+		"Pin every action the same way; commit SHAs with a version comment are the most robust":                                                          "すべてのアクションを同じ方法で固定してください。バージョンコメント付きのコミット SHA が最も堅牢です",
+		"Job %s is the only job of the workflow and has %d steps":                                                                                        "ジョブ %s はワークフロー唯一のジョブで、%d 個のステップがあります",
+		"Split it into jobs such as lint, test and build linked with needs:, so independent parts run in parallel and a failure points at the right job": "lint、test、build などのジョブに分けて needs: でつなげてください。独立した部分が並列に実行され、失敗箇所も分かりやすくなります",
+
+		// Combined reports
+		"Also found in %d more places": "ほかに %d か所でも検出",
+
+		// Run timings by conclusion
+		"Successful runs: %d, %v on average, %v at most":          "成功した実行: %d 回、平均 %v、最大 %v",
+		"Failed runs: %d, %v on average, %v at most":              "失敗した実行: %d 回、平均 %v、最大 %v",
+		"Left out of the timings: %d runs in progress or skipped": "時間の集計から除外: 実行中またはスキップされた実行 %d 回",
+
+		// Cancelled runs
+		"Cancelled runs: %d, %d of them superseded by a newer run of the same branch, avoiding about %v of duplicate work": "キャンセルされた実行: %d 回、うち %d 回は同じブランチの新しい実行に置き換えられ、約 %v の重複作業を回避",
+		"Cancelled runs: %d, left out of the timings": "キャンセルされた実行: %d 回、時間の集計から除外",
+
+		// Cache key entropy
+		"Cache key %q in job %s changes on every run, so the cache is never restored":                                                                                     "ジョブ %[2]s のキャッシュキー %[1]q は実行ごとに変わるため、キャッシュが復元されません",
+		"Key the cache on the hash of the lockfile instead of run IDs, timestamps or commits, and add restore-keys so a changed lockfile still restores the latest cache": "実行 ID、タイムスタンプ、コミットではなくロックファイルのハッシュをキャッシュキーにし、ロックファイルが変わっても最新のキャッシュが復元されるよう restore-keys を追加してください",
+		"it missed in %d lookups and was never restored":                                                                                                                  "%d 回の検索でヒットせず、一度も復元されませんでした",
+		"Cache key %q in job %s doesn't change with the cached content, so it keeps restoring stale data":                                                                 "ジョブ %[2]s のキャッシュキー %[1]q はキャッシュの内容が変わっても変化しないため、古いデータを復元し続けます",
+		"A saved cache key is never overwritten, so add the hash of the lockfile to the key to save a fresh cache whenever dependencies change":                           "保存済みのキャッシュキーは上書きされないため、依存関係が変わるたびに新しいキャッシュが保存されるよう、キーにロックファイルのハッシュを追加してください",
+		"it was restored in %d lookups and never saved again":                                                                                                             "%d 回の検索で復元され、一度も再保存されていません",
+
+		// Disk space
+		"A job of this workflow": "このワークフローのジョブ",
+		"Job %s":                 "ジョブ %s",
+		"%s ran out of disk space in %d of %d runs": "%s は %[3]d 回中 %[2]d 回の実行でディスク容量が不足しました",
+		"%s ran low on disk space in %d of %d runs": "%s は %[3]d 回中 %[2]d 回の実行でディスク容量が少なくなりました",
+		"%s filled the root filesystem to %d%%":     "%s はルートファイルシステムを %d%% まで使用しました",
+		"as little as %d MB was left":               "空き容量は最少で %d MB でした",
+		"df showed the root filesystem %d%% full":   "df ではルートファイルシステムの使用率が %d%% でした",
+		"Free about 30 GB at the start of the job by removing the preinstalled toolchains it doesn't use, or move it to a larger runner with more disk space":                                                   "ジョブの開始時に使わないプリインストール済みツールチェーンを削除して約 30 GB を確保するか、ディスクの大きいラージランナーに移してください",
+		"Clean the workspace and prune Docker images and build cache on the self-hosted runner between jobs, or give it a larger disk":                                                                          "ジョブの間にセルフホストランナーのワークスペースを掃除し、Docker イメージとビルドキャッシュを削除するか、より大きなディスクを割り当ててください",
+		"Image builds keep every layer and the build cache on disk; prune them between builds with docker builder prune, or push with docker/build-push-action instead of loading images into the local daemon": "イメージのビルドはすべてのレイヤーとビルドキャッシュをディスクに残すため、ビルドの間に docker builder prune で削除するか、ローカルデーモンに読み込まず docker/build-push-action で直接プッシュしてください",
+
+		// Network flakiness
+		"timeout":          "タイムアウト",
+		"connection reset": "接続リセット",
+		"DNS":              "DNS",
+		"TLS handshake":    "TLS ハンドシェイク",
+		"server error":     "サーバーエラー",
+		"Transient network errors reaching %s in %d of %d runs (%s)": "%[3]d 回中 %[2]d 回の実行で %[1]s への接続中に一時的なネットワークエラーが発生 (%[4]s)",
+		"%d of those runs failed":                                    "うち %d 回は失敗しました",
+		"Install from the lockfile with npm ci, yarn install --frozen-lockfile or pnpm install --frozen-lockfile, cache the package store, and raise npm's fetch-retries": "npm ci、yarn install --frozen-lockfile、pnpm install --frozen-lockfile でロックファイルからインストールし、パッケージストアをキャッシュし、npm の fetch-retries を増やしてください",
+		"Install from a pinned requirements or lock file, cache pip's download cache, and pass --retries 5 --timeout 60 to pip":                                           "バージョンを固定した requirements またはロックファイルからインストールし、pip のダウンロードキャッシュをキャッシュし、pip に --retries 5 --timeout 60 を渡してください",
+		"Retry image pulls, and mirror the images to a registry close to the runners such as ghcr.io or a pull-through cache":                                             "イメージのプルを再試行し、ghcr.io やプルスルーキャッシュなどランナーに近いレジストリにイメージをミラーしてください",
+		"Let apt retry with -o Acquire::Retries=3, or bake the packages into a container image so jobs don't install them on every run":                                   "-o Acquire::Retries=3 で apt に再試行させるか、パッケージをコンテナイメージに組み込んで実行ごとにインストールしないようにしてください",
+		"Cache ~/.m2 or ~/.gradle keyed on the build files, and resolve dependencies through a repository mirror with retries":                                            "ビルドファイルをキーに ~/.m2 または ~/.gradle をキャッシュし、再試行付きのリポジトリミラー経由で依存関係を解決してください",
+		"Cache the Go module cache keyed on go.sum so modules are downloaded only when dependencies change":                                                               "go.sum をキーに Go モジュールキャッシュをキャッシュし、依存関係が変わったときだけモジュールをダウンロードしてください",
+		"Retry downloads with backoff, e.g. curl --retry 5 --retry-all-errors, and cache downloaded tools with actions/cache or a setup action":                           "curl --retry 5 --retry-all-errors のようにバックオフ付きでダウンロードを再試行し、ダウンロードしたツールは actions/cache や setup アクションでキャッシュしてください",
+		"Retry the step with backoff, e.g. with nick-fields/retry, and fetch through a mirror or caching proxy closer to the runners":                                     "nick-fields/retry などでステップをバックオフ付きで再試行し、ランナーに近いミラーやキャッシュプロキシ経由で取得してください",
+
+		// github-script
+		"Step %q in job %s runs a %d-line inline github-script": "ジョブ %[2]s のステップ %[1]q は %[3]d 行のインライン github-script を実行しています",
+		"Move the script into a versioned file under .github/scripts that exports a function, so it can be linted and unit tested, or into a custom JavaScript action when several workflows share it; the job must check out the repository first": "スクリプトを関数をエクスポートする .github/scripts 配下のバージョン管理されたファイルに移して lint と単体テストができるようにするか、複数のワークフローで共有するならカスタム JavaScript アクションにしてください。ジョブでは先にリポジトリをチェックアウトする必要があります",
+		"github-script step %q in job %s relies on the default token permissions for its API calls":                                                       "ジョブ %[2]s の github-script ステップ %[1]q は API 呼び出しにデフォルトのトークン権限を使っています",
+		"Declare the permissions the script needs on the job, so it keeps working when the default token permissions are read-only and gets nothing more": "スクリプトに必要な権限をジョブに宣言し、デフォルトのトークン権限が読み取り専用でも動作し、それ以上の権限を持たないようにしてください",
+		"github-script step %q in job %s calls APIs needing %s, which the job's permissions don't grant":                                                  "ジョブ %[2]s の github-script ステップ %[1]q は %[3]s が必要な API を呼び出していますが、ジョブの権限で許可されていません",
+		"Grant the missing permissions on the job, or the API calls fail with 403 Resource not accessible by integration":                                 "ジョブに不足している権限を付与してください。付与しないと API 呼び出しが 403 Resource not accessible by integration で失敗します",
+
+		// Policy enforcement
+		"The workflow": "ワークフロー",
+		"%s grants write-all permissions, which the policy forbids":                                "%s は方針で禁止されている write-all 権限を付与しています",
+		"Grant only the scopes the jobs need, e.g. contents: read":                                 "contents: read のように、ジョブに必要なスコープだけを付与してください",
+		"%s grants %s: %s, but the policy allows at most %s":                                       "%[1]s は %[2]s: %[3]s を付与していますが、方針で許可されているのは %[4]s までです",
+		"Lower %s to %s, or ask for an exception to the policy":                                    "%s を %s に下げるか、方針の例外を申請してください",
+		"%s runs on %q, which isn't an allowed runner label":                                       "%s は許可されていないランナーラベル %q で実行されます",
+		"Use one of the allowed runner labels: %s":                                                 "許可されたランナーラベルのいずれかを使ってください: %s",
+		"%s calls the reusable workflow %s, which the policy doesn't allow":                        "%s は方針で許可されていない再利用可能ワークフロー %s を呼び出しています",
+		"%s uses %s, which the policy doesn't allow":                                               "%s は方針で許可されていない %s を使っています",
+		"Replace it with an approved action or workflow, or ask for it to be added to the policy":  "承認済みのアクションやワークフローに置き換えるか、方針への追加を申請してください",
+		"%s doesn't declare permissions, so it gets the default token permissions":                 "%s は権限を宣言していないため、デフォルトのトークン権限が付与されます",
+		"Declare permissions on the workflow or the job; the policy requires explicit permissions": "ワークフローまたはジョブで権限を宣言してください。方針で明示的な権限が求められています",
+
+		// Custom Rego policies
+		"Custom policies could not be evaluated: %v":                                                              "カスタムポリシーを評価できませんでした: %v",
+		"Fix the policy error; test policies locally with opa eval -d <policy_dir> -i <input.json> data.analyzer": "ポリシーのエラーを修正してください。opa eval -d <policy_dir> -i <input.json> data.analyzer でローカルにテストできます",
+
+		// Cached reports
+		"No changes: the workflow file and its runs are unchanged since the analysis at %s, so its report is reused": "変更なし: %s の分析以降、ワークフローファイルと実行履歴が変わっていないため、そのレポートを再利用します",
+
+		// Cost, trend and grade
+		"Workflow Grade":                             "ワークフロー評価",
+		"Cost Estimate":                              "コスト見積もり",
+		"%d billable min × $%.3f = $%.2f":            "課金 %d 分 × $%.3f = $%.2f",
+		"Total over %d runs: %d billable min, $%.2f": "%d 回の実行の合計: 課金 %d 分、$%.2f",
+		"Projected per month: $%.2f":                 "月間予測: $%.2f",
+		"GitHub's list prices for private repositories; standard runners are free for public repositories and self-hosted runners aren't billed": "プライベートリポジトリ向けの GitHub の定価です。パブリックリポジトリの標準ランナーは無料で、セルフホステッドランナーは課金されません",
+		"Trend: recent successful runs are %d%% slower, %v on average against %v before":                                                         "傾向: 最近の成功した実行は %d%% 遅くなっています。平均 %v (以前は %v)",
+		"Trend: recent successful runs are %d%% faster, %v on average against %v before":                                                         "傾向: 最近の成功した実行は %d%% 速くなっています。平均 %v (以前は %v)",
+		"Trend: run times are stable":     "傾向: 実行時間は安定しています",
+		"failure rate %.0f%%, was %.0f%%": "失敗率 %.0f%% (以前は %.0f%%)",
+
+		// Built-in setup-* caches
+		"Job %s installs dependencies with %s, but %s doesn't cache them":                                                                                             "ジョブ %[1]s は %[2]s で依存関係をインストールしますが、%[3]s がそれをキャッシュしていません",
+		"Add cache: %s to the step's inputs; it restores and saves the package manager's download cache keyed on the lockfile, without a separate actions/cache step": "ステップの入力に cache: %s を追加してください。別途 actions/cache ステップを使わずに、ロックファイルをキーにパッケージマネージャーのダウンロードキャッシュを復元・保存します",
+		"Turn on the cache input of actions/setup-go v3, or upgrade to v4 or later, which caches by default":                                                          "actions/setup-go v3 の cache 入力を有効にするか、デフォルトでキャッシュする v4 以降にアップグレードしてください",
+
+		// Digest mode
+		"Workflow Digest":        "ワークフローダイジェスト",
+		"Metric Changes":         "指標の変化",
+		"New Findings":           "新しい検出事項",
+		"Resolved Findings":      "解決した検出事項",
+		"Metric":                 "指標",
+		"Before":                 "前回",
+		"After":                  "今回",
+		"Average successful run": "成功した実行の平均時間",
+		"Failure rate":           "失敗率",
+		"Estimated monthly cost": "推定月額コスト",
+		"First digest: this analysis is the baseline for the next one": "最初のダイジェスト: 今回の分析が次回の基準になります",
+		"No changes since %s": "%s 以降の変更はありません",
+		"Changes since %s":    "%s 以降の変更",
+
+		// Called workflows and actions
+		"Fetch the reusable workflows and actions called, %d levels deep": "呼び出される再利用可能ワークフローとアクションを %d 階層まで取得",
+		"Upper bound; once per called workflow or action":                 "上限値。呼び出されるワークフローまたはアクションごとに 1 回",
+
+		// Custom action linting
+		"Input %s of the action has no description":                                                                                                      "アクションの入力 %s に説明がありません",
+		"Describe every input; the description is what users see in the Marketplace and in editor completions":                                           "すべての入力に説明を付けてください。説明は Marketplace やエディターの補完に表示されます",
+		"The action runs on %s, which is end of life and deprecated on GitHub's runners":                                                                 "アクションは %s で実行されますが、サポートが終了しており GitHub のランナーで非推奨です",
+		"Move to a supported runtime and test the action on it; callers get warnings, and the runner may force the newer runtime anyway":                 "サポートされているランタイムに移行し、その上でアクションをテストしてください。呼び出し側に警告が表示され、ランナーが新しいランタイムを強制することもあります",
+		"Composite step uses %s, which isn't pinned to a commit SHA":                                                                                     "コンポジットのステップがコミット SHA に固定されていない %s を使用しています",
+		"Pin actions inside a composite action to a full commit SHA with the version in a comment; every workflow using the action inherits a moved tag": "コンポジットアクション内のアクションは完全なコミット SHA に固定し、バージョンはコメントに残してください。タグが移動すると、このアクションを使うすべてのワークフローが影響を受けます",
+		"The action has no branding":                                        "アクションに branding がありません",
+		"Add an icon and color so the action stands out in the Marketplace": "Marketplace で目立つようにアイコンと色を追加してください",
+		"The action's image %s isn't pinned by digest":                      "アクションのイメージ %s がダイジェストで固定されていません",
+		"Pin the image by digest, e.g. image:tag@sha256:..., so every run of the action uses the same image; update the digest with Dependabot or Renovate": "アクションのすべての実行が同じイメージを使うように image:tag@sha256:... のようにダイジェストで固定し、Dependabot や Renovate でダイジェストを更新してください",
+		"The action's image %s floats on the latest tag":                                         "アクションのイメージ %s が latest タグに追従しています",
+		"Fetch the repository's own action.yml files and Docker action Dockerfiles to lint them": "リポジトリ自身の action.yml ファイルと Docker アクションの Dockerfile を取得して検査",
+		"Upper bound; only when the repository has actions":                                      "上限値。リポジトリにアクションがある場合のみ",
+
+		// Unused workflows
+		"disabled manually": "手動で無効化されています",
+		"disabled after 60 days without repository activity":                                                     "リポジトリのアクティビティが 60 日間なかったため無効化されています",
+		"Workflow %s is triggered by %s, which isn't a GitHub Actions event":                                     "ワークフロー %s のトリガー %s は GitHub Actions のイベントではありません",
+		"Check the spelling against the events GitHub documents; a misspelled trigger never starts the workflow": "GitHub のドキュメントにあるイベント名とスペルを照合してください。スペルを誤ったトリガーではワークフローは起動しません",
+		"Workflow %s is %s": "ワークフロー %s: %s",
+		"Delete the workflow file if it's no longer needed, or enable it again; a disabled workflow still reads as live to anyone browsing the repository": "不要であればワークフローファイルを削除し、そうでなければ再び有効化してください。無効化されたワークフローも、リポジトリを見る人には使われているように見えます",
+		"Workflow %s hasn't run in the last %d days": "ワークフロー %s は直近 %d 日間実行されていません",
+		"Delete the workflow if it's dead, or check its triggers: branch and path filters that no longer match keep it from ever starting": "使われていないワークフローなら削除し、そうでなければトリガーを確認してください。一致しなくなったブランチやパスのフィルターが起動を妨げている可能性があります",
+		"List the repository's workflows to find disabled ones":                                                                            "無効化されたワークフローを見つけるためにリポジトリのワークフローを一覧取得",
+		"Count each workflow's runs in the last %d days to find workflows that never run":                                                  "実行されないワークフローを見つけるために直近 %d 日間の各ワークフローの実行数を集計",
+		"Upper bound; once per workflow file":                                                                                              "上限値。ワークフローファイルごとに 1 回",
+
+		// Toolchain end of life
+		"Job %s installs %s %s, which has reached end of life":                             "ジョブ %s はサポートが終了した %s %s をインストールしています",
+		"Job %s installs %s %s, which reached end of life on %s":                           "ジョブ %s は %[4]s にサポートが終了した %[2]s %[3]s をインストールしています",
+		"Move to a supported version; end-of-life toolchains no longer get security fixes": "サポートされているバージョンに移行してください。サポートが終了したツールチェーンにはセキュリティ修正が提供されません",
+
+		// Hardcoded credentials
+		"%s looks like a hardcoded %s": "%s はハードコードされた %s のようです",
+		"Revoke the credential now, as anyone who can read the repository or its history has it, then store the new one as an encrypted secret": "リポジトリやその履歴を読める人は誰でもこの認証情報を入手できるため、今すぐ失効させ、新しい認証情報は暗号化されたシークレットとして保存してください",
+		"AWS access key ID": "AWS アクセスキー ID",
+		"GitHub token":      "GitHub トークン",
+		"Slack token":       "Slack トークン",
+		"Google API key":    "Google API キー",
+		"Stripe live key":   "Stripe ライブキー",
+		"private key":       "秘密鍵",
+		"curl password":     "curl のパスワード",
+		"password in a URL": "URL 内のパスワード",
+		"credential":        "認証情報",
+
+		// Fork pull request exposure
+		"Fork Pull Request Exposure": "フォークのプルリクエストへの露出",
+		"Rating":                     "評価",
+		"none":                       "なし",
+		"low":                        "低",
+		"medium":                     "中",
+		"high":                       "高",
+		"No workflow runs with the repository's privileges for pull requests from forks":                    "フォークからのプルリクエストに対してリポジトリの権限で実行されるワークフローはありません",
+		"Checks out or downloads code built from the pull request":                                          "プルリクエストからビルドされたコードをチェックアウトまたはダウンロードします",
+		"Checks out the pull request's code":                                                                "プルリクエストのコードをチェックアウトします",
+		"Interpolates %s into scripts":                                                                      "%s をスクリプトに埋め込みます",
+		"Uses secrets %s":                                                                                   "シークレット %s を使用します",
+		"Passes every secret to a reusable workflow with secrets: inherit":                                  "secrets: inherit で再利用可能ワークフローにすべてのシークレットを渡します",
+		"Grants the GITHUB_TOKEN write access to %s":                                                        "GITHUB_TOKEN に %s への書き込み権限を付与します",
+		"Leaves the GITHUB_TOKEN with the repository's default permissions, which may include write access": "GITHUB_TOKEN がリポジトリの既定の権限のままで、書き込み権限を含む場合があります",
+		"Workflow %s runs a fork's code or input from %s":                                                   "ワークフロー %s は %s でフォークのコードや入力を実行します",
+		"Workflow %s runs a fork's code or input from %s with secrets or a write token":                     "ワークフロー %s は %s でシークレットや書き込みトークンを持ったままフォークのコードや入力を実行します",
+		"Split the workflow in two: build and test the pull request under pull_request, without secrets, and act on its uploaded results in a workflow_run workflow that never executes them": "ワークフローを 2 つに分割してください。プルリクエストはシークレットなしで pull_request でビルド・テストし、アップロードされた結果はそれを決して実行しない workflow_run ワークフローで処理します",
+		"Treat the triggering run's code, artifacts and branch names as untrusted: never execute them, and pass event values to scripts through env variables":                                "トリガーした実行のコード、アーティファクト、ブランチ名は信頼できないものとして扱ってください。決して実行せず、イベントの値は env 変数でスクリプトに渡します",
+
+		// continue-on-error audit
+		"Step %s of job %s failed in %d of %d successful runs, hidden by continue-on-error":                                                 "ジョブ %[2]s のステップ %[1]s は成功した実行 %[4]d 回のうち %[3]d 回失敗しましたが、continue-on-error で隠れています",
+		"Fix the step or remove it; if its failures are expected, check its outcome in a later step so they're reported instead of ignored": "ステップを修正するか削除してください。失敗が想定内なら、後続のステップで outcome を確認し、無視せずに報告されるようにします",
+
+		// HTML report
+		"Run Timeline": "実行タイムライン",
+		"Run #%d":      "実行 #%d",
+		"Dashed lines are time spent waiting for a runner or for needed jobs; click a job to show its steps.": "破線はランナーや依存ジョブを待った時間です。ジョブをクリックするとステップを表示します。",
+		"Waited %s": "%s 待機",
+
+		// Mermaid diagrams
+		"Job Graph":     "ジョブグラフ",
+		"%v on average": "平均 %v",
+
+		// Adoption tracking
+		"Adopted Since Last Run":                             "前回の実行以降に採用された推奨事項",
+		"%d recommendations adopted since %s, %d still open": "%[2]s 以降に推奨事項を %[1]d 件採用、残り %[3]d 件",
+
+		// Cost attribution
+		"Most expensive branches and pull requests": "最もコストの高いブランチとプルリクエスト",
+		"%d runs, %d billable min, $%.2f":           "%d 回の実行、課金 %d 分、$%.2f",
+		"Branch or pull request":                    "ブランチまたはプルリクエスト",
+		"Runs":                                      "実行",
+		"Billable minutes":                          "課金時間(分)",
+		"Cost":                                      "コスト",
+		"bot":                                       "ボット",
+
+		// Custom pricing
+		"Prices from %s; other runners at GitHub's list prices less %.0f%%, self-hosted ones free": "%s の価格です。その他のランナーは GitHub の定価から %.0f%% 割引した価格で、セルフホステッドランナーは無料です",
+		"Prices from %s; other runners at GitHub's list prices, self-hosted ones free":             "%s の価格です。その他のランナーは GitHub の定価で、セルフホステッドランナーは無料です",
+
+		// Required status checks
+		"Required Status Checks":          "必須ステータスチェック",
+		"Branch":                          "ブランチ",
+		"Check":                           "チェック",
+		"Reported by":                     "報告元",
+		"reported outside GitHub Actions": "GitHub Actions の外部から報告",
+		"no workflow job reports it":      "報告するワークフロージョブなし",
+		"Required check %q of %s isn't reported by any workflow job, so pull requests wait for it and can't merge":                                                                                        "%[2]s の必須チェック %[1]q を報告するワークフロージョブがないため、プルリクエストはその完了を待ち続けてマージできません",
+		"Update the branch protection or ruleset to the job's current check name, or remove the check; renaming a job, adding a matrix or calling a reusable workflow changes the name its check reports": "ブランチ保護またはルールセットをジョブの現在のチェック名に更新するか、チェックを削除してください。ジョブ名の変更、マトリックスの追加、再利用可能ワークフローの呼び出しでチェック名は変わります",
+		"Required check %q takes %v on average, and every pull request to %s waits for it before merging":                                                                                                 "必須チェック %q は平均 %v かかり、%s へのすべてのプルリクエストがマージ前にその完了を待ちます",
+		"Shard its tests across parallel jobs and cache its dependencies, or move the slowest part out of the required checks, e.g. to run on push to the default branch or in the merge queue":           "テストを並列ジョブに分割して依存関係をキャッシュするか、最も遅い部分を必須チェックから外してデフォルトブランチへの push やマージキューで実行してください",
+		"Jobs %s run on pull requests but aren't required checks of %s, so pull requests can merge while they fail":                                                                                       "ジョブ %s はプルリクエストで実行されますが %s の必須チェックではないため、失敗してもプルリクエストをマージできます",
+		"Require them in the branch protection or ruleset, or add one job that needs them all and require only that one, so renaming or adding jobs doesn't change the required checks":                   "ブランチ保護またはルールセットで必須にするか、それらすべてを needs に持つジョブを 1 つ追加してそれだけを必須にし、ジョブの名前変更や追加で必須チェックが変わらないようにしてください",
+		"Get the status checks the default branch requires, with the repository and its rulesets":                                                                                                         "リポジトリとルールセットを含め、デフォルトブランチの必須ステータスチェックを取得",
+
+		// Merge queue
+		"Merge Queue":                      "マージキュー",
+		"required":                         "必須",
+		"Jobs in the queue, slowest first": "キュー内のジョブ (遅い順)",
+		"Throughput: %.1f entries merged per day over %d days": "スループット: %[2]d 日間で 1 日あたり %.1[1]f 件をマージ",
+		"Latency per entry: %v on average, %v at most":         "エントリーあたりの待ち時間: 平均 %v、最大 %v",
+		"Removed from the queue: %d of %d entries (%.0f%%)":    "キューから除外: %[2]d 件中 %[1]d 件 (%.0[3]f%%)",
+		"Workflow %s reports required checks of %s but isn't triggered by merge_group, so merge queue entries wait for them until the queue times out":                                   "ワークフロー %s は %s の必須チェックを報告しますが merge_group でトリガーされないため、マージキューのエントリーはタイムアウトするまでそれを待ちます",
+		"Trigger the workflow on merge_group as well, so its checks also report on the merge queue's temporary branches":                                                                 "merge_group でもワークフローをトリガーし、マージキューの一時ブランチでもチェックが報告されるようにしてください",
+		"Job %s takes %v on average in the merge queue, and every entry waits for it before merging":                                                                                     "ジョブ %s はマージキューで平均 %v かかり、すべてのエントリーがマージ前にその完了を待ちます",
+		"Run it on pull requests only and keep fast checks that catch conflicts between pull requests in the queue; a job skipped in the merge group still satisfies its required check": "プルリクエストでのみ実行し、キューにはプルリクエスト間の競合を検出する高速なチェックだけを残してください。マージグループでスキップされたジョブも必須チェックを満たします",
+
+		// Run start heatmap
+		"Run Start Heatmap":                             "実行開始ヒートマップ",
+		"Busiest: %s %02d:00 UTC, %d of %d runs":        "最も混雑: %s %02d:00 UTC、%[4]d 回中 %[3]d 回",
+		"Quietest hour for scheduled jobs: %02d:00 UTC": "スケジュールジョブに最も空いている時間: %02d:00 UTC",
+		"Monday":    "月曜日",
+		"Tuesday":   "火曜日",
+		"Wednesday": "水曜日",
+		"Thursday":  "木曜日",
+		"Friday":    "金曜日",
+		"Saturday":  "土曜日",
+		"Sunday":    "日曜日",
+
+		// Runner image lifecycle
+		"Job %s runs on %s, which GitHub removes on %s; until then, jobs on it fail during scheduled brownouts": "ジョブ %s は %s で実行されますが、GitHub は %s にこのイメージを削除します。それまでは予定されたブラウンアウトの間、このイメージのジョブが失敗します",
+		"Job %s runs on %s, which GitHub removed on %s, so the job no longer starts":                            "ジョブ %s は %s で実行されますが、GitHub が %s にこのイメージを削除したため、ジョブはもう開始されません",
+		"Move to %s and check the preinstalled tool versions, which change between images":                      "%s に移行し、イメージごとに変わるプリインストールツールのバージョンを確認してください",
+		"macOS 15 runners are Apple silicon; use macos-15-intel for x64 builds":                                 "macOS 15 ランナーは Apple シリコンです。x64 ビルドには macos-15-intel を使用してください",
+		"Windows Server 2025 runners come with Visual Studio 2022 instead of 2019":                              "Windows Server 2025 ランナーには Visual Studio 2019 ではなく 2022 がインストールされています",
+
+		// Windows steps and line endings
+		"Step %q in job %s writes to $%s in PowerShell, where it's an unset variable, so the value is lost":                                                                                           "ジョブ %[2]s のステップ %[1]q は PowerShell で $%[3]s に書き込みますが、PowerShell では未設定の変数のため値は失われます",
+		"Use $env:%s in PowerShell, or set shell: bash on the step":                                                                                                                                   "PowerShell では $env:%s を使うか、ステップに shell: bash を設定してください",
+		"Step %q in job %s pastes ${{ %s }} into a bash script on Windows, where the path's backslashes are read as escapes":                                                                          "ジョブ %[2]s のステップ %[1]q は Windows の bash スクリプトに ${{ %[3]s }} を埋め込むため、パスのバックスラッシュがエスケープとして解釈されます",
+		"Read the path from the quoted environment variable \"$%s\" instead, and convert it with cygpath -u for tools that expect a POSIX path":                                                       "代わりに引用符で囲んだ環境変数 \"$%s\" からパスを読み、POSIX パスを期待するツールには cygpath -u で変換してください",
+		"Job %s checks out files with CRLF line endings on Windows, and the logs of %d of %d runs show scripts or linters tripping over them":                                                         "ジョブ %s は Windows で CRLF 改行のファイルをチェックアウトし、%[3]d 回中 %[2]d 回の実行ログでスクリプトやリンターがそれにつまずいています",
+		"Git converts line endings on checkout because core.autocrlf is true on Windows runners; commit a .gitattributes with * text=auto eol=lf, or turn the conversion off before actions/checkout": "Windows ランナーでは core.autocrlf が true のため、Git はチェックアウト時に改行を変換します。* text=auto eol=lf を含む .gitattributes をコミットするか、actions/checkout の前に変換を無効にしてください",
+
+		// ML workloads on GPU and larger runners
+		"Step %q in ML job %s averages %s on CPU, %.0f%% of the job's %s ($%.2f per run on %s)": "ML ジョブ %[2]s のステップ %[1]q は CPU で平均 %[3]s かかり、ジョブ全体 %[5]s の %.0[4]f%% を占めます (%[7]s で 1 回あたり $%.2[6]f)",
+		"~%s and $%.2f per run":                        "1 回あたり約 %s、$%.2f",
+		"T4 GPU runner: %s, assuming a %.0fx speedup":  "T4 GPU ランナー: %s (%.0f 倍の高速化を想定)",
+		"16-core runner: %s, assuming a %.1fx speedup": "16 コアランナー: %s (%.1f 倍の高速化を想定)",
+		"Estimated on faster runners: %s. Measure the speedup on a run before switching; GPU runners need the CUDA builds of the frameworks": "より高速なランナーでの見積もり: %s。切り替える前に実際の実行で高速化を測定してください。GPU ランナーにはフレームワークの CUDA ビルドが必要です",
+
+		// CODEOWNERS team ownership
+		"Owners":                    "オーナー",
+		"Unowned":                   "オーナーなし",
+		"Findings and Cost by Team": "チーム別の検出事項とコスト",
+		"%d findings (%d critical, %d warnings) in %d workflows": "%[4]d 個のワークフローで検出事項 %[1]d 件 (重大 %[2]d 件、警告 %[3]d 件)",
+		"$%.2f per month": "月 $%.2f",
+
+		// Report audiences
+		"Findings Overview": "検出事項の概要",
+		"Critical":          "重大",
+		"%d findings: %d critical, %d warnings, %d info": "検出事項 %d 件: 重大 %d 件、警告 %d 件、情報 %d 件",
+		"By category: %s": "カテゴリ別: %s",
+		"Applying the recommendations saves about %v of runner time per month": "推奨事項を適用すると月に約 %v のランナー時間を節約できます",
+		"about $%.2f": "約 $%.2f",
+
+		// Finding confidence
+		"Confidence: %.0f%%, drawn from job logs": "信頼度: %.0f%%、ジョブログから推定",
+
+		// Starter workflows of an organization's .github repository
+		"Starter Workflows":                      "スターターワークフロー",
+		"no findings":                            "検出事項なし",
+		"%d findings (%d critical, %d warnings)": "検出事項 %d 件 (重大 %d 件、警告 %d 件)",
+		"Repositories created from these templates inherit their findings, so fixing a template fixes every new copy": "これらのテンプレートから作成したリポジトリは検出事項をそのまま引き継ぐため、テンプレートを直せば新しいコピーすべてが直ります",
+
+		// Artifact download ordering
+		"Job %s downloads artifact %q, but job %s uploads it per matrix entry as %q at line %d":                                    "ジョブ %s はアーティファクト %q をダウンロードしますが、ジョブ %s は %[5]d 行目でマトリックスの項目ごとに %[4]q としてアップロードしています",
+		"Download with pattern: %s* and merge-multiple: true to get the artifacts of every matrix entry":                           "すべてのマトリックス項目のアーティファクトを取得するには pattern: %s* と merge-multiple: true でダウンロードしてください",
+		"Job %s downloads artifact %q, which no job of this workflow uploads":                                                      "ジョブ %s はアーティファクト %q をダウンロードしますが、このワークフローのどのジョブもアップロードしていません",
+		"Check the name against the upload-artifact steps, or set run-id to download it from another workflow run":                 "upload-artifact ステップの名前と照合するか、別のワークフロー実行から取得するには run-id を設定してください",
+		"Job %s downloads artifact %q before uploading it at line %d":                                                              "ジョブ %s はアーティファクト %q を %d 行目でアップロードする前にダウンロードしています",
+		"Move the download after the upload, or drop it and use the files directly":                                                "ダウンロードをアップロードの後に移すか、ダウンロードをやめてファイルを直接使ってください",
+		"Job %s downloads artifact %q uploaded by job %s at line %d, but doesn't need that job, so the artifact may not exist yet": "ジョブ %[1]s はジョブ %[3]s が %[4]d 行目でアップロードするアーティファクト %[2]q をダウンロードしますが、そのジョブを needs に含めていないため、アーティファクトがまだ存在しない可能性があります",
+		"Add %s to the needs of job %s": "ジョブ %[2]s の needs に %[1]s を追加してください",
+
+		// Repeated and complex expressions
+		"Expression ${{ %s }} is repeated in %d steps of job %s (lines %s)":                                                 "式 ${{ %s }} がジョブ %[3]s の %[2]d 個のステップで繰り返されています (%[4]s 行目)",
+		"Compute it once in a step that writes it to $GITHUB_OUTPUT and refer to that output in the other steps":            "1 つのステップで一度だけ計算して $GITHUB_OUTPUT に書き出し、他のステップではその出力を参照してください",
+		"Hoist it into the job's env: under a descriptive name and refer to that variable in the steps":                     "わかりやすい名前でジョブの env: に移し、ステップではその変数を参照してください",
+		"Step %d of job %s has an expression of %d characters with %d conditions":                                           "ジョブ %[2]s のステップ %[1]d に、条件 %[4]d 個からなる %[3]d 文字の式があります",
+		"Split it into named parts in the job's env: or a step output, so each condition can be read and reused on its own": "ジョブの env: やステップの出力で名前付きの部分に分け、各条件を個別に読んで再利用できるようにしてください",
+
+		// Cache save and restore time
+		"Saving cache %q in job %s took %v across %d runs, more than the %v its %d restores saved":                                                                             "ジョブ %[2]s のキャッシュ %[1]q の保存に %[4]d 回の実行で %[3]v かかり、%[6]d 回の復元で節約した %[5]v を上回っています",
+		"the job averages %v when the cache misses and %v when it's restored, %v of which is the restore":                                                                      "ジョブはキャッシュミス時に平均 %v、復元時に平均 %v かかり、そのうち復元が %v です",
+		"Cache the package manager's download cache, e.g. ~/.npm, instead of node_modules; it's smaller to save and restore, and npm ci rebuilds node_modules from it quickly": "node_modules の代わりに ~/.npm などパッケージマネージャーのダウンロードキャッシュをキャッシュしてください。保存と復元が小さく済み、npm ci がそこから node_modules をすばやく再構築します",
+		"Cache fewer files: narrow path to downloaded dependencies rather than build outputs, and key it on the lockfile hash so it's only saved when dependencies change":     "キャッシュするファイルを減らしてください。path をビルド成果物ではなくダウンロードした依存関係に絞り、依存関係が変わったときだけ保存されるようキーをロックファイルのハッシュにしてください",
+
+		// QEMU and Buildx setup
+		"Job %s sets up QEMU but only builds for the platform of its runner":                                         "ジョブ %s は QEMU をセットアップしていますが、ランナー自身のプラットフォーム向けにしかビルドしていません",
+		"Remove the setup-qemu-action step; QEMU is only needed to build or run images for another CPU architecture": "setup-qemu-action ステップを削除してください。QEMU は別の CPU アーキテクチャ向けのイメージをビルドまたは実行するときにだけ必要です",
+		"(%v per run)": "(実行あたり %v)",
+		"Setting up Buildx in job %s takes %v per run": "ジョブ %s の Buildx のセットアップに実行あたり %v かかっています",
+		"Create a persistent builder on the runner once (docker buildx create --name ci --driver docker-container --bootstrap) and select it with name: ci, keep-state: true and cleanup: false, so the builder and its layer cache survive between jobs": "ランナーに永続的なビルダーを一度作成し (docker buildx create --name ci --driver docker-container --bootstrap)、name: ci、keep-state: true、cleanup: false で選択して、ビルダーとレイヤーキャッシュをジョブ間で保持してください",
+		"Builds without multi-platform output or cache export can use driver: docker, which needs no BuildKit container; otherwise pin version: so the buildx binary is reused from the tool cache":                                                       "マルチプラットフォーム出力やキャッシュのエクスポートがないビルドは、BuildKit コンテナーが不要な driver: docker を使えます。そうでなければ version: を固定して buildx バイナリをツールキャッシュから再利用してください",
+
+		// Container registry logins
+		"Job %s logs in to %s again after the login at line %d":                                                                   "ジョブ %s は %[3]d 行目のログインの後で %[2]s に再びログインしています",
+		"Remove the repeated login; a login lasts for the rest of the job":                                                        "繰り返しのログインを削除してください。ログインはジョブの終わりまで有効です",
+		"Job %s logs in to a container registry but never pushes":                                                                 "ジョブ %s はコンテナーレジストリにログインしていますが、プッシュしていません",
+		"Remove the login unless the job pulls private images; every login puts a registry token within reach of the job's steps": "ジョブがプライベートイメージをプルしないならログインを削除してください。ログインのたびにレジストリトークンがジョブのステップから参照できるようになります",
+		"Job %s leaves its %s credentials in the Docker config of a self-hosted runner":                                           "ジョブ %s は %s の認証情報をセルフホステッドランナーの Docker 設定に残しています",
+		"Add a final step with if: always() running docker logout, or use docker/login-action, which logs out when the job ends":  "if: always() で docker logout を実行する最後のステップを追加するか、ジョブの終了時にログアウトする docker/login-action を使ってください",
+		"Keep the action's default logout, so the next job on the runner can't reuse the credentials":                             "ランナーの次のジョブが認証情報を再利用できないよう、アクションのデフォルトのログアウトを維持してください",
+
+		// release hardening
+		"Release Hardening": "リリースの堅牢化",
+		"Trigger limited to release tags or published releases": "リリースタグまたは公開済みリリースに限定されたトリガー",
+		"Build provenance attestation":                          "ビルドの来歴証明",
+		"Signed artifacts":                                      "署名済みアーティファクト",
+		"Each release asset uploaded once":                      "各リリースアセットを一度だけアップロード",
+		"Release workflow runs on %s, which also matches tags or release events that aren't releases":                                                                          "リリースワークフローは %s で実行され、リリースではないタグやリリースイベントにも一致します",
+		"Filter push tags to the release pattern, e.g. v[0-9]+.[0-9]+.[0-9]+, and release events to types: [published], so test tags and edited releases don't publish":        "テストタグや編集されたリリースが公開されないよう、push タグを v[0-9]+.[0-9]+.[0-9]+ などのリリースパターンに、リリースイベントを types: [published] に絞り込んでください",
+		"Release asset %s is uploaded again after job %s uploaded it at line %d":                                                                                               "リリースアセット %[1]s はジョブ %[2]s が %[3]d 行目でアップロードした後に再びアップロードされます",
+		"Upload each asset from one step; a second upload either fails because the asset exists or silently replaces it":                                                       "各アセットは一つのステップからアップロードしてください。二度目のアップロードはアセットが存在するため失敗するか、黙って置き換えます",
+		"Every matrix entry of job %s uploads release asset %s":                                                                                                                "ジョブ %s のすべてのマトリックスエントリがリリースアセット %s をアップロードします",
+		"Name the asset after the matrix entry, e.g. with ${{ matrix.os }}, or upload from a single job after the matrix finishes":                                             "${{ matrix.os }} などでアセット名にマトリックスエントリを含めるか、マトリックス完了後に単一のジョブからアップロードしてください",
+		"Release workflow publishes without a build provenance attestation":                                                                                                    "リリースワークフローはビルドの来歴証明なしで公開しています",
+		"Attest the built artifacts with actions/attest-build-provenance, which needs id-token: write and attestations: write, or call the SLSA generator's reusable workflow": "id-token: write と attestations: write が必要な actions/attest-build-provenance でビルド成果物を証明するか、SLSA generator の再利用可能ワークフローを呼び出してください",
+		"Release workflow doesn't sign what it publishes":                                                                                                                      "リリースワークフローは公開するものに署名していません",
+		"Sign images and files keylessly with cosign, which needs id-token: write, so users can verify they came from this workflow":                                           "利用者がこのワークフローから来たものか検証できるよう、id-token: write が必要な cosign でイメージとファイルにキーレス署名してください",
+
+		// rollbacks
+		"Rollbacks: %d, %.0f%% of successful deployments": "ロールバック: %d 回、成功したデプロイの %.0f%%",
+		"%v on average after the deployment they undo":    "取り消したデプロイから平均 %v 後",
+
+		// monorepo build system caches
+		"Turborepo remote cache":                                   "Turborepo リモートキャッシュ",
+		"Share Turborepo task outputs through Vercel Remote Cache": "Vercel Remote Cache で Turborepo のタスク出力を共有",
+		"Tasks whose inputs didn't change replay their outputs instead of running again, across jobs, branches and developer machines; actions/cache on .turbo only restores one whole snapshot per key": "入力が変わらないタスクは再実行せず、ジョブ、ブランチ、開発者マシン間で出力を再生します。.turbo に対する actions/cache はキーごとにスナップショット全体を一つ復元するだけです",
+		"Turborepo remote cache on GitHub":                                                                                     "GitHub 上の Turborepo リモートキャッシュ",
+		"Serve Turborepo's remote cache from the GitHub Actions cache":                                                         "GitHub Actions キャッシュから Turborepo のリモートキャッシュを提供",
+		"Gives per-task remote caching without an external account; entries count toward the repository's Actions cache quota": "外部アカウントなしでタスク単位のリモートキャッシュを提供します。エントリはリポジトリの Actions キャッシュ容量に含まれます",
+		"Nx Cloud remote cache":                  "Nx Cloud リモートキャッシュ",
+		"Share Nx task outputs through Nx Cloud": "Nx Cloud で Nx のタスク出力を共有",
+		"Tasks whose inputs didn't change replay their outputs instead of running again, across jobs, branches and developer machines; actions/cache on .nx/cache only restores one whole snapshot per key": "入力が変わらないタスクは再実行せず、ジョブ、ブランチ、開発者マシン間で出力を再生します。.nx/cache に対する actions/cache はキーごとにスナップショット全体を一つ復元するだけです",
+		"Restore the Nx local cache from the GitHub Actions cache": "GitHub Actions キャッシュから Nx のローカルキャッシュを復元",
+		"Without a remote cache, restoring the latest cache of the branch or main still skips unchanged tasks; nx affected skips unaffected projects altogether": "リモートキャッシュがなくても、ブランチや main の最新キャッシュを復元すれば変更のないタスクをスキップできます。nx affected は影響のないプロジェクトをまるごとスキップします",
+		"Bazel remote cache": "Bazel リモートキャッシュ",
+		"Point Bazel at a remote cache such as BuildBuddy, EngFlow or a bazel-remote server":                                                                                           "BuildBuddy、EngFlow、bazel-remote サーバーなどのリモートキャッシュを Bazel に指定",
+		"Actions are looked up by their inputs' digest, so only changed targets rebuild, in every job; caching the output base with actions/cache is large and invalidated as a whole": "アクションは入力のダイジェストで検索されるため、どのジョブでも変更されたターゲットだけが再ビルドされます。actions/cache で output base をキャッシュすると大きく、まるごと無効になります",
+		"Bazel disk and repository cache": "Bazel ディスクおよびリポジトリキャッシュ",
+		"Keep Bazel's disk and repository caches in the GitHub Actions cache with setup-bazel":                                    "setup-bazel で Bazel のディスクおよびリポジトリキャッシュを GitHub Actions キャッシュに保持",
+		"Without a remote cache, the disk cache is saved per workflow and restored by digest, so unchanged targets don't rebuild": "リモートキャッシュがなくても、ディスクキャッシュはワークフローごとに保存されダイジェストで復元されるため、変更のないターゲットは再ビルドされません",
+
+		// missing lockfiles
+		"%s has no lockfile, so every install resolves dependency versions anew": "%s にロックファイルがないため、インストールのたびに依存関係のバージョンが新たに解決されます",
+		"Commit the lockfile your package manager writes, such as package-lock.json, yarn.lock or pnpm-lock.yaml, and install with npm ci or --frozen-lockfile; builds become reproducible and caches keyed on the lockfile's hash start to hit": "package-lock.json、yarn.lock、pnpm-lock.yaml などパッケージマネージャーが書き出すロックファイルをコミットし、npm ci または --frozen-lockfile でインストールしてください。ビルドが再現可能になり、ロックファイルのハッシュをキーにしたキャッシュがヒットするようになります",
+		"%s has no go.sum, so module checksums aren't verified and the module cache can't be keyed on them":                                                                                                                                      "%s に go.sum がないため、モジュールのチェックサムが検証されず、モジュールキャッシュのキーにも使えません",
+		"Run go mod tidy and commit go.sum":                                                               "go mod tidy を実行して go.sum をコミットしてください",
+		"%s has no Gemfile.lock, so every install resolves gem versions anew":                             "%s に Gemfile.lock がないため、インストールのたびに gem のバージョンが新たに解決されます",
+		"Run bundle lock and commit Gemfile.lock; bundler-cache keys on it":                               "bundle lock を実行して Gemfile.lock をコミットしてください。bundler-cache はこのファイルをキーにします",
+		"(installed in %d of %d logged runs)":                                                             "(ログのある %[2]d 回の実行のうち %[1]d 回でインストール)",
+		"setup actions found no lockfile to key their cache on in %d of %d logged runs":                   "ログのある %[2]d 回の実行のうち %[1]d 回で、setup アクションがキャッシュのキーにするロックファイルを見つけられませんでした",
+		"The go command reported go.mod or go.sum out of date in %d of %d logged runs":                    "ログのある %[2]d 回の実行のうち %[1]d 回で、go コマンドが go.mod または go.sum が古いと報告しました",
+		"Run go mod tidy and commit both files, and add a check that fails when go mod tidy changes them": "go mod tidy を実行して両方のファイルをコミットし、go mod tidy がそれらを変更したら失敗するチェックを追加してください",
+		"%s has no lockfile or pinned requirements, so every install resolves dependency versions anew":   "%s にロックファイルも固定された requirements もないため、インストールのたびに依存関係のバージョンが新たに解決されます",
+		"Commit the lockfile of uv, Poetry or PDM, or compile pinned requirements with pip-compile or uv pip compile; builds become reproducible and caches keyed on the lockfile's hash start to hit":                  "uv、Poetry、PDM のロックファイルをコミットするか、pip-compile または uv pip compile で固定された requirements を生成してください。ビルドが再現可能になり、ロックファイルのハッシュをキーにしたキャッシュがヒットするようになります",
+		"%s leaves %d of %d requirements unpinned, e.g. %s, so every install may resolve different versions":                                                                                                            "%[1]s は %[3]d 個中 %[2]d 個の requirements を固定していないため(例: %[4]s)、インストールのたびに異なるバージョンが解決される可能性があります",
+		"Keep the loose requirements in requirements.in and compile them into pinned requirements.txt with pip-compile or uv pip compile, so installs are reproducible and the pip cache keyed on the file stays valid": "緩い requirements は requirements.in に置き、pip-compile または uv pip compile で固定された requirements.txt に変換してください。インストールが再現可能になり、このファイルをキーにした pip キャッシュも有効なままになります",
+
+		// write-all and read-all under a policy maximum
+		"%s grants %s, which includes %s: %s, but the policy allows at most %s": "%[1]s は %[2]s を付与しており %[3]s: %[4]s を含みますが、方針で許可されているのは %[5]s までです",
+		"Replace %s with the scopes the jobs need, granting %s at most %s":      "%[1]s の代わりにジョブに必要なスコープだけを付与し、%[2]s は最大 %[3]s にしてください",
+
+		// DORA rollback workflows
+		"Rollback workflows": "ロールバックワークフロー",
+	},
+}
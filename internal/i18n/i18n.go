@@ -0,0 +1,43 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lang identifies a report locale
+type Lang string
+
+const (
+	English  Lang = "en"
+	Korean   Lang = "ko"
+	Japanese Lang = "ja"
+)
+
+// ParseLang converts an input string into a supported Lang
+func ParseLang(s string) (Lang, error) {
+	switch Lang(strings.ToLower(strings.TrimSpace(s))) {
+	case "", English:
+		return English, nil
+	case Korean:
+		return Korean, nil
+	case Japanese:
+		return Japanese, nil
+	default:
+		return "", fmt.Errorf("unsupported language %q: expected en, ko or ja", s)
+	}
+}
+
+// T returns the translation of msg, falling back to the English source text.
+// Messages are keyed by their English text so untranslated strings still render.
+func (l Lang) T(msg string) string {
+	if translated, ok := catalog[l][msg]; ok {
+		return translated
+	}
+	return msg
+}
+
+// Sprintf translates format and then formats it with args
+func (l Lang) Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(l.T(format), args...)
+}
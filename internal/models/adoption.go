@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Adoption is the progress on the analyzer's recommendations: the findings of
+// the previous analysis that no longer show up, and the running totals since
+// tracking started. Since is nil on the first analysis, which only records the
+// recommendations to follow.
+type Adoption struct {
+	Since        *time.Time `json:"since,omitempty"`
+	TrackedSince time.Time  `json:"tracked_since"`
+	Adopted      []Finding  `json:"adopted"`
+	TotalAdopted int        `json:"total_adopted"`
+	Open         int        `json:"open"`
+}
+
+// AdoptedFindings returns the previous findings the current ones no longer
+// include. They're matched like the digest's, so a finding whose measured
+// values changed isn't taken for adopted.
+func AdoptedFindings(previous, current []Finding) []Finding {
+	now := make(map[string]bool, len(current))
+	for _, f := range current {
+		now[findingKey(f)] = true
+	}
+	adopted := []Finding{}
+	for _, f := range previous {
+		if !now[findingKey(f)] {
+			adopted = append(adopted, f)
+		}
+	}
+	return adopted
+}
+
+// adoptionProgress describes the adoption totals
+func (r *PerformanceReport) adoptionProgress() string {
+	a := r.Adoption
+	return r.Lang.Sprintf("%d recommendations adopted since %s, %d still open",
+		a.TotalAdopted, a.TrackedSince.UTC().Format("2006-01-02"), a.Open)
+}
+
+// adoptionSummary renders the adopted recommendations section of the text report
+func (r *PerformanceReport) adoptionSummary() string {
+	a := r.Adoption
+	if len(a.Adopted) == 0 {
+		return ""
+	}
+	summary := heading("🎉", r.Lang.T("Adopted Since Last Run"))
+	for _, finding := range a.Adopted {
+		summary += fmt.Sprintf("  • [%s] %s\n", finding.Severity, finding.Location())
+		summary += fmt.Sprintf("    ↳ %s\n", finding.Message)
+	}
+	return summary + "  ↳ " + r.adoptionProgress() + "\n\n"
+}
+
+// markdownAdoption renders the adopted recommendations section as Markdown
+func (r *PerformanceReport) markdownAdoption() string {
+	a := r.Adoption
+	if len(a.Adopted) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Adopted Since Last Run"))
+	for _, finding := range a.Adopted {
+		fmt.Fprintf(&b, "- ✅ **%s** `%s`: %s\n", finding.Severity, finding.Location(), finding.Message)
+	}
+	fmt.Fprintf(&b, "\n%s\n", r.adoptionProgress())
+	return b.String()
+}
@@ -0,0 +1,157 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Report audiences, choosing the sections and detail of the text, Markdown and
+// HTML reports
+const (
+	AudienceDeveloper = "developer"
+	AudienceManager   = "manager"
+	AudienceSecurity  = "security"
+)
+
+// Audiences lists the supported report audiences
+func Audiences() []string {
+	return []string{AudienceDeveloper, AudienceManager, AudienceSecurity}
+}
+
+// securityCategories are the finding categories the security audience sees
+var securityCategories = map[string]bool{"security": true, "policy": true}
+
+// overviewCritical is the number of critical findings the manager overview names
+const overviewCritical = 5
+
+// forAudience returns the report as its audience reads it. Developers get every
+// section. Managers get the outcome instead of snippets: run statistics, cost,
+// delivery metrics and an overview of the findings with the time and money the
+// recommendations save. Security reviewers get the security and policy findings
+// in full with fork exposure and required checks, and none of the performance
+// and cost sections. The analysis itself, and the JSON report, are the same for
+// every audience.
+func (r *PerformanceReport) forAudience() *PerformanceReport {
+	switch r.Audience {
+	case AudienceManager:
+		v := *r
+		v.savedPerMonth = r.monthlySavings()
+		v.SlowSteps, v.CacheRecommendations, v.DockerOptimizations = nil, nil, nil
+		v.Timeline, v.Jobs, v.CacheUsage = nil, nil, nil
+		v.ForkExposure, v.RequiredChecks = nil, nil
+		v.Migrations, v.ReleaseHardening, v.Patches = nil, nil, ""
+		if r.WorkflowAnalysis != nil {
+			v.WorkflowAnalysis = &WorkflowAnalysis{ParallelJobs: r.WorkflowAnalysis.ParallelJobs, MatrixStrategy: r.WorkflowAnalysis.MatrixStrategy}
+		}
+		return &v
+	case AudienceSecurity:
+		v := *r
+		v.Findings = nil
+		for _, f := range r.Findings {
+			if securityCategories[f.Category] {
+				v.Findings = append(v.Findings, f)
+			}
+		}
+		v.SlowSteps, v.CacheRecommendations, v.DockerOptimizations, v.CostSavingTips = nil, nil, nil, nil
+		v.Timeline, v.Jobs, v.CacheUsage, v.WorkflowChain = nil, nil, nil, nil
+		v.DORA, v.MergeQueue, v.Heatmap, v.Teams = nil, nil, nil, nil
+		v.Cost, v.Sustainability, v.Migrations, v.Adoption = nil, nil, nil, nil
+		v.Patches = ""
+		if r.WorkflowAnalysis != nil {
+			v.WorkflowAnalysis = &WorkflowAnalysis{
+				ParallelJobs:   r.WorkflowAnalysis.ParallelJobs,
+				MatrixStrategy: r.WorkflowAnalysis.MatrixStrategy,
+				SecurityTips:   r.WorkflowAnalysis.SecurityTips,
+			}
+		}
+		return &v
+	}
+	return r
+}
+
+// monthlySavings sums the monthly time the findings, cache recommendations and
+// Docker optimizations are estimated to save
+func (r *PerformanceReport) monthlySavings() time.Duration {
+	var total time.Duration
+	add := func(s *Savings) {
+		if s != nil {
+			total += s.PerMonth
+		}
+	}
+	for _, f := range r.Findings {
+		add(f.EstimatedSavings)
+	}
+	for _, c := range r.CacheRecommendations {
+		add(c.EstimatedSavings)
+	}
+	for _, d := range r.DockerOptimizations {
+		add(d.EstimatedSavings)
+	}
+	return total
+}
+
+// overviewLines summarize the findings for the manager audience: how many there
+// are of each severity and category, what the recommendations save, and the
+// critical findings
+func (r *PerformanceReport) overviewLines() []string {
+	severities := make(map[string]int)
+	categories := make(map[string]int)
+	var critical []string
+	for _, f := range r.Findings {
+		severities[f.Severity]++
+		categories[f.Category]++
+		if f.Severity == SeverityCritical && len(critical) < overviewCritical {
+			critical = append(critical, r.Lang.T("Critical")+": "+f.Message)
+		}
+	}
+	lines := []string{r.Lang.Sprintf("%d findings: %d critical, %d warnings, %d info",
+		len(r.Findings), severities[SeverityCritical], severities[SeverityWarning], severities[SeverityInfo])}
+
+	if len(categories) > 0 {
+		names := make([]string, 0, len(categories))
+		for name := range categories {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if categories[names[i]] != categories[names[j]] {
+				return categories[names[i]] > categories[names[j]]
+			}
+			return names[i] < names[j]
+		})
+		var parts []string
+		for _, name := range names {
+			parts = append(parts, fmt.Sprintf("%s %d", name, categories[name]))
+		}
+		lines = append(lines, r.Lang.Sprintf("By category: %s", strings.Join(parts, ", ")))
+	}
+
+	if saved := r.savedPerMonth; saved > 0 {
+		line := r.Lang.Sprintf("Applying the recommendations saves about %v of runner time per month", saved.Round(time.Minute))
+		if c := r.Cost; c != nil && c.Minutes > 0 {
+			line += ", " + r.Lang.Sprintf("about $%.2f", saved.Minutes()*c.Cost/c.Minutes)
+		}
+		lines = append(lines, line)
+	}
+	return append(lines, critical...)
+}
+
+// overviewSummary renders the manager's findings overview in the text report
+func (r *PerformanceReport) overviewSummary() string {
+	summary := heading("📊", r.Lang.T("Findings Overview"))
+	for _, line := range r.overviewLines() {
+		summary += "  • " + line + "\n"
+	}
+	return summary + "\n"
+}
+
+// markdownOverview renders the manager's findings overview as Markdown
+func (r *PerformanceReport) markdownOverview() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Findings Overview"))
+	for _, line := range r.overviewLines() {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	return b.String()
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ActionsCache is one entry of a repository's GitHub Actions cache
+type ActionsCache struct {
+	ID             int64     `json:"id"`
+	Ref            string    `json:"ref"`
+	Key            string    `json:"key"`
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	SizeInBytes    int64     `json:"size_in_bytes"`
+}
+
+// CacheKeyVariants counts the entries saved under one key prefix
+type CacheKeyVariants struct {
+	Prefix   string `json:"prefix"`
+	Variants int    `json:"variants"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// CacheUsage summarizes the repository's Actions cache against its size limit
+type CacheUsage struct {
+	Bytes            int64              `json:"bytes"`
+	Count            int                `json:"count"`
+	LimitBytes       int64              `json:"limit_bytes"`
+	StaleBytes       int64              `json:"stale_bytes"` // not accessed recently
+	StaleCount       int                `json:"stale_count"`
+	PullRequestBytes int64              `json:"pull_request_bytes"` // only usable by the pull request that saved them
+	ExcessiveKeys    []CacheKeyVariants `json:"excessive_keys,omitempty"`
+	Recommendations  []string           `json:"recommendations,omitempty"`
+}
+
+// FormatBytes renders a size in the largest binary unit that keeps it above one
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
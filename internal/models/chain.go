@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// ChainStage is one workflow of a workflow_run chain
+type ChainStage struct {
+	File        string        `json:"file"`
+	Name        string        `json:"name"`
+	Depth       int           `json:"depth"`              // 0 for the workflow that starts the chain
+	Runs        int           `json:"runs"`               // runs matched to a run of the first workflow
+	AvgDuration time.Duration `json:"avg_duration"`       // from start to completion
+	AvgWait     time.Duration `json:"avg_wait,omitempty"` // from the triggering run's completion to this run's start
+}
+
+// WorkflowChain is the end-to-end latency of workflows linked by workflow_run triggers
+type WorkflowChain struct {
+	Stages      []ChainStage  `json:"stages"`
+	Runs        int           `json:"runs"`
+	AvgLeadTime time.Duration `json:"avg_lead_time"`
+	MaxLeadTime time.Duration `json:"max_lead_time"`
+}
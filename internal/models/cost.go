@@ -0,0 +1,154 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MaxCostAttributions caps the branches and pull requests ranked by cost
+const MaxCostAttributions = 10
+
+// CostEntry is the runner time and cost of one runner type
+type CostEntry struct {
+	Runner          string  `json:"runner"`
+	Minutes         float64 `json:"minutes"`
+	BillableMinutes int     `json:"billable_minutes"` // each job rounded up to a whole minute
+	RatePerMinute   float64 `json:"rate_per_minute"`
+	Cost            float64 `json:"cost"`
+}
+
+// CostEstimate prices the runner minutes of the analyzed runs in USD
+type CostEstimate struct {
+	Runs            int         `json:"runs"`
+	Entries         []CostEntry `json:"entries"`
+	Minutes         float64     `json:"minutes"`
+	BillableMinutes int         `json:"billable_minutes"`
+	Cost            float64     `json:"cost"`
+	MonthlyCost     float64     `json:"monthly_cost"`
+	Assumptions     string      `json:"assumptions"`
+
+	// Attribution ranks the branches and pull requests the runs were for by cost
+	Attribution []CostAttribution `json:"attribution,omitempty"`
+}
+
+// CostAttribution is the runner time and cost of the runs of one branch, or of
+// one pull request when GitHub links the runs to it. Bot is set when bots, such
+// as Dependabot, triggered every run.
+type CostAttribution struct {
+	Branch          string  `json:"branch"`
+	PullRequest     int     `json:"pull_request,omitempty"`
+	Bot             bool    `json:"bot,omitempty"`
+	Runs            int     `json:"runs"`
+	BillableMinutes int     `json:"billable_minutes"`
+	Cost            float64 `json:"cost"`
+}
+
+// Attribute records the runner time of one run of a branch or pull request
+func (c *CostEstimate) Attribute(branch string, pullRequest int, bot bool, billable int, cost float64) {
+	for i := range c.Attribution {
+		a := &c.Attribution[i]
+		if a.Branch == branch && a.PullRequest == pullRequest {
+			a.Bot = a.Bot && bot
+			a.Runs++
+			a.BillableMinutes += billable
+			a.Cost += cost
+			return
+		}
+	}
+	c.Attribution = append(c.Attribution, CostAttribution{
+		Branch: branch, PullRequest: pullRequest, Bot: bot, Runs: 1, BillableMinutes: billable, Cost: cost,
+	})
+}
+
+// rankAttribution orders the attribution by billable minutes, most first, and
+// keeps the first MaxCostAttributions
+func (c *CostEstimate) rankAttribution() {
+	sort.SliceStable(c.Attribution, func(i, j int) bool {
+		return c.Attribution[i].BillableMinutes > c.Attribution[j].BillableMinutes
+	})
+	if len(c.Attribution) > MaxCostAttributions {
+		c.Attribution = c.Attribution[:MaxCostAttributions]
+	}
+}
+
+// attributionName names a branch or pull request in the report, marking bots
+func (r *PerformanceReport) attributionName(a CostAttribution) string {
+	name := a.Branch
+	if a.PullRequest > 0 {
+		name = fmt.Sprintf("#%d (%s)", a.PullRequest, a.Branch)
+	}
+	if a.Bot {
+		name += " [" + r.Lang.T("bot") + "]"
+	}
+	return name
+}
+
+// Add records the runner time of one job
+func (c *CostEstimate) Add(runner string, rate, minutes float64, billable int) {
+	var entry *CostEntry
+	for i := range c.Entries {
+		if c.Entries[i].Runner == runner {
+			entry = &c.Entries[i]
+		}
+	}
+	if entry == nil {
+		c.Entries = append(c.Entries, CostEntry{Runner: runner, RatePerMinute: rate})
+		entry = &c.Entries[len(c.Entries)-1]
+	}
+	entry.Minutes += minutes
+	entry.BillableMinutes += billable
+	entry.Cost += float64(billable) * rate
+	c.Minutes += minutes
+	c.BillableMinutes += billable
+	c.Cost += float64(billable) * rate
+}
+
+// Sort orders the entries by cost, most expensive first, and ranks the attribution
+func (c *CostEstimate) Sort() {
+	sort.SliceStable(c.Entries, func(i, j int) bool { return c.Entries[i].Cost > c.Entries[j].Cost })
+	c.rankAttribution()
+}
+
+// merge adds the estimate of another workflow
+func (c *CostEstimate) merge(other *CostEstimate) {
+	for _, entry := range other.Entries {
+		found := false
+		for i := range c.Entries {
+			if c.Entries[i].Runner == entry.Runner {
+				c.Entries[i].Minutes += entry.Minutes
+				c.Entries[i].BillableMinutes += entry.BillableMinutes
+				c.Entries[i].Cost += entry.Cost
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.Entries = append(c.Entries, entry)
+		}
+	}
+	for _, a := range other.Attribution {
+		found := false
+		for i := range c.Attribution {
+			if c.Attribution[i].Branch == a.Branch && c.Attribution[i].PullRequest == a.PullRequest {
+				c.Attribution[i].Bot = c.Attribution[i].Bot && a.Bot
+				c.Attribution[i].Runs += a.Runs
+				c.Attribution[i].BillableMinutes += a.BillableMinutes
+				c.Attribution[i].Cost += a.Cost
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.Attribution = append(c.Attribution, a)
+		}
+	}
+	c.Runs += other.Runs
+	c.Minutes += other.Minutes
+	c.BillableMinutes += other.BillableMinutes
+	c.Cost += other.Cost
+	c.MonthlyCost += other.MonthlyCost
+	if c.Assumptions == "" {
+		c.Assumptions = other.Assumptions
+	}
+	c.Sort()
+}
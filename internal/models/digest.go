@@ -0,0 +1,199 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// digestTolerance is the relative change below which durations and costs count
+// as unchanged, so day-to-day noise stays out of the digest
+const digestTolerance = 0.05
+
+// Digest is what changed since the previous analysis of the same workflows: the
+// findings that appeared or went away and the metrics that moved. Since is nil
+// on the first digest, which only records the baseline.
+type Digest struct {
+	Since    *time.Time    `json:"since,omitempty"`
+	New      []Finding     `json:"new_findings"`
+	Resolved []Finding     `json:"resolved_findings"`
+	Deltas   []MetricDelta `json:"metric_deltas"`
+}
+
+// MetricDelta is a metric whose value changed since the previous analysis
+type MetricDelta struct {
+	Metric string `json:"metric"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Empty reports whether nothing changed since the previous analysis
+func (d *Digest) Empty() bool {
+	return len(d.New) == 0 && len(d.Resolved) == 0 && len(d.Deltas) == 0
+}
+
+// measuredValue matches the counts, durations, sizes, rates and costs messages
+// embed, e.g. 3, 2m30s, 12.5%, $4.20 or 1.2GB, but not the digits of names
+// such as ubuntu-22.04 or actions/checkout@v4
+var measuredValue = regexp.MustCompile(`(^|[\s(\[:=~$])\d[\d.,]*(?:[a-zA-Z%]+[\d.,]*)*`)
+
+// findingKey identifies a finding across analyses. Lines move as the file is
+// edited and measured values change from run to run, so both are left out.
+func findingKey(f Finding) string {
+	return f.Category + "\x00" + f.File + "\x00" + measuredValue.ReplaceAllString(f.Message, "${1}#")
+}
+
+// NewDigest compares the current report with the previous one, analyzed at since.
+// Without a previous report, the digest only marks the baseline.
+func NewDigest(previous *PerformanceReport, since time.Time, current *PerformanceReport) *Digest {
+	d := &Digest{New: []Finding{}, Resolved: []Finding{}, Deltas: []MetricDelta{}}
+	if previous == nil {
+		return d
+	}
+	d.Since = &since
+
+	before := make(map[string]bool, len(previous.Findings))
+	for _, f := range previous.Findings {
+		before[findingKey(f)] = true
+	}
+	after := make(map[string]bool, len(current.Findings))
+	for _, f := range current.Findings {
+		after[findingKey(f)] = true
+		if !before[findingKey(f)] {
+			d.New = append(d.New, f)
+		}
+	}
+	for _, f := range previous.Findings {
+		if !after[findingKey(f)] {
+			d.Resolved = append(d.Resolved, f)
+		}
+	}
+
+	add := func(metric, before, after string) {
+		if before != after {
+			d.Deltas = append(d.Deltas, MetricDelta{Metric: metric, Before: before, After: after})
+		}
+	}
+	grade := func(r *PerformanceReport) string {
+		letter, score := r.Grade()
+		return fmt.Sprintf("%s (%d/100)", letter, score)
+	}
+	add("Workflow Grade", grade(previous), grade(current))
+
+	if p, c := previous.RunStats, current.RunStats; p != nil && c != nil {
+		if changed(float64(p.Successful.Average), float64(c.Successful.Average)) {
+			add("Average successful run", p.Successful.Average.Round(time.Second).String(), c.Successful.Average.Round(time.Second).String())
+		}
+		add("Failure rate", failureRate(p), failureRate(c))
+	}
+	if p, c := previous.Cost, current.Cost; p != nil && c != nil && changed(p.MonthlyCost, c.MonthlyCost) {
+		add("Estimated monthly cost", fmt.Sprintf("$%.2f", p.MonthlyCost), fmt.Sprintf("$%.2f", c.MonthlyCost))
+	}
+	return d
+}
+
+// changed reports whether a value moved by more than digestTolerance
+func changed(before, after float64) bool {
+	if before == 0 {
+		return after != 0
+	}
+	return math.Abs(after-before)/before > digestTolerance
+}
+
+// failureRate renders the share of finished runs that failed
+func failureRate(s *RunStats) string {
+	finished := s.Successful.Runs + s.Failed.Runs
+	if finished == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f%%", float64(s.Failed.Runs)*100/float64(finished))
+}
+
+// digestSummary renders the digest as text in the report's language
+func (r *PerformanceReport) digestSummary() string {
+	t, d := r.Lang.T, r.Digest
+
+	summary := "\n" + boxHeader(t("Workflow Digest")) + "\n"
+	summary += fmt.Sprintf("• %s: %s\n", t("Repository"), r.Repository)
+	summary += fmt.Sprintf("• %s: %s\n", t("Workflow"), r.WorkflowFile)
+	summary += "• " + r.digestStatus() + "\n\n"
+
+	if len(d.Deltas) > 0 {
+		summary += heading("📈", t("Metric Changes"))
+		for _, delta := range d.Deltas {
+			summary += fmt.Sprintf("  • %s: %s → %s\n", t(delta.Metric), delta.Before, delta.After)
+		}
+		summary += "\n"
+	}
+	for _, group := range []struct {
+		icon, title string
+		findings    []Finding
+	}{
+		{"🆕", t("New Findings"), d.New},
+		{"✅", t("Resolved Findings"), d.Resolved},
+	} {
+		if len(group.findings) == 0 {
+			continue
+		}
+		summary += heading(group.icon, group.title)
+		for _, finding := range group.findings {
+			summary += fmt.Sprintf("  • [%s] %s\n", finding.Severity, finding.Location())
+			summary += fmt.Sprintf("    ↳ %s\n", finding.Message)
+		}
+		summary += "\n"
+	}
+
+	if r.Plain {
+		summary = toPlainText(summary)
+	}
+	return summary
+}
+
+// digestStatus describes what the digest compares against
+func (r *PerformanceReport) digestStatus() string {
+	d := r.Digest
+	switch {
+	case d.Since == nil:
+		return r.Lang.T("First digest: this analysis is the baseline for the next one")
+	case d.Empty():
+		return r.Lang.Sprintf("No changes since %s", d.Since.UTC().Format("2006-01-02 15:04 UTC"))
+	default:
+		return r.Lang.Sprintf("Changes since %s", d.Since.UTC().Format("2006-01-02 15:04 UTC"))
+	}
+}
+
+// markdownDigest renders the digest as GitHub-flavored Markdown, short enough
+// for a chat message or an issue comment
+func (r *PerformanceReport) markdownDigest() string {
+	t, d := r.Lang.T, r.Digest
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s: %s `%s`\n\n", t("Workflow Digest"), r.Repository, r.WorkflowFile)
+	fmt.Fprintf(&b, "%s\n", r.digestStatus())
+
+	if len(d.Deltas) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Metric Changes"))
+		fmt.Fprintf(&b, "| %s | %s | %s |\n|---|---|---|\n", t("Metric"), t("Before"), t("After"))
+		for _, delta := range d.Deltas {
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", t(delta.Metric), delta.Before, delta.After)
+		}
+	}
+	for _, group := range []struct {
+		title    string
+		findings []Finding
+	}{
+		{t("New Findings"), d.New},
+		{t("Resolved Findings"), d.Resolved},
+	} {
+		if len(group.findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", group.title)
+		for _, finding := range group.findings {
+			fmt.Fprintf(&b, "- **%s** `%s`: %s\n", finding.Severity, finding.Location(), finding.Message)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// DORA performance levels, from best to worst
+const (
+	DORAElite  = "Elite"
+	DORAHigh   = "High"
+	DORAMedium = "Medium"
+	DORALow    = "Low"
+)
+
+// DORAMetrics are the four DORA delivery metrics measured from deploy workflow runs
+type DORAMetrics struct {
+	Workflows         []string      `json:"workflows"`
+	RollbackWorkflows []string      `json:"rollback_workflows,omitempty"` // their runs count as rollbacks, not deployments
+	Period            time.Duration `json:"period"`                       // from the oldest deployment considered until now
+	Deployments       int           `json:"deployments"`
+	FailedDeployments int           `json:"failed_deployments"`
+	DeploysPerWeek    float64       `json:"deploys_per_week"`
+	LeadTime          time.Duration `json:"lead_time"` // median, from commit to successful deployment
+	ChangeFailureRate float64       `json:"change_failure_rate"`
+	Recoveries        int           `json:"recoveries"`
+	TimeToRestore     time.Duration `json:"time_to_restore,omitempty"` // median, from a failed deployment to the next successful one
+	Rollbacks         int           `json:"rollbacks"`
+	RollbackRate      float64       `json:"rollback_rate"`              // rollbacks as a share of successful deployments, which rollback workflows' runs aren't
+	TimeToRollback    time.Duration `json:"time_to_rollback,omitempty"` // mean, from a deployment to the rollback undoing it
+	FrequencyLevel    string        `json:"frequency_level"`
+	LeadTimeLevel     string        `json:"lead_time_level,omitempty"`
+	FailureRateLevel  string        `json:"failure_rate_level"`
+	RestoreLevel      string        `json:"restore_level,omitempty"`
+}
+
+// rollbackLine describes how often deployments were rolled back and how soon
+func (r *PerformanceReport) rollbackLine(d *DORAMetrics) string {
+	line := r.Lang.Sprintf("Rollbacks: %d, %.0f%% of successful deployments", d.Rollbacks, d.RollbackRate*100)
+	if d.TimeToRollback > 0 {
+		line += ", " + r.Lang.Sprintf("%v on average after the deployment they undo", d.TimeToRollback.Round(time.Minute))
+	}
+	return line
+}
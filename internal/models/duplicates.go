@@ -0,0 +1,203 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// DuplicateSimilarity is the share of structure two workflows must have in
+// common to count as near-identical
+const DuplicateSimilarity = 0.8
+
+// WorkflowFingerprint is the structure of a workflow file: its triggers, runners
+// and steps, without job names, action versions or inputs, so copies of a
+// workflow that drifted apart a little still look alike
+type WorkflowFingerprint struct {
+	File     string   `json:"file"`
+	Features []string `json:"features"` // sorted and unique
+}
+
+// DuplicateCluster is a group of near-identical workflows spread across several
+// repositories, with a plan to replace them with one reusable workflow
+type DuplicateCluster struct {
+	Workflows    []string `json:"workflows"` // owner/repo/path, the copy closest to the shared structure first
+	Repositories int      `json:"repositories"`
+	Similarity   float64  `json:"similarity"` // lowest similarity of any two workflows of the cluster
+	Shared       []string `json:"shared"`     // structure every workflow of the cluster has
+	Plan         []string `json:"plan"`
+}
+
+// similarity is the Jaccard index of two sorted feature lists
+func similarity(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 0
+	}
+	common, i, j := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			common++
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
+
+// DuplicateClusters finds the workflows of reports, e.g. those of an audit, that
+// share at least DuplicateSimilarity of their structure. Clusters are merged by
+// complete linkage, closest first, so every workflow of a cluster is that close
+// to every other, rather than chained through workflows in between. Only
+// clusters spanning several repositories are returned, the largest first.
+func DuplicateClusters(reports []*PerformanceReport) []DuplicateCluster {
+	type entry struct {
+		repository string
+		fp         WorkflowFingerprint
+	}
+	var entries []entry
+	for _, r := range reports {
+		for _, fp := range r.Fingerprints {
+			if len(fp.Features) > 0 {
+				entries = append(entries, entry{r.Repository, fp})
+			}
+		}
+	}
+
+	// Similarities of the clusters close enough to merge, starting from one
+	// cluster per workflow. A merged cluster is as close to another as the least
+	// similar pair of their workflows, so it keeps only the neighbours both halves
+	// had, at the lower similarity.
+	links := make([]map[int]float64, len(entries))
+	members := make([][]int, len(entries))
+	lowest := make([]float64, len(entries))
+	for i := range entries {
+		links[i] = make(map[int]float64)
+		members[i] = []int{i}
+		lowest[i] = 1
+	}
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			if s := similarity(entries[i].fp.Features, entries[j].fp.Features); s >= DuplicateSimilarity {
+				links[i][j] = s
+				links[j][i] = s
+			}
+		}
+	}
+	for {
+		best, bi, bj := 0.0, -1, -1
+		for i := range links {
+			for j, s := range links[i] {
+				if j > i && (s > best || s == best && (i < bi || i == bi && j < bj)) {
+					best, bi, bj = s, i, j
+				}
+			}
+		}
+		if bi < 0 {
+			break
+		}
+		merged := make(map[int]float64)
+		for k, s := range links[bi] {
+			if t, ok := links[bj][k]; ok {
+				merged[k] = min(s, t)
+			}
+		}
+		for k := range links[bi] {
+			delete(links[k], bi)
+		}
+		for k := range links[bj] {
+			delete(links[k], bj)
+		}
+		for k, s := range merged {
+			links[k][bi] = s
+		}
+		links[bi], links[bj] = merged, nil
+		members[bi], members[bj] = append(members[bi], members[bj]...), nil
+		lowest[bi] = min(lowest[bi], lowest[bj], best)
+	}
+
+	groups := make(map[int][]entry)
+	for root, m := range members {
+		sort.Ints(m)
+		for _, i := range m {
+			groups[root] = append(groups[root], entries[i])
+		}
+	}
+
+	var clusters []DuplicateCluster
+	for root, group := range groups {
+		repos := make(map[string]bool)
+		for _, e := range group {
+			repos[e.repository] = true
+		}
+		if len(repos) < 2 {
+			continue
+		}
+
+		shared := group[0].fp.Features
+		for _, e := range group[1:] {
+			shared = intersect(shared, e.fp.Features)
+		}
+		// The copies closest to the shared structure are the easiest to replace
+		sort.SliceStable(group, func(i, j int) bool {
+			return similarity(group[i].fp.Features, shared) > similarity(group[j].fp.Features, shared)
+		})
+		cluster := DuplicateCluster{Repositories: len(repos), Similarity: lowest[root], Shared: shared}
+		for _, e := range group {
+			cluster.Workflows = append(cluster.Workflows, e.repository+"/"+e.fp.File)
+		}
+		cluster.Plan = adoptionPlan(group[0].repository, group[0].fp.File, cluster)
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Repositories != clusters[j].Repositories {
+			return clusters[i].Repositories > clusters[j].Repositories
+		}
+		return clusters[i].Workflows[0] < clusters[j].Workflows[0]
+	})
+	return clusters
+}
+
+// intersect returns the features two sorted lists share
+func intersect(a, b []string) []string {
+	var common []string
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			common = append(common, a[i])
+			i++
+			j++
+		case a[i] < b[j]:
+			i++
+		default:
+			j++
+		}
+	}
+	return common
+}
+
+// adoptionPlan lists the steps to replace a cluster's copies with one reusable
+// workflow kept in the organization's .github repository, starting from the
+// copy in repository closest to the shared structure
+func adoptionPlan(repository, file string, c DuplicateCluster) []string {
+	owner, _, _ := strings.Cut(repository, "/")
+	target := fmt.Sprintf("%s/.github/.github/workflows/%s", owner, path.Base(file))
+	plan := []string{
+		fmt.Sprintf("Copy %s/%s to %s and trigger it with on: workflow_call", repository, file, target),
+		fmt.Sprintf("Turn what the %d copies do differently, such as versions, paths and secrets, into inputs and secrets: inherit", len(c.Workflows)),
+	}
+	for i, w := range c.Workflows {
+		verb := "Replace"
+		if i == 0 {
+			verb = "First replace"
+		}
+		plan = append(plan, fmt.Sprintf("%s the jobs of %s with one job calling uses: %s@main", verb, w, target))
+	}
+	return append(plan, "Pin the callers to a release tag of the .github repository once the reusable workflow is stable")
+}
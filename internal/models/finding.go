@@ -0,0 +1,116 @@
+package models
+
+import "fmt"
+
+// Severity levels for findings
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Finding is a single issue located in a workflow file
+type Finding struct {
+	Category   string `json:"category"`
+	Severity   string `json:"severity"`
+	File       string `json:"file"`
+	Line       int    `json:"line,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Example    string `json:"example,omitempty"`
+	URL        string `json:"url,omitempty"` // the evidence: the file line, or the sampled job a measurement comes from
+
+	// Owners are the CODEOWNERS owners of File
+	Owners []string `json:"owners,omitempty"`
+
+	EstimatedSavings *Savings `json:"estimated_savings,omitempty"`
+
+	// Confidence rates heuristic findings drawn from job logs, from 0 to 1; findings
+	// read from the workflow file or measured through the API have none
+	Confidence float64 `json:"confidence,omitempty"`
+
+	// Locations lists every place a deduplicated finding occurs, starting with File and Line
+	Locations []Position `json:"locations,omitempty"`
+}
+
+// Position is one place a finding occurs
+type Position struct {
+	File string `json:"file"`
+	Line int    `json:"line,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Location renders the position as file:line
+func (p Position) Location() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d", p.File, p.Line)
+	}
+	return p.File
+}
+
+// Location renders the finding position as file:line
+func (f Finding) Location() string {
+	return Position{File: f.File, Line: f.Line}.Location()
+}
+
+// AtLeast reports whether the finding is as severe as severity or more
+func (f Finding) AtLeast(severity string) bool {
+	return gradePenalties[f.Severity] >= gradePenalties[severity]
+}
+
+// Positions returns every place the finding occurs
+func (f Finding) Positions() []Position {
+	if len(f.Locations) > 0 {
+		return f.Locations
+	}
+	return []Position{{File: f.File, Line: f.Line, URL: f.URL}}
+}
+
+// DedupeFindings merges findings that differ only in where they occur, such as
+// the same unpinned action in several workflows, into one finding listing all
+// locations and their owners, and the sum of their estimated savings. The first
+// occurrence keeps its place in the list.
+func DedupeFindings(findings []Finding) []Finding {
+	type key struct{ category, severity, message, suggestion, example string }
+	index := make(map[key]int)
+	var deduped []Finding
+	for _, f := range findings {
+		k := key{f.Category, f.Severity, f.Message, f.Suggestion, f.Example}
+		i, seen := index[k]
+		if !seen {
+			index[k] = len(deduped)
+			deduped = append(deduped, f)
+			continue
+		}
+		if len(deduped[i].Locations) == 0 {
+			deduped[i].Locations = deduped[i].Positions()
+		}
+		deduped[i].Locations = append(deduped[i].Locations, f.Positions()...)
+		// Clipped so appending never writes into a slice shared with other findings
+		owners := deduped[i].Owners[:len(deduped[i].Owners):len(deduped[i].Owners)]
+		for _, owner := range f.Owners {
+			if !contains(owners, owner) {
+				owners = append(owners, owner)
+			}
+		}
+		deduped[i].Owners = owners
+		deduped[i].EstimatedSavings = addSavings(deduped[i].EstimatedSavings, f.EstimatedSavings)
+	}
+	return deduped
+}
+
+// addSavings sums the savings of a finding found in several places, which each
+// save on their own runs
+func addSavings(a, b *Savings) *Savings {
+	if a == nil || b == nil {
+		if a == nil {
+			return b
+		}
+		return a
+	}
+	sum := &Savings{PerRun: a.PerRun + b.PerRun, PerMonth: a.PerMonth + b.PerMonth, Basis: a.Basis}
+	if b.Basis != a.Basis {
+		sum.Basis += "; " + b.Basis
+	}
+	return sum
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fork exposure ratings, from no workflow that forks can reach with privileges
+// to one running a fork's code or input with secrets or a write token
+const (
+	ExposureNone   = "none"
+	ExposureLow    = "low"
+	ExposureMedium = "medium"
+	ExposureHigh   = "high"
+)
+
+// exposureRank orders the ratings
+var exposureRank = map[string]int{ExposureNone: 0, ExposureLow: 1, ExposureMedium: 2, ExposureHigh: 3}
+
+// ForkExposure rates how much a malicious pull request from a fork can reach
+// through the repository's workflows. Rating is the highest of its workflows'.
+type ForkExposure struct {
+	Rating    string                `json:"rating"`
+	Workflows []ForkExposedWorkflow `json:"workflows"`
+}
+
+// ForkExposedWorkflow is a workflow that pull requests from forks can trigger with
+// the base repository's privileges, through pull_request_target or workflow_run
+type ForkExposedWorkflow struct {
+	File    string   `json:"file"`
+	Trigger string   `json:"trigger"`
+	Rating  string   `json:"rating"`
+	Risks   []string `json:"risks,omitempty"`
+}
+
+// Add records a workflow and raises the rating to its own
+func (e *ForkExposure) Add(w ForkExposedWorkflow) {
+	e.Workflows = append(e.Workflows, w)
+	if exposureRank[w.Rating] > exposureRank[e.Rating] {
+		e.Rating = w.Rating
+	}
+}
+
+// forkExposureSummary renders the fork exposure section of the text report
+func (r *PerformanceReport) forkExposureSummary() string {
+	t, e := r.Lang.T, r.ForkExposure
+	summary := heading("🍴", t("Fork Pull Request Exposure"))
+	summary += fmt.Sprintf("  • %s: %s\n", t("Rating"), t(e.Rating))
+	if len(e.Workflows) == 0 {
+		summary += "    ↳ " + t("No workflow runs with the repository's privileges for pull requests from forks") + "\n"
+	}
+	for _, w := range e.Workflows {
+		summary += fmt.Sprintf("  • %s (%s): %s\n", w.File, w.Trigger, t(w.Rating))
+		for _, risk := range w.Risks {
+			summary += fmt.Sprintf("    ↳ %s\n", risk)
+		}
+	}
+	return summary + "\n"
+}
+
+// markdownForkExposure renders the fork exposure section as Markdown
+func (r *PerformanceReport) markdownForkExposure() string {
+	t, e := r.Lang.T, r.ForkExposure
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", t("Fork Pull Request Exposure"))
+	fmt.Fprintf(&b, "%s: **%s**\n\n", t("Rating"), t(e.Rating))
+	for _, w := range e.Workflows {
+		fmt.Fprintf(&b, "- `%s` (`%s`): %s\n", w.File, w.Trigger, t(w.Rating))
+		for _, risk := range w.Risks {
+			fmt.Fprintf(&b, "  - %s\n", risk)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,291 @@
+package models
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Encodings of the report's step outputs. Output values are never evaluated as
+// expressions, so raw outputs hold examples such as ${{ hashFiles('**/go.sum') }}
+// verbatim; base64 additionally keeps them intact when a downstream step pastes
+// an output into a shell script instead of passing it through env.
+const (
+	EncodingRaw    = "raw"
+	EncodingBase64 = "base64"
+)
+
+// MaxOutputBytes keeps each step output under GitHub's 1 MB limit per output
+const MaxOutputBytes = 1000 * 1000
+
+// outputDirName is where outputs too large for $GITHUB_OUTPUT are written in full,
+// relative to the workspace so later steps can read or upload them
+const outputDirName = "github-action-analyzer-outputs"
+
+// outputDir returns the directory for full copies of truncated outputs
+func outputDir() string {
+	if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
+		return filepath.Join(workspace, outputDirName)
+	}
+	return outputDirName
+}
+
+// GitHubOutputRenderer writes the report sections as step outputs in the
+// $GITHUB_OUTPUT name<<delimiter format. An output over MaxBytes is truncated and
+// its full value is written to a file in Dir, listed in the truncated_outputs output.
+type GitHubOutputRenderer struct {
+	MaxBytes int // zero means no limit
+	Dir      string
+}
+
+func (g GitHubOutputRenderer) Render(w io.Writer, r *PerformanceReport) error {
+	// Convert metrics to JSON, calculated on a copy as JSONRenderer does
+	report := *r
+	report.calculateMetrics()
+	metricsSummary, err := json.Marshal(report.Metrics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metrics: %v", err)
+	}
+
+	// Convert report sections to JSON strings
+	performanceSummary, err := json.Marshal(map[string]interface{}{
+		"repository":       r.Repository,
+		"workflow_file":    r.WorkflowFile,
+		"total_execution":  r.TotalExecutionTime.String(),
+		"slow_steps_count": len(r.SlowSteps),
+		"run_stats":        r.RunStats,
+	})
+	if err != nil {
+		return err
+	}
+
+	cacheRecs, err := json.Marshal(r.CacheRecommendations)
+	if err != nil {
+		return err
+	}
+
+	dockerOpts, err := json.Marshal(r.DockerOptimizations)
+	if err != nil {
+		return err
+	}
+
+	findings, err := json.Marshal(r.Findings)
+	if err != nil {
+		return err
+	}
+
+	inventory, err := json.Marshal(r.secretsInventory())
+	if err != nil {
+		return err
+	}
+
+	securityFindings := []Finding{}
+	for _, finding := range r.Findings {
+		if finding.Category == "security" {
+			securityFindings = append(securityFindings, finding)
+		}
+	}
+	security, err := json.Marshal(securityFindings)
+	if err != nil {
+		return err
+	}
+
+	cost, err := json.Marshal(r.Cost)
+	if err != nil {
+		return err
+	}
+
+	var trendSummary map[string]interface{}
+	if r.Trend != nil {
+		trendSummary = r.Trend.summary()
+	}
+	trend, err := json.Marshal(trendSummary)
+	if err != nil {
+		return err
+	}
+
+	digest := ""
+	if r.Digest != nil {
+		digest = r.markdownDigest()
+	}
+
+	outputs := []output{
+		{"metrics_summary", metricsSummary},
+		{"performance_summary", performanceSummary},
+		{"cache_recommendations", cacheRecs},
+		{"docker_optimizations", dockerOpts},
+		{"findings", findings},
+		{"patches", []byte(r.Patches)},
+		{"secrets_inventory", inventory},
+		{"security_findings", security},
+		{"cost_estimate", cost},
+		{"trend_summary", trend},
+		{"digest", []byte(digest)},
+		{"mermaid_graph", []byte(r.MermaidGraph())},
+		{"mermaid_gantt", []byte(r.MermaidGantt())},
+	}
+
+	encoding := r.OutputEncoding
+	if encoding == "" {
+		encoding = EncodingRaw
+	}
+	// Leave room for the base64 encoding's growth
+	limit := g.MaxBytes
+	if encoding == EncodingBase64 {
+		limit = limit / 4 * 3
+	}
+
+	truncated := make(map[string]string)
+	for i, out := range outputs {
+		if limit > 0 && len(out.value) > limit {
+			path, err := g.saveFull(out)
+			if err != nil {
+				return err
+			}
+			log.Printf("::warning::Output %s is %s, over the %s limit; it was truncated and its full value written to %s",
+				out.name, FormatBytes(int64(len(out.value))), FormatBytes(int64(limit)), path)
+			outputs[i].value = truncateOutput(out.value, limit)
+			truncated[out.name] = path
+		}
+		if encoding == EncodingBase64 {
+			outputs[i].value = []byte(base64.StdEncoding.EncodeToString(outputs[i].value))
+		}
+	}
+
+	status := "success"
+	if r.Partial {
+		status = "partial"
+	}
+	// Scalar outputs stay readable for if: conditions whatever the encoding
+	grade, _ := r.Grade()
+	outputs = append(outputs,
+		output{"status", []byte(status)},
+		output{"output_encoding", []byte(encoding)},
+		output{"workflow_grade", []byte(grade)},
+		output{"security_findings_count", []byte(strconv.Itoa(len(securityFindings)))},
+	)
+	if r.ForkExposure != nil {
+		outputs = append(outputs, output{"fork_exposure", []byte(r.ForkExposure.Rating)})
+	}
+	if len(truncated) > 0 {
+		list, err := json.Marshal(truncated)
+		if err != nil {
+			return err
+		}
+		outputs = append(outputs, output{"truncated_outputs", list})
+	}
+
+	return writeOutputs(w, outputs)
+}
+
+// saveFull writes an output's complete value to a file in Dir
+func (g GitHubOutputRenderer) saveFull(out output) (string, error) {
+	if err := os.MkdirAll(g.Dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", g.Dir, err)
+	}
+	ext := ".json"
+	if !json.Valid(out.value) {
+		ext = ".txt"
+	}
+	path := filepath.Join(g.Dir, out.name+ext)
+	if err := os.WriteFile(path, out.value, 0644); err != nil {
+		return "", fmt.Errorf("failed to write full %s output: %v", out.name, err)
+	}
+	// The action's container mounts the workspace at another path than later
+	// steps see, so point to the file relative to the workspace
+	if workspace := os.Getenv("GITHUB_WORKSPACE"); workspace != "" {
+		if rel, err := filepath.Rel(workspace, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+	}
+	return path, nil
+}
+
+// output is one name and value written to $GITHUB_OUTPUT
+type output struct {
+	name  string
+	value []byte
+}
+
+// writeOutputs writes outputs in the name<<delimiter format, using a delimiter
+// that occurs in none of the values
+func writeOutputs(w io.Writer, outputs []output) error {
+	values := make([][]byte, len(outputs))
+	for i, out := range outputs {
+		values[i] = out.value
+	}
+	delimiter, err := outputDelimiter(values...)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	for _, out := range outputs {
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", out.name, delimiter, out.value, delimiter)
+	}
+	_, err = w.Write(b.Bytes())
+	return err
+}
+
+// randRead fills output delimiters with random bytes, replaced in tests
+var randRead = rand.Read
+
+// outputDelimiter returns a random heredoc delimiter that occurs in none of the values
+func outputDelimiter(values ...[]byte) (string, error) {
+	for {
+		buf := make([]byte, 16)
+		if _, err := randRead(buf); err != nil {
+			return "", fmt.Errorf("failed to generate output delimiter: %v", err)
+		}
+		delimiter := "ghadelimiter_" + hex.EncodeToString(buf)
+		collides := false
+		for _, value := range values {
+			if bytes.Contains(value, []byte(delimiter)) {
+				collides = true
+				break
+			}
+		}
+		if !collides {
+			return delimiter, nil
+		}
+	}
+}
+
+// truncateOutput cuts value to at most max bytes. A JSON array keeps its leading
+// elements so it stays valid JSON; other values are cut at a line break.
+func truncateOutput(value []byte, max int) []byte {
+	var items []json.RawMessage
+	if json.Unmarshal(value, &items) == nil {
+		size := 2 // brackets
+		n := 0
+		for n < len(items) && size+len(items[n])+1 <= max {
+			size += len(items[n]) + 1
+			n++
+		}
+		kept, _ := json.Marshal(items[:n])
+		return kept
+	}
+
+	cut := value[:max]
+	if i := bytes.LastIndexByte(cut, '\n'); i > 0 {
+		cut = cut[:i+1]
+	}
+	// Don't leave half of a multi-byte character behind
+	for len(cut) > 0 {
+		if r, size := utf8.DecodeLastRune(cut); r != utf8.RuneError || size != 1 {
+			break
+		}
+		cut = cut[:len(cut)-1]
+	}
+	return cut
+}
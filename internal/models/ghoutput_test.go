@@ -0,0 +1,272 @@
+package models
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// parseOutputs reads name<<delimiter outputs the way the runner does
+func parseOutputs(t *testing.T, data string) map[string]string {
+	t.Helper()
+	outputs := make(map[string]string)
+	lines := strings.Split(data, "\n")
+	for i := 0; i < len(lines); i++ {
+		if lines[i] == "" {
+			continue
+		}
+		name, delimiter, ok := strings.Cut(lines[i], "<<")
+		if !ok {
+			t.Fatalf("line %d is not a name<<delimiter header: %q", i+1, lines[i])
+		}
+		var value []string
+		for i++; ; i++ {
+			if i == len(lines) {
+				t.Fatalf("output %s has no closing delimiter", name)
+			}
+			if lines[i] == delimiter {
+				break
+			}
+			value = append(value, lines[i])
+		}
+		outputs[name] = strings.Join(value, "\n")
+	}
+	return outputs
+}
+
+func TestWriteOutputs(t *testing.T) {
+	outputs := []output{
+		{"single", []byte("one line")},
+		{"multiline", []byte("first\nsecond\n\nfourth")},
+		{"heredoc", []byte("EOF\nname<<EOF\nEOF")},
+		{"delimiter_like", []byte("ghadelimiter_\nghadelimiter_00")},
+		{"expression", []byte("key: ${{ hashFiles('**/go.sum') }}")},
+		{"empty", nil},
+	}
+	var b bytes.Buffer
+	if err := writeOutputs(&b, outputs); err != nil {
+		t.Fatal(err)
+	}
+	got := parseOutputs(t, b.String())
+	if len(got) != len(outputs) {
+		t.Fatalf("parsed %d outputs, want %d:\n%s", len(got), len(outputs), b.String())
+	}
+	for _, out := range outputs {
+		if got[out.name] != string(out.value) {
+			t.Errorf("output %s = %q, want %q", out.name, got[out.name], out.value)
+		}
+	}
+}
+
+func TestOutputDelimiterCollision(t *testing.T) {
+	defer func(read func([]byte) (int, error)) { randRead = read }(randRead)
+	// The first delimiter drawn is all zeros, the second all ones
+	draws := 0
+	randRead = func(buf []byte) (int, error) {
+		draws++
+		for i := range buf {
+			buf[i] = byte(draws - 1)
+		}
+		return len(buf), nil
+	}
+	zeros := "ghadelimiter_" + strings.Repeat("00", 16)
+	value := []byte("before\n" + zeros + "\nafter")
+
+	delimiter, err := outputDelimiter([]byte("other"), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ghadelimiter_" + strings.Repeat("01", 16); delimiter != want {
+		t.Errorf("outputDelimiter = %s, want %s after the colliding one", delimiter, want)
+	}
+	if draws != 2 {
+		t.Errorf("outputDelimiter drew %d delimiters, want 2", draws)
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		max   int
+		want  string
+	}{
+		{"json array keeps leading elements", `[{"a":1},{"b":2},{"c":3}]`, 18, `[{"a":1},{"b":2}]`},
+		{"json array too small for one element", `[{"a":1}]`, 5, `[]`},
+		{"text cut at a line break", "first line\nsecond line\nthird", 20, "first line\n"},
+		{"text without line breaks", "abcdefghij", 4, "abcd"},
+		{"multi-byte character not split", "가나다", 7, "가나"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := truncateOutput([]byte(tt.value), tt.max)
+			if string(got) != tt.want {
+				t.Errorf("truncateOutput(%q, %d) = %q, want %q", tt.value, tt.max, got, tt.want)
+			}
+			if len(got) > tt.max {
+				t.Errorf("truncateOutput(%q, %d) is %d bytes, over the limit", tt.value, tt.max, len(got))
+			}
+			if !utf8.Valid(got) {
+				t.Errorf("truncateOutput(%q, %d) = %q is not valid UTF-8", tt.value, tt.max, got)
+			}
+		})
+	}
+}
+
+// manyFindings returns a report whose findings output is several kilobytes
+func manyFindings() *PerformanceReport {
+	r := &PerformanceReport{Repository: "owner/repo", WorkflowFile: "ci.yml"}
+	for i := 0; i < 50; i++ {
+		r.Findings = append(r.Findings, Finding{
+			Category: "performance",
+			Severity: SeverityInfo,
+			File:     ".github/workflows/ci.yml",
+			Line:     i + 1,
+			Message:  fmt.Sprintf("Finding %d\nspanning two lines", i),
+		})
+	}
+	return r
+}
+
+func TestGitHubOutputRendererTruncates(t *testing.T) {
+	// Paths are reported relative to the workspace inside Actions
+	t.Setenv("GITHUB_WORKSPACE", "")
+	dir := t.TempDir()
+	r := manyFindings()
+	full, err := json.Marshal(r.Findings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	g := GitHubOutputRenderer{MaxBytes: 1000, Dir: dir}
+	if err := g.Render(&b, r); err != nil {
+		t.Fatal(err)
+	}
+	outputs := parseOutputs(t, b.String())
+
+	findings := outputs["findings"]
+	if len(findings) > g.MaxBytes {
+		t.Errorf("findings output is %d bytes, over the %d limit", len(findings), g.MaxBytes)
+	}
+	var kept []Finding
+	if err := json.Unmarshal([]byte(findings), &kept); err != nil {
+		t.Fatalf("truncated findings output is not valid JSON: %v", err)
+	}
+	if len(kept) == 0 || len(kept) >= len(r.Findings) {
+		t.Errorf("truncated findings output keeps %d of %d findings", len(kept), len(r.Findings))
+	}
+
+	var truncated map[string]string
+	if err := json.Unmarshal([]byte(outputs["truncated_outputs"]), &truncated); err != nil {
+		t.Fatalf("truncated_outputs = %q: %v", outputs["truncated_outputs"], err)
+	}
+	path, ok := truncated["findings"]
+	if !ok {
+		t.Fatalf("truncated_outputs = %v, want findings listed", truncated)
+	}
+	if path != filepath.Join(dir, "findings.json") {
+		t.Errorf("findings written to %s, want %s", path, filepath.Join(dir, "findings.json"))
+	}
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(saved, full) {
+		t.Error("the saved findings differ from the full output")
+	}
+	if _, ok := truncated["metrics_summary"]; ok {
+		t.Error("metrics_summary is listed as truncated, but it is under the limit")
+	}
+}
+
+// encodedOutputs are the outputs written in the report's output encoding
+var encodedOutputs = []string{
+	"metrics_summary", "performance_summary", "cache_recommendations", "docker_optimizations",
+	"findings", "patches", "secrets_inventory", "security_findings", "cost_estimate",
+	"trend_summary", "digest", "mermaid_graph", "mermaid_gantt",
+}
+
+// renderOutputs renders r in encoding and parses the outputs back
+func renderOutputs(t *testing.T, r *PerformanceReport, encoding string, g GitHubOutputRenderer) map[string]string {
+	t.Helper()
+	report := *r
+	report.OutputEncoding = encoding
+	var b bytes.Buffer
+	if err := g.Render(&b, &report); err != nil {
+		t.Fatal(err)
+	}
+	return parseOutputs(t, b.String())
+}
+
+func TestOutputEncodingRoundTrip(t *testing.T) {
+	r := manyFindings()
+	r.Patches = "--- a/.github/workflows/ci.yml\n+++ b/.github/workflows/ci.yml\n@@ -1 +1 @@\n-key: old\n+key: ${{ hashFiles('**/go.sum') }}\n"
+	r.CacheRecommendations = []CacheRecommendation{{
+		Path:    "~/go/pkg/mod",
+		Example: "- uses: actions/cache@v4\n  with:\n    key: ${{ runner.os }}-go-${{ hashFiles('**/go.sum') }}\n    path: \"$(go env GOMODCACHE)\"",
+	}}
+
+	raw := renderOutputs(t, r, EncodingRaw, GitHubOutputRenderer{})
+	encoded := renderOutputs(t, r, EncodingBase64, GitHubOutputRenderer{})
+	if raw["output_encoding"] != EncodingRaw || encoded["output_encoding"] != EncodingBase64 {
+		t.Fatalf("output_encoding = %q and %q, want raw and base64", raw["output_encoding"], encoded["output_encoding"])
+	}
+	for _, name := range encodedOutputs {
+		decoded, err := base64.StdEncoding.DecodeString(encoded[name])
+		if err != nil {
+			t.Errorf("base64 output %s doesn't decode: %v", name, err)
+			continue
+		}
+		if string(decoded) != raw[name] {
+			t.Errorf("base64 output %s decodes to %q, want the raw %q", name, decoded, raw[name])
+		}
+		if strings.Contains(encoded[name], "\n") {
+			t.Errorf("base64 output %s spans several lines", name)
+		}
+	}
+	if !strings.Contains(raw["patches"], "${{ hashFiles('**/go.sum') }}") {
+		t.Errorf("raw patches output = %q, want the expression verbatim", raw["patches"])
+	}
+	// Scalar outputs stay readable whatever the encoding
+	for _, name := range []string{"status", "workflow_grade", "security_findings_count"} {
+		if encoded[name] != raw[name] {
+			t.Errorf("output %s = %q with base64, want %q as with raw", name, encoded[name], raw[name])
+		}
+	}
+}
+
+func TestOutputEncodingTruncation(t *testing.T) {
+	t.Setenv("GITHUB_WORKSPACE", "")
+	g := GitHubOutputRenderer{MaxBytes: 1000, Dir: t.TempDir()}
+	encoded := renderOutputs(t, manyFindings(), EncodingBase64, g)
+
+	if len(encoded["findings"]) > g.MaxBytes {
+		t.Errorf("base64 findings output is %d bytes, over the %d limit", len(encoded["findings"]), g.MaxBytes)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded["findings"])
+	if err != nil {
+		t.Fatalf("base64 findings output doesn't decode: %v", err)
+	}
+	var kept []Finding
+	if err := json.Unmarshal(decoded, &kept); err != nil {
+		t.Fatalf("decoded findings output is not valid JSON: %v", err)
+	}
+	if len(kept) == 0 {
+		t.Error("truncated findings output keeps no findings")
+	}
+	// The list of truncated outputs is plain JSON, like the other scalar outputs
+	var truncated map[string]string
+	if err := json.Unmarshal([]byte(encoded["truncated_outputs"]), &truncated); err != nil {
+		t.Fatalf("truncated_outputs = %q: %v", encoded["truncated_outputs"], err)
+	}
+	if _, ok := truncated["findings"]; !ok {
+		t.Errorf("truncated_outputs = %v, want findings listed", truncated)
+	}
+}
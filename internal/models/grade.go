@@ -0,0 +1,29 @@
+package models
+
+// Points a finding takes off the workflow score, by severity
+var gradePenalties = map[string]int{
+	SeverityCritical: 15,
+	SeverityWarning:  5,
+	SeverityInfo:     1,
+}
+
+// Grade scores the workflow from 100 down by its findings and maps the score to a
+// letter: A from 90, B from 80, C from 70, D from 60 and F below
+func (r *PerformanceReport) Grade() (letter string, score int) {
+	score = 100
+	for _, finding := range r.Findings {
+		score -= gradePenalties[finding.Severity]
+	}
+	score = max(score, 0)
+	switch {
+	case score >= 90:
+		return "A", score
+	case score >= 80:
+		return "B", score
+	case score >= 70:
+		return "C", score
+	case score >= 60:
+		return "D", score
+	}
+	return "F", score
+}
@@ -0,0 +1,137 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// heatShades render a heatmap cell's share of the busiest cell, from none to most
+var heatShades = []rune(" ░▒▓█")
+
+// weekdays are the heatmap's rows, starting on Monday
+var weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+
+// RunHeatmap counts run starts by day of the week and hour in UTC, revealing the
+// windows where runs contend for runners
+type RunHeatmap struct {
+	Counts [7][24]int `json:"counts"` // by time.Weekday, Sunday first, and hour
+	Runs   int        `json:"runs"`
+}
+
+// Add records a run starting at t
+func (h *RunHeatmap) Add(t time.Time) {
+	t = t.UTC()
+	h.Counts[t.Weekday()][t.Hour()]++
+	h.Runs++
+}
+
+// Peak returns the busiest day and hour and its run count
+func (h *RunHeatmap) Peak() (time.Weekday, int, int) {
+	day, hour, peak := time.Monday, 0, -1
+	for _, d := range weekdays {
+		for hr, n := range h.Counts[d] {
+			if n > peak {
+				day, hour, peak = d, hr, n
+			}
+		}
+	}
+	return day, hour, peak
+}
+
+// QuietHour returns the hour of the day with the fewest runs over the week,
+// where heavy scheduled jobs contend least for runners
+func (h *RunHeatmap) QuietHour() int {
+	quiet, least := 0, -1
+	for hr := 0; hr < 24; hr++ {
+		n := 0
+		for d := range h.Counts {
+			n += h.Counts[d][hr]
+		}
+		if least < 0 || n < least {
+			quiet, least = hr, n
+		}
+	}
+	return quiet
+}
+
+// merge adds the runs of another workflow
+func (h *RunHeatmap) merge(other *RunHeatmap) {
+	for d := range h.Counts {
+		for hr := range h.Counts[d] {
+			h.Counts[d][hr] += other.Counts[d][hr]
+		}
+	}
+	h.Runs += other.Runs
+}
+
+// shade renders a count as a block as dark as its share of the busiest cell
+func shade(n, peak int) rune {
+	if n == 0 || peak <= 0 {
+		return heatShades[0]
+	}
+	return heatShades[1+(n*(len(heatShades)-2)+peak-1)/peak]
+}
+
+// heatmapLines describes the busiest and the quietest times
+func (r *PerformanceReport) heatmapLines() []string {
+	h := r.Heatmap
+	day, hour, peak := h.Peak()
+	return []string{
+		r.Lang.Sprintf("Busiest: %s %02d:00 UTC, %d of %d runs", r.Lang.T(day.String()), hour, peak, h.Runs),
+		r.Lang.Sprintf("Quietest hour for scheduled jobs: %02d:00 UTC", h.QuietHour()),
+	}
+}
+
+// heatmapSummary renders the heatmap section of the text report as a grid of
+// shaded hours
+func (r *PerformanceReport) heatmapSummary() string {
+	h := r.Heatmap
+	summary := heading("🗓️", r.Lang.T("Run Start Heatmap"))
+	for _, line := range r.heatmapLines() {
+		summary += "  • " + line + "\n"
+	}
+	_, _, peak := h.Peak()
+	summary += "\n         0     6     12    18     UTC\n"
+	for _, d := range weekdays {
+		var row strings.Builder
+		for _, n := range h.Counts[d] {
+			row.WriteRune(shade(n, peak))
+		}
+		summary += fmt.Sprintf("    %-3s |%s|\n", day3(d), row.String())
+	}
+	return summary + "\n"
+}
+
+// markdownHeatmap renders the heatmap section as a table of run counts
+func (r *PerformanceReport) markdownHeatmap() string {
+	h := r.Heatmap
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Run Start Heatmap"))
+	for _, line := range r.heatmapLines() {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	b.WriteString("\n| UTC |")
+	for hr := 0; hr < 24; hr++ {
+		fmt.Fprintf(&b, " %02d |", hr)
+	}
+	b.WriteString("\n|---|" + strings.Repeat("--:|", 24) + "\n")
+	for _, d := range weekdays {
+		fmt.Fprintf(&b, "| %s |", r.Lang.T(d.String()))
+		for _, n := range h.Counts[d] {
+			if n == 0 {
+				b.WriteString("  |")
+			} else {
+				fmt.Fprintf(&b, " %d |", n)
+			}
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// day3 abbreviates a day of the week in English, as the grid's labels must stay
+// three columns wide
+func day3(d time.Weekday) string {
+	return d.String()[:3]
+}
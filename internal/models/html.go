@@ -0,0 +1,218 @@
+package models
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+// HTMLRenderer writes the report as a standalone HTML page: the overview, a
+// Gantt chart of the timeline run's jobs and steps, the heatmap of run start
+// times, and the findings, as far as the report's audience sees them. Clicking a
+// job expands its steps; hovering over a bar shows its timings.
+type HTMLRenderer struct{}
+
+func (HTMLRenderer) Render(w io.Writer, r *PerformanceReport) error {
+	r = r.forAudience()
+	grade, score := r.Grade()
+	var heatmapLines []string
+	if r.Heatmap != nil {
+		heatmapLines = r.heatmapLines()
+	}
+	var overviewLines, teamLines, starterLines []string
+	if r.Audience == AudienceManager {
+		overviewLines = r.overviewLines()
+	}
+	for _, s := range r.Teams {
+		teamLines = append(teamLines, r.teamLine(s))
+	}
+	for _, s := range r.StarterWorkflows {
+		starterLines = append(starterLines, r.starterLine(s))
+	}
+	return htmlReport.Execute(w, struct {
+		*PerformanceReport
+		Grade         string
+		HeatmapLines  []string
+		TeamLines     []string
+		StarterLines  []string
+		OverviewLines []string
+	}{r, fmt.Sprintf("%s (%d/100)", grade, score), heatmapLines, teamLines, starterLines, overviewLines})
+}
+
+// htmlFuncs are the helpers of the HTML report template
+var htmlFuncs = template.FuncMap{
+	"join": strings.Join,
+	"percent": func(f float64) float64 {
+		return f * 100
+	},
+	"dur": func(d time.Duration) string {
+		return d.Round(time.Second).String()
+	},
+	// bar positions a span on the chart as a share of the run's duration
+	"bar": func(start, end, total time.Duration) template.CSS {
+		if total <= 0 {
+			return ""
+		}
+		left := 100 * float64(start) / float64(total)
+		width := max(100*float64(end-start)/float64(total), 0.3)
+		return template.CSS(fmt.Sprintf("left:%.2f%%;width:%.2f%%", left, width))
+	},
+	// ticks returns the chart's time axis labels, one per fifth of the run
+	"ticks": func(total time.Duration) []time.Duration {
+		ticks := make([]time.Duration, 0, 6)
+		for i := 0; i <= 5; i++ {
+			ticks = append(ticks, total*time.Duration(i)/5)
+		}
+		return ticks
+	},
+	// heatmap returns the heatmap's rows from Monday, each cell shaded by its
+	// share of the busiest cell
+	"heatmap": func(h *RunHeatmap) []heatmapRow {
+		_, _, peak := h.Peak()
+		rows := make([]heatmapRow, 0, len(weekdays))
+		for _, d := range weekdays {
+			row := heatmapRow{Day: d.String()}
+			for _, n := range h.Counts[d] {
+				cell := heatmapCell{Runs: n}
+				if n > 0 && peak > 0 {
+					cell.Style = template.CSS(fmt.Sprintf("background:rgba(9,105,218,%.2f)", 0.1+0.9*float64(n)/float64(peak)))
+				}
+				row.Cells = append(row.Cells, cell)
+			}
+			rows = append(rows, row)
+		}
+		return rows
+	},
+	"hours": func() []string {
+		hours := make([]string, 24)
+		for hr := range hours {
+			hours[hr] = fmt.Sprintf("%02d", hr)
+		}
+		return hours
+	},
+}
+
+// heatmapRow is a day of the week in the HTML heatmap
+type heatmapRow struct {
+	Day   string
+	Cells []heatmapCell
+}
+
+// heatmapCell is an hour in the HTML heatmap
+type heatmapCell struct {
+	Runs  int
+	Style template.CSS
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(htmlFuncs).Parse(`<!DOCTYPE html>
+<html lang="{{.Lang}}">
+<head>
+<meta charset="utf-8">
+<title>{{.Lang.T "Workflow Analysis Report"}}: {{.Repository}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #d0d7de; padding: 6px 8px; text-align: left; vertical-align: top; }
+.gantt { font-size: 13px; }
+.row { display: flex; align-items: center; height: 24px; }
+.label { box-sizing: border-box; width: 28%; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; padding-right: 8px; }
+.step .label { padding-left: 1.5em; color: #59636e; }
+.track { position: relative; flex: 1; height: 16px; background: #f6f8fa; }
+.bar { position: absolute; top: 0; height: 100%; border-radius: 3px; background: #0969da; }
+.step .bar { background: #54aeff; }
+.wait { position: absolute; top: 7px; height: 2px; background: repeating-linear-gradient(90deg, #afb8c1 0 4px, transparent 4px 8px); }
+.failure, .timed_out { background: #cf222e !important; }
+.cancelled, .skipped { background: #8c959f !important; }
+.axis { display: flex; justify-content: space-between; margin-left: 28%; color: #59636e; }
+summary { list-style: none; cursor: pointer; }
+.critical { color: #cf222e; } .warning { color: #9a6700; } .info { color: #0969da; }
+pre { background: #f6f8fa; padding: 8px; overflow-x: auto; }
+.heatmap td, .heatmap th { text-align: center; padding: 4px 0; font-size: 12px; border-bottom: 1px solid #fff; }
+</style>
+</head>
+<body>
+<h1>{{.Lang.T "Workflow Analysis Report"}}</h1>
+<ul>
+<li><strong>{{.Lang.T "Repository"}}</strong>: {{.Repository}}</li>
+<li><strong>{{.Lang.T "Workflow"}}</strong>: <code>{{.WorkflowFile}}</code></li>
+<li><strong>{{.Lang.T "Total Execution Time"}}</strong>: {{dur .TotalExecutionTime}}</li>
+{{- if or .Findings .WorkflowAnalysis}}
+<li><strong>{{.Lang.T "Workflow Grade"}}</strong>: {{.Grade}}</li>
+{{- end}}
+{{- if .Sampling}}
+<li><strong>{{.Lang.T "Sampling"}}</strong>: {{.Sampling}}</li>
+{{- end}}
+{{- if .Owners}}
+<li><strong>{{.Lang.T "Owners"}}</strong>: {{join .Owners ", "}}</li>
+{{- end}}
+</ul>
+{{- with .Timeline}}{{$total := .Duration}}
+<h2>{{$.Lang.T "Run Timeline"}}</h2>
+<p>{{if .URL}}<a href="{{.URL}}">{{$.Lang.Sprintf "Run #%d" .RunNumber}}</a>{{else}}{{$.Lang.Sprintf "Run #%d" .RunNumber}}{{end}}: {{.Conclusion}}, {{dur .Duration}}. {{$.Lang.T "Dashed lines are time spent waiting for a runner or for needed jobs; click a job to show its steps."}}</p>
+<div class="gantt">
+{{- range .Jobs}}
+<details class="job">
+<summary class="row"><span class="label" title="{{.Name}}">{{.Name}}</span><span class="track">
+<span class="wait" style="{{bar 0 .Start $total}}" title="{{$.Lang.Sprintf "Waited %s" (dur .Start)}}"></span>
+<span class="bar {{.Conclusion}}" style="{{bar .Start .End $total}}" title="{{.Name}}: {{dur .Duration}} (+{{dur .Start}}){{if .Runner}}, {{.Runner}}{{end}}"></span></span></summary>
+{{- range .Steps}}
+<div class="row step"><span class="label" title="{{.Name}}">{{.Name}}</span><span class="track"><span class="bar {{.Conclusion}}" style="{{bar .Start .End $total}}" title="{{.Name}}: {{dur .Duration}} (+{{dur .Start}})"></span></span></div>
+{{- end}}
+</details>
+{{- end}}
+<div class="axis">{{range ticks $total}}<span>{{dur .}}</span>{{end}}</div>
+</div>
+{{- end}}
+{{- with .Heatmap}}
+<h2>{{$.Lang.T "Run Start Heatmap"}}</h2>
+<ul>
+{{- range $.HeatmapLines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+<table class="heatmap">
+<tr><th>UTC</th>{{range hours}}<th>{{.}}</th>{{end}}</tr>
+{{- range heatmap .}}
+<tr><th>{{$.Lang.T .Day}}</th>{{range .Cells}}<td style="{{.Style}}"{{if .Runs}} title="{{.Runs}}"{{end}}>{{if .Runs}}{{.Runs}}{{end}}</td>{{end}}</tr>
+{{- end}}
+</table>
+{{- end}}
+{{- if .TeamLines}}
+<h2>{{$.Lang.T "Findings and Cost by Team"}}</h2>
+<ul>
+{{- range .TeamLines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .StarterLines}}
+<h2>{{$.Lang.T "Starter Workflows"}}</h2>
+<p>{{$.Lang.T "Repositories created from these templates inherit their findings, so fixing a template fixes every new copy"}}</p>
+<ul>
+{{- range .StarterLines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .OverviewLines}}
+<h2>{{.Lang.T "Findings Overview"}}</h2>
+<ul>
+{{- range .OverviewLines}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- else if .Findings}}
+<h2>{{.Lang.T "Workflow Findings"}}</h2>
+<table>
+<tr><th>{{.Lang.T "Severity"}}</th><th>{{.Lang.T "Location"}}</th><th>{{.Lang.T "Finding"}}</th></tr>
+{{- range .Findings}}
+<tr><td class="{{.Severity}}">{{.Severity}}</td><td>{{if .URL}}<a href="{{.URL}}">{{.File}}{{if .Line}}:{{.Line}}{{end}}</a>{{else}}{{.File}}{{if .Line}}:{{.Line}}{{end}}{{end}}</td>
+<td>{{.Message}}{{if .Suggestion}}<br>{{.Suggestion}}{{end}}{{if .Confidence}}<br>{{$.Lang.Sprintf "Confidence: %.0f%%, drawn from job logs" (percent .Confidence)}}{{end}}{{if .Example}}<pre>{{.Example}}</pre>{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MergeReports combines the reports of several workflows of one repository into
+// one. Identical findings, cache recommendations, Docker optimizations and tips
+// are listed once and sustainability estimates are summed; repository-wide
+// sections such as cache usage and the DORA metrics come from the first report
+// that has them. The job graphs and run timelines of every workflow are kept,
+// their jobs named after their workflow. When CODEOWNERS assigns the workflows
+// to teams, the findings and cost are also grouped by team.
+func MergeReports(reports []*PerformanceReport) *PerformanceReport {
+	if len(reports) == 1 {
+		return reports[0]
+	}
+
+	merged := &PerformanceReport{}
+	var files []string
+	cacheRecs := make(map[string]bool)
+	dockerOpts := make(map[string]bool)
+	tips := make(map[string]bool)
+	skipped := make(map[string]bool)
+	var patches strings.Builder
+	reanalyzed := false
+	for _, r := range reports {
+		if merged.Repository == "" {
+			merged.Repository, merged.Sampling, merged.Lang = r.Repository, r.Sampling, r.Lang
+		}
+		files = append(files, r.WorkflowFile)
+		for _, owner := range r.Owners {
+			if !contains(merged.Owners, owner) {
+				merged.Owners = append(merged.Owners, owner)
+			}
+		}
+		merged.TotalExecutionTime += r.TotalExecutionTime
+		if r.Secrets != nil {
+			if merged.Secrets == nil {
+				merged.Secrets = &SecretsInventory{}
+			}
+			merged.Secrets.merge(r.Secrets)
+		}
+		if r.Trend != nil {
+			if merged.Trend == nil {
+				merged.Trend = &RunTrend{}
+			}
+			merged.Trend.merge(r.Trend)
+		}
+		if r.Cost != nil {
+			if merged.Cost == nil {
+				merged.Cost = &CostEstimate{}
+			}
+			merged.Cost.merge(r.Cost)
+		}
+		if r.RunStats != nil {
+			if merged.RunStats == nil {
+				merged.RunStats = &RunStats{}
+			}
+			merged.RunStats.Successful.merge(r.RunStats.Successful)
+			merged.RunStats.Failed.merge(r.RunStats.Failed)
+			merged.RunStats.Cancelled.Runs += r.RunStats.Cancelled.Runs
+			merged.RunStats.Cancelled.Superseded += r.RunStats.Cancelled.Superseded
+			merged.RunStats.Cancelled.Duration += r.RunStats.Cancelled.Duration
+			merged.RunStats.Cancelled.Saved += r.RunStats.Cancelled.Saved
+			merged.RunStats.Excluded += r.RunStats.Excluded
+		}
+
+		// Slow steps are named "job / step", so say which workflow they belong to
+		for _, step := range r.SlowSteps {
+			step.Name = fmt.Sprintf("%s: %s", r.WorkflowFile, step.Name)
+			merged.SlowSteps = append(merged.SlowSteps, step)
+		}
+		for _, rec := range r.CacheRecommendations {
+			if key := rec.Path + "\x00" + rec.Directory + "\x00" + rec.Description; !cacheRecs[key] {
+				cacheRecs[key] = true
+				merged.CacheRecommendations = append(merged.CacheRecommendations, rec)
+			}
+		}
+		for _, opt := range r.DockerOptimizations {
+			if !dockerOpts[opt.Issue] {
+				dockerOpts[opt.Issue] = true
+				merged.DockerOptimizations = append(merged.DockerOptimizations, opt)
+			}
+		}
+		for _, tip := range r.CostSavingTips {
+			if !tips[tip] {
+				tips[tip] = true
+				merged.CostSavingTips = append(merged.CostSavingTips, tip)
+			}
+		}
+		merged.Findings = append(merged.Findings, r.Findings...)
+		merged.Migrations = append(merged.Migrations, r.Migrations...)
+		merged.ReleaseHardening = append(merged.ReleaseHardening, r.ReleaseHardening...)
+		merged.Fingerprints = append(merged.Fingerprints, r.Fingerprints...)
+		patches.WriteString(r.Patches)
+
+		if merged.CacheUsage == nil {
+			merged.CacheUsage = r.CacheUsage
+		}
+		merged.Jobs = append(merged.Jobs, workflowJobs(r.WorkflowFile, r.Jobs)...)
+		merged.Timeline = mergeTimelines(merged.Timeline, r.WorkflowFile, r.Timeline)
+		if merged.WorkflowChain == nil {
+			merged.WorkflowChain = r.WorkflowChain
+		}
+		if merged.DORA == nil {
+			merged.DORA = r.DORA
+		}
+		if merged.ForkExposure == nil {
+			merged.ForkExposure = r.ForkExposure
+		}
+		if merged.RequiredChecks == nil {
+			merged.RequiredChecks = r.RequiredChecks
+		}
+		if merged.MergeQueue == nil {
+			merged.MergeQueue = r.MergeQueue
+		}
+		if merged.StarterWorkflows == nil {
+			merged.StarterWorkflows = r.StarterWorkflows
+		}
+		if r.Heatmap != nil {
+			if merged.Heatmap == nil {
+				merged.Heatmap = &RunHeatmap{}
+			}
+			merged.Heatmap.merge(r.Heatmap)
+		}
+		if merged.WorkflowAnalysis == nil {
+			merged.WorkflowAnalysis = r.WorkflowAnalysis
+		}
+		merged.Sustainability = addSustainability(merged.Sustainability, r.Sustainability)
+
+		merged.Partial = merged.Partial || r.Partial
+		// The merged report is only reused when every workflow's report is
+		if r.CachedAt == nil {
+			reanalyzed = true
+		} else if merged.CachedAt == nil || r.CachedAt.Before(*merged.CachedAt) {
+			merged.CachedAt = r.CachedAt
+		}
+		for _, stage := range r.SkippedStages {
+			if !skipped[stage] {
+				skipped[stage] = true
+				merged.SkippedStages = append(merged.SkippedStages, stage)
+			}
+		}
+	}
+
+	if reanalyzed {
+		merged.CachedAt = nil
+	}
+	merged.WorkflowFile = strings.Join(files, ", ")
+	merged.Teams = TeamSummaries(reports)
+	merged.Findings = DedupeFindings(merged.Findings)
+	merged.Patches = patches.String()
+	return merged
+}
+
+// workflowJobs prefixes the IDs and names of a workflow's jobs, and the needs
+// between them, with the workflow file, so the jobs of several workflows don't
+// collide in one graph
+func workflowJobs(file string, jobs []JobNode) []JobNode {
+	prefixed := make([]JobNode, 0, len(jobs))
+	for _, job := range jobs {
+		name := job.Name
+		if name == "" {
+			name = job.ID
+		}
+		job.ID, job.Name = file+"/"+job.ID, fmt.Sprintf("%s: %s", file, name)
+		needs := make([]string, 0, len(job.Needs))
+		for _, need := range job.Needs {
+			needs = append(needs, file+"/"+need)
+		}
+		job.Needs = needs
+		prefixed = append(prefixed, job)
+	}
+	return prefixed
+}
+
+// mergeTimelines adds the jobs of a workflow's run timeline to the merged one,
+// named after the workflow. Each run keeps its offsets from its own start, so
+// the workflows' runs are laid side by side from zero. The merged timeline
+// takes the run number and link of the longest run and fails when any did.
+func mergeTimelines(merged *RunTimeline, file string, t *RunTimeline) *RunTimeline {
+	if t == nil {
+		return merged
+	}
+	jobs := make([]TimelineJob, 0, len(t.Jobs))
+	for _, job := range t.Jobs {
+		job.Name = fmt.Sprintf("%s: %s", file, job.Name)
+		jobs = append(jobs, job)
+	}
+	if merged == nil {
+		sum := *t
+		sum.Jobs = jobs
+		return &sum
+	}
+	sum := *merged
+	sum.Jobs = append(append([]TimelineJob(nil), merged.Jobs...), jobs...)
+	if t.Duration > sum.Duration {
+		sum.RunID, sum.RunNumber, sum.URL, sum.Duration = t.RunID, t.RunNumber, t.URL, t.Duration
+	}
+	if t.StartedAt.Before(sum.StartedAt) {
+		sum.StartedAt = t.StartedAt
+	}
+	if t.Conclusion != "success" {
+		sum.Conclusion = t.Conclusion
+	}
+	return &sum
+}
+
+// addSustainability sums two footprint estimates, combining entries of the same runner
+func addSustainability(a, b *Sustainability) *Sustainability {
+	if a == nil || b == nil {
+		if a == nil {
+			return b
+		}
+		return a
+	}
+	sum := *a
+	sum.Entries = append([]SustainabilityEntry(nil), a.Entries...)
+	for _, entry := range b.Entries {
+		found := false
+		for i := range sum.Entries {
+			if sum.Entries[i].Runner == entry.Runner {
+				sum.Entries[i].Minutes += entry.Minutes
+				sum.Entries[i].EnergyKWh += entry.EnergyKWh
+				sum.Entries[i].CO2Grams += entry.CO2Grams
+				found = true
+				break
+			}
+		}
+		if !found {
+			sum.Entries = append(sum.Entries, entry)
+		}
+	}
+	sum.Runs += b.Runs
+	sum.Minutes += b.Minutes
+	sum.EnergyKWh += b.EnergyKWh
+	sum.CO2Grams += b.CO2Grams
+	sum.MonthlyEnergyKWh += b.MonthlyEnergyKWh
+	sum.MonthlyCO2Grams += b.MonthlyCO2Grams
+	return &sum
+}
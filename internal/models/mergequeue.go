@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// MergeQueue summarizes the runs a merge queue triggered through merge_group,
+// apart from the workflow's other runs. An entry's latency runs from the queue
+// creating its merge group to the group's run finishing.
+type MergeQueue struct {
+	Merged RunDurations    `json:"merged"` // entries whose checks passed
+	Failed RunDurations    `json:"failed"` // entries removed from the queue by a failed check
+	PerDay float64         `json:"per_day"`
+	Period time.Duration   `json:"period"` // from the oldest to the newest entry
+	Jobs   []MergeQueueJob `json:"jobs,omitempty"`
+}
+
+// MergeQueueJob is a job of the sampled merge_group runs, slowest first
+type MergeQueueJob struct {
+	Name        string        `json:"name"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	Runs        int           `json:"runs"`
+	Required    bool          `json:"required,omitempty"`
+}
+
+// FailureRate returns the share of entries removed from the queue
+func (q *MergeQueue) FailureRate() float64 {
+	if total := q.Merged.Runs + q.Failed.Runs; total > 0 {
+		return float64(q.Failed.Runs) / float64(total)
+	}
+	return 0
+}
+
+// mergeQueueLines describes the queue's throughput and latency
+func (r *PerformanceReport) mergeQueueLines() []string {
+	q := r.MergeQueue
+	lines := []string{r.Lang.Sprintf("Throughput: %.1f entries merged per day over %d days", q.PerDay, int(q.Period.Hours()/24)+1)}
+	if q.Merged.Runs > 0 {
+		lines = append(lines, r.Lang.Sprintf("Latency per entry: %v on average, %v at most", q.Merged.Average.Round(time.Second), q.Merged.Max.Round(time.Second)))
+	}
+	lines = append(lines, r.Lang.Sprintf("Removed from the queue: %d of %d entries (%.0f%%)", q.Failed.Runs, q.Merged.Runs+q.Failed.Runs, q.FailureRate()*100))
+	return lines
+}
+
+// mergeQueueJobLine describes a job of the queue's runs
+func (r *PerformanceReport) mergeQueueJobLine(job MergeQueueJob) string {
+	line := job.Name + ": " + r.Lang.Sprintf("%v on average over %d runs", job.AvgDuration.Round(time.Second), job.Runs)
+	if job.Required {
+		line += " (" + r.Lang.T("required") + ")"
+	}
+	return line
+}
+
+// mergeQueueSummary renders the merge queue section of the text report
+func (r *PerformanceReport) mergeQueueSummary() string {
+	summary := heading("🚂", r.Lang.T("Merge Queue"))
+	for _, line := range r.mergeQueueLines() {
+		summary += "  • " + line + "\n"
+	}
+	for _, job := range r.MergeQueue.Jobs {
+		summary += "    ↳ " + r.mergeQueueJobLine(job) + "\n"
+	}
+	return summary + "\n"
+}
+
+// markdownMergeQueue renders the merge queue section as Markdown
+func (r *PerformanceReport) markdownMergeQueue() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Merge Queue"))
+	for _, line := range r.mergeQueueLines() {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	if len(r.MergeQueue.Jobs) > 0 {
+		fmt.Fprintf(&b, "\n%s:\n\n", r.Lang.T("Jobs in the queue, slowest first"))
+		for _, job := range r.MergeQueue.Jobs {
+			fmt.Fprintf(&b, "- %s\n", r.mergeQueueJobLine(job))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JobNode is a job of the analyzed workflow with the jobs it needs and its
+// average duration over the sampled runs
+type JobNode struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name,omitempty"`
+	Needs       []string      `json:"needs,omitempty"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	Runs        int           `json:"runs"` // measured job runs, matrix expansions included
+}
+
+var (
+	// mermaidText makes a name safe inside a quoted flowchart label
+	mermaidText = strings.NewReplacer("#", "#35;", `"`, "#quot;", "\n", " ")
+	// ganttText makes a name safe as a Gantt title, section or task, which
+	// can't hold colons, semicolons or entities
+	ganttText = strings.NewReplacer(":", " ", ";", ",", "#", "", "\n", " ")
+)
+
+// criticalPath returns the jobs on the longest chain of needs by average
+// duration, the one holding up the run
+func criticalPath(jobs []JobNode) map[string]bool {
+	byID := make(map[string]JobNode, len(jobs))
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+	finish := make(map[string]time.Duration)
+	prev := make(map[string]string)
+	var visit func(id string, depth int) time.Duration
+	visit = func(id string, depth int) time.Duration {
+		if d, ok := finish[id]; ok {
+			return d
+		}
+		job, ok := byID[id]
+		if !ok || depth > len(jobs) {
+			return 0
+		}
+		var start time.Duration
+		for _, need := range job.Needs {
+			if d := visit(need, depth+1); d > start {
+				start, prev[id] = d, need
+			}
+		}
+		finish[id] = start + job.AvgDuration
+		return finish[id]
+	}
+
+	last, longest := "", time.Duration(-1)
+	for _, job := range jobs {
+		if d := visit(job.ID, 0); d > longest {
+			last, longest = job.ID, d
+		}
+	}
+	path := make(map[string]bool)
+	for id := last; id != "" && !path[id]; id = prev[id] {
+		path[id] = true
+	}
+	return path
+}
+
+// MermaidGraph returns a Mermaid flowchart of the workflow's jobs and their
+// needs, labeled with average durations. The jobs on the critical path are
+// highlighted.
+func (r *PerformanceReport) MermaidGraph() string {
+	if len(r.Jobs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	b.WriteString("  classDef critical stroke:#cf222e,stroke-width:3px\n")
+	nodes := make(map[string]string, len(r.Jobs))
+	for i, job := range r.Jobs {
+		nodes[job.ID] = fmt.Sprintf("j%d", i)
+	}
+	critical := criticalPath(r.Jobs)
+	for _, job := range r.Jobs {
+		label := job.Name
+		if label == "" {
+			label = job.ID
+		}
+		label = mermaidText.Replace(label)
+		if job.Runs > 0 {
+			label += "<br/>" + mermaidText.Replace(r.Lang.Sprintf("%v on average", job.AvgDuration.Round(time.Second)))
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]", nodes[job.ID], label)
+		if critical[job.ID] && len(r.Jobs) > 1 {
+			b.WriteString(":::critical")
+		}
+		b.WriteString("\n")
+	}
+	for _, job := range r.Jobs {
+		for _, need := range job.Needs {
+			if from, ok := nodes[need]; ok {
+				fmt.Fprintf(&b, "  %s --> %s\n", from, nodes[job.ID])
+			}
+		}
+	}
+	return b.String()
+}
+
+// MermaidGantt returns a Mermaid Gantt chart of the timeline run, a section per
+// job with the job and its steps as tasks. Failed ones are marked critical.
+func (r *PerformanceReport) MermaidGantt() string {
+	t := r.Timeline
+	if t == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "  title %s\n", ganttText.Replace(r.Lang.Sprintf("Run #%d", t.RunNumber)))
+	b.WriteString("  dateFormat X\n  axisFormat %H:%M:%S\n")
+	task := func(span TimelineSpan) {
+		tag := "done, "
+		if span.Conclusion == "failure" || span.Conclusion == "timed_out" {
+			tag = "crit, "
+		}
+		fmt.Fprintf(&b, "    %s :%s%d, %d\n", ganttText.Replace(span.Name), tag,
+			int64(span.Start/time.Second), int64(max(span.End, span.Start+time.Second)/time.Second))
+	}
+	for _, job := range t.Jobs {
+		fmt.Fprintf(&b, "  section %s\n", ganttText.Replace(job.Name))
+		task(job.TimelineSpan)
+		for _, step := range job.Steps {
+			task(step)
+		}
+	}
+	return b.String()
+}
+
+// markdownMermaid renders the job graph and the run's Gantt chart as Mermaid
+// blocks, which GitHub draws in job summaries and comments
+func (r *PerformanceReport) markdownMermaid() string {
+	var b strings.Builder
+	if graph := r.MermaidGraph(); graph != "" {
+		fmt.Fprintf(&b, "\n## %s\n\n```mermaid\n%s```\n", r.Lang.T("Job Graph"), graph)
+	}
+	if gantt := r.MermaidGantt(); gantt != "" {
+		fmt.Fprintf(&b, "\n## %s\n\n```mermaid\n%s```\n", r.Lang.T("Run Timeline"), gantt)
+	}
+	return b.String()
+}
@@ -0,0 +1,25 @@
+package models
+
+// ConstructMapping maps a keyword of another CI system to its GitHub Actions equivalent
+type ConstructMapping struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Note      string `json:"note"`
+	CarryOver bool   `json:"carry_over"`
+}
+
+// MigratedJob is a job found in another CI system's config
+type MigratedJob struct {
+	Name     string   `json:"name"`
+	Line     int      `json:"line"`
+	Needs    []string `json:"needs,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// MigrationAdvice describes how a GitLab CI or CircleCI config maps to GitHub Actions
+type MigrationAdvice struct {
+	Source   string             `json:"source"`
+	File     string             `json:"file"`
+	Jobs     []MigratedJob      `json:"jobs"`
+	Mappings []ConstructMapping `json:"mappings"`
+}
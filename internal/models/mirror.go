@@ -0,0 +1,55 @@
+package models
+
+import "reflect"
+
+// Mirror copies src into dst, both pointers to structs declaring the same
+// exported fields by name, e.g. a PerformanceReport and the stable report type
+// of pkg/report. Named basic types such as i18n.Lang are converted to their
+// counterpart's type.
+func Mirror(dst, src interface{}) {
+	mirror(reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem())
+}
+
+func mirror(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(dst.Type().Elem()))
+		mirror(dst.Elem(), src.Elem())
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			mirror(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		for it := src.MapRange(); it.Next(); {
+			k := reflect.New(dst.Type().Key()).Elem()
+			mirror(k, it.Key())
+			v := reflect.New(dst.Type().Elem()).Elem()
+			mirror(v, it.Value())
+			dst.SetMapIndex(k, v)
+		}
+	case reflect.Struct:
+		// Shared types such as time.Time are copied whole
+		if src.Type() == dst.Type() {
+			dst.Set(src)
+			return
+		}
+		for i := 0; i < dst.NumField(); i++ {
+			if f := dst.Type().Field(i); f.IsExported() {
+				mirror(dst.Field(i), src.FieldByName(f.Name))
+			}
+		}
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// plainReplacer maps the report's Unicode punctuation to ASCII equivalents
+var plainReplacer = strings.NewReplacer(
+	"•", "-",
+	"↳", ">",
+	"─", "-",
+	"│", "",
+	"→", "->",
+	"←", "<-",
+	"✓", "+",
+	"✗", "x",
+	"░", ".",
+	"▒", ":",
+	"▓", "*",
+	"█", "#",
+)
+
+// toPlainText strips box-drawing characters and emoji from a rendered report,
+// keeping its structure through indentation and ASCII rules
+func toPlainText(s string) string {
+	lines := strings.Split(s, "\n")
+	out := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		// Box borders become plain rules
+		if strings.HasPrefix(line, "╭") || strings.HasPrefix(line, "╰") {
+			out = append(out, strings.Repeat("=", boxWidth))
+			continue
+		}
+
+		line = strings.TrimRight(stripEmoji(plainReplacer.Replace(line)), " ")
+
+		// Keep heading underlines as long as the heading they belong to
+		if line != "" && strings.Trim(line, "-") == "" && len(out) > 0 {
+			line = strings.Repeat("-", len(strings.TrimSpace(out[len(out)-1])))
+		}
+
+		out = append(out, line)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// stripEmoji removes pictographs, variation selectors and the space following them
+func stripEmoji(line string) string {
+	var b strings.Builder
+	skipSpace := false
+	for _, r := range line {
+		if isEmoji(r) {
+			skipSpace = true
+			continue
+		}
+		if skipSpace && r == ' ' {
+			skipSpace = false
+			continue
+		}
+		skipSpace = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isEmoji reports whether r is an emoji or emoji presentation modifier. Only
+// the symbol blocks are matched, so CJK text of translated reports is kept.
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1F6FF:
+		// Mahjong tiles and playing cards up to transport and map symbols, with
+		// the regional indicators of flags and the skin tone modifiers
+		return true
+	case r >= 0x1F780 && r <= 0x1F7FF, r >= 0x1F900 && r <= 0x1FAFF:
+		// Geometric shapes such as 🟢 and the supplemental pictographs
+		return true
+	case r >= 0xE0020 && r <= 0xE007F:
+		// Tags of subdivision flags
+		return true
+	case r == 0xFE0F, r == 0x200D, r == 0x20E3:
+		// Emoji presentation selector, zero width joiner and keycap
+		return true
+	case r >= 0x2300 && r <= 0x23FF, r >= 0x2600 && r <= 0x27BF:
+		// Miscellaneous technical symbols such as ⏱, symbols and dingbats
+		return true
+	case r > 0x2100 && r <= 0x2BFF:
+		// Other symbols from letterlike symbols to arrows, such as ℹ and ⭐
+		return unicode.Is(unicode.So, r)
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+)
+
+// PlannedCall describes a group of GitHub API requests the analysis would make
+type PlannedCall struct {
+	Endpoint string `json:"endpoint"`
+	Purpose  string `json:"purpose"`
+	Count    int    `json:"count"`
+	Note     string `json:"note,omitempty"`
+}
+
+// AnalysisPlan is the result of a dry run: what would be fetched and what it would cost
+type AnalysisPlan struct {
+	Repository         string        `json:"repository"`
+	WorkflowFile       string        `json:"workflow_file"`
+	Mode               string        `json:"mode"`
+	RunsAvailable      int           `json:"runs_available"`
+	RunsToAnalyze      int           `json:"runs_to_analyze"`
+	Calls              []PlannedCall `json:"calls"`
+	EstimatedCost      int           `json:"estimated_cost"`
+	RateLimitRemaining int           `json:"rate_limit_remaining"`
+	RateLimitReset     time.Time     `json:"rate_limit_reset"`
+	Lang               i18n.Lang     `json:"lang,omitempty"`
+	Plain              bool          `json:"-"`
+}
+
+// Output prints the plan and writes it to GitHub Actions outputs
+func (p *AnalysisPlan) Output() error {
+	t := p.Lang.T
+
+	summary := "\n" + boxHeader(t("Analysis Dry Run Plan")) + "\n"
+	summary += fmt.Sprintf("📋 %s\n", t("Overview"))
+	summary += fmt.Sprintf("• %s: %s\n", t("Repository"), p.Repository)
+	summary += fmt.Sprintf("• %s: %s\n", t("Workflow"), p.WorkflowFile)
+	summary += fmt.Sprintf("• %s: %s\n", t("Mode"), p.Mode)
+	summary += "• " + p.Lang.Sprintf("Runs: %d to analyze (%d available)", p.RunsToAnalyze, p.RunsAvailable) + "\n\n"
+
+	summary += heading("🛰️", t("Planned API Calls"))
+	for _, call := range p.Calls {
+		summary += fmt.Sprintf("  • %-4d %s\n", call.Count, call.Endpoint)
+		summary += fmt.Sprintf("    ↳ %s\n", call.Purpose)
+		if call.Note != "" {
+			summary += fmt.Sprintf("    ↳ %s\n", call.Note)
+		}
+	}
+	summary += "\n"
+
+	summary += heading("📊", t("Quota Estimate"))
+	summary += "  • " + p.Lang.Sprintf("Estimated requests: %d", p.EstimatedCost) + "\n"
+	summary += "  • " + p.Lang.Sprintf("Remaining rate limit: %d (resets %s)", p.RateLimitRemaining, p.RateLimitReset.Format(time.RFC3339)) + "\n"
+	if p.EstimatedCost > p.RateLimitRemaining {
+		summary += "  • ⚠️ " + t("The estimate exceeds the remaining quota; lower analysis_depth or use survey mode") + "\n"
+	}
+	summary += "\n"
+
+	if p.Plain {
+		summary = toPlainText(summary)
+	}
+
+	fmt.Println(summary)
+
+	outputFile := os.Getenv("GITHUB_OUTPUT")
+	if outputFile == "" {
+		return nil
+	}
+
+	plan, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan: %v", err)
+	}
+
+	f, err := os.OpenFile(outputFile, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open GITHUB_OUTPUT file: %v", err)
+	}
+	defer f.Close()
+
+	return writeOutputs(f, []output{
+		{"dry_run_plan", plan},
+		{"status", []byte("dry_run")},
+	})
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReleaseHardening is how well a workflow triggered by tags or releases guards
+// what it publishes
+type ReleaseHardening struct {
+	File          string   `json:"file"`
+	Triggers      []string `json:"triggers"`       // e.g. "push tags v*.*.*" or "release published"
+	TagFilter     bool     `json:"tag_filter"`     // the triggers only match release tags or published releases
+	Provenance    bool     `json:"provenance"`     // a build provenance attestation is generated
+	Signing       bool     `json:"signing"`        // artifacts or images are signed
+	UniqueUploads bool     `json:"unique_uploads"` // every release asset is uploaded by one step of one job
+}
+
+// releaseLines describes the checks of a release workflow, passed or not
+func (r *PerformanceReport) releaseLines(h ReleaseHardening) []string {
+	t := r.Lang.T
+	mark := func(ok bool, check string) string {
+		if ok {
+			return "✓ " + t(check)
+		}
+		return "✗ " + t(check)
+	}
+	return []string{
+		mark(h.TagFilter, "Trigger limited to release tags or published releases"),
+		mark(h.Provenance, "Build provenance attestation"),
+		mark(h.Signing, "Signed artifacts"),
+		mark(h.UniqueUploads, "Each release asset uploaded once"),
+	}
+}
+
+// releaseSummary renders the release hardening section of the text report
+func (r *PerformanceReport) releaseSummary() string {
+	summary := heading("🏷️", r.Lang.T("Release Hardening"))
+	for _, h := range r.ReleaseHardening {
+		summary += fmt.Sprintf("  • %s (%s)\n", h.File, strings.Join(h.Triggers, ", "))
+		for _, line := range r.releaseLines(h) {
+			summary += "    ↳ " + line + "\n"
+		}
+	}
+	return summary + "\n"
+}
+
+// markdownRelease renders the release hardening section as Markdown
+func (r *PerformanceReport) markdownRelease() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Release Hardening"))
+	for _, h := range r.ReleaseHardening {
+		fmt.Fprintf(&b, "- `%s` (%s)\n", h.File, strings.Join(h.Triggers, ", "))
+		for _, line := range r.releaseLines(h) {
+			fmt.Fprintf(&b, "  - %s\n", line)
+		}
+	}
+	return b.String()
+}
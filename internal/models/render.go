@@ -0,0 +1,391 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Output formats registered by default
+const (
+	FormatConsole      = "console"
+	FormatMarkdown     = "markdown"
+	FormatJSON         = "json"
+	FormatGitHubOutput = "github-output"
+	FormatSecrets      = "secrets" // the secrets inventory only, as JSON
+	FormatHTML         = "html"
+)
+
+// Renderer writes a report in one output format
+type Renderer interface {
+	Render(w io.Writer, r *PerformanceReport) error
+}
+
+// renderers maps format names to constructors; see RegisterRenderer
+var renderers = map[string]func() Renderer{
+	FormatConsole:      func() Renderer { return ConsoleRenderer{} },
+	FormatMarkdown:     func() Renderer { return MarkdownRenderer{} },
+	FormatJSON:         func() Renderer { return JSONRenderer{Indent: true} },
+	FormatGitHubOutput: func() Renderer { return GitHubOutputRenderer{MaxBytes: MaxOutputBytes, Dir: outputDir()} },
+	FormatSecrets:      func() Renderer { return SecretsRenderer{} },
+	FormatHTML:         func() Renderer { return HTMLRenderer{} },
+}
+
+// RegisterRenderer adds an output format, or replaces the renderer of an existing one
+func RegisterRenderer(format string, newRenderer func() Renderer) {
+	renderers[format] = newRenderer
+}
+
+// NewRenderer returns the renderer registered for format
+func NewRenderer(format string) (Renderer, error) {
+	newRenderer, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q (available: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return newRenderer(), nil
+}
+
+// Formats lists the registered output format names
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for format := range renderers {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Target is one output of a run: a format and where to write it. An empty path
+// is stdout, except for github-output, which defaults to the $GITHUB_OUTPUT file.
+type Target struct {
+	Format string
+	Path   string
+}
+
+// DefaultTargets print the report and set the action outputs
+var DefaultTargets = []Target{{Format: FormatConsole}, {Format: FormatGitHubOutput}}
+
+// ParseTargets parses a comma-separated list of format or format:path entries,
+// e.g. "console,markdown:report.md,json:report.json"
+func ParseTargets(s string) ([]Target, error) {
+	var targets []Target
+	for _, entry := range strings.Split(s, ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		format, path, _ := strings.Cut(entry, ":")
+		target := Target{Format: strings.TrimSpace(format), Path: strings.TrimSpace(path)}
+		if _, ok := renderers[target.Format]; !ok {
+			return nil, fmt.Errorf("unknown output format %q", target.Format)
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// open returns the writer for the target. The action outputs file is shared by
+// every step of the job, so it is appended to; other files are replaced.
+func (t Target) open() (io.WriteCloser, error) {
+	path, flags := t.Path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY
+	if t.Format == FormatGitHubOutput {
+		flags = os.O_APPEND | os.O_WRONLY
+		if path == "" {
+			if path = os.Getenv("GITHUB_OUTPUT"); path == "" {
+				return nil, fmt.Errorf("GITHUB_OUTPUT environment variable not set")
+			}
+		}
+	}
+	if path == "" || path == "-" {
+		return nopCloser{os.Stdout}, nil
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	return f, nil
+}
+
+type nopCloser struct{ io.Writer }
+
+func (nopCloser) Close() error { return nil }
+
+// write renders the report to the target
+func (t Target) write(r *PerformanceReport) error {
+	renderer, err := NewRenderer(t.Format)
+	if err != nil {
+		return err
+	}
+	w, err := t.open()
+	if err != nil {
+		return err
+	}
+	if err := renderer.Render(w, r); err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// ConsoleRenderer writes the text report shown in the job log
+type ConsoleRenderer struct{}
+
+func (ConsoleRenderer) Render(w io.Writer, r *PerformanceReport) error {
+	_, err := fmt.Fprintln(w, r.Summary())
+	return err
+}
+
+// JSONRenderer writes the whole report as JSON
+type JSONRenderer struct {
+	Indent bool
+}
+
+func (j JSONRenderer) Render(w io.Writer, r *PerformanceReport) error {
+	enc := json.NewEncoder(w)
+	if j.Indent {
+		enc.SetIndent("", "  ")
+	}
+	// Metrics are derived when the report is rendered, and renderers are used
+	// without Output, e.g. for uploads, so they're calculated on a copy here
+	report := *r
+	report.calculateMetrics()
+	if err := enc.Encode(&report); err != nil {
+		return fmt.Errorf("failed to marshal report: %v", err)
+	}
+	return nil
+}
+
+// MarkdownRenderer writes the report as GitHub-flavored Markdown, e.g. for
+// $GITHUB_STEP_SUMMARY or a pull request comment, with the sections of the
+// report's audience. In digest mode only the digest is written.
+type MarkdownRenderer struct{}
+
+func (MarkdownRenderer) Render(w io.Writer, r *PerformanceReport) error {
+	if r.Digest != nil {
+		_, err := io.WriteString(w, r.markdownDigest())
+		return err
+	}
+	r = r.forAudience()
+	t := r.Lang.T
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", t("Workflow Analysis Report"))
+	fmt.Fprintf(&b, "- **%s**: %s\n", t("Repository"), r.Repository)
+	fmt.Fprintf(&b, "- **%s**: `%s`\n", t("Workflow"), r.WorkflowFile)
+	if r.PullRequest > 0 {
+		fmt.Fprintf(&b, "- **%s**: #%d\n", t("Pull Request"), r.PullRequest)
+	}
+	fmt.Fprintf(&b, "- **%s**: %v\n", t("Total Execution Time"), r.TotalExecutionTime)
+	for _, line := range r.runStatsLines() {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	if line := r.trendLine(); line != "" {
+		fmt.Fprintf(&b, "- %s\n", line)
+	}
+	if grade, score := r.Grade(); len(r.Findings) > 0 || r.WorkflowAnalysis != nil {
+		fmt.Fprintf(&b, "- **%s**: %s (%d/100)\n", t("Workflow Grade"), grade, score)
+	}
+	if r.Sampling != "" {
+		fmt.Fprintf(&b, "- **%s**: %s\n", t("Sampling"), r.Sampling)
+	}
+	if len(r.Owners) > 0 {
+		fmt.Fprintf(&b, "- **%s**: %s\n", t("Owners"), strings.Join(r.Owners, ", "))
+	}
+	if r.Partial {
+		fmt.Fprintf(&b, "\n> [!WARNING]\n> %s\n", r.Lang.Sprintf("Partial results: the analysis ran out of time or API rate limit before finishing (skipped: %s)", strings.Join(r.SkippedStages, ", ")))
+	}
+	if r.CachedAt != nil {
+		fmt.Fprintf(&b, "\n> [!NOTE]\n> %s\n", r.Lang.Sprintf("No changes: the workflow file and its runs are unchanged since the analysis at %s, so its report is reused", r.CachedAt.UTC().Format("2006-01-02 15:04 UTC")))
+	}
+
+	if len(r.SlowSteps) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Slow Steps Detected"))
+		fmt.Fprintf(&b, "| %s | %s |\n|---|---|\n", t("Step"), t("Duration"))
+		for _, step := range r.SlowSteps {
+			fmt.Fprintf(&b, "| %s | %v |\n", markdownCell(step.Name), step.ExecutionTime)
+		}
+	}
+
+	if len(r.CacheRecommendations) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Cache Optimization Tips"))
+		for _, cache := range r.CacheRecommendations {
+			fmt.Fprintf(&b, "- **`%s`**", cache.Path)
+			if cache.Directory != "" {
+				fmt.Fprintf(&b, " (%s: `%s`)", t("Directory"), cache.Directory)
+			}
+			fmt.Fprintf(&b, ": %s\n  - %s: %s\n", cache.Description, t("Impact"), cache.Impact)
+			if cache.EstimatedSavings != nil {
+				fmt.Fprintf(&b, "  - %s: %s\n", t("Estimated Savings"), cache.EstimatedSavings.Basis)
+			}
+			if cache.Confidence > 0 {
+				fmt.Fprintf(&b, "  - %s\n", r.Lang.Sprintf("Confidence: %.0f%%, drawn from job logs", cache.Confidence*100))
+			}
+			if cache.Example != "" {
+				fmt.Fprintf(&b, "\n  ```yaml\n%s\n  ```\n", indent(cache.Example, "  "))
+			}
+		}
+	}
+
+	b.WriteString(r.markdownMermaid())
+
+	if c := r.WorkflowChain; c != nil {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Workflow Chain"))
+		for _, stage := range c.Stages {
+			fmt.Fprintf(&b, "%s- %s (`%s`)", strings.Repeat("  ", stage.Depth), stage.Name, stage.File)
+			if stage.Runs > 0 {
+				b.WriteString(": " + r.Lang.Sprintf("%v on average over %d runs", stage.AvgDuration.Round(time.Second), stage.Runs))
+			}
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "\n%s\n", r.Lang.Sprintf("End-to-end lead time: %v on average, %v at most over %d runs",
+			c.AvgLeadTime.Round(time.Second), c.MaxLeadTime.Round(time.Second), c.Runs))
+	}
+
+	if d := r.DORA; d != nil {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("DORA Metrics"))
+		fmt.Fprintf(&b, "- %s (%s)\n", r.Lang.Sprintf("Deployment frequency: %.1f per week", d.DeploysPerWeek), t(d.FrequencyLevel))
+		if d.LeadTimeLevel != "" {
+			fmt.Fprintf(&b, "- %s (%s)\n", r.Lang.Sprintf("Lead time for changes: %v median", d.LeadTime.Round(time.Minute)), t(d.LeadTimeLevel))
+		}
+		fmt.Fprintf(&b, "- %s (%s)\n", r.Lang.Sprintf("Change failure rate: %.0f%% (%d of %d deployments)",
+			d.ChangeFailureRate*100, d.FailedDeployments, d.Deployments+d.FailedDeployments), t(d.FailureRateLevel))
+		if d.RestoreLevel != "" {
+			fmt.Fprintf(&b, "- %s (%s)\n", r.Lang.Sprintf("Time to restore: %v median over %d recoveries", d.TimeToRestore.Round(time.Minute), d.Recoveries), t(d.RestoreLevel))
+		}
+		if d.Rollbacks > 0 {
+			fmt.Fprintf(&b, "- %s\n", r.rollbackLine(d))
+		}
+	}
+
+	if r.ForkExposure != nil {
+		b.WriteString(r.markdownForkExposure())
+	}
+
+	if r.RequiredChecks != nil {
+		b.WriteString(r.markdownRequiredChecks())
+	}
+
+	if len(r.ReleaseHardening) > 0 {
+		b.WriteString(r.markdownRelease())
+	}
+
+	if r.MergeQueue != nil {
+		b.WriteString(r.markdownMergeQueue())
+	}
+
+	if r.Heatmap != nil {
+		b.WriteString(r.markdownHeatmap())
+	}
+
+	if len(r.Teams) > 0 {
+		b.WriteString(r.markdownTeams())
+	}
+
+	if len(r.StarterWorkflows) > 0 {
+		b.WriteString(r.markdownStarterWorkflows())
+	}
+
+	if u := r.CacheUsage; u != nil {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Actions Cache Usage"))
+		fmt.Fprintf(&b, "- %s\n", r.Lang.Sprintf("%s of %s in %d caches", FormatBytes(u.Bytes), FormatBytes(u.LimitBytes), u.Count))
+		for _, rec := range u.Recommendations {
+			fmt.Fprintf(&b, "- %s\n", rec)
+		}
+	}
+
+	if len(r.DockerOptimizations) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Docker Optimization Tips"))
+		for _, docker := range r.DockerOptimizations {
+			fmt.Fprintf(&b, "- **%s**: %s\n  - %s: %s\n", docker.Issue, docker.Suggestion, t("Expected Improvement"), docker.Improvement)
+		}
+	}
+
+	if len(r.CostSavingTips) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Cost Saving Opportunities"))
+		for _, tip := range r.CostSavingTips {
+			fmt.Fprintf(&b, "- %s\n", tip)
+		}
+	}
+
+	if a := r.WorkflowAnalysis; a != nil && len(a.Recommendations)+len(a.RunnerOptimizations)+len(a.SecurityTips) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Workflow Structure Analysis"))
+		for _, tip := range append(append(append([]string{}, a.Recommendations...), a.RunnerOptimizations...), a.SecurityTips...) {
+			fmt.Fprintf(&b, "- %s\n", tip)
+		}
+	}
+
+	if c := r.Cost; c != nil {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Cost Estimate"))
+		fmt.Fprintf(&b, "- %s\n", r.Lang.Sprintf("Total over %d runs: %d billable min, $%.2f", c.Runs, c.BillableMinutes, c.Cost))
+		fmt.Fprintf(&b, "- %s\n", r.Lang.Sprintf("Projected per month: $%.2f", c.MonthlyCost))
+		if len(c.Attribution) > 1 {
+			fmt.Fprintf(&b, "\n| %s | %s | %s | %s |\n|---|---|---|---|\n", t("Branch or pull request"), t("Runs"), t("Billable minutes"), t("Cost"))
+			for _, a := range c.Attribution {
+				fmt.Fprintf(&b, "| %s | %d | %d | $%.2f |\n", r.attributionName(a), a.Runs, a.BillableMinutes, a.Cost)
+			}
+		}
+	}
+
+	if s := r.Sustainability; s != nil {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Sustainability Estimate"))
+		fmt.Fprintf(&b, "- %s\n", r.Lang.Sprintf("Total over %d runs: %.0f min, %.3f kWh, %.0f gCO2e", s.Runs, s.Minutes, s.EnergyKWh, s.CO2Grams))
+		fmt.Fprintf(&b, "- %s\n", r.Lang.Sprintf("Projected per month: %.2f kWh, %.1f kgCO2e", s.MonthlyEnergyKWh, s.MonthlyCO2Grams/1000))
+	}
+
+	if len(r.Migrations) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("CI Migration Advice"))
+		for _, m := range r.Migrations {
+			fmt.Fprintf(&b, "- %s (`%s`): %s\n", m.Source, m.File, r.Lang.Sprintf("%d jobs", len(m.Jobs)))
+		}
+	}
+
+	if r.Adoption != nil {
+		b.WriteString(r.markdownAdoption())
+	}
+
+	if r.Audience == AudienceManager {
+		b.WriteString(r.markdownOverview())
+	} else if len(r.Findings) > 0 {
+		fmt.Fprintf(&b, "\n## %s\n\n", t("Workflow Findings"))
+		fmt.Fprintf(&b, "| %s | %s | %s |\n|---|---|---|\n", t("Severity"), t("Location"), t("Finding"))
+		for _, finding := range r.Findings {
+			message := markdownCell(finding.Message)
+			if finding.Suggestion != "" {
+				message += "<br>↳ " + markdownCell(finding.Suggestion)
+			}
+			if finding.Confidence > 0 {
+				message += "<br>↳ " + r.Lang.Sprintf("Confidence: %.0f%%, drawn from job logs", finding.Confidence*100)
+			}
+			var locations []string
+			for _, pos := range finding.Positions() {
+				location := "`" + pos.Location() + "`"
+				if pos.URL != "" {
+					location = "[" + location + "](" + pos.URL + ")"
+				}
+				locations = append(locations, location)
+			}
+			location := strings.Join(locations, "<br>")
+			fmt.Fprintf(&b, "| %s | %s | %s |\n", finding.Severity, location, message)
+		}
+	}
+
+	if r.Patches != "" {
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n\n```diff\n%s```\n", t("Suggested Patches"), t("Save as a file and apply with git apply after review:"), r.Patches)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// markdownCell keeps text on one table row
+func markdownCell(s string) string {
+	return strings.NewReplacer("|", "\\|", "\r", "", "\n", "<br>").Replace(s)
+}
+
+// indent prefixes every line of s
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
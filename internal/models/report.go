@@ -1,11 +1,12 @@
 package models
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"time"
+	"unicode"
+
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
 )
 
 type StepAnalysis struct {
@@ -16,61 +17,146 @@ type StepAnalysis struct {
 }
 
 type CacheRecommendation struct {
-	Path        string `json:"path"`
-	Description string `json:"description"`
-	Impact      string `json:"impact"`
-	Example     string `json:"example"`
+	Path             string   `json:"path"`
+	Directory        string   `json:"directory,omitempty"` // subproject the recommendation is scoped to
+	Description      string   `json:"description"`
+	Impact           string   `json:"impact"`
+	Example          string   `json:"example"`
+	EstimatedSavings *Savings `json:"estimated_savings,omitempty"`
+
+	// Confidence rates recommendations for a tool only job logs mention, as Finding's does
+	Confidence float64 `json:"confidence,omitempty"`
 }
 
 type DockerOptimization struct {
-	Issue       string `json:"issue"`
-	Suggestion  string `json:"suggestion"`
-	Improvement string `json:"improvement"`
+	Issue            string   `json:"issue"`
+	Suggestion       string   `json:"suggestion"`
+	Improvement      string   `json:"improvement"`
+	EstimatedSavings *Savings `json:"estimated_savings,omitempty"`
+}
+
+// Savings estimates the time a recommendation saves, derived from measured run history
+type Savings struct {
+	PerRun   time.Duration `json:"per_run"`
+	PerMonth time.Duration `json:"per_month"`
+	Basis    string        `json:"basis"`
 }
 
 type PerformanceReport struct {
 	Repository           string                `json:"repository"`
 	WorkflowFile         string                `json:"workflow_file"`
 	TotalExecutionTime   time.Duration         `json:"total_execution_time"`
+	RunStats             *RunStats             `json:"run_stats,omitempty"`
+	Trend                *RunTrend             `json:"trend,omitempty"`
+	Cost                 *CostEstimate         `json:"cost,omitempty"`
+	Sampling             string                `json:"sampling,omitempty"`
 	SlowSteps            []StepAnalysis        `json:"slow_steps"`
 	CacheRecommendations []CacheRecommendation `json:"cache_recommendations"`
+	CacheUsage           *CacheUsage           `json:"cache_usage,omitempty"`
+	WorkflowChain        *WorkflowChain        `json:"workflow_chain,omitempty"`
+	Timeline             *RunTimeline          `json:"timeline,omitempty"` // the newest run analyzed in deep mode, of each workflow when merged
+	Jobs                 []JobNode             `json:"jobs,omitempty"`
+	DORA                 *DORAMetrics          `json:"dora,omitempty"`
+	ForkExposure         *ForkExposure         `json:"fork_exposure,omitempty"`
+	RequiredChecks       *RequiredChecks       `json:"required_checks,omitempty"`
+	MergeQueue           *MergeQueue           `json:"merge_queue,omitempty"`
+	Heatmap              *RunHeatmap           `json:"heatmap,omitempty"`
+	Owners               []string              `json:"owners,omitempty"` // owners of the workflow file from CODEOWNERS
+	Teams                []TeamSummary         `json:"teams,omitempty"`  // findings and cost by owner, in merged reports
+	StarterWorkflows     []StarterWorkflow     `json:"starter_workflows,omitempty"`
+	ReleaseHardening     []ReleaseHardening    `json:"release_hardening,omitempty"`
+	Fingerprints         []WorkflowFingerprint `json:"fingerprints,omitempty"` // structure of the workflow files, to find copies across repositories
 	DockerOptimizations  []DockerOptimization  `json:"docker_optimizations"`
 	CostSavingTips       []string              `json:"cost_saving_tips"`
 	WorkflowAnalysis     *WorkflowAnalysis     `json:"workflow_analysis"`
+	Findings             []Finding             `json:"findings"`
+	Sustainability       *Sustainability       `json:"sustainability,omitempty"`
+	Migrations           []MigrationAdvice     `json:"migrations,omitempty"`
+	Secrets              *SecretsInventory     `json:"secrets,omitempty"`
+	Patches              string                `json:"patches,omitempty"` // unified diff for git apply
+	Partial              bool                  `json:"partial"`
+	CachedAt             *time.Time            `json:"cached_at,omitempty"` // reused from an analysis at this time
+	Digest               *Digest               `json:"digest,omitempty"`    // changes since the previous analysis, in digest mode
+	Adoption             *Adoption             `json:"adoption,omitempty"`  // recommendations adopted since the previous analysis
+	SkippedStages        []string              `json:"skipped_stages,omitempty"`
+	PullRequest          int                   `json:"pull_request,omitempty"`
+	HeadSHA              string                `json:"head_sha,omitempty"`
+	Lang                 i18n.Lang             `json:"lang,omitempty"`
+	Plain                bool                  `json:"-"`
+	Audience             string                `json:"-"` // one of Audiences; empty for developers
+	OutputEncoding       string                `json:"-"` // EncodingRaw or EncodingBase64 for the step outputs
 	Metrics              struct {
 		AverageStepDuration time.Duration `json:"average_step_duration"`
 		MaxStepDuration     time.Duration `json:"max_step_duration"`
 		TotalSteps          int           `json:"total_steps"`
 		FailedSteps         int           `json:"failed_steps"`
 	} `json:"metrics"`
+
+	// savedPerMonth is summed by forAudience for the manager's findings overview,
+	// before the sections it comes from are left out
+	savedPerMonth time.Duration
 }
 
-func (r *PerformanceReport) Output() error {
+// Output renders the report to each target, or to DefaultTargets when none are given
+func (r *PerformanceReport) Output(targets ...Target) error {
 	r.calculateMetrics()
 
-	// JSON 마샬링 시 특수 문자 이스케이프 처리
-	for i, rec := range r.CacheRecommendations {
-		rec.Example = strings.ReplaceAll(rec.Example, "${", "\\${")
-		r.CacheRecommendations[i] = rec
+	if len(targets) == 0 {
+		targets = DefaultTargets
+	}
+	for _, target := range targets {
+		if err := target.write(r); err != nil {
+			return fmt.Errorf("failed to write %s output: %v", target.Format, err)
+		}
 	}
 
-	summary := fmt.Sprintf(`
-╭──────────────────────────────────────────────╮
-│           Workflow Analysis Report            │
-╰──────────────────────────────────────────────╯
-
-📋 Overview
-• Repository: %s
-• Workflow: %s
-• Total Execution Time: %v
+	return nil
+}
 
-`, r.Repository, r.WorkflowFile, r.TotalExecutionTime)
+// Summary renders the report as text in the report's language for its
+// audience, or only the digest in digest mode
+func (r *PerformanceReport) Summary() string {
+	if r.Digest != nil {
+		return r.digestSummary()
+	}
+	r = r.forAudience()
+	t := r.Lang.T
+
+	summary := "\n" + boxHeader(t("Workflow Analysis Report")) + "\n"
+	summary += fmt.Sprintf("📋 %s\n", t("Overview"))
+	summary += fmt.Sprintf("• %s: %s\n", t("Repository"), r.Repository)
+	summary += fmt.Sprintf("• %s: %s\n", t("Workflow"), r.WorkflowFile)
+	if r.PullRequest > 0 {
+		summary += fmt.Sprintf("• %s: #%d\n", t("Pull Request"), r.PullRequest)
+	}
+	summary += fmt.Sprintf("• %s: %v\n", t("Total Execution Time"), r.TotalExecutionTime)
+	for _, line := range r.runStatsLines() {
+		summary += "• " + line + "\n"
+	}
+	if line := r.trendLine(); line != "" {
+		summary += "• " + line + "\n"
+	}
+	if grade, score := r.Grade(); len(r.Findings) > 0 || r.WorkflowAnalysis != nil {
+		summary += fmt.Sprintf("• %s: %s (%d/100)\n", t("Workflow Grade"), grade, score)
+	}
+	if r.Sampling != "" {
+		summary += fmt.Sprintf("• %s: %s\n", t("Sampling"), r.Sampling)
+	}
+	if len(r.Owners) > 0 {
+		summary += fmt.Sprintf("• %s: %s\n", t("Owners"), strings.Join(r.Owners, ", "))
+	}
+	if r.Partial {
+		summary += "⚠️ " + r.Lang.Sprintf("Partial results: the analysis ran out of time or API rate limit before finishing (skipped: %s)", strings.Join(r.SkippedStages, ", ")) + "\n"
+	}
+	if r.CachedAt != nil {
+		summary += "♻️ " + r.Lang.Sprintf("No changes: the workflow file and its runs are unchanged since the analysis at %s, so its report is reused", r.CachedAt.UTC().Format("2006-01-02 15:04 UTC")) + "\n"
+	}
+	summary += "\n"
 
 	if len(r.SlowSteps) > 0 {
-		summary += "🐌 Slow Steps Detected\n"
-		summary += "──────────────────────\n"
+		summary += heading("🐌", t("Slow Steps Detected"))
 		for _, step := range r.SlowSteps {
-			summary += fmt.Sprintf("  • %s (Duration: %v)\n", step.Name, step.ExecutionTime)
+			summary += fmt.Sprintf("  • %s (%s: %v)\n", step.Name, t("Duration"), step.ExecutionTime)
 			for _, rec := range step.Recommendations {
 				summary += fmt.Sprintf("    ↳ %s\n", rec)
 			}
@@ -79,46 +165,135 @@ func (r *PerformanceReport) Output() error {
 	}
 
 	if len(r.CacheRecommendations) > 0 {
-		summary += "🔄 Cache Optimization Tips\n"
-		summary += "─────────────────────────\n"
+		summary += heading("🔄", t("Cache Optimization Tips"))
 		for _, cache := range r.CacheRecommendations {
 			summary += fmt.Sprintf("  • %s\n", cache.Path)
-			summary += fmt.Sprintf("    ↳ What: %s\n", cache.Description)
-			summary += fmt.Sprintf("    ↳ Impact: %s\n", cache.Impact)
+			if cache.Directory != "" {
+				summary += fmt.Sprintf("    ↳ %s: %s\n", t("Directory"), cache.Directory)
+			}
+			summary += fmt.Sprintf("    ↳ %s: %s\n", t("What"), cache.Description)
+			summary += fmt.Sprintf("    ↳ %s: %s\n", t("Impact"), cache.Impact)
+			if cache.EstimatedSavings != nil {
+				summary += fmt.Sprintf("    ↳ %s: %s\n", t("Estimated Savings"), cache.EstimatedSavings.Basis)
+			}
+			if cache.Confidence > 0 {
+				summary += "    ↳ " + r.Lang.Sprintf("Confidence: %.0f%%, drawn from job logs", cache.Confidence*100) + "\n"
+			}
 			if cache.Example != "" {
-				summary += "    ↳ Example:\n"
+				summary += fmt.Sprintf("    ↳ %s:\n", t("Example"))
 				summary += fmt.Sprintf("      ```yaml\n%s\n      ```\n", cache.Example)
 			}
 			summary += "\n"
 		}
 	}
 
+	if c := r.WorkflowChain; c != nil {
+		summary += heading("⛓️", t("Workflow Chain"))
+		for _, stage := range c.Stages {
+			line := fmt.Sprintf("  %s• %s (%s)", strings.Repeat("  ", stage.Depth), stage.Name, stage.File)
+			if stage.Runs > 0 {
+				line += ": " + r.Lang.Sprintf("%v on average over %d runs", stage.AvgDuration.Round(time.Second), stage.Runs)
+			}
+			if stage.Depth > 0 && stage.Runs > 0 {
+				line += ", " + r.Lang.Sprintf("starts %v after the triggering run", stage.AvgWait.Round(time.Second))
+			}
+			summary += line + "\n"
+		}
+		summary += "  ↳ " + r.Lang.Sprintf("End-to-end lead time: %v on average, %v at most over %d runs",
+			c.AvgLeadTime.Round(time.Second), c.MaxLeadTime.Round(time.Second), c.Runs) + "\n\n"
+	}
+
+	if d := r.DORA; d != nil {
+		summary += heading("🚀", t("DORA Metrics"))
+		summary += fmt.Sprintf("  • %s: %s\n", t("Deploy workflows"), strings.Join(d.Workflows, ", "))
+		if len(d.RollbackWorkflows) > 0 {
+			summary += fmt.Sprintf("  • %s: %s\n", t("Rollback workflows"), strings.Join(d.RollbackWorkflows, ", "))
+		}
+		summary += "  • " + r.Lang.Sprintf("Deployment frequency: %.1f per week", d.DeploysPerWeek) + fmt.Sprintf(" (%s)\n", t(d.FrequencyLevel))
+		if d.LeadTimeLevel != "" {
+			summary += "  • " + r.Lang.Sprintf("Lead time for changes: %v median", d.LeadTime.Round(time.Minute)) + fmt.Sprintf(" (%s)\n", t(d.LeadTimeLevel))
+		}
+		summary += "  • " + r.Lang.Sprintf("Change failure rate: %.0f%% (%d of %d deployments)",
+			d.ChangeFailureRate*100, d.FailedDeployments, d.Deployments+d.FailedDeployments) + fmt.Sprintf(" (%s)\n", t(d.FailureRateLevel))
+		if d.RestoreLevel != "" {
+			summary += "  • " + r.Lang.Sprintf("Time to restore: %v median over %d recoveries", d.TimeToRestore.Round(time.Minute), d.Recoveries) + fmt.Sprintf(" (%s)\n", t(d.RestoreLevel))
+		}
+		if d.Rollbacks > 0 {
+			summary += "  • " + r.rollbackLine(d) + "\n"
+		}
+		summary += "  ↳ " + r.Lang.Sprintf("Measured over the last %d days of deploy workflow runs", int(d.Period.Hours()/24)+1) + "\n\n"
+	}
+
+	if r.ForkExposure != nil {
+		summary += r.forkExposureSummary()
+	}
+
+	if r.RequiredChecks != nil {
+		summary += r.requiredChecksSummary()
+	}
+
+	if len(r.ReleaseHardening) > 0 {
+		summary += r.releaseSummary()
+	}
+
+	if r.MergeQueue != nil {
+		summary += r.mergeQueueSummary()
+	}
+
+	if r.Heatmap != nil {
+		summary += r.heatmapSummary()
+	}
+
+	if len(r.Teams) > 0 {
+		summary += r.teamsSummary()
+	}
+
+	if len(r.StarterWorkflows) > 0 {
+		summary += r.starterWorkflowsSummary()
+	}
+
+	if r.CacheUsage != nil {
+		u := r.CacheUsage
+		summary += heading("💾", t("Actions Cache Usage"))
+		summary += "  • " + r.Lang.Sprintf("%s of %s in %d caches", FormatBytes(u.Bytes), FormatBytes(u.LimitBytes), u.Count) + "\n"
+		if u.StaleCount > 0 {
+			summary += "  • " + r.Lang.Sprintf("Stale (unused for 3 days): %d caches, %s", u.StaleCount, FormatBytes(u.StaleBytes)) + "\n"
+		}
+		if u.PullRequestBytes > 0 {
+			summary += "  • " + r.Lang.Sprintf("Saved by pull requests: %s", FormatBytes(u.PullRequestBytes)) + "\n"
+		}
+		for _, rec := range u.Recommendations {
+			summary += fmt.Sprintf("    ↳ %s\n", rec)
+		}
+		summary += "\n"
+	}
+
 	if len(r.DockerOptimizations) > 0 {
-		summary += "🐳 Docker Optimization Tips\n"
-		summary += "──────────────────────────\n"
+		summary += heading("🐳", t("Docker Optimization Tips"))
 		for _, docker := range r.DockerOptimizations {
-			summary += fmt.Sprintf("  • Issue: %s\n", docker.Issue)
-			summary += fmt.Sprintf("    ↳ Solution: %s\n", docker.Suggestion)
-			summary += fmt.Sprintf("    ↳ Expected Improvement: %s\n", docker.Improvement)
+			summary += fmt.Sprintf("  • %s: %s\n", t("Issue"), docker.Issue)
+			summary += fmt.Sprintf("    ↳ %s: %s\n", t("Solution"), docker.Suggestion)
+			summary += fmt.Sprintf("    ↳ %s: %s\n", t("Expected Improvement"), docker.Improvement)
+			if docker.EstimatedSavings != nil {
+				summary += fmt.Sprintf("    ↳ %s: %s\n", t("Estimated Savings"), docker.EstimatedSavings.Basis)
+			}
 			summary += "\n"
 		}
 	}
 
 	if len(r.CostSavingTips) > 0 {
-		summary += "💰 Cost Saving Opportunities\n"
-		summary += "──────────────────────────\n"
+		summary += heading("💰", t("Cost Saving Opportunities"))
 		for _, tip := range r.CostSavingTips {
 			summary += fmt.Sprintf("  • %s\n", tip)
 		}
 		summary += "\n"
 	}
 
-	if r.WorkflowAnalysis != nil {
-		summary += "⚙️ Workflow Structure Analysis\n"
-		summary += "────────────────────────────\n"
+	if a := r.WorkflowAnalysis; a != nil && len(a.Recommendations)+len(a.RunnerOptimizations)+len(a.SecurityTips) > 0 {
+		summary += heading("⚙️", t("Workflow Structure Analysis"))
 
 		if len(r.WorkflowAnalysis.Recommendations) > 0 {
-			summary += "  📝 General Recommendations:\n"
+			summary += fmt.Sprintf("  📝 %s:\n", t("General Recommendations"))
 			for _, rec := range r.WorkflowAnalysis.Recommendations {
 				summary += fmt.Sprintf("    • %s\n", rec)
 			}
@@ -126,7 +301,7 @@ func (r *PerformanceReport) Output() error {
 		}
 
 		if len(r.WorkflowAnalysis.RunnerOptimizations) > 0 {
-			summary += "  🏃 Runner Optimizations:\n"
+			summary += fmt.Sprintf("  🏃 %s:\n", t("Runner Optimizations"))
 			for _, opt := range r.WorkflowAnalysis.RunnerOptimizations {
 				summary += fmt.Sprintf("    • %s\n", opt)
 			}
@@ -134,7 +309,7 @@ func (r *PerformanceReport) Output() error {
 		}
 
 		if len(r.WorkflowAnalysis.SecurityTips) > 0 {
-			summary += "  🔒 Security Recommendations:\n"
+			summary += fmt.Sprintf("  🔒 %s:\n", t("Security Recommendations"))
 			for _, tip := range r.WorkflowAnalysis.SecurityTips {
 				summary += fmt.Sprintf("    • %s\n", tip)
 			}
@@ -142,83 +317,150 @@ func (r *PerformanceReport) Output() error {
 		}
 	}
 
-	summary += "╭──────────────────────────────────────────────╮\n"
-	summary += "│            End of Analysis Report            │\n"
-	summary += "╰──────────────────────────────────────────────╯\n"
-
-	// Write to GitHub Actions output
-	fmt.Println(summary)
-
-	// Set GitHub Actions outputs
-	if err := r.setGitHubOutputs(); err != nil {
-		return fmt.Errorf("failed to set GitHub outputs: %v", err)
+	if c := r.Cost; c != nil {
+		summary += heading("💵", t("Cost Estimate"))
+		for _, entry := range c.Entries {
+			summary += fmt.Sprintf("  • %s: %s\n", entry.Runner, r.Lang.Sprintf("%d billable min × $%.3f = $%.2f", entry.BillableMinutes, entry.RatePerMinute, entry.Cost))
+		}
+		summary += "  • " + r.Lang.Sprintf("Total over %d runs: %d billable min, $%.2f", c.Runs, c.BillableMinutes, c.Cost) + "\n"
+		summary += "  • " + r.Lang.Sprintf("Projected per month: $%.2f", c.MonthlyCost) + "\n"
+		summary += fmt.Sprintf("    ↳ %s: %s\n", t("Assumptions"), c.Assumptions)
+		if len(c.Attribution) > 1 {
+			summary += fmt.Sprintf("  • %s:\n", t("Most expensive branches and pull requests"))
+			for _, a := range c.Attribution {
+				summary += fmt.Sprintf("    ↳ %s: %s\n", r.attributionName(a), r.Lang.Sprintf("%d runs, %d billable min, $%.2f", a.Runs, a.BillableMinutes, a.Cost))
+			}
+		}
+		summary += "\n"
 	}
 
-	return nil
-}
-
-func (r *PerformanceReport) setGitHubOutputs() error {
-	// Convert metrics to JSON
-	metricsSummary, err := json.Marshal(r.Metrics)
-	if err != nil {
-		return fmt.Errorf("failed to marshal metrics: %v", err)
+	if r.Sustainability != nil {
+		s := r.Sustainability
+		summary += heading("🌱", t("Sustainability Estimate"))
+		for _, entry := range s.Entries {
+			summary += fmt.Sprintf("  • %s (%d vCPU, %d GB): %.0f min, %.3f kWh, %.0f gCO2e\n",
+				entry.Runner, entry.VCPU, entry.MemoryGB, entry.Minutes, entry.EnergyKWh, entry.CO2Grams)
+		}
+		summary += "  • " + r.Lang.Sprintf("Total over %d runs: %.0f min, %.3f kWh, %.0f gCO2e", s.Runs, s.Minutes, s.EnergyKWh, s.CO2Grams) + "\n"
+		summary += "  • " + r.Lang.Sprintf("Projected per month: %.2f kWh, %.1f kgCO2e", s.MonthlyEnergyKWh, s.MonthlyCO2Grams/1000) + "\n"
+		summary += fmt.Sprintf("    ↳ %s: %s\n\n", t("Assumptions"), s.Assumptions)
 	}
 
-	// Convert report sections to JSON strings
-	performanceSummary, err := json.Marshal(map[string]interface{}{
-		"repository":       r.Repository,
-		"workflow_file":    r.WorkflowFile,
-		"total_execution":  r.TotalExecutionTime.String(),
-		"slow_steps_count": len(r.SlowSteps),
-	})
-	if err != nil {
-		return err
+	if len(r.Migrations) > 0 {
+		summary += heading("🔀", t("CI Migration Advice"))
+		for _, m := range r.Migrations {
+			summary += fmt.Sprintf("  • %s (%s): %s\n", m.Source, m.File, r.Lang.Sprintf("%d jobs", len(m.Jobs)))
+			for _, job := range m.Jobs {
+				line := fmt.Sprintf("    ↳ %s", job.Name)
+				if len(job.Needs) > 0 {
+					line += " ← " + strings.Join(job.Needs, ", ")
+				}
+				summary += line + "\n"
+			}
+			summary += fmt.Sprintf("  %s:\n", t("Carries over"))
+			for _, mapping := range m.Mappings {
+				if mapping.CarryOver {
+					summary += fmt.Sprintf("    ✓ %s → %s: %s\n", mapping.Source, mapping.Target, mapping.Note)
+				}
+			}
+			summary += fmt.Sprintf("  %s:\n", t("Needs manual rework"))
+			for _, mapping := range m.Mappings {
+				if !mapping.CarryOver {
+					summary += fmt.Sprintf("    ✗ %s → %s: %s\n", mapping.Source, mapping.Target, mapping.Note)
+				}
+			}
+			summary += "\n"
+		}
 	}
 
-	// Escape GitHub expression in cache recommendations
-	for i := range r.CacheRecommendations {
-		r.CacheRecommendations[i].Example = strings.ReplaceAll(
-			r.CacheRecommendations[i].Example,
-			"${{",
-			"${'{'}{",
-		)
+	if r.Patches != "" {
+		summary += heading("🩹", t("Suggested Patches"))
+		summary += fmt.Sprintf("  %s\n", t("Save as a file and apply with git apply after review:"))
+		summary += fmt.Sprintf("```diff\n%s```\n\n", r.Patches)
 	}
 
-	cacheRecs, err := json.Marshal(r.CacheRecommendations)
-	if err != nil {
-		return err
+	if r.Adoption != nil {
+		summary += r.adoptionSummary()
 	}
 
-	dockerOpts, err := json.Marshal(r.DockerOptimizations)
-	if err != nil {
-		return err
+	if r.Audience == AudienceManager {
+		summary += r.overviewSummary()
+	} else if len(r.Findings) > 0 {
+		summary += heading("🔍", t("Workflow Findings"))
+		for _, finding := range r.Findings {
+			summary += fmt.Sprintf("  • [%s] %s\n", finding.Severity, finding.Location())
+			summary += fmt.Sprintf("    ↳ %s\n", finding.Message)
+			if finding.Suggestion != "" {
+				summary += fmt.Sprintf("    ↳ %s\n", finding.Suggestion)
+			}
+			if finding.Confidence > 0 {
+				summary += "    ↳ " + r.Lang.Sprintf("Confidence: %.0f%%, drawn from job logs", finding.Confidence*100) + "\n"
+			}
+			if finding.EstimatedSavings != nil {
+				summary += fmt.Sprintf("    ↳ %s: %s\n", t("Estimated Savings"), finding.EstimatedSavings.Basis)
+			}
+			if finding.URL != "" {
+				summary += fmt.Sprintf("    ↳ %s\n", finding.URL)
+			}
+			if len(finding.Locations) > 1 {
+				summary += fmt.Sprintf("    ↳ %s:\n", r.Lang.Sprintf("Also found in %d more places", len(finding.Locations)-1))
+				for _, pos := range finding.Locations[1:] {
+					summary += fmt.Sprintf("      • %s\n", pos.Location())
+				}
+			}
+			if finding.Example != "" {
+				summary += fmt.Sprintf("    ↳ %s:\n", t("Example"))
+				summary += fmt.Sprintf("      ```yaml\n%s\n      ```\n", finding.Example)
+			}
+		}
+		summary += "\n"
 	}
 
-	// Get GitHub output file path from environment
-	outputFile := os.Getenv("GITHUB_OUTPUT")
-	if outputFile == "" {
-		return fmt.Errorf("GITHUB_OUTPUT environment variable not set")
-	}
+	summary += boxHeader(t("End of Analysis Report"))
 
-	// Open the file for appending
-	f, err := os.OpenFile(outputFile, os.O_APPEND|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open GITHUB_OUTPUT file: %v", err)
+	if r.Plain {
+		summary = toPlainText(summary)
 	}
-	defer f.Close()
+	return summary
+}
 
-	// Write outputs to the file with proper escaping
-	// Use delimiter to safely handle multiline values
-	delimiter := "EOF_" + time.Now().Format("20060102150405")
+// boxWidth is the inner width of the report's header frame
+const boxWidth = 46
+
+// boxHeader renders title centered inside the report's rounded frame
+func boxHeader(title string) string {
+	pad := boxWidth - displayWidth(title)
+	if pad < 0 {
+		pad = 0
+	}
+	left := pad / 2
+	right := pad - left
+	line := strings.Repeat("─", boxWidth)
+	return "╭" + line + "╮\n" +
+		"│" + strings.Repeat(" ", left) + title + strings.Repeat(" ", right) + "│\n" +
+		"╰" + line + "╯\n"
+}
 
-	// Write each output with its own delimiter
-	fmt.Fprintf(f, "metrics_summary<<%s\n%s\n%s\n", delimiter, metricsSummary, delimiter)
-	fmt.Fprintf(f, "performance_summary<<%s\n%s\n%s\n", delimiter, performanceSummary, delimiter)
-	fmt.Fprintf(f, "cache_recommendations<<%s\n%s\n%s\n", delimiter, cacheRecs, delimiter)
-	fmt.Fprintf(f, "docker_optimizations<<%s\n%s\n%s\n", delimiter, dockerOpts, delimiter)
-	fmt.Fprintf(f, "status=success\n")
+// heading renders a section title with an underline matching its width
+func heading(icon, title string) string {
+	text := icon + " " + title
+	return text + "\n" + strings.Repeat("─", displayWidth(text)) + "\n"
+}
 
-	return nil
+// displayWidth approximates the terminal width of s, counting CJK runes as two columns
+func displayWidth(s string) int {
+	width := 0
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\uFE0F':
+			// combining marks and emoji variation selectors take no space
+		case unicode.In(r, unicode.Han, unicode.Hangul, unicode.Hiragana, unicode.Katakana), r >= 0x1F300:
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
 }
 
 func (r *PerformanceReport) calculateMetrics() {
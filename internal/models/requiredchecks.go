@@ -0,0 +1,64 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RequiredChecks maps the status checks the default branch requires, through
+// branch protection or rulesets, to the workflow jobs reporting them
+type RequiredChecks struct {
+	Branch string          `json:"branch"`
+	Checks []RequiredCheck `json:"checks"`
+}
+
+// RequiredCheck is one required status check and the job reporting it. File
+// and Job are empty when no workflow job reports the check.
+type RequiredCheck struct {
+	Context     string        `json:"context"`
+	File        string        `json:"file,omitempty"`
+	Job         string        `json:"job,omitempty"`
+	External    bool          `json:"external,omitempty"`     // reported by another app or CI service
+	AvgDuration time.Duration `json:"avg_duration,omitempty"` // of the analyzed workflow's runs
+	Runs        int           `json:"runs,omitempty"`
+}
+
+// requiredCheckTarget describes what reports a required check
+func (r *PerformanceReport) requiredCheckTarget(c RequiredCheck) string {
+	t := r.Lang.T
+	switch {
+	case c.External:
+		return t("reported outside GitHub Actions")
+	case c.Job == "":
+		return t("no workflow job reports it")
+	case c.Runs > 0:
+		return fmt.Sprintf("%s / %s, ", c.File, c.Job) + r.Lang.Sprintf("%v on average over %d runs", c.AvgDuration.Round(time.Second), c.Runs)
+	default:
+		return fmt.Sprintf("%s / %s", c.File, c.Job)
+	}
+}
+
+// requiredChecksSummary renders the required checks section of the text report
+func (r *PerformanceReport) requiredChecksSummary() string {
+	t, rc := r.Lang.T, r.RequiredChecks
+	summary := heading("🚦", t("Required Status Checks"))
+	summary += fmt.Sprintf("  • %s: %s\n", t("Branch"), rc.Branch)
+	for _, c := range rc.Checks {
+		summary += fmt.Sprintf("  • %s\n    ↳ %s\n", c.Context, r.requiredCheckTarget(c))
+	}
+	return summary + "\n"
+}
+
+// markdownRequiredChecks renders the required checks section as Markdown
+func (r *PerformanceReport) markdownRequiredChecks() string {
+	t, rc := r.Lang.T, r.RequiredChecks
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", t("Required Status Checks"))
+	fmt.Fprintf(&b, "%s: `%s`\n\n", t("Branch"), rc.Branch)
+	fmt.Fprintf(&b, "| %s | %s |\n|---|---|\n", t("Check"), t("Reported by"))
+	for _, c := range rc.Checks {
+		fmt.Fprintf(&b, "| `%s` | %s |\n", c.Context, r.requiredCheckTarget(c))
+	}
+	return b.String()
+}
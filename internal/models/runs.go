@@ -0,0 +1,76 @@
+package models
+
+import "time"
+
+// RunStats aggregates run durations by conclusion. Runs still in progress,
+// cancelled or skipped are only counted, as their durations would skew the timings.
+type RunStats struct {
+	Successful RunDurations  `json:"successful"`
+	Failed     RunDurations  `json:"failed"` // failed or timed out
+	Cancelled  CancelledRuns `json:"cancelled"`
+	Excluded   int           `json:"excluded"` // in progress, skipped or neutral
+}
+
+// CancelledRuns tallies cancelled runs. Superseded runs were cancelled once a newer
+// run of the same branch started, as a concurrency group with cancel-in-progress
+// does; the others were cancelled by hand.
+type CancelledRuns struct {
+	Runs       int           `json:"runs"`
+	Superseded int           `json:"superseded"`
+	Duration   time.Duration `json:"duration"` // run time spent before the cancellations
+	Saved      time.Duration `json:"saved"`    // estimated run time the superseded runs didn't spend
+}
+
+// RunDurations summarizes the durations of a group of runs
+type RunDurations struct {
+	Runs    int           `json:"runs"`
+	Total   time.Duration `json:"total"`
+	Average time.Duration `json:"average"`
+	Max     time.Duration `json:"max"`
+}
+
+// Add records the duration of one run
+func (d *RunDurations) Add(duration time.Duration) {
+	d.Runs++
+	d.Total += duration
+	d.Average = d.Total / time.Duration(d.Runs)
+	d.Max = max(d.Max, duration)
+}
+
+// merge combines the durations of another group of runs
+func (d *RunDurations) merge(other RunDurations) {
+	if other.Runs == 0 {
+		return
+	}
+	d.Runs += other.Runs
+	d.Total += other.Total
+	d.Average = d.Total / time.Duration(d.Runs)
+	d.Max = max(d.Max, other.Max)
+}
+
+// runStatsLines describes the run timings for the report overview
+func (r *PerformanceReport) runStatsLines() []string {
+	s := r.RunStats
+	if s == nil {
+		return nil
+	}
+	var lines []string
+	if s.Successful.Runs > 0 {
+		lines = append(lines, r.Lang.Sprintf("Successful runs: %d, %v on average, %v at most",
+			s.Successful.Runs, s.Successful.Average.Round(time.Second), s.Successful.Max.Round(time.Second)))
+	}
+	if s.Failed.Runs > 0 {
+		lines = append(lines, r.Lang.Sprintf("Failed runs: %d, %v on average, %v at most",
+			s.Failed.Runs, s.Failed.Average.Round(time.Second), s.Failed.Max.Round(time.Second)))
+	}
+	if c := s.Cancelled; c.Superseded > 0 {
+		lines = append(lines, r.Lang.Sprintf("Cancelled runs: %d, %d of them superseded by a newer run of the same branch, avoiding about %v of duplicate work",
+			c.Runs, c.Superseded, c.Saved.Round(time.Second)))
+	} else if c.Runs > 0 {
+		lines = append(lines, r.Lang.Sprintf("Cancelled runs: %d, left out of the timings", c.Runs))
+	}
+	if s.Excluded > 0 {
+		lines = append(lines, r.Lang.Sprintf("Left out of the timings: %d runs in progress or skipped", s.Excluded))
+	}
+	return lines
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+)
+
+// SecretsInventory lists the secrets the analyzed workflows reference, by name
+// only; secret values are never read
+type SecretsInventory struct {
+	Secrets     []SecretUsage `json:"secrets"`
+	InheritedBy []string      `json:"inherited_by,omitempty"` // jobs passing every secret to a reusable workflow with secrets: inherit
+}
+
+// SecretUsage is where one secret is used
+type SecretUsage struct {
+	Name              string   `json:"name"`
+	Jobs              []string `json:"jobs"`                          // <workflow path>:<job id>
+	Actions           []string `json:"actions,omitempty"`             // actions and reusable workflows the secret is passed to
+	ThirdPartyActions []string `json:"third_party_actions,omitempty"` // of those, the ones not from the repository owner, actions or github
+}
+
+// merge adds the usages of another inventory
+func (inv *SecretsInventory) merge(other *SecretsInventory) {
+	byName := make(map[string]*SecretUsage, len(inv.Secrets))
+	for i := range inv.Secrets {
+		byName[inv.Secrets[i].Name] = &inv.Secrets[i]
+	}
+	for _, usage := range other.Secrets {
+		if existing := byName[usage.Name]; existing != nil {
+			existing.Jobs = union(existing.Jobs, usage.Jobs)
+			existing.Actions = union(existing.Actions, usage.Actions)
+			existing.ThirdPartyActions = union(existing.ThirdPartyActions, usage.ThirdPartyActions)
+			continue
+		}
+		inv.Secrets = append(inv.Secrets, usage)
+		byName[usage.Name] = &inv.Secrets[len(inv.Secrets)-1]
+	}
+	sort.Slice(inv.Secrets, func(i, j int) bool { return inv.Secrets[i].Name < inv.Secrets[j].Name })
+	inv.InheritedBy = union(inv.InheritedBy, other.InheritedBy)
+}
+
+// union returns the sorted distinct values of a and b
+func union(a, b []string) []string {
+	seen := make(map[string]bool, len(a)+len(b))
+	var values []string
+	for _, v := range append(append([]string(nil), a...), b...) {
+		if !seen[v] {
+			seen[v] = true
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// SecretsRenderer writes the secrets inventory as indented JSON, for security reviews
+type SecretsRenderer struct{}
+
+func (SecretsRenderer) Render(w io.Writer, r *PerformanceReport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.secretsInventory())
+}
+
+// secretsInventory returns the report's inventory, empty rather than nil
+func (r *PerformanceReport) secretsInventory() *SecretsInventory {
+	if r.Secrets == nil {
+		return &SecretsInventory{Secrets: []SecretUsage{}}
+	}
+	return r.Secrets
+}
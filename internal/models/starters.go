@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// StarterWorkflow is a workflow template of an organization's .github repository
+// with the findings every repository created from it starts out with
+type StarterWorkflow struct {
+	Path       string   `json:"path"`
+	Name       string   `json:"name"`
+	Findings   int      `json:"findings"`
+	Critical   int      `json:"critical"`
+	Warnings   int      `json:"warnings"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// starterLine describes the anti-patterns a template propagates
+func (r *PerformanceReport) starterLine(s StarterWorkflow) string {
+	line := fmt.Sprintf("%s (%s): ", s.Name, s.Path)
+	if s.Findings == 0 {
+		return line + r.Lang.T("no findings")
+	}
+	return line + r.Lang.Sprintf("%d findings (%d critical, %d warnings)", s.Findings, s.Critical, s.Warnings) +
+		" — " + strings.Join(s.Categories, ", ")
+}
+
+// starterWorkflowsSummary renders the starter workflow section of the text report
+func (r *PerformanceReport) starterWorkflowsSummary() string {
+	summary := heading("🧬", r.Lang.T("Starter Workflows"))
+	for _, s := range r.StarterWorkflows {
+		summary += "  • " + r.starterLine(s) + "\n"
+	}
+	summary += "  ↳ " + r.Lang.T("Repositories created from these templates inherit their findings, so fixing a template fixes every new copy") + "\n"
+	return summary + "\n"
+}
+
+// markdownStarterWorkflows renders the starter workflow section as Markdown
+func (r *PerformanceReport) markdownStarterWorkflows() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Starter Workflows"))
+	fmt.Fprintf(&b, "%s\n\n", r.Lang.T("Repositories created from these templates inherit their findings, so fixing a template fixes every new copy"))
+	for _, s := range r.StarterWorkflows {
+		fmt.Fprintf(&b, "- %s\n", r.starterLine(s))
+	}
+	return b.String()
+}
@@ -0,0 +1,24 @@
+package models
+
+// SustainabilityEntry is the estimated footprint of one runner type
+type SustainabilityEntry struct {
+	Runner    string  `json:"runner"`
+	VCPU      int     `json:"vcpu"`
+	MemoryGB  int     `json:"memory_gb"`
+	Minutes   float64 `json:"minutes"`
+	EnergyKWh float64 `json:"energy_kwh"`
+	CO2Grams  float64 `json:"co2_grams"`
+}
+
+// Sustainability estimates the energy use and emissions of the analyzed runs
+type Sustainability struct {
+	Runs             int                   `json:"runs"`
+	Entries          []SustainabilityEntry `json:"entries"`
+	Minutes          float64               `json:"minutes"`
+	EnergyKWh        float64               `json:"energy_kwh"`
+	CO2Grams         float64               `json:"co2_grams"`
+	MonthlyEnergyKWh float64               `json:"monthly_energy_kwh"`
+	MonthlyCO2Grams  float64               `json:"monthly_co2_grams"`
+	GridCarbon       float64               `json:"grid_carbon_g_per_kwh"`
+	Assumptions      string                `json:"assumptions"`
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TeamSummary is the share of several reports' findings and cost that falls to
+// one owner from CODEOWNERS. A workflow owned by several teams counts fully for
+// each of them; Team is empty for workflows and files nobody owns.
+type TeamSummary struct {
+	Team            string   `json:"team"`
+	Repositories    []string `json:"repositories"`
+	Workflows       []string `json:"workflows"`
+	Findings        int      `json:"findings"`
+	Critical        int      `json:"critical"`
+	Warnings        int      `json:"warnings"`
+	BillableMinutes int      `json:"billable_minutes"`
+	Cost            float64  `json:"cost"`
+	MonthlyCost     float64  `json:"monthly_cost"`
+}
+
+// TeamSummaries groups the findings and cost of reports by the teams owning them,
+// the costliest team first. Findings count for the owners of the file they are
+// in and cost for the owners of the workflow. It returns nil when no report has
+// owners, e.g. when the repositories have no CODEOWNERS file.
+func TeamSummaries(reports []*PerformanceReport) []TeamSummary {
+	owned := false
+	for _, r := range reports {
+		owned = owned || len(r.Owners) > 0
+		for _, f := range r.Findings {
+			owned = owned || len(f.Owners) > 0
+		}
+	}
+	if !owned {
+		return nil
+	}
+
+	teams := make(map[string]*TeamSummary)
+	team := func(name string, r *PerformanceReport) *TeamSummary {
+		s := teams[name]
+		if s == nil {
+			s = &TeamSummary{Team: name}
+			teams[name] = s
+		}
+		if !contains(s.Repositories, r.Repository) {
+			s.Repositories = append(s.Repositories, r.Repository)
+		}
+		if !contains(s.Workflows, r.WorkflowFile) {
+			s.Workflows = append(s.Workflows, r.WorkflowFile)
+		}
+		return s
+	}
+	for _, r := range reports {
+		for _, name := range orUnowned(r.Owners) {
+			s := team(name, r)
+			if r.Cost != nil {
+				s.BillableMinutes += r.Cost.BillableMinutes
+				s.Cost += r.Cost.Cost
+				s.MonthlyCost += r.Cost.MonthlyCost
+			}
+		}
+		for _, f := range r.Findings {
+			for _, name := range orUnowned(f.Owners) {
+				s := team(name, r)
+				s.Findings++
+				switch f.Severity {
+				case SeverityCritical:
+					s.Critical++
+				case SeverityWarning:
+					s.Warnings++
+				}
+			}
+		}
+	}
+
+	summaries := make([]TeamSummary, 0, len(teams))
+	for _, s := range teams {
+		summaries = append(summaries, *s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if a.MonthlyCost != b.MonthlyCost {
+			return a.MonthlyCost > b.MonthlyCost
+		}
+		if a.Findings != b.Findings {
+			return a.Findings > b.Findings
+		}
+		return a.Team < b.Team
+	})
+	return summaries
+}
+
+// orUnowned returns owners, or the empty team for files nobody owns
+func orUnowned(owners []string) []string {
+	if len(owners) == 0 {
+		return []string{""}
+	}
+	return owners
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// teamLine describes a team's findings and cost
+func (r *PerformanceReport) teamLine(s TeamSummary) string {
+	name := s.Team
+	if name == "" {
+		name = r.Lang.T("Unowned")
+	}
+	line := name + ": " + r.Lang.Sprintf("%d findings (%d critical, %d warnings) in %d workflows", s.Findings, s.Critical, s.Warnings, len(s.Workflows))
+	if s.MonthlyCost > 0 {
+		line += ", " + r.Lang.Sprintf("$%.2f per month", s.MonthlyCost)
+	}
+	return line
+}
+
+// teamsSummary renders the team section of the text report
+func (r *PerformanceReport) teamsSummary() string {
+	summary := heading("👥", r.Lang.T("Findings and Cost by Team"))
+	for _, s := range r.Teams {
+		summary += "  • " + r.teamLine(s) + "\n"
+	}
+	return summary + "\n"
+}
+
+// markdownTeams renders the team section as Markdown
+func (r *PerformanceReport) markdownTeams() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", r.Lang.T("Findings and Cost by Team"))
+	for _, s := range r.Teams {
+		fmt.Fprintf(&b, "- %s\n", r.teamLine(s))
+	}
+	return b.String()
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// RunTimeline is when each job and step of one run started and finished,
+// relative to the run's start, for the Gantt chart of the HTML report
+type RunTimeline struct {
+	RunID      int64         `json:"run_id"`
+	RunNumber  int           `json:"run_number"`
+	URL        string        `json:"url,omitempty"`
+	Conclusion string        `json:"conclusion"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	Jobs       []TimelineJob `json:"jobs"`
+}
+
+// TimelineJob is a job of the run with its steps. Start is when a runner picked
+// the job up, so the time after the run's start, or its needs, is spent queued.
+type TimelineJob struct {
+	TimelineSpan
+	Runner string         `json:"runner,omitempty"`
+	Steps  []TimelineSpan `json:"steps,omitempty"`
+}
+
+// TimelineSpan is a job or step's name, outcome and offsets from the run's start
+type TimelineSpan struct {
+	Name       string        `json:"name"`
+	Conclusion string        `json:"conclusion"`
+	Start      time.Duration `json:"start"`
+	End        time.Duration `json:"end"`
+}
+
+// Duration returns how long the job or step ran
+func (s TimelineSpan) Duration() time.Duration {
+	return s.End - s.Start
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Trend directions
+const (
+	TrendSlower = "slower"
+	TrendFaster = "faster"
+	TrendStable = "stable"
+)
+
+// trendThreshold is the change in average duration below which a workflow is stable
+const trendThreshold = 0.1
+
+// RunTrend compares the older and the newer half of the analyzed runs. Durations
+// are of successful runs only; failures are counted over successful and failed runs.
+type RunTrend struct {
+	Previous       RunDurations `json:"previous"`
+	Recent         RunDurations `json:"recent"`
+	PreviousFailed int          `json:"previous_failed"`
+	RecentFailed   int          `json:"recent_failed"`
+	PreviousRuns   int          `json:"previous_runs"` // successful and failed
+	RecentRuns     int          `json:"recent_runs"`
+}
+
+// DurationChange is the relative change of the average successful run duration,
+// e.g. 0.25 for 25% slower
+func (t *RunTrend) DurationChange() float64 {
+	if t.Previous.Average == 0 || t.Recent.Runs == 0 {
+		return 0
+	}
+	return float64(t.Recent.Average-t.Previous.Average) / float64(t.Previous.Average)
+}
+
+// Direction says whether runs got slower, faster or stayed about the same
+func (t *RunTrend) Direction() string {
+	switch change := t.DurationChange(); {
+	case change >= trendThreshold:
+		return TrendSlower
+	case change <= -trendThreshold:
+		return TrendFaster
+	}
+	return TrendStable
+}
+
+// FailureRates returns the share of failed runs in the older and the newer half
+func (t *RunTrend) FailureRates() (previous, recent float64) {
+	if t.PreviousRuns > 0 {
+		previous = float64(t.PreviousFailed) / float64(t.PreviousRuns)
+	}
+	if t.RecentRuns > 0 {
+		recent = float64(t.RecentFailed) / float64(t.RecentRuns)
+	}
+	return previous, recent
+}
+
+// summary is the trend as a flat object for step outputs
+func (t *RunTrend) summary() map[string]interface{} {
+	previousRate, recentRate := t.FailureRates()
+	return map[string]interface{}{
+		"direction":             t.Direction(),
+		"duration_change_pct":   int(t.DurationChange() * 100),
+		"previous_average":      t.Previous.Average.Round(time.Second).String(),
+		"recent_average":        t.Recent.Average.Round(time.Second).String(),
+		"previous_failure_rate": previousRate,
+		"recent_failure_rate":   recentRate,
+		"runs":                  t.PreviousRuns + t.RecentRuns,
+	}
+}
+
+// merge adds the trend of another workflow
+func (t *RunTrend) merge(other *RunTrend) {
+	t.Previous.merge(other.Previous)
+	t.Recent.merge(other.Recent)
+	t.PreviousFailed += other.PreviousFailed
+	t.RecentFailed += other.RecentFailed
+	t.PreviousRuns += other.PreviousRuns
+	t.RecentRuns += other.RecentRuns
+}
+
+// trendLine describes the trend for the report overview
+func (r *PerformanceReport) trendLine() string {
+	t := r.Trend
+	if t == nil {
+		return ""
+	}
+	var line string
+	change := int(math.Round(math.Abs(t.DurationChange()) * 100))
+	recent, previous := t.Recent.Average.Round(time.Second), t.Previous.Average.Round(time.Second)
+	switch t.Direction() {
+	case TrendSlower:
+		line = r.Lang.Sprintf("Trend: recent successful runs are %d%% slower, %v on average against %v before", change, recent, previous)
+	case TrendFaster:
+		line = r.Lang.Sprintf("Trend: recent successful runs are %d%% faster, %v on average against %v before", change, recent, previous)
+	default:
+		line = r.Lang.T("Trend: run times are stable")
+	}
+	previousRate, recentRate := t.FailureRates()
+	return line + "; " + r.Lang.Sprintf("failure rate %.0f%%, was %.0f%%", recentRate*100, previousRate*100)
+}
@@ -0,0 +1,160 @@
+package patch
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around each change, as in diff -u
+const contextLines = 3
+
+// Edit replaces Delete lines starting at Line (1-based) with Insert. Line may be
+// one past the last line to append to the file.
+type Edit struct {
+	Line   int
+	Delete int
+	Insert []string
+}
+
+// end returns the line after the last line the edit removes
+func (e Edit) end() int {
+	return e.Line + e.Delete
+}
+
+// hunkLine is one line of a hunk body
+type hunkLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// Unified renders edits to a file as a unified diff that git apply accepts.
+// Edits must not overlap. It returns "" when there is nothing to change.
+func Unified(path, content string, edits []Edit) string {
+	if len(edits) == 0 {
+		return ""
+	}
+
+	lines := strings.Split(content, "\n")
+	missingNewline := !strings.HasSuffix(content, "\n")
+	if !missingNewline {
+		lines = lines[:len(lines)-1]
+	}
+	n := len(lines)
+
+	edits = append([]Edit(nil), edits...)
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].Line < edits[j].Line })
+	if last := &edits[len(edits)-1]; missingNewline && n > 0 && last.Line == n+1 {
+		// Appending gives the old last line a newline, so it changes too
+		last.Line, last.Delete = n, 1
+		last.Insert = append([]string{lines[n-1]}, last.Insert...)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", path, path, path, path)
+
+	offset := 0 // lines added minus removed by earlier hunks
+	for i := 0; i < len(edits); {
+		// Group edits whose context would overlap into one hunk
+		group := []Edit{edits[i]}
+		for i++; i < len(edits) && edits[i].Line-group[len(group)-1].end() <= 2*contextLines; i++ {
+			group = append(group, edits[i])
+		}
+
+		start := max(1, group[0].Line-contextLines)
+		stop := min(n, group[len(group)-1].end()-1+contextLines)
+
+		var body []hunkLine
+		next := 0
+		for line := start; line <= stop+1; line++ {
+			if next < len(group) && group[next].Line == line {
+				edit := group[next]
+				for d := 0; d < edit.Delete; d++ {
+					body = append(body, hunkLine{'-', lines[line-1+d]})
+				}
+				for _, text := range edit.Insert {
+					body = append(body, hunkLine{'+', text})
+				}
+				line += edit.Delete - 1
+				next++
+				continue
+			}
+			if line <= stop {
+				body = append(body, hunkLine{' ', lines[line-1]})
+			}
+		}
+
+		oldCount, newCount := 0, 0
+		for _, l := range body {
+			if l.op != '+' {
+				oldCount++
+			}
+			if l.op != '-' {
+				newCount++
+			}
+		}
+		oldStart, newStart := start, start+offset
+		if oldCount == 0 {
+			oldStart--
+		}
+		if newCount == 0 {
+			newStart--
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+
+		// The old and new files end at the last '-'/' ' and '+'/' ' lines of the final hunk
+		lastOld, lastNew := -1, -1
+		if missingNewline && stop == n {
+			for j, l := range body {
+				if l.op != '+' {
+					lastOld = j
+				}
+				if l.op != '-' {
+					lastNew = j
+				}
+			}
+		}
+		for j, l := range body {
+			fmt.Fprintf(&b, "%c%s\n", l.op, l.text)
+			if j == lastOld || (j == lastNew && l.op == '+') {
+				b.WriteString("\\ No newline at end of file\n")
+			}
+		}
+		offset += newCount - oldCount
+	}
+	return b.String()
+}
+
+// hunkRange formats a hunk's start and line count, omitting a count of one
+func hunkRange(start, count int) string {
+	if count == 1 {
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
+
+// Apply returns content with edits made, keeping whether it ends in a newline.
+// Edits must not overlap.
+func Apply(content string, edits []Edit) string {
+	lines := strings.Split(content, "\n")
+	trailingNewline := strings.HasSuffix(content, "\n")
+	if trailingNewline {
+		lines = lines[:len(lines)-1]
+	}
+
+	edits = append([]Edit(nil), edits...)
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].Line < edits[j].Line })
+	// From the bottom up, so the line numbers of the remaining edits stay valid
+	for i := len(edits) - 1; i >= 0; i-- {
+		edit := edits[i]
+		start := min(edit.Line-1, len(lines))
+		end := min(edit.end()-1, len(lines))
+		lines = append(append(append([]string(nil), lines[:start]...), edit.Insert...), lines[end:]...)
+	}
+
+	result := strings.Join(lines, "\n")
+	if trailingNewline {
+		result += "\n"
+	}
+	return result
+}
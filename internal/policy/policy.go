@@ -0,0 +1,135 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is an organization's rules for workflows, read from a YAML file:
+//
+//	runners:
+//	  allowed: [ubuntu-24.04, ubuntu-latest, self-hosted, linux]
+//	actions:
+//	  banned: [actions-rs/*]
+//	  allowed: [actions/*, docker/*, my-org/*]
+//	permissions:
+//	  require_explicit: true
+//	  forbid_write_all: true
+//	  max:
+//	    contents: read
+//
+// Runner labels and actions are matched as path.Match patterns. Empty lists
+// don't restrict anything.
+type Policy struct {
+	Runners struct {
+		Allowed []string `yaml:"allowed"`
+	} `yaml:"runners"`
+	Actions struct {
+		Banned  []string `yaml:"banned"`
+		Allowed []string `yaml:"allowed"`
+	} `yaml:"actions"`
+	Permissions struct {
+		RequireExplicit bool              `yaml:"require_explicit"`
+		ForbidWriteAll  bool              `yaml:"forbid_write_all"`
+		Max             map[string]string `yaml:"max"`
+	} `yaml:"permissions"`
+}
+
+// levels orders permission levels
+var levels = map[string]int{"none": 0, "read": 1, "write": 2}
+
+// Load reads and validates the policy file at file
+func Load(file string) (*Policy, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %v", err)
+	}
+	var p Policy
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy %s: %v", file, err)
+	}
+
+	patterns := append(append(append([]string(nil), p.Runners.Allowed...), p.Actions.Banned...), p.Actions.Allowed...)
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %q in policy %s: %v", pattern, file, err)
+		}
+	}
+	for scope, level := range p.Permissions.Max {
+		if _, ok := levels[level]; !ok {
+			return nil, fmt.Errorf("invalid level %q for permission %s in policy %s, use none, read or write", level, scope, file)
+		}
+	}
+	return &p, nil
+}
+
+// matchAny reports whether value matches one of the patterns
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsRunner reports whether a runner label is allowed
+func (p *Policy) AllowsRunner(label string) bool {
+	return len(p.Runners.Allowed) == 0 || matchAny(p.Runners.Allowed, label)
+}
+
+// AllowsAction reports whether an action or reusable workflow reference is
+// allowed. Patterns match the reference with and without its @ref, and the
+// owner/repo it lives in, so "owner/*", "owner/action@v1" and
+// "owner/repo/.github/workflows/*" all work. Local actions are always allowed.
+func (p *Policy) AllowsAction(uses string) bool {
+	if strings.HasPrefix(uses, "./") {
+		return true
+	}
+	name, _, _ := strings.Cut(uses, "@")
+	repo := name
+	if parts := strings.SplitN(name, "/", 3); len(parts) == 3 {
+		repo = parts[0] + "/" + parts[1]
+	}
+	matches := func(patterns []string) bool {
+		return matchAny(patterns, uses) || matchAny(patterns, name) || matchAny(patterns, repo)
+	}
+	if matches(p.Actions.Banned) {
+		return false
+	}
+	return len(p.Actions.Allowed) == 0 || matches(p.Actions.Allowed)
+}
+
+// ExceededByAll returns the scopes, sorted, whose maximum a grant of every scope
+// at once goes beyond, as permissions: write-all or read-all do
+func (p *Policy) ExceededByAll(level string) []string {
+	var scopes []string
+	for scope := range p.Permissions.Max {
+		if _, exceeds := p.ExceedsMax(scope, strings.TrimSuffix(level, "-all")); exceeds {
+			scopes = append(scopes, scope)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// ExceedsMax reports whether granting level for scope goes beyond the policy's
+// maximum, returning the maximum
+func (p *Policy) ExceedsMax(scope, level string) (string, bool) {
+	max, ok := p.Permissions.Max[scope]
+	if !ok {
+		return "", false
+	}
+	granted, ok := levels[level]
+	if !ok {
+		return max, false
+	}
+	return max, granted > levels[max]
+}
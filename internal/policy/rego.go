@@ -0,0 +1,145 @@
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+)
+
+// RegoPackage is the package custom Rego policies declare their rules in
+const RegoPackage = "analyzer"
+
+// Rego holds the compiled Rego policies of a directory. Policies add violations
+// to the deny and warn sets, either as a message or as an object:
+//
+//	package analyzer
+//
+//	deny contains {"msg": msg, "job": job.id} if {
+//		some job in input.workflow.jobs
+//		"self-hosted" in job.runs_on
+//		not job.environment
+//		msg := sprintf("job %s runs on a self-hosted runner outside an environment", [job.id])
+//	}
+type Rego struct {
+	query  rego.PreparedEvalQuery
+	digest string
+}
+
+// Violation is one entry of a policy's deny or warn set
+type Violation struct {
+	Rule       string `json:"-"` // deny or warn
+	Message    string `json:"msg"`
+	Job        string `json:"job,omitempty"`
+	Line       int    `json:"line,omitempty"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// LoadRego compiles the .rego files under dir, skipping _test.rego files
+func LoadRego(ctx context.Context, dir string) (*Rego, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(path, ".rego") && !strings.HasSuffix(path, "_test.rego") {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policies: %v", err)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .rego files in %s", dir)
+	}
+
+	options := []func(*rego.Rego){rego.Query("data." + RegoPackage)}
+	hash := sha256.New()
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy: %v", err)
+		}
+		options = append(options, rego.Module(file, string(content)))
+		fmt.Fprintf(hash, "%s\n%s\n", file, content)
+	}
+	query, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile policies: %v", err)
+	}
+	return &Rego{query: query, digest: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// Digest identifies the loaded policies, changing whenever a policy file does
+func (r *Rego) Digest() string {
+	return r.digest
+}
+
+// Evaluate runs the policies against input and returns their violations, deny
+// rules first
+func (r *Rego) Evaluate(ctx context.Context, input interface{}) ([]Violation, error) {
+	results, err := r.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate policies: %v", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return nil, nil
+	}
+	rules, ok := results[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	var violations []Violation
+	for _, rule := range []string{"deny", "warn"} {
+		entries, ok := rules[rule].([]interface{})
+		if !ok {
+			continue
+		}
+		var found []Violation
+		for _, entry := range entries {
+			v, err := violation(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s entry %v: %v", rule, entry, err)
+			}
+			v.Rule = rule
+			found = append(found, v)
+		}
+		// Sets have no order, so sort for stable reports
+		sort.Slice(found, func(i, j int) bool {
+			if found[i].Line != found[j].Line {
+				return found[i].Line < found[j].Line
+			}
+			return found[i].Message < found[j].Message
+		})
+		violations = append(violations, found...)
+	}
+	return violations, nil
+}
+
+// violation converts a deny or warn entry, a message or an object, to a Violation
+func violation(entry interface{}) (Violation, error) {
+	if msg, ok := entry.(string); ok {
+		return Violation{Message: msg}, nil
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return Violation{}, err
+	}
+	var v Violation
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Violation{}, err
+	}
+	if v.Message == "" {
+		return Violation{}, fmt.Errorf("missing msg")
+	}
+	return v, nil
+}
@@ -0,0 +1,117 @@
+package pricing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Pricing is a table of per-minute runner prices in USD, read from a YAML file,
+// for self-hosted infrastructure or negotiated enterprise rates:
+//
+//	runners:
+//	  - name: gpu
+//	    labels: [self-hosted, gpu]
+//	    per_minute: 0.12
+//	  - labels: [self-hosted]
+//	    per_minute: 0.002
+//	  - labels: [ubuntu-*]
+//	    per_minute: 0.006
+//	discount: 0.2
+//
+// A job is priced by the first runner whose label patterns, matched as
+// path.Match patterns, each match one of the job's labels. Other jobs get
+// GitHub's list prices, less the discount.
+type Pricing struct {
+	Runners  []Runner `yaml:"runners"`
+	Discount float64  `yaml:"discount"`
+
+	// Source is the file the pricing was read from
+	Source string `yaml:"-"`
+}
+
+// Runner prices the jobs running on matching labels
+type Runner struct {
+	Name      string   `yaml:"name"` // shown in the report, the labels when empty
+	Labels    []string `yaml:"labels"`
+	PerMinute float64  `yaml:"per_minute"`
+}
+
+// Load reads and validates the pricing file at file
+func Load(file string) (*Pricing, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing: %v", err)
+	}
+	var p Pricing
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse pricing %s: %v", file, err)
+	}
+
+	for i, r := range p.Runners {
+		if len(r.Labels) == 0 {
+			return nil, fmt.Errorf("runner %d in pricing %s has no labels", i+1, file)
+		}
+		for _, pattern := range r.Labels {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in pricing %s: %v", pattern, file, err)
+			}
+		}
+		if r.PerMinute < 0 {
+			return nil, fmt.Errorf("negative per_minute price %v in pricing %s", r.PerMinute, file)
+		}
+	}
+	if p.Discount < 0 || p.Discount >= 1 {
+		return nil, fmt.Errorf("invalid discount %v in pricing %s, use a fraction from 0 to 1, e.g. 0.2", p.Discount, file)
+	}
+	p.Source = file
+	return &p, nil
+}
+
+// Digest identifies the rates, changing whenever a runner's labels or price or
+// the discount do
+func (p *Pricing) Digest() string {
+	h := sha256.New()
+	for _, r := range p.Runners {
+		fmt.Fprintf(h, "%s|%s|%g\n", r.Name, strings.Join(r.Labels, ","), r.PerMinute)
+	}
+	fmt.Fprintf(h, "%g", p.Discount)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Match returns the runner pricing the jobs on labels, if any
+func (p *Pricing) Match(labels []string) (Runner, bool) {
+	for _, r := range p.Runners {
+		if matchAll(r.Labels, labels) {
+			if r.Name == "" {
+				r.Name = strings.Join(r.Labels, ",")
+			}
+			return r, true
+		}
+	}
+	return Runner{}, false
+}
+
+// matchAll reports whether each pattern matches one of the labels
+func matchAll(patterns, labels []string) bool {
+	for _, pattern := range patterns {
+		found := false
+		for _, label := range labels {
+			if ok, _ := path.Match(pattern, label); ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,385 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/store"
+)
+
+const (
+	// queueSize is the number of jobs that may wait for a worker
+	queueSize = 100
+	// maxFinishedJobs is how many finished jobs are kept for their results
+	maxFinishedJobs = 1000
+	// shutdownGrace is how long in-flight requests get to finish on shutdown
+	shutdownGrace = 10 * time.Second
+)
+
+// Job states
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Analyzer runs one analysis; *analyzer.Analyzer satisfies it
+type Analyzer interface {
+	Analyze(ctx context.Context, owner, repo, workflowFile string) (*models.PerformanceReport, error)
+}
+
+// Job is an analysis requested through the API
+type Job struct {
+	ID         string                    `json:"id"`
+	Repository string                    `json:"repository"`
+	Workflow   string                    `json:"workflow"`
+	Status     string                    `json:"status"`
+	Error      string                    `json:"error,omitempty"`
+	CreatedAt  time.Time                 `json:"created_at"`
+	StartedAt  *time.Time                `json:"started_at,omitempty"`
+	FinishedAt *time.Time                `json:"finished_at,omitempty"`
+	Report     *models.PerformanceReport `json:"report,omitempty"`
+}
+
+// analyzeRequest is the body of POST /analyze
+type analyzeRequest struct {
+	Repo     string `json:"repo"`
+	Workflow string `json:"workflow"`
+}
+
+// Server exposes the analyzer as an HTTP API. Analyses run asynchronously on a
+// fixed number of workers; clients poll the job they get back for the result.
+type Server struct {
+	analyzer Analyzer
+	workers  int
+	queue    chan *Job
+
+	apiToken      []byte
+	webhookSecret []byte
+	store         *store.Store
+
+	mu       sync.Mutex
+	jobs     map[string]*Job
+	queued   map[string]*Job // queued jobs by repository and workflow
+	finished []string        // IDs of finished jobs, oldest first
+	stats    map[string]*workflowStats
+}
+
+// Option configures optional Server behaviour
+type Option func(*Server)
+
+// WithAPIToken requires API requests to send token as a bearer token
+func WithAPIToken(token string) Option {
+	return func(s *Server) {
+		s.apiToken = []byte(token)
+	}
+}
+
+// WithWebhookSecret enables the webhook endpoint, which rejects deliveries not
+// signed with secret. Without a secret the endpoint isn't served, since anyone
+// could post forged runs to it.
+func WithWebhookSecret(secret string) Option {
+	return func(s *Server) {
+		s.webhookSecret = []byte(secret)
+	}
+}
+
+// WithStore persists analyses, their findings and webhook runs in st, and restores
+// the rolling statistics from it on startup
+func WithStore(st *store.Store) Option {
+	return func(s *Server) {
+		s.store = st
+	}
+}
+
+// New creates a server running at most workers analyses at once
+func New(analyzer Analyzer, workers int, opts ...Option) *Server {
+	s := &Server{
+		analyzer: analyzer,
+		workers:  max(workers, 1),
+		queue:    make(chan *Job, queueSize),
+		jobs:     make(map[string]*Job),
+		queued:   make(map[string]*Job),
+		stats:    make(map[string]*workflowStats),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.store != nil {
+		s.restoreStats()
+	}
+	return s
+}
+
+// Handler returns the API routes
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /analyze", s.authorized(s.handleAnalyze))
+	mux.HandleFunc("GET /jobs/{id}", s.authorized(s.handleJob))
+	mux.HandleFunc("GET /jobs/{id}/report", s.authorized(s.handleReport))
+	if len(s.webhookSecret) > 0 {
+		mux.HandleFunc("POST /webhook", s.handleWebhook)
+	}
+	mux.HandleFunc("GET /stats", s.authorized(s.handleStats))
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+// authorized rejects requests without the API token. Without a token set,
+// every request is rejected rather than served to anyone.
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || len(s.apiToken) == 0 || subtle.ConstantTimeCompare([]byte(token), s.apiToken) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "missing or invalid API token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// ListenAndServe starts the workers and serves the API on addr until ctx is
+// cancelled. It returns right away when addr can't be listened on.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", addr, err)
+	}
+
+	// Workers stop when serving fails as well as when ctx is cancelled
+	workCtx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	for i := 0; i < s.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(workCtx)
+		}()
+	}
+	defer wg.Wait()
+	defer cancel()
+
+	srv := &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.Serve(ln)
+	}()
+	log.Printf("Serving the analyzer API on %s", addr)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownGrace)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("failed to shut down: %v", err)
+		}
+		return nil
+	}
+}
+
+// work runs queued jobs until ctx is cancelled
+func (s *Server) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.queue:
+			s.run(ctx, job)
+		}
+	}
+}
+
+// run analyzes a job's workflow and records the outcome
+func (s *Server) run(ctx context.Context, job *Job) {
+	s.update(job, func() {
+		now := time.Now()
+		job.Status, job.StartedAt = StatusRunning, &now
+		delete(s.queued, workflowKey(job.Repository, job.Workflow))
+	})
+
+	owner, repo, _ := strings.Cut(job.Repository, "/")
+	report, err := s.analyzer.Analyze(ctx, owner, repo, job.Workflow)
+
+	s.update(job, func() {
+		now := time.Now()
+		job.FinishedAt = &now
+		if err != nil {
+			job.Status, job.Error = StatusFailed, err.Error()
+		} else {
+			job.Status, job.Report = StatusSucceeded, report
+		}
+		s.finished = append(s.finished, job.ID)
+		for len(s.finished) > maxFinishedJobs {
+			delete(s.jobs, s.finished[0])
+			s.finished = s.finished[1:]
+		}
+	})
+
+	if s.store != nil {
+		s.mu.Lock()
+		analysis := &store.Analysis{
+			ID: job.ID, Repository: job.Repository, Workflow: job.Workflow, Status: job.Status, Error: job.Error,
+			CreatedAt: job.CreatedAt, FinishedAt: job.FinishedAt, Report: job.Report,
+		}
+		s.mu.Unlock()
+		if err := s.store.SaveAnalysis(context.Background(), analysis); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+}
+
+// update changes a job while holding the lock
+func (s *Server) update(job *Job, change func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	change()
+}
+
+// Submit queues an analysis of a workflow, or returns the analysis of it that is
+// already waiting. It fails when the queue is full.
+func (s *Server) Submit(repository, workflow string) (*Job, error) {
+	id, err := newJobID()
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{ID: id, Repository: repository, Workflow: workflow, Status: StatusQueued, CreatedAt: time.Now()}
+	key := workflowKey(repository, workflow)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if waiting := s.queued[key]; waiting != nil {
+		return waiting, nil
+	}
+	select {
+	case s.queue <- job:
+		s.jobs[id] = job
+		s.queued[key] = job
+		return job, nil
+	default:
+		return nil, errQueueFull
+	}
+}
+
+// errQueueFull is returned by Submit when every queue slot is taken
+var errQueueFull = errors.New("too many queued analyses, try again later")
+
+// handleAnalyze queues the requested analysis and answers with the job to poll
+func (s *Server) handleAnalyze(w http.ResponseWriter, r *http.Request) {
+	var req analyzeRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if owner, repo, ok := strings.Cut(req.Repo, "/"); !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("repo must have the format owner/repo, got %q", req.Repo))
+		return
+	}
+	if !strings.HasSuffix(req.Workflow, ".yml") && !strings.HasSuffix(req.Workflow, ".yaml") {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("workflow must be a .yml or .yaml file, got %q", req.Workflow))
+		return
+	}
+
+	job, err := s.Submit(req.Repo, req.Workflow)
+	if errors.Is(err, errQueueFull) {
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	s.writeJob(w, http.StatusAccepted, job)
+}
+
+// handleJob returns a job's status, with the report once it succeeded
+func (s *Server) handleJob(w http.ResponseWriter, r *http.Request) {
+	job := s.job(r.PathValue("id"))
+	if job == nil {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	s.writeJob(w, http.StatusOK, job)
+}
+
+// handleReport returns a succeeded job's report as text, or as plain text without
+// emoji with ?plain=true. ?audience= picks the sections as the audience input does.
+func (s *Server) handleReport(w http.ResponseWriter, r *http.Request) {
+	job := s.job(r.PathValue("id"))
+	if job == nil {
+		writeError(w, http.StatusNotFound, "job not found")
+		return
+	}
+	s.mu.Lock()
+	report, status := job.Report, job.Status
+	s.mu.Unlock()
+	if report == nil {
+		writeError(w, http.StatusConflict, fmt.Sprintf("job is %s", status))
+		return
+	}
+
+	text := *report
+	text.Plain = r.URL.Query().Get("plain") == "true"
+	text.Audience = r.URL.Query().Get("audience")
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, text.Summary())
+}
+
+// job looks up a job by ID
+func (s *Server) job(id string) *Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.jobs[id]
+}
+
+// writeJob encodes a snapshot of the job taken under the lock
+func (s *Server) writeJob(w http.ResponseWriter, status int, job *Job) {
+	s.mu.Lock()
+	snapshot := *job
+	s.mu.Unlock()
+	writeJSON(w, status, snapshot)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Warning: failed to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// workflowKey identifies a workflow across repositories
+func workflowKey(repository, workflow string) string {
+	return repository + "/" + workflow
+}
+
+// newJobID returns a random job identifier
+func newJobID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate job ID: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,280 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+const (
+	// maxWebhookPayload is the largest delivery GitHub sends
+	maxWebhookPayload = 25 << 20
+	// statsWindow is the number of latest runs the rolling statistics cover
+	statsWindow = 50
+	// seenRuns is the number of latest run attempts remembered per workflow to
+	// ignore redelivered webhooks
+	seenRuns = 20 * statsWindow
+)
+
+// runRecord is a completed run reported by a webhook delivery
+type runRecord struct {
+	id         int64
+	attempt    int
+	conclusion string
+	duration   time.Duration
+	finishedAt time.Time
+}
+
+// workflowStats accumulates the runs of one workflow reported by webhooks
+type workflowStats struct {
+	repository  string
+	workflow    string
+	runs        int
+	conclusions map[string]int
+	recent      []runRecord // oldest first, at most statsWindow
+	last        runRecord
+	cancelled   int
+	lastJobID   string
+	seen        map[runAttempt]bool // whether the attempt's analysis was queued
+	seenOrder   []runAttempt        // oldest first, at most seenRuns
+}
+
+// runAttempt identifies one attempt of a run; a re-run is a new attempt
+type runAttempt struct {
+	id      int64
+	attempt int
+}
+
+// add records a run, dropping the oldest one beyond the window, and reports
+// whether it still has to be analyzed. Attempts already recorded, e.g. from a
+// redelivered webhook, are counted once and analyzed again only if queueing
+// their analysis failed. Cancelled runs are only tallied, so they don't skew
+// the durations and the failure rate.
+func (w *workflowStats) add(run runRecord) bool {
+	key := runAttempt{run.id, run.attempt}
+	if queued, ok := w.seen[key]; ok {
+		w.seen[key] = true
+		return !queued
+	}
+	w.seen[key] = true
+	w.seenOrder = append(w.seenOrder, key)
+	if len(w.seenOrder) > seenRuns {
+		delete(w.seen, w.seenOrder[0])
+		w.seenOrder = w.seenOrder[1:]
+	}
+
+	w.runs++
+	w.conclusions[run.conclusion]++
+	w.last = run
+	if run.conclusion == "cancelled" {
+		w.cancelled++
+		return true
+	}
+	w.recent = append(w.recent, run)
+	if len(w.recent) > statsWindow {
+		w.recent = w.recent[len(w.recent)-statsWindow:]
+	}
+	return true
+}
+
+// WorkflowStats are the rolling statistics of a workflow served by GET /stats
+type WorkflowStats struct {
+	Repository     string         `json:"repository"`
+	Workflow       string         `json:"workflow"`
+	Runs           int            `json:"runs"` // runs reported since the server started, plus those restored from the store
+	Conclusions    map[string]int `json:"conclusions"`
+	Cancelled      int            `json:"cancelled"` // left out of the figures below
+	Window         int            `json:"window"`    // latest runs the figures below cover, cancelled ones aside
+	AvgDuration    time.Duration  `json:"avg_duration"`
+	P50Duration    time.Duration  `json:"p50_duration"`
+	P95Duration    time.Duration  `json:"p95_duration"`
+	FailureRate    float64        `json:"failure_rate"`
+	LastRunID      int64          `json:"last_run_id"`
+	LastConclusion string         `json:"last_conclusion"`
+	LastFinishedAt time.Time      `json:"last_finished_at"`
+	LastJobID      string         `json:"last_job_id,omitempty"` // analysis of the latest run
+}
+
+// snapshot computes the public statistics over the window
+func (w *workflowStats) snapshot() WorkflowStats {
+	stats := WorkflowStats{
+		Repository:  w.repository,
+		Workflow:    w.workflow,
+		Runs:        w.runs,
+		Conclusions: make(map[string]int, len(w.conclusions)),
+		Cancelled:   w.cancelled,
+		Window:      len(w.recent),
+		LastJobID:   w.lastJobID,
+	}
+	for conclusion, n := range w.conclusions {
+		stats.Conclusions[conclusion] = n
+	}
+	stats.LastRunID, stats.LastConclusion, stats.LastFinishedAt = w.last.id, w.last.conclusion, w.last.finishedAt
+	if len(w.recent) == 0 {
+		return stats
+	}
+
+	durations := make([]time.Duration, 0, len(w.recent))
+	var total time.Duration
+	failures := 0
+	for _, run := range w.recent {
+		durations = append(durations, run.duration)
+		total += run.duration
+		if run.conclusion == "failure" || run.conclusion == "timed_out" {
+			failures++
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	stats.AvgDuration = total / time.Duration(len(durations))
+	stats.P50Duration = percentile(durations, 0.5)
+	stats.P95Duration = percentile(durations, 0.95)
+	stats.FailureRate = float64(failures) / float64(len(w.recent))
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
+
+// handleWebhook takes workflow_run deliveries: every completed run updates its
+// workflow's rolling statistics and queues an analysis of the workflow
+func (s *Server) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxWebhookPayload)
+	payload, err := gh.ValidatePayload(r, s.webhookSecret)
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, fmt.Sprintf("invalid webhook delivery: %v", err))
+		return
+	}
+	if gh.WebHookType(r) != "workflow_run" {
+		// ping and any other events the hook is subscribed to
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	var event gh.WorkflowRunEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid workflow_run payload: %v", err))
+		return
+	}
+	// Runs of dynamic workflows such as code scanning have no workflow file to analyze
+	workflowPath := event.GetWorkflow().GetPath()
+	if event.GetAction() != "completed" || (!strings.HasSuffix(workflowPath, ".yml") && !strings.HasSuffix(workflowPath, ".yaml")) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	repository, workflow := event.GetRepo().GetFullName(), path.Base(workflowPath)
+	if !strings.Contains(repository, "/") {
+		writeError(w, http.StatusBadRequest, "workflow_run payload has no repository")
+		return
+	}
+
+	run := event.GetWorkflowRun()
+	if s.store != nil {
+		if err := s.store.SaveRuns(r.Context(), repository, workflow, []*gh.WorkflowRun{run}); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	started := run.GetRunStartedAt().Time
+	if started.IsZero() {
+		started = run.GetCreatedAt().Time
+	}
+	recorded := s.recordRun(repository, workflow, runRecord{
+		id:         run.GetID(),
+		attempt:    run.GetRunAttempt(),
+		conclusion: run.GetConclusion(),
+		duration:   run.GetUpdatedAt().Sub(started),
+		finishedAt: run.GetUpdatedAt().Time,
+	})
+	if !recorded {
+		// A redelivery of a run already counted and queued for analysis
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	job, err := s.Submit(repository, workflow)
+	s.mu.Lock()
+	stats := s.stats[workflowKey(repository, workflow)]
+	if err != nil {
+		// Let a redelivery of the event retry the analysis
+		if key := (runAttempt{run.GetID(), run.GetRunAttempt()}); stats.seen[key] {
+			stats.seen[key] = false
+		}
+		s.mu.Unlock()
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+		return
+	}
+	stats.lastJobID = job.ID
+	s.mu.Unlock()
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	s.writeJob(w, http.StatusAccepted, job)
+}
+
+// recordRun adds a completed run to its workflow's statistics and reports
+// whether its analysis still has to be queued
+func (s *Server) recordRun(repository, workflow string, run runRecord) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := workflowKey(repository, workflow)
+	if s.stats[key] == nil {
+		s.stats[key] = &workflowStats{
+			repository:  repository,
+			workflow:    workflow,
+			conclusions: make(map[string]int),
+			seen:        make(map[runAttempt]bool),
+		}
+	}
+	return s.stats[key].add(run)
+}
+
+// restoreStats seeds the rolling statistics with the latest stored runs
+func (s *Server) restoreStats() {
+	runs, err := s.store.RecentRuns(context.Background(), statsWindow)
+	if err != nil {
+		log.Printf("Warning: failed to restore statistics: %v", err)
+		return
+	}
+	for _, run := range runs {
+		s.recordRun(run.Repository, run.Workflow, runRecord{
+			id:         run.ID,
+			attempt:    run.Attempt,
+			conclusion: run.Conclusion,
+			duration:   run.Duration,
+			finishedAt: run.FinishedAt,
+		})
+	}
+}
+
+// handleStats lists the rolling statistics of every workflow, or of one repository's
+// workflows with ?repo=owner/repo
+func (s *Server) handleStats(w http.ResponseWriter, r *http.Request) {
+	repository := r.URL.Query().Get("repo")
+
+	s.mu.Lock()
+	list := make([]WorkflowStats, 0, len(s.stats))
+	for _, stats := range s.stats {
+		if repository == "" || stats.repository == repository {
+			list = append(list, stats.snapshot())
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Repository != list[j].Repository {
+			return list[i].Repository < list[j].Repository
+		}
+		return list[i].Workflow < list[j].Workflow
+	})
+	writeJSON(w, http.StatusOK, list)
+}
@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/jwt"
+)
+
+// gcsScope allows writing objects to Cloud Storage
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// Location is a bucket and key prefix parsed from an s3:// or gs:// URL
+type Location struct {
+	Scheme string // "s3" or "gs"
+	Bucket string
+	Prefix string
+}
+
+// ParseLocation parses s3://bucket/prefix or gs://bucket/prefix; the prefix is optional
+func ParseLocation(raw string) (*Location, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "s3" && u.Scheme != "gs" {
+		return nil, fmt.Errorf("unsupported scheme %q, use s3:// or gs://", u.Scheme)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("missing bucket name")
+	}
+	return &Location{Scheme: u.Scheme, Bucket: u.Host, Prefix: strings.Trim(u.Path, "/")}, nil
+}
+
+// Key joins the location's prefix with an object name
+func (l *Location) Key(name string) string {
+	return path.Join(l.Prefix, name)
+}
+
+// String returns the location's URL form for an object name
+func (l *Location) String(name string) string {
+	return fmt.Sprintf("%s://%s/%s", l.Scheme, l.Bucket, l.Key(name))
+}
+
+// Put uploads data as the named object under the location, authenticating with the
+// standard credentials of the cloud the bucket is in. A nil client uses http.DefaultClient.
+func Put(ctx context.Context, client *http.Client, loc *Location, name, contentType string, data []byte) error {
+	var req *http.Request
+	var err error
+	if loc.Scheme == "s3" {
+		req, err = s3Request(ctx, loc.Bucket, loc.Key(name), contentType, data)
+	} else {
+		req, err = gcsRequest(ctx, loc.Bucket, loc.Key(name), contentType, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %v", loc.String(name), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: %s: %s", loc.String(name), resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// s3Request builds a PutObject request signed with AWS Signature Version 4 from the
+// AWS_* variables set by aws-actions/configure-aws-credentials. AWS_ENDPOINT_URL_S3
+// or AWS_ENDPOINT_URL point it at S3-compatible storage such as MinIO.
+func s3Request(ctx context.Context, bucket, key, contentType string, data []byte) (*http.Request, error) {
+	accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for s3:// uploads")
+	}
+	region := firstEnv("AWS_REGION", "AWS_DEFAULT_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	// Custom endpoints use path-style addressing, AWS virtual-hosted style
+	objectPath := "/" + escapePath(key)
+	endpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, region)
+	if custom := firstEnv("AWS_ENDPOINT_URL_S3", "AWS_ENDPOINT_URL"); custom != "" {
+		endpoint = strings.TrimSuffix(custom, "/")
+		objectPath = "/" + escapePath(bucket) + objectPath
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint+objectPath, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(data)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+	signV4(req, objectPath, payloadHash, accessKey, secretKey, region, now)
+	return req, nil
+}
+
+// signV4 adds the Authorization header for the request's headers and payload
+func signV4(req *http.Request, canonicalPath, payloadHash, accessKey, secretKey, region string, now time.Time) {
+	names := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	if req.Header.Get("X-Amz-Security-Token") != "" {
+		names = append(names, "x-amz-security-token")
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		fmt.Fprintf(&canonicalHeaders, "%s:%s\n", name, strings.TrimSpace(value))
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, canonicalPath, "", canonicalHeaders.String(), signedHeaders, payloadHash,
+	}, "\n")
+	date := now.Format("20060102")
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", now.Format("20060102T150405Z"), scope, sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// gcsRequest builds a Cloud Storage upload authorized by an OAuth access token: the
+// GOOGLE_OAUTH_ACCESS_TOKEN variable (google-github-actions/auth with token_format:
+// access_token) or a token minted for the service account key in
+// GOOGLE_APPLICATION_CREDENTIALS
+func gcsRequest(ctx context.Context, bucket, key, contentType string, data []byte) (*http.Request, error) {
+	token := firstEnv("GOOGLE_OAUTH_ACCESS_TOKEN", "CLOUDSDK_AUTH_ACCESS_TOKEN")
+	if token == "" {
+		var err error
+		if token, err = serviceAccountToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	target := fmt.Sprintf("https://storage.googleapis.com/%s/%s", escapePath(bucket), escapePath(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, target, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req, nil
+}
+
+// serviceAccountToken exchanges the service account key in GOOGLE_APPLICATION_CREDENTIALS
+// for an access token
+func serviceAccountToken(ctx context.Context) (string, error) {
+	file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if file == "" {
+		return "", fmt.Errorf("GOOGLE_OAUTH_ACCESS_TOKEN or GOOGLE_APPLICATION_CREDENTIALS is required for gs:// uploads")
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read Google credentials: %v", err)
+	}
+	var key struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return "", fmt.Errorf("failed to parse Google credentials: %v", err)
+	}
+	if key.Type != "service_account" {
+		return "", fmt.Errorf("Google credentials of type %q are not supported; pass an access token in GOOGLE_OAUTH_ACCESS_TOKEN instead", key.Type)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+
+	cfg := &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{gcsScope},
+		TokenURL:     key.TokenURI,
+	}
+	token, err := cfg.TokenSource(ctx).Token()
+	if err != nil {
+		return "", fmt.Errorf("failed to get a Google access token: %v", err)
+	}
+	return token.AccessToken, nil
+}
+
+// escapePath percent-encodes everything in an object key but unreserved characters
+// and slashes, as Signature Version 4 expects
+func escapePath(key string) string {
+	var b strings.Builder
+	for _, c := range []byte(key) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', strings.IndexByte("-_.~/", c) >= 0:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// firstEnv returns the first of the environment variables that is set
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
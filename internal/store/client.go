@@ -0,0 +1,72 @@
+package store
+
+import (
+	"context"
+	"log"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+)
+
+// Client records the runs and jobs an analysis fetches in the store. Jobs of
+// completed runs never change, so they are served from the store once saved.
+type Client struct {
+	analyzer.GithubClient
+
+	store *Store
+}
+
+// NewClient wraps client so its runs and jobs are kept in store
+func NewClient(client analyzer.GithubClient, store *Store) *Client {
+	return &Client{GithubClient: client, store: store}
+}
+
+// GetWorkflowRuns lists runs from the API and stores them
+func (c *Client) GetWorkflowRuns(ctx context.Context, owner, repo, workflowFile string) ([]*gh.WorkflowRun, error) {
+	runs, err := c.GithubClient.GetWorkflowRuns(ctx, owner, repo, workflowFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store.SaveRuns(ctx, owner+"/"+repo, workflowFile, runs); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return runs, nil
+}
+
+// GetWorkflowJobs returns stored jobs of completed run attempts and fetches the
+// others. The latest attempt, 0, is always fetched, since a re-run changes it.
+func (c *Client) GetWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*gh.WorkflowJob, error) {
+	if attempt > 0 {
+		jobs, err := c.store.Jobs(ctx, runID, attempt)
+		if err != nil {
+			log.Printf("Warning: failed to read stored jobs: %v", err)
+		}
+		if completed(jobs) {
+			return jobs, nil
+		}
+	}
+
+	jobs, err := c.GithubClient.GetWorkflowJobs(ctx, owner, repo, runID, attempt)
+	if err != nil {
+		return nil, err
+	}
+	if attempt > 0 {
+		if err := c.store.SaveJobs(ctx, attempt, jobs); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	}
+	return jobs, nil
+}
+
+// completed reports whether every job finished, so the run's jobs won't change
+func completed(jobs []*gh.WorkflowJob) bool {
+	if len(jobs) == 0 {
+		return false
+	}
+	for _, job := range jobs {
+		if job.GetStatus() != "completed" {
+			return false
+		}
+	}
+	return true
+}
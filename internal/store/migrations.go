@@ -0,0 +1,104 @@
+package store
+
+// migrations are applied in order, each once; append new ones and never edit applied ones
+var migrations = []string{
+	// 1: runs, jobs and steps of analyzed workflows
+	`CREATE TABLE runs (
+		id          INTEGER PRIMARY KEY,
+		repository  TEXT NOT NULL,
+		workflow    TEXT NOT NULL,
+		run_number  INTEGER NOT NULL,
+		run_attempt INTEGER NOT NULL,
+		event       TEXT NOT NULL,
+		branch      TEXT NOT NULL,
+		head_sha    TEXT NOT NULL,
+		status      TEXT NOT NULL,
+		conclusion  TEXT NOT NULL,
+		created_at  TIMESTAMP NOT NULL,
+		started_at  TIMESTAMP,
+		updated_at  TIMESTAMP NOT NULL,
+		duration_ms INTEGER NOT NULL,
+		data        TEXT NOT NULL
+	);
+	CREATE INDEX runs_workflow ON runs (repository, workflow, created_at);
+
+	CREATE TABLE jobs (
+		id           INTEGER PRIMARY KEY,
+		run_id       INTEGER NOT NULL,
+		name         TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		conclusion   TEXT NOT NULL,
+		runner_name  TEXT NOT NULL,
+		labels       TEXT NOT NULL,
+		started_at   TIMESTAMP,
+		completed_at TIMESTAMP,
+		duration_ms  INTEGER NOT NULL,
+		data         TEXT NOT NULL
+	);
+	CREATE INDEX jobs_run ON jobs (run_id);
+
+	CREATE TABLE steps (
+		job_id       INTEGER NOT NULL,
+		number       INTEGER NOT NULL,
+		name         TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		conclusion   TEXT NOT NULL,
+		started_at   TIMESTAMP,
+		completed_at TIMESTAMP,
+		duration_ms  INTEGER NOT NULL,
+		PRIMARY KEY (job_id, number)
+	);`,
+
+	// 2: analyses requested through the server and their findings
+	`CREATE TABLE analyses (
+		id          TEXT PRIMARY KEY,
+		repository  TEXT NOT NULL,
+		workflow    TEXT NOT NULL,
+		status      TEXT NOT NULL,
+		error       TEXT NOT NULL,
+		partial     BOOLEAN NOT NULL,
+		created_at  TIMESTAMP NOT NULL,
+		finished_at TIMESTAMP,
+		report      TEXT
+	);
+	CREATE INDEX analyses_workflow ON analyses (repository, workflow, created_at);
+
+	CREATE TABLE findings (
+		analysis_id TEXT NOT NULL REFERENCES analyses (id) ON DELETE CASCADE,
+		category    TEXT NOT NULL,
+		severity    TEXT NOT NULL,
+		file        TEXT NOT NULL,
+		line        INTEGER NOT NULL,
+		message     TEXT NOT NULL,
+		suggestion  TEXT NOT NULL
+	);
+	CREATE INDEX findings_analysis ON findings (analysis_id);`,
+
+	// 3: every attempt of a run and the jobs of each attempt, since a re-run replaces them
+	`CREATE TABLE run_attempts (
+		id          INTEGER NOT NULL,
+		repository  TEXT NOT NULL,
+		workflow    TEXT NOT NULL,
+		run_number  INTEGER NOT NULL,
+		run_attempt INTEGER NOT NULL,
+		event       TEXT NOT NULL,
+		branch      TEXT NOT NULL,
+		head_sha    TEXT NOT NULL,
+		status      TEXT NOT NULL,
+		conclusion  TEXT NOT NULL,
+		created_at  TIMESTAMP NOT NULL,
+		started_at  TIMESTAMP,
+		updated_at  TIMESTAMP NOT NULL,
+		duration_ms INTEGER NOT NULL,
+		data        TEXT NOT NULL,
+		PRIMARY KEY (id, run_attempt)
+	);
+	INSERT INTO run_attempts SELECT * FROM runs;
+	DROP TABLE runs;
+	ALTER TABLE run_attempts RENAME TO runs;
+	CREATE INDEX runs_workflow ON runs (repository, workflow, created_at);
+
+	ALTER TABLE jobs ADD COLUMN run_attempt INTEGER NOT NULL DEFAULT 0;
+	DROP INDEX jobs_run;
+	CREATE INDEX jobs_run ON jobs (run_id, run_attempt);`,
+}
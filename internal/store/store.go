@@ -0,0 +1,291 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	_ "modernc.org/sqlite"
+)
+
+// Store persists workflow runs, jobs, steps, analyses and findings in SQLite, so
+// trends can be queried over a longer period than the GitHub API keeps in one page
+type Store struct {
+	db *sql.DB
+}
+
+// Analysis is one analysis requested through the server
+type Analysis struct {
+	ID         string
+	Repository string
+	Workflow   string
+	Status     string
+	Error      string
+	CreatedAt  time.Time
+	FinishedAt *time.Time
+	Report     *models.PerformanceReport
+}
+
+// RunSummary is the outcome of a stored completed run
+type RunSummary struct {
+	Repository string
+	Workflow   string
+	ID         int64
+	Attempt    int
+	Conclusion string
+	Duration   time.Duration
+	FinishedAt time.Time
+}
+
+// Open opens or creates the database at path and applies pending migrations
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %v", err)
+	}
+	// SQLite allows one writer at a time
+	db.SetMaxOpenConns(1)
+
+	s := &Store{db: db}
+	if err := s.migrate(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migrate applies the migrations newer than the database's schema version
+func (s *Store) migrate() error {
+	if _, err := s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL
+	)`); err != nil {
+		return fmt.Errorf("failed to create migrations table: %v", err)
+	}
+
+	var version int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %v", err)
+	}
+	for i := version; i < len(migrations); i++ {
+		err := s.inTx(context.Background(), func(tx *sql.Tx) error {
+			if _, err := tx.Exec(migrations[i]); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at) VALUES (?, ?)`, i+1, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d: %v", i+1, err)
+		}
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction, committing when it succeeds
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// SaveRuns stores runs of a workflow, replacing earlier copies of the same attempts
+func (s *Store) SaveRuns(ctx context.Context, repository, workflow string, runs []*gh.WorkflowRun) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, run := range runs {
+			data, err := json.Marshal(run)
+			if err != nil {
+				return err
+			}
+			var started *time.Time
+			duration := time.Duration(0)
+			if run.RunStartedAt != nil {
+				started = &run.RunStartedAt.Time
+				duration = run.GetUpdatedAt().Sub(run.RunStartedAt.Time)
+			}
+			_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO runs
+				(id, repository, workflow, run_number, run_attempt, event, branch, head_sha, status, conclusion,
+				 created_at, started_at, updated_at, duration_ms, data)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				run.GetID(), repository, workflow, run.GetRunNumber(), run.GetRunAttempt(), run.GetEvent(),
+				run.GetHeadBranch(), run.GetHeadSHA(), run.GetStatus(), run.GetConclusion(),
+				run.GetCreatedAt().Time.UTC(), utc(started), run.GetUpdatedAt().Time.UTC(), duration.Milliseconds(), string(data))
+			if err != nil {
+				return fmt.Errorf("failed to save run %d: %v", run.GetID(), err)
+			}
+		}
+		return nil
+	})
+}
+
+// SaveJobs stores the jobs of one attempt of a run with their steps
+func (s *Store) SaveJobs(ctx context.Context, attempt int, jobs []*gh.WorkflowJob) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, job := range jobs {
+			data, err := json.Marshal(job)
+			if err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, `INSERT OR REPLACE INTO jobs
+				(id, run_id, run_attempt, name, status, conclusion, runner_name, labels, started_at, completed_at, duration_ms, data)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				job.GetID(), job.GetRunID(), attempt, job.GetName(), job.GetStatus(), job.GetConclusion(), job.GetRunnerName(),
+				strings.Join(job.Labels, ","), timestamp(job.StartedAt), timestamp(job.CompletedAt),
+				span(job.StartedAt, job.CompletedAt).Milliseconds(), string(data))
+			if err != nil {
+				return fmt.Errorf("failed to save job %d: %v", job.GetID(), err)
+			}
+
+			if _, err := tx.ExecContext(ctx, `DELETE FROM steps WHERE job_id = ?`, job.GetID()); err != nil {
+				return err
+			}
+			for _, step := range job.Steps {
+				_, err := tx.ExecContext(ctx, `INSERT INTO steps
+					(job_id, number, name, status, conclusion, started_at, completed_at, duration_ms)
+					VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+					job.GetID(), step.GetNumber(), step.GetName(), step.GetStatus(), step.GetConclusion(),
+					timestamp(step.StartedAt), timestamp(step.CompletedAt), span(step.StartedAt, step.CompletedAt).Milliseconds())
+				if err != nil {
+					return fmt.Errorf("failed to save step %d of job %d: %v", step.GetNumber(), job.GetID(), err)
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// Jobs returns the stored jobs of one attempt of a run, or none when it hasn't been stored
+func (s *Store) Jobs(ctx context.Context, runID int64, attempt int) ([]*gh.WorkflowJob, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM jobs WHERE run_id = ? AND run_attempt = ? ORDER BY id`, runID, attempt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*gh.WorkflowJob
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		job := &gh.WorkflowJob{}
+		if err := json.Unmarshal([]byte(data), job); err != nil {
+			return nil, fmt.Errorf("failed to decode stored job: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// SaveAnalysis stores an analysis and replaces its findings
+func (s *Store) SaveAnalysis(ctx context.Context, a *Analysis) error {
+	var report []byte
+	partial := false
+	if a.Report != nil {
+		var err error
+		if report, err = json.Marshal(a.Report); err != nil {
+			return err
+		}
+		partial = a.Report.Partial
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT OR REPLACE INTO analyses
+			(id, repository, workflow, status, error, partial, created_at, finished_at, report)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			a.ID, a.Repository, a.Workflow, a.Status, a.Error, partial, a.CreatedAt.UTC(), utc(a.FinishedAt), nullString(report))
+		if err != nil {
+			return fmt.Errorf("failed to save analysis %s: %v", a.ID, err)
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM findings WHERE analysis_id = ?`, a.ID); err != nil {
+			return err
+		}
+		if a.Report == nil {
+			return nil
+		}
+		for _, f := range a.Report.Findings {
+			_, err := tx.ExecContext(ctx, `INSERT INTO findings
+				(analysis_id, category, severity, file, line, message, suggestion)
+				VALUES (?, ?, ?, ?, ?, ?, ?)`,
+				a.ID, f.Category, f.Severity, f.File, f.Line, f.Message, f.Suggestion)
+			if err != nil {
+				return fmt.Errorf("failed to save finding of analysis %s: %v", a.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// RecentRuns returns up to limit of the latest completed run attempts of every workflow,
+// oldest first
+func (s *Store) RecentRuns(ctx context.Context, limit int) ([]RunSummary, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT repository, workflow, id, run_attempt, conclusion, duration_ms, updated_at FROM (
+			SELECT *, ROW_NUMBER() OVER (PARTITION BY repository, workflow ORDER BY created_at DESC, run_attempt DESC) AS n
+			FROM runs WHERE status = 'completed'
+		) WHERE n <= ? ORDER BY created_at, run_attempt`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []RunSummary
+	for rows.Next() {
+		var run RunSummary
+		var ms int64
+		if err := rows.Scan(&run.Repository, &run.Workflow, &run.ID, &run.Attempt, &run.Conclusion, &ms, &run.FinishedAt); err != nil {
+			return nil, err
+		}
+		run.Duration = time.Duration(ms) * time.Millisecond
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// timestamp converts an optional API timestamp to a nullable column value
+func timestamp(t *gh.Timestamp) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Time.UTC()
+}
+
+// utc converts an optional time to a nullable column value
+func utc(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// span returns the time between two optional timestamps, or zero when either is missing
+func span(start, end *gh.Timestamp) time.Duration {
+	if start == nil || end == nil {
+		return 0
+	}
+	return end.Sub(start.Time)
+}
+
+// nullString stores empty data as NULL
+func nullString(data []byte) interface{} {
+	if data == nil {
+		return nil
+	}
+	return string(data)
+}
@@ -0,0 +1,122 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultDatadogSite is the Datadog site of US1 accounts
+const DefaultDatadogSite = "datadoghq.com"
+
+// DatadogExporter sends workflow runs to Datadog CI Visibility through the CI
+// pipelines API: a pipeline resource per run and a job resource per job
+type DatadogExporter struct {
+	apiKey string
+	site   string
+	client *http.Client
+}
+
+// NewDatadogExporter returns an exporter to the Datadog site, e.g. datadoghq.eu.
+// A nil client uses http.DefaultClient.
+func NewDatadogExporter(apiKey, site string, client *http.Client) *DatadogExporter {
+	if site == "" {
+		site = DefaultDatadogSite
+	}
+	return &DatadogExporter{apiKey: apiKey, site: site, client: orDefault(client)}
+}
+
+func (e *DatadogExporter) Name() string {
+	return "datadog"
+}
+
+// Export sends each finished run and its jobs, one resource per request as the
+// API takes them
+func (e *DatadogExporter) Export(ctx context.Context, repository string, runs []Run) (int, error) {
+	url := fmt.Sprintf("https://api.%s/api/v2/ci/pipeline", e.site)
+	headers := map[string]string{"DD-API-KEY": e.apiKey}
+	exported := 0
+	for _, run := range finished(runs) {
+		for _, resource := range datadogResources(repository, run) {
+			request := map[string]interface{}{"data": map[string]interface{}{
+				"type": "cipipeline_resource_request",
+				"attributes": map[string]interface{}{
+					"provider_name": "github-actions",
+					"resource":      resource,
+				},
+			}}
+			if err := postJSON(ctx, e.client, url, headers, request); err != nil {
+				return exported, fmt.Errorf("failed to send run %d to Datadog: %v", run.Run.GetID(), err)
+			}
+		}
+		exported++
+	}
+	return exported, nil
+}
+
+// datadogResources converts a run to its pipeline resource and the resources of
+// its finished jobs
+func datadogResources(repository string, run Run) []map[string]interface{} {
+	r := run.Run
+	uniqueID := fmt.Sprintf("%s/%d/%d", repository, r.GetID(), r.GetRunAttempt())
+	pipeline := map[string]interface{}{
+		"level":         "pipeline",
+		"unique_id":     uniqueID,
+		"name":          r.GetName(),
+		"url":           r.GetHTMLURL(),
+		"start":         runStart(r).Format(time.RFC3339Nano),
+		"end":           r.GetUpdatedAt().Format(time.RFC3339Nano),
+		"status":        datadogStatus(r.GetConclusion()),
+		"partial_retry": r.GetRunAttempt() > 1,
+		"tags": []string{
+			"github.event:" + r.GetEvent(),
+			"github.run_number:" + strconv.Itoa(r.GetRunNumber()),
+		},
+	}
+	if email := r.GetHeadCommit().GetAuthor().GetEmail(); email != "" {
+		pipeline["git"] = map[string]interface{}{
+			"repository_url": r.GetRepository().GetHTMLURL(),
+			"sha":            r.GetHeadSHA(),
+			"branch":         r.GetHeadBranch(),
+			"author_email":   email,
+			"message":        r.GetHeadCommit().GetMessage(),
+		}
+	}
+
+	resources := []map[string]interface{}{pipeline}
+	for _, job := range run.Jobs {
+		if job.StartedAt == nil || job.CompletedAt == nil {
+			continue
+		}
+		resources = append(resources, map[string]interface{}{
+			"level":              "job",
+			"id":                 strconv.FormatInt(job.GetID(), 10),
+			"name":               job.GetName(),
+			"pipeline_unique_id": uniqueID,
+			"pipeline_name":      r.GetName(),
+			"url":                job.GetHTMLURL(),
+			"start":              job.StartedAt.Format(time.RFC3339Nano),
+			"end":                job.CompletedAt.Format(time.RFC3339Nano),
+			"status":             datadogStatus(job.GetConclusion()),
+			"node":               map[string]interface{}{"name": job.GetRunnerName(), "labels": job.Labels},
+		})
+	}
+	return resources
+}
+
+// datadogStatus maps a GitHub conclusion to a CI Visibility status
+func datadogStatus(conclusion string) string {
+	switch conclusion {
+	case "failure", "timed_out", "startup_failure":
+		return "error"
+	case "cancelled":
+		return "canceled"
+	case "skipped":
+		return "skipped"
+	case "action_required":
+		return "blocked"
+	}
+	return "success"
+}
@@ -0,0 +1,183 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Honeycomb defaults
+const (
+	DefaultHoneycombDataset = "github-actions"
+	honeycombAPI            = "https://api.honeycomb.io"
+	// honeycombStateFile keeps the high-water mark of each workflow in the state directory
+	honeycombStateFile = "honeycomb-export.json"
+)
+
+// HoneycombExporter sends workflow runs to a Honeycomb dataset as events: one
+// per run with its duration, outcome and job counts, and one per job. Events
+// can't be replaced like traces, so only runs that finished after the newest
+// run exported before, its high-water mark, are sent.
+type HoneycombExporter struct {
+	apiKey   string
+	dataset  string
+	client   *http.Client
+	stateDir string
+
+	mu    sync.Mutex
+	marks map[string]time.Time // repository/workflow ID -> when the newest exported run finished
+}
+
+// NewHoneycombExporter returns an exporter to the dataset, created on the first
+// event. A nil client uses http.DefaultClient. The high-water marks are kept in
+// stateDir between analyses; without one, they only last the process.
+func NewHoneycombExporter(apiKey, dataset, stateDir string, client *http.Client) *HoneycombExporter {
+	if dataset == "" {
+		dataset = DefaultHoneycombDataset
+	}
+	return &HoneycombExporter{apiKey: apiKey, dataset: dataset, client: orDefault(client), stateDir: stateDir}
+}
+
+// loadMarks reads the persisted high-water marks once; a missing or unreadable
+// file starts over
+func (e *HoneycombExporter) loadMarks() {
+	if e.marks != nil {
+		return
+	}
+	e.marks = make(map[string]time.Time)
+	if e.stateDir == "" {
+		return
+	}
+	if data, err := os.ReadFile(filepath.Join(e.stateDir, honeycombStateFile)); err == nil {
+		json.Unmarshal(data, &e.marks)
+	}
+}
+
+// saveMarks persists the high-water marks
+func (e *HoneycombExporter) saveMarks() error {
+	if e.stateDir == "" {
+		return nil
+	}
+	data, err := json.Marshal(e.marks)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(e.stateDir, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(e.stateDir, honeycombStateFile), data, 0644)
+}
+
+// markKey identifies a workflow of a repository for its high-water mark
+func markKey(repository string, run Run) string {
+	return repository + "/" + strconv.FormatInt(run.Run.GetWorkflowID(), 10)
+}
+
+func (e *HoneycombExporter) Name() string {
+	return "honeycomb"
+}
+
+// honeycombEvent is an event of the batch events API
+type honeycombEvent struct {
+	Time time.Time              `json:"time"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// Export sends the events of the runs finished since the high-water mark in
+// one batch, then moves the mark to the newest of them
+func (e *HoneycombExporter) Export(ctx context.Context, repository string, runs []Run) (int, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.loadMarks()
+
+	var fresh []Run
+	marks := make(map[string]time.Time)
+	for _, run := range finished(runs) {
+		key := markKey(repository, run)
+		updated := run.Run.GetUpdatedAt().Time
+		if !updated.After(e.marks[key]) {
+			continue
+		}
+		fresh = append(fresh, run)
+		if updated.After(marks[key]) {
+			marks[key] = updated
+		}
+	}
+	if len(fresh) == 0 {
+		return 0, nil
+	}
+	var events []honeycombEvent
+	for _, run := range fresh {
+		events = append(events, honeycombEvents(repository, run)...)
+	}
+	endpoint := fmt.Sprintf("%s/1/batch/%s", honeycombAPI, url.PathEscape(e.dataset))
+	if err := postJSON(ctx, e.client, endpoint, map[string]string{"X-Honeycomb-Team": e.apiKey}, events); err != nil {
+		return 0, fmt.Errorf("failed to send events to Honeycomb: %v", err)
+	}
+	for key, mark := range marks {
+		e.marks[key] = mark
+	}
+	if err := e.saveMarks(); err != nil {
+		return len(fresh), fmt.Errorf("sent %d runs but failed to save the high-water mark: %v", len(fresh), err)
+	}
+	return len(fresh), nil
+}
+
+// honeycombEvents converts a run to its event and the events of its finished jobs
+func honeycombEvents(repository string, run Run) []honeycombEvent {
+	r := run.Run
+	start := runStart(r)
+	failed := 0
+	for _, job := range run.Jobs {
+		if c := job.GetConclusion(); c == "failure" || c == "timed_out" {
+			failed++
+		}
+	}
+	base := func() map[string]interface{} {
+		return map[string]interface{}{
+			"repository":  repository,
+			"workflow":    r.GetName(),
+			"run_id":      r.GetID(),
+			"run_number":  r.GetRunNumber(),
+			"run_attempt": r.GetRunAttempt(),
+			"event":       r.GetEvent(),
+			"branch":      r.GetHeadBranch(),
+			"sha":         r.GetHeadSHA(),
+		}
+	}
+
+	data := base()
+	data["type"] = "run"
+	data["name"] = r.GetName()
+	data["conclusion"] = r.GetConclusion()
+	data["duration_ms"] = r.GetUpdatedAt().Sub(start).Milliseconds()
+	data["url"] = r.GetHTMLURL()
+	data["jobs"] = len(run.Jobs)
+	data["failed_jobs"] = failed
+	events := []honeycombEvent{{Time: start, Data: data}}
+
+	for _, job := range run.Jobs {
+		if job.StartedAt == nil || job.CompletedAt == nil {
+			continue
+		}
+		data := base()
+		data["type"] = "job"
+		data["name"] = job.GetName()
+		data["conclusion"] = job.GetConclusion()
+		data["duration_ms"] = job.CompletedAt.Sub(job.StartedAt.Time).Milliseconds()
+		data["url"] = job.GetHTMLURL()
+		data["runner"] = job.GetRunnerName()
+		data["steps"] = len(job.Steps)
+		// The wait from the run's start until a runner picked the job up
+		data["wait_ms"] = job.StartedAt.Sub(start).Milliseconds()
+		events = append(events, honeycombEvent{Time: job.StartedAt.Time, Data: data})
+	}
+	return events
+}
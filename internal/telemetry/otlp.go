@@ -0,0 +1,253 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tracesPath is where OTLP/HTTP collectors receive traces
+const tracesPath = "/v1/traces"
+
+// Span kinds and status codes of the OTLP trace model
+const (
+	spanKindInternal = 1
+	statusOK         = 1
+	statusError      = 2
+)
+
+// Endpoint is an OTLP/HTTP collector and the headers sent with every request,
+// e.g. an API key
+type Endpoint struct {
+	URL     string
+	Headers map[string]string
+}
+
+// ParseEndpoint parses a collector URL and headers given as comma-separated
+// key=value pairs, the format of OTEL_EXPORTER_OTLP_HEADERS. A URL without the
+// traces path gets /v1/traces appended.
+func ParseEndpoint(rawURL, headers string) (*Endpoint, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, fmt.Errorf("must be an http(s) URL")
+	}
+	if !strings.HasSuffix(u.Path, tracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + tracesPath
+	}
+	endpoint := &Endpoint{URL: u.String(), Headers: make(map[string]string)}
+	for _, pair := range strings.Split(headers, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("header %q isn't a key=value pair", pair)
+		}
+		if unescaped, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = unescaped
+		}
+		endpoint.Headers[strings.TrimSpace(key)] = value
+	}
+	return endpoint, nil
+}
+
+// OTLPExporter sends workflow runs to an OTLP/HTTP collector as traces: a run
+// is a trace with a root span, its jobs are child spans and their steps are the
+// jobs' child spans. IDs are derived from the run, so exporting a run again
+// sends the same trace.
+type OTLPExporter struct {
+	endpoint *Endpoint
+	client   *http.Client
+}
+
+// NewOTLPExporter returns an exporter to the endpoint. A nil client uses
+// http.DefaultClient.
+func NewOTLPExporter(endpoint *Endpoint, client *http.Client) *OTLPExporter {
+	return &OTLPExporter{endpoint: endpoint, client: orDefault(client)}
+}
+
+func (e *OTLPExporter) Name() string {
+	return "otlp"
+}
+
+// Export sends the finished runs of a repository's workflow in one request
+func (e *OTLPExporter) Export(ctx context.Context, repository string, runs []Run) (int, error) {
+	runs = finished(runs)
+	if len(runs) == 0 {
+		return 0, nil
+	}
+	var spans []span
+	for _, run := range runs {
+		spans = append(spans, runSpans(repository, run)...)
+	}
+	request := tracesRequest{ResourceSpans: []resourceSpans{{
+		Resource: resource{Attributes: attributes(
+			"service.name", "github-actions",
+			"vcs.repository.name", repository,
+		)},
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: "github-action-analyzer"}, Spans: spans}},
+	}}}
+	if err := postJSON(ctx, e.client, e.endpoint.URL, e.endpoint.Headers, request); err != nil {
+		return 0, fmt.Errorf("failed to send traces: %v", err)
+	}
+	return len(runs), nil
+}
+
+// runSpans converts a run to its root span and the spans of its jobs and steps
+func runSpans(repository string, run Run) []span {
+	r := run.Run
+	seed := fmt.Sprintf("%s/%d/%d", repository, r.GetID(), r.GetRunAttempt())
+	traceID := id(seed, 16)
+	rootID := id(seed+"/run", 8)
+
+	spans := []span{{
+		TraceID: traceID,
+		SpanID:  rootID,
+		Name:    fmt.Sprintf("%s #%d", r.GetName(), r.GetRunNumber()),
+		Kind:    spanKindInternal,
+		Start:   nanos(runStart(r)),
+		End:     nanos(r.GetUpdatedAt().Time),
+		Attributes: attributes(
+			"cicd.pipeline.name", r.GetName(),
+			"cicd.pipeline.run.id", strconv.FormatInt(r.GetID(), 10),
+			"cicd.pipeline.run.url.full", r.GetHTMLURL(),
+			"cicd.pipeline.result", r.GetConclusion(),
+			"github.event", r.GetEvent(),
+			"github.run_attempt", strconv.Itoa(r.GetRunAttempt()),
+			"vcs.ref.head.name", r.GetHeadBranch(),
+			"vcs.ref.head.revision", r.GetHeadSHA(),
+		),
+		Status: status(r.GetConclusion()),
+	}}
+
+	for _, job := range run.Jobs {
+		if job.StartedAt == nil || job.CompletedAt == nil {
+			continue
+		}
+		jobID := id(fmt.Sprintf("%s/job/%d", seed, job.GetID()), 8)
+		attrs := attributes(
+			"cicd.pipeline.task.name", job.GetName(),
+			"cicd.pipeline.task.run.id", strconv.FormatInt(job.GetID(), 10),
+			"cicd.pipeline.task.run.url.full", job.GetHTMLURL(),
+			"cicd.pipeline.task.run.result", job.GetConclusion(),
+			"cicd.worker.name", job.GetRunnerName(),
+			"github.runner.labels", strings.Join(job.Labels, ","),
+		)
+		spans = append(spans, span{
+			TraceID:      traceID,
+			SpanID:       jobID,
+			ParentSpanID: rootID,
+			Name:         job.GetName(),
+			Kind:         spanKindInternal,
+			Start:        nanos(job.StartedAt.Time),
+			End:          nanos(job.CompletedAt.Time),
+			Attributes:   attrs,
+			Status:       status(job.GetConclusion()),
+		})
+
+		for _, step := range job.Steps {
+			if step.StartedAt == nil || step.CompletedAt == nil {
+				continue
+			}
+			spans = append(spans, span{
+				TraceID:      traceID,
+				SpanID:       id(fmt.Sprintf("%s/job/%d/step/%d", seed, job.GetID(), step.GetNumber()), 8),
+				ParentSpanID: jobID,
+				Name:         step.GetName(),
+				Kind:         spanKindInternal,
+				Start:        nanos(step.StartedAt.Time),
+				End:          nanos(step.CompletedAt.Time),
+				Attributes: attributes(
+					"github.step.number", strconv.FormatInt(step.GetNumber(), 10),
+					"github.step.conclusion", step.GetConclusion(),
+				),
+				Status: status(step.GetConclusion()),
+			})
+		}
+	}
+	return spans
+}
+
+// id derives a trace or span ID of n bytes from a seed, hex-encoded as OTLP/JSON
+// expects
+func id(seed string, n int) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:n])
+}
+
+// nanos formats a time as Unix nanoseconds, a string in OTLP/JSON
+func nanos(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+// status maps a GitHub conclusion to a span status; skipped and cancelled work
+// is left unset
+func status(conclusion string) *spanStatus {
+	switch conclusion {
+	case "success":
+		return &spanStatus{Code: statusOK}
+	case "failure", "timed_out", "startup_failure":
+		return &spanStatus{Code: statusError, Message: conclusion}
+	}
+	return nil
+}
+
+// attributes builds string attributes from key, value pairs, leaving out empty values
+func attributes(pairs ...string) []attribute {
+	var attrs []attribute
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			attrs = append(attrs, attribute{Key: pairs[i], Value: value{String: pairs[i+1]}})
+		}
+	}
+	return attrs
+}
+
+// The OTLP/JSON encoding of an ExportTraceServiceRequest
+type (
+	tracesRequest struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+	resourceSpans struct {
+		Resource   resource     `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	resource struct {
+		Attributes []attribute `json:"attributes"`
+	}
+	scopeSpans struct {
+		Scope scope  `json:"scope"`
+		Spans []span `json:"spans"`
+	}
+	scope struct {
+		Name string `json:"name"`
+	}
+	span struct {
+		TraceID      string      `json:"traceId"`
+		SpanID       string      `json:"spanId"`
+		ParentSpanID string      `json:"parentSpanId,omitempty"`
+		Name         string      `json:"name"`
+		Kind         int         `json:"kind"`
+		Start        string      `json:"startTimeUnixNano"`
+		End          string      `json:"endTimeUnixNano"`
+		Attributes   []attribute `json:"attributes,omitempty"`
+		Status       *spanStatus `json:"status,omitempty"`
+	}
+	spanStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	attribute struct {
+		Key   string `json:"key"`
+		Value value  `json:"value"`
+	}
+	value struct {
+		String string `json:"stringValue"`
+	}
+)
@@ -0,0 +1,83 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	gh "github.com/google/go-github/v45/github"
+)
+
+// Exporter sends analyzed workflow runs to a monitoring backend
+type Exporter interface {
+	// Name is the exporter's name in the exporters input
+	Name() string
+	// Export sends the runs of a repository's workflow and returns how many it sent
+	Export(ctx context.Context, repository string, runs []Run) (int, error)
+}
+
+// Run is a workflow run with its jobs and their steps
+type Run struct {
+	Run  *gh.WorkflowRun
+	Jobs []*gh.WorkflowJob
+}
+
+// finished returns the completed runs; runs still in progress have no end yet
+func finished(runs []Run) []Run {
+	var done []Run
+	for _, run := range runs {
+		if run.Run.GetStatus() == "completed" {
+			done = append(done, run)
+		}
+	}
+	return done
+}
+
+// runStart returns when a run started, or when it was created for runs without
+// a start time
+func runStart(r *gh.WorkflowRun) time.Time {
+	if start := r.GetRunStartedAt().Time; !start.IsZero() {
+		return start
+	}
+	return r.GetCreatedAt().Time
+}
+
+// orDefault returns client, or http.DefaultClient when it's nil
+func orDefault(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
+
+// postJSON posts v as JSON with the headers and fails on responses other than 2xx,
+// quoting the start of the response body
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, v interface{}) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s returned %s: %s", req.URL.Host, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Client fetches the runs and jobs to browse; analyzer.GithubClient satisfies it
+type Client interface {
+	GetWorkflowRuns(ctx context.Context, owner, repo, workflowFile string) ([]*gh.WorkflowRun, error)
+	GetWorkflowJobs(ctx context.Context, owner, repo string, runID int64, attempt int) ([]*gh.WorkflowJob, error)
+}
+
+// Analyzer produces the findings to filter; *analyzer.Analyzer satisfies it
+type Analyzer interface {
+	Analyze(ctx context.Context, owner, repo, workflowFile string) (*models.PerformanceReport, error)
+}
+
+// view is one of the explorer's screens
+type view int
+
+const (
+	viewRuns view = iota
+	viewJobs
+	viewFindings
+)
+
+// Messages delivering data loaded in the background
+type (
+	runsLoaded struct {
+		runs []*gh.WorkflowRun
+		err  error
+	}
+	jobsLoaded struct {
+		runID int64
+		jobs  []*gh.WorkflowJob
+		err   error
+	}
+	reportLoaded struct {
+		report *models.PerformanceReport
+		err    error
+	}
+)
+
+// severities are cycled through by the findings severity filter; "" shows all
+var severities = []string{"", models.SeverityCritical, models.SeverityWarning, models.SeverityInfo}
+
+// model is the explorer's state
+type model struct {
+	ctx                   context.Context
+	client                Client
+	analyzer              Analyzer
+	owner, repo, workflow string
+
+	view          view
+	width, height int
+	err           error
+
+	runs      []*gh.WorkflowRun
+	runCursor int
+
+	jobs      map[int64][]*gh.WorkflowJob
+	jobsErr   map[int64]error // shown until the run is entered again
+	loading   map[int64]bool
+	jobCursor int
+	expanded  map[int64]bool
+
+	report    *models.PerformanceReport
+	analyzing bool
+	reportErr error
+
+	filter         string
+	editingFilter  bool
+	severity       int // index into severities
+	category       string
+	findingsCursor int
+}
+
+// Run starts the interactive explorer for a workflow and blocks until the user quits
+func Run(ctx context.Context, client Client, analyzer Analyzer, owner, repo, workflow string) error {
+	m := &model{
+		ctx:       ctx,
+		client:    client,
+		analyzer:  analyzer,
+		owner:     owner,
+		repo:      repo,
+		workflow:  workflow,
+		jobs:      make(map[int64][]*gh.WorkflowJob),
+		jobsErr:   make(map[int64]error),
+		loading:   make(map[int64]bool),
+		expanded:  make(map[int64]bool),
+		analyzing: true,
+	}
+	_, err := tea.NewProgram(m, tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("terminal UI failed: %v", err)
+	}
+	return nil
+}
+
+// Init loads the run list and starts the analysis for the findings view
+func (m *model) Init() tea.Cmd {
+	return tea.Batch(m.loadRuns, m.analyze)
+}
+
+func (m *model) loadRuns() tea.Msg {
+	runs, err := m.client.GetWorkflowRuns(m.ctx, m.owner, m.repo, m.workflow)
+	return runsLoaded{runs: runs, err: err}
+}
+
+func (m *model) analyze() tea.Msg {
+	report, err := m.analyzer.Analyze(m.ctx, m.owner, m.repo, m.workflow)
+	return reportLoaded{report: report, err: err}
+}
+
+// loadJobs fetches a run's jobs unless they are loaded or on their way
+func (m *model) loadJobs(run *gh.WorkflowRun) tea.Cmd {
+	runID := run.GetID()
+	if m.jobs[runID] != nil || m.loading[runID] {
+		return nil
+	}
+	delete(m.jobsErr, runID)
+	m.loading[runID] = true
+	return func() tea.Msg {
+		jobs, err := m.client.GetWorkflowJobs(m.ctx, m.owner, m.repo, runID, run.GetRunAttempt())
+		return jobsLoaded{runID: runID, jobs: jobs, err: err}
+	}
+}
+
+// Update applies a message to the state
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case runsLoaded:
+		m.runs, m.err = msg.runs, msg.err
+	case jobsLoaded:
+		delete(m.loading, msg.runID)
+		if msg.err != nil {
+			m.jobsErr[msg.runID] = msg.err
+		} else {
+			m.jobs[msg.runID], m.err = msg.jobs, nil
+		}
+	case reportLoaded:
+		m.analyzing = false
+		m.report, m.reportErr = msg.report, msg.err
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+// handleKey dispatches a key press to the current view
+func (m *model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if m.editingFilter {
+		switch key {
+		case "enter", "esc":
+			m.editingFilter = false
+		case "backspace":
+			if m.filter != "" {
+				m.filter = m.filter[:len(m.filter)-1]
+			}
+		case "ctrl+c":
+			return m, tea.Quit
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				m.filter += string(msg.Runes)
+			}
+		}
+		m.findingsCursor = 0
+		return m, nil
+	}
+
+	switch key {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "tab":
+		if m.view == viewFindings {
+			m.view = viewRuns
+		} else {
+			m.view = viewFindings
+		}
+		return m, nil
+	}
+
+	switch m.view {
+	case viewRuns:
+		switch key {
+		case "up", "k":
+			m.runCursor = max(m.runCursor-1, 0)
+		case "down", "j":
+			m.runCursor = min(m.runCursor+1, max(len(m.runs)-1, 0))
+		case "enter", "right", "l":
+			if len(m.runs) > 0 {
+				m.view, m.jobCursor = viewJobs, 0
+				return m, m.loadJobs(m.runs[m.runCursor])
+			}
+		}
+	case viewJobs:
+		jobs := m.jobs[m.selectedRun().GetID()]
+		switch key {
+		case "up", "k":
+			m.jobCursor = max(m.jobCursor-1, 0)
+		case "down", "j":
+			m.jobCursor = min(m.jobCursor+1, max(len(jobs)-1, 0))
+		case "enter", " ":
+			if len(jobs) > 0 {
+				id := jobs[m.jobCursor].GetID()
+				m.expanded[id] = !m.expanded[id]
+			}
+		case "esc", "backspace", "left", "h":
+			m.view = viewRuns
+		}
+	case viewFindings:
+		switch key {
+		case "up", "k":
+			m.findingsCursor = max(m.findingsCursor-1, 0)
+		case "down", "j":
+			m.findingsCursor = min(m.findingsCursor+1, max(len(m.visibleFindings())-1, 0))
+		case "/":
+			m.editingFilter = true
+		case "s":
+			m.severity = (m.severity + 1) % len(severities)
+			m.findingsCursor = 0
+		case "c":
+			m.category = m.nextCategory()
+			m.findingsCursor = 0
+		case "esc":
+			m.filter, m.severity, m.category, m.findingsCursor = "", 0, "", 0
+		}
+	}
+	return m, nil
+}
+
+// selectedRun returns the run under the cursor
+func (m *model) selectedRun() *gh.WorkflowRun {
+	if m.runCursor >= len(m.runs) {
+		return nil
+	}
+	return m.runs[m.runCursor]
+}
+
+// visibleFindings applies the text, severity and category filters
+func (m *model) visibleFindings() []models.Finding {
+	if m.report == nil {
+		return nil
+	}
+	filter := strings.ToLower(m.filter)
+	var list []models.Finding
+	for _, f := range m.report.Findings {
+		if severities[m.severity] != "" && f.Severity != severities[m.severity] {
+			continue
+		}
+		if m.category != "" && f.Category != m.category {
+			continue
+		}
+		if filter != "" && !strings.Contains(strings.ToLower(f.Message+" "+f.Location()+" "+f.Suggestion), filter) {
+			continue
+		}
+		list = append(list, f)
+	}
+	return list
+}
+
+// nextCategory cycles the category filter through the categories present, then back to all
+func (m *model) nextCategory() string {
+	if m.report == nil {
+		return ""
+	}
+	var categories []string
+	seen := make(map[string]bool)
+	for _, f := range m.report.Findings {
+		if !seen[f.Category] {
+			seen[f.Category] = true
+			categories = append(categories, f.Category)
+		}
+	}
+	for i, c := range categories {
+		if c == m.category {
+			if i+1 < len(categories) {
+				return categories[i+1]
+			}
+			return ""
+		}
+	}
+	if m.category == "" && len(categories) > 0 {
+		return categories[0]
+	}
+	return ""
+}
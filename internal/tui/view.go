@@ -0,0 +1,241 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	gh "github.com/google/go-github/v45/github"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// barWidth is the width of the duration bars
+const barWidth = 24
+
+var (
+	titleStyle    = lipgloss.NewStyle().Bold(true)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	dimStyle      = lipgloss.NewStyle().Faint(true)
+	errorStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+
+	conclusionStyles = map[string]lipgloss.Style{
+		"success":   lipgloss.NewStyle().Foreground(lipgloss.Color("2")),
+		"failure":   lipgloss.NewStyle().Foreground(lipgloss.Color("1")),
+		"cancelled": lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
+	severityStyles = map[string]lipgloss.Style{
+		models.SeverityCritical: lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Bold(true),
+		models.SeverityWarning:  lipgloss.NewStyle().Foreground(lipgloss.Color("3")),
+		models.SeverityInfo:     lipgloss.NewStyle().Foreground(lipgloss.Color("4")),
+	}
+)
+
+// View renders the current screen
+func (m *model) View() string {
+	var header, help string
+	var lines []string
+	cursor := 0
+
+	switch m.view {
+	case viewRuns:
+		header = fmt.Sprintf("%s/%s · %s · runs", m.owner, m.repo, m.workflow)
+		help = "↑/↓ move · enter jobs · tab findings · q quit"
+		lines, cursor = m.runLines()
+	case viewJobs:
+		run := m.selectedRun()
+		header = fmt.Sprintf("%s/%s · %s · run #%d (%s)", m.owner, m.repo, m.workflow, run.GetRunNumber(), run.GetHeadBranch())
+		help = "↑/↓ move · enter expand steps · esc back · tab findings · q quit"
+		lines, cursor = m.jobLines()
+	case viewFindings:
+		header = fmt.Sprintf("%s/%s · %s · findings", m.owner, m.repo, m.workflow)
+		help = "↑/↓ move · / search · s severity · c category · esc clear · tab runs · q quit"
+		lines, cursor = m.findingLines()
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(header) + "\n")
+	if m.err != nil {
+		b.WriteString(errorStyle.Render("Error: "+m.err.Error()) + "\n")
+	}
+	b.WriteString("\n")
+	for _, line := range m.window(lines, cursor) {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render(help))
+	return b.String()
+}
+
+// window keeps the lines around the cursor that fit the terminal
+func (m *model) window(lines []string, cursor int) []string {
+	height := m.height - 5 // header, blank lines and help
+	if height <= 0 || len(lines) <= height {
+		return lines
+	}
+	start := min(max(cursor-height/2, 0), len(lines)-height)
+	return lines[start : start+height]
+}
+
+// runLines lists the runs with their conclusion and duration
+func (m *model) runLines() ([]string, int) {
+	if m.runs == nil {
+		return []string{dimStyle.Render("Loading runs…")}, 0
+	}
+	if len(m.runs) == 0 {
+		return []string{dimStyle.Render("No runs found")}, 0
+	}
+
+	var longest time.Duration
+	for _, run := range m.runs {
+		longest = max(longest, runDuration(run))
+	}
+	lines := make([]string, 0, len(m.runs))
+	for i, run := range m.runs {
+		d := runDuration(run)
+		line := fmt.Sprintf("#%-6d %-10s %-20.20s %-12.12s %s %8s",
+			run.GetRunNumber(), conclusion(run.GetStatus(), run.GetConclusion()), run.GetHeadBranch(), run.GetEvent(),
+			bar(d, longest), d.Round(time.Second))
+		lines = append(lines, m.highlight(line, i == m.runCursor))
+	}
+	return lines, m.runCursor
+}
+
+// jobLines lists the selected run's jobs, with the steps of expanded jobs
+func (m *model) jobLines() ([]string, int) {
+	runID := m.selectedRun().GetID()
+	jobs := m.jobs[runID]
+	if err := m.jobsErr[runID]; err != nil {
+		return []string{errorStyle.Render("Failed to load jobs: " + err.Error()), dimStyle.Render("Go back and enter the run to retry")}, 0
+	}
+	if jobs == nil {
+		return []string{dimStyle.Render("Loading jobs…")}, 0
+	}
+	if len(jobs) == 0 {
+		return []string{dimStyle.Render("No jobs found")}, 0
+	}
+
+	var longest time.Duration
+	for _, job := range jobs {
+		longest = max(longest, span(job.StartedAt, job.CompletedAt))
+	}
+	var lines []string
+	cursor := 0
+	for i, job := range jobs {
+		marker := "▸"
+		if m.expanded[job.GetID()] {
+			marker = "▾"
+		}
+		d := span(job.StartedAt, job.CompletedAt)
+		line := fmt.Sprintf("%s %-40.40s %-10s %s %8s", marker, job.GetName(),
+			conclusion(job.GetStatus(), job.GetConclusion()), bar(d, longest), d.Round(time.Second))
+		if i == m.jobCursor {
+			cursor = len(lines)
+		}
+		lines = append(lines, m.highlight(line, i == m.jobCursor))
+
+		if !m.expanded[job.GetID()] {
+			continue
+		}
+		for _, step := range job.Steps {
+			sd := span(step.StartedAt, step.CompletedAt)
+			lines = append(lines, fmt.Sprintf("    %-38.38s %-10s %s %8s", step.GetName(),
+				conclusion(step.GetStatus(), step.GetConclusion()), bar(sd, d), sd.Round(time.Second)))
+		}
+	}
+	return lines, cursor
+}
+
+// findingLines lists the findings that pass the filters
+func (m *model) findingLines() ([]string, int) {
+	filters := "severity: " + or(severities[m.severity], "all") + " · category: " + or(m.category, "all") + " · search: "
+	if m.editingFilter {
+		filters += m.filter + "█"
+	} else {
+		filters += or(m.filter, "-")
+	}
+	lines := []string{dimStyle.Render(filters), ""}
+
+	switch {
+	case m.analyzing:
+		return append(lines, dimStyle.Render("Analyzing the workflow…")), 0
+	case m.reportErr != nil:
+		return append(lines, errorStyle.Render("Analysis failed: "+m.reportErr.Error())), 0
+	}
+	findings := m.visibleFindings()
+	if len(findings) == 0 {
+		return append(lines, dimStyle.Render("No findings match")), 0
+	}
+
+	cursor := 0
+	for i, f := range findings {
+		severity := f.Severity
+		if style, ok := severityStyles[f.Severity]; ok {
+			severity = style.Render(fmt.Sprintf("%-8s", f.Severity))
+		}
+		if i == m.findingsCursor {
+			cursor = len(lines)
+		}
+		lines = append(lines, m.highlight(fmt.Sprintf("%s %-12s %s", severity, f.Category, f.Location()), i == m.findingsCursor))
+		lines = append(lines, "    "+f.Message)
+		if i == m.findingsCursor && f.Suggestion != "" {
+			lines = append(lines, dimStyle.Render("    ↳ "+f.Suggestion))
+		}
+		if i == m.findingsCursor && f.URL != "" {
+			lines = append(lines, dimStyle.Render("    ↳ "+f.URL))
+		}
+	}
+	return lines, cursor
+}
+
+// highlight marks the line under the cursor
+func (m *model) highlight(line string, selected bool) string {
+	if selected {
+		return selectedStyle.Render(line)
+	}
+	return line
+}
+
+// conclusion renders a run, job or step outcome, or its status while unfinished
+func conclusion(status, result string) string {
+	if status != "completed" {
+		return fmt.Sprintf("%-10s", status)
+	}
+	if style, ok := conclusionStyles[result]; ok {
+		return style.Render(fmt.Sprintf("%-10s", result))
+	}
+	return fmt.Sprintf("%-10s", result)
+}
+
+// bar draws d as a share of longest
+func bar(d, longest time.Duration) string {
+	if longest <= 0 {
+		return strings.Repeat(" ", barWidth)
+	}
+	n := min(max(int(float64(barWidth)*float64(d)/float64(longest)), 0), barWidth)
+	return strings.Repeat("█", n) + strings.Repeat("·", barWidth-n)
+}
+
+// runDuration returns the time from a run's start to its last update
+func runDuration(run *gh.WorkflowRun) time.Duration {
+	start := run.GetRunStartedAt().Time
+	if start.IsZero() {
+		start = run.GetCreatedAt().Time
+	}
+	return run.GetUpdatedAt().Sub(start)
+}
+
+// span returns the time between two optional timestamps, or zero when either is missing
+func span(start, end *gh.Timestamp) time.Duration {
+	if start == nil || end == nil {
+		return 0
+	}
+	return end.Sub(start.Time)
+}
+
+// or returns fallback for an empty value
+func or(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action is the parsed form of an action's metadata file, action.yml
+type Action struct {
+	Name      string        `json:"name,omitempty"`
+	Inputs    []ActionInput `json:"inputs,omitempty"`
+	Branding  bool          `json:"branding"`
+	Using     string        `json:"using"` // runs.using: composite, node20, docker...
+	UsingLine int           `json:"using_line,omitempty"`
+	Image     string        `json:"image,omitempty"` // runs.image of a Docker action: a Dockerfile or docker:// image
+	ImageLine int           `json:"image_line,omitempty"`
+	Steps     []*Step       `json:"steps,omitempty"` // steps of a composite action
+
+	// Root is the document's top-level mapping node
+	Root *yaml.Node `json:"-"`
+}
+
+// ActionInput is a single entry under an action's inputs:
+type ActionInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Line        int    `json:"line"`
+}
+
+// ParseAction decodes action metadata YAML into an Action, keeping line numbers
+// of each step
+func ParseAction(content string) (*Action, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("action is empty")
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: action must be a mapping", root.Line)
+	}
+
+	action := &Action{Root: root}
+	if name := Lookup(root, "name"); name != nil {
+		action.Name = name.Value
+	}
+	for _, pair := range Pairs(Lookup(root, "inputs")) {
+		input := ActionInput{Name: pair[0].Value, Line: pair[0].Line}
+		if description := Lookup(pair[1], "description"); description != nil {
+			input.Description = description.Value
+		}
+		action.Inputs = append(action.Inputs, input)
+	}
+	action.Branding = Lookup(root, "branding") != nil
+	runs := Lookup(root, "runs")
+	if using := Lookup(runs, "using"); using != nil {
+		action.Using, action.UsingLine = using.Value, using.Line
+	}
+	if image := Lookup(runs, "image"); image != nil {
+		action.Image, action.ImageLine = image.Value, image.Line
+	}
+	if steps := Lookup(runs, "steps"); steps != nil && steps.Kind == yaml.SequenceNode {
+		for i, stepNode := range steps.Content {
+			action.Steps = append(action.Steps, parseStep(i, stepNode))
+		}
+	}
+	return action, nil
+}
+
+// Composite reports whether the action runs steps rather than a program
+func (a *Action) Composite() bool {
+	return a.Using == "composite"
+}
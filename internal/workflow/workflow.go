@@ -0,0 +1,403 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Workflow is the parsed form of a GitHub Actions workflow file
+type Workflow struct {
+	Name           string            `json:"name,omitempty"`
+	On             []string          `json:"on"`
+	OnLine         int               `json:"on_line,omitempty"`
+	TriggeredBy    []string          `json:"triggered_by,omitempty"` // workflow names listed under on.workflow_run
+	Permissions    map[string]string `json:"permissions,omitempty"`
+	HasPerms       bool              `json:"has_permissions"`
+	HasConcurrency bool              `json:"has_concurrency"`
+	Env            map[string]string `json:"env,omitempty"`
+	DefaultShell   string            `json:"default_shell,omitempty"` // defaults.run.shell
+	Jobs           []*Job            `json:"jobs"`
+
+	// Root is the document's top-level mapping node
+	Root *yaml.Node `json:"-"`
+	// Lines are the source lines, to locate text within multi-line scalars
+	Lines []string `json:"-"`
+}
+
+// Job is a single entry under jobs:
+type Job struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name,omitempty"`
+	Line         int               `json:"line"`
+	RunsOn       []string          `json:"runs_on,omitempty"`
+	RunsOnLine   int               `json:"runs_on_line,omitempty"`
+	Needs        []string          `json:"needs,omitempty"`
+	If           string            `json:"if,omitempty"`
+	Uses         string            `json:"uses,omitempty"`
+	Environment  string            `json:"environment,omitempty"`
+	Permissions  map[string]string `json:"permissions,omitempty"`
+	HasPerms     bool              `json:"has_permissions"`
+	HasMatrix    bool              `json:"has_matrix"`
+	Env          map[string]string `json:"env,omitempty"`
+	DefaultShell string            `json:"default_shell,omitempty"` // defaults.run.shell
+	Container    string            `json:"container,omitempty"`     // job container image
+	Services     map[string]string `json:"services,omitempty"`      // service name → image
+	Steps        []*Step           `json:"steps,omitempty"`
+
+	Node *yaml.Node `json:"-"`
+}
+
+// Step is a single entry under a job's steps:
+type Step struct {
+	Index           int               `json:"index"`
+	ID              string            `json:"id,omitempty"`
+	Name            string            `json:"name,omitempty"`
+	Line            int               `json:"line"`
+	Uses            string            `json:"uses,omitempty"`
+	Run             string            `json:"run,omitempty"`
+	RunLine         int               `json:"run_line,omitempty"`
+	Shell           string            `json:"shell,omitempty"`
+	If              string            `json:"if,omitempty"`
+	With            map[string]string `json:"with,omitempty"`
+	Env             map[string]string `json:"env,omitempty"`
+	ContinueOnError string            `json:"continue_on_error,omitempty"`
+
+	Node *yaml.Node `json:"-"`
+}
+
+// DisplayName returns the step's name, falling back to its action or index
+func (s *Step) DisplayName() string {
+	switch {
+	case s.Name != "":
+		return s.Name
+	case s.Uses != "":
+		return s.Uses
+	default:
+		return fmt.Sprintf("step %d", s.Index+1)
+	}
+}
+
+// Parse decodes workflow YAML into a Workflow, keeping line numbers of each element
+func Parse(content string) (*Workflow, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("workflow is empty")
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: workflow must be a mapping", root.Line)
+	}
+
+	wf := &Workflow{Root: root, Lines: strings.Split(content, "\n")}
+	for _, pair := range Pairs(root) {
+		key, value := pair[0], pair[1]
+		switch key.Value {
+		case "name":
+			wf.Name = value.Value
+		case "on":
+			wf.OnLine = key.Line
+			wf.On = triggerNames(value)
+			wf.TriggeredBy = Strings(Lookup(Lookup(value, "workflow_run"), "workflows"))
+		case "permissions":
+			wf.HasPerms = true
+			wf.Permissions = permissions(value)
+		case "concurrency":
+			wf.HasConcurrency = true
+		case "env":
+			wf.Env = stringMap(value)
+		case "defaults":
+			wf.DefaultShell = defaultShell(value)
+		case "jobs":
+			for _, jobPair := range Pairs(value) {
+				wf.Jobs = append(wf.Jobs, parseJob(jobPair[0], jobPair[1]))
+			}
+		}
+	}
+
+	return wf, nil
+}
+
+// Job returns the job with the given ID, or nil
+func (w *Workflow) Job(id string) *Job {
+	for _, job := range w.Jobs {
+		if job.ID == id {
+			return job
+		}
+	}
+	return nil
+}
+
+// HasTrigger reports whether the workflow is triggered by event
+func (w *Workflow) HasTrigger(event string) bool {
+	for _, on := range w.On {
+		if on == event {
+			return true
+		}
+	}
+	return false
+}
+
+func parseJob(key, value *yaml.Node) *Job {
+	job := &Job{ID: key.Value, Line: key.Line, Node: value}
+	for _, pair := range Pairs(value) {
+		k, v := pair[0], pair[1]
+		switch k.Value {
+		case "name":
+			job.Name = v.Value
+		case "runs-on":
+			job.RunsOnLine = k.Line
+			job.RunsOn = runsOn(v)
+		case "needs":
+			job.Needs = Strings(v)
+		case "if":
+			job.If = v.Value
+		case "uses":
+			job.Uses = v.Value
+		case "environment":
+			if v.Kind == yaml.MappingNode {
+				if name := Lookup(v, "name"); name != nil {
+					job.Environment = name.Value
+				}
+			} else {
+				job.Environment = v.Value
+			}
+		case "permissions":
+			job.HasPerms = true
+			job.Permissions = permissions(v)
+		case "strategy":
+			job.HasMatrix = Lookup(v, "matrix") != nil
+		case "env":
+			job.Env = stringMap(v)
+		case "defaults":
+			job.DefaultShell = defaultShell(v)
+		case "container":
+			job.Container = containerImage(v)
+		case "services":
+			job.Services = make(map[string]string)
+			for _, service := range Pairs(v) {
+				job.Services[service[0].Value] = containerImage(service[1])
+			}
+		case "steps":
+			for i, stepNode := range v.Content {
+				job.Steps = append(job.Steps, parseStep(i, stepNode))
+			}
+		}
+	}
+	return job
+}
+
+// MatrixValues returns the values a matrix key takes, including those added by
+// include entries. ok is false when the matrix is built by an expression, such as
+// fromJSON, so its values aren't known before the run.
+func (j *Job) MatrixValues(key string) (values []string, ok bool) {
+	matrix := Lookup(Lookup(j.Node, "strategy"), "matrix")
+	if matrix == nil || matrix.Kind != yaml.MappingNode {
+		return nil, false
+	}
+	if v := Lookup(matrix, key); v != nil {
+		if v.Kind != yaml.SequenceNode {
+			return nil, false
+		}
+		values = Strings(v)
+	}
+	if include := Lookup(matrix, "include"); include != nil {
+		if include.Kind != yaml.SequenceNode {
+			return nil, false
+		}
+		for _, entry := range include.Content {
+			if v := Lookup(entry, key); v != nil {
+				values = append(values, v.Value)
+			}
+		}
+	}
+	return values, len(values) > 0
+}
+
+func parseStep(index int, node *yaml.Node) *Step {
+	step := &Step{Index: index, Line: node.Line, Node: node}
+	for _, pair := range Pairs(node) {
+		k, v := pair[0], pair[1]
+		switch k.Value {
+		case "id":
+			step.ID = v.Value
+		case "name":
+			step.Name = v.Value
+		case "uses":
+			step.Uses = v.Value
+		case "run":
+			step.Run = v.Value
+			step.RunLine = ScalarStartLine(v)
+		case "shell":
+			step.Shell = v.Value
+		case "if":
+			step.If = v.Value
+		case "with":
+			step.With = stringMap(v)
+		case "env":
+			step.Env = stringMap(v)
+		case "continue-on-error":
+			step.ContinueOnError = v.Value
+		}
+	}
+	return step
+}
+
+// ScalarStartLine returns the line where a scalar's text begins; block scalars
+// (| and >) start on the line after their indicator
+func ScalarStartLine(node *yaml.Node) int {
+	if node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return node.Line + 1
+	}
+	return node.Line
+}
+
+// ScalarLine returns the line on which text appears in a scalar node, or where
+// the scalar begins when it doesn't appear verbatim. Folded, plain and quoted
+// scalars join several source lines into one line of their value, so the line
+// is looked up in the source lines the node spans: from its position on, as far
+// as the lines are indented deeper than the one it starts on.
+func ScalarLine(lines []string, node *yaml.Node, text string) int {
+	if node.Line < 1 || node.Line > len(lines) {
+		return ScalarStartLine(node)
+	}
+	indent := func(line string) int {
+		return len(line) - len(strings.TrimLeft(line, " "))
+	}
+	parent := indent(lines[node.Line-1])
+	for i := node.Line - 1; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if i >= node.Line && strings.TrimSpace(line) != "" && indent(line) <= parent {
+			break
+		}
+		if strings.Contains(line, text) {
+			return i + 1
+		}
+	}
+	return ScalarStartLine(node)
+}
+
+// Pairs returns the key/value node pairs of a mapping node
+func Pairs(node *yaml.Node) [][2]*yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	pairs := make([][2]*yaml.Node, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs = append(pairs, [2]*yaml.Node{node.Content[i], node.Content[i+1]})
+	}
+	return pairs
+}
+
+// Lookup returns the value node for key in a mapping node, or nil
+func Lookup(node *yaml.Node, key string) *yaml.Node {
+	for _, pair := range Pairs(node) {
+		if pair[0].Value == key {
+			return pair[1]
+		}
+	}
+	return nil
+}
+
+// Strings flattens a scalar or sequence of scalars into a string slice
+func Strings(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return []string{node.Value}
+	case yaml.SequenceNode:
+		var values []string
+		for _, item := range node.Content {
+			if item.Kind == yaml.ScalarNode {
+				values = append(values, item.Value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// Scalars returns every scalar value under node, keys included, in document order
+func Scalars(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}
+	}
+	var values []string
+	for _, child := range node.Content {
+		values = append(values, Scalars(child)...)
+	}
+	return values
+}
+
+func triggerNames(node *yaml.Node) []string {
+	if node.Kind == yaml.MappingNode {
+		var names []string
+		for _, pair := range Pairs(node) {
+			names = append(names, pair[0].Value)
+		}
+		return names
+	}
+	return Strings(node)
+}
+
+func runsOn(node *yaml.Node) []string {
+	if node.Kind == yaml.MappingNode {
+		labels := Strings(Lookup(node, "labels"))
+		if group := Lookup(node, "group"); group != nil {
+			labels = append(labels, "group:"+group.Value)
+		}
+		return labels
+	}
+	return Strings(node)
+}
+
+func defaultShell(node *yaml.Node) string {
+	if shell := Lookup(Lookup(node, "run"), "shell"); shell != nil {
+		return shell.Value
+	}
+	return ""
+}
+
+// containerImage returns the image of a container or service, which is either
+// the image itself or a mapping with an image key
+func containerImage(node *yaml.Node) string {
+	if node.Kind == yaml.MappingNode {
+		if image := Lookup(node, "image"); image != nil {
+			return image.Value
+		}
+		return ""
+	}
+	return node.Value
+}
+
+func permissions(node *yaml.Node) map[string]string {
+	if node.Kind == yaml.ScalarNode {
+		if node.Value == "" || node.Value == "{}" {
+			return map[string]string{}
+		}
+		return map[string]string{"*": node.Value}
+	}
+	return stringMap(node)
+}
+
+func stringMap(node *yaml.Node) map[string]string {
+	pairs := Pairs(node)
+	if pairs == nil {
+		return nil
+	}
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		values[pair[0].Value] = pair[1].Value
+	}
+	return values
+}
@@ -0,0 +1,335 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/analyzer"
+	"github.com/somaz94/github-action-analyzer/internal/github"
+	"github.com/somaz94/github-action-analyzer/internal/i18n"
+	"github.com/somaz94/github-action-analyzer/internal/models"
+	"github.com/somaz94/github-action-analyzer/internal/policy"
+	"github.com/somaz94/github-action-analyzer/pkg/report"
+)
+
+// Mode selects how much data is fetched for each workflow run
+type Mode = analyzer.Mode
+
+// Analysis modes
+const (
+	// ModeSurvey uses run and job metadata only
+	ModeSurvey = analyzer.ModeSurvey
+	// ModeDeep also downloads job logs, the default
+	ModeDeep = analyzer.ModeDeep
+)
+
+// settings collects the Options passed to New
+type settings struct {
+	httpClient  *http.Client
+	baseURL     string
+	cacheDir    string
+	logCacheDir string
+	mode        Mode
+	lang        string
+	channel     string
+	source      string
+	sampleSize  int
+	sample      string
+	timeout     time.Duration
+	progress    io.Writer
+	debug       bool
+	styleChecks bool
+	confidence  float64
+	maskedRate  float64
+	policyFile  string
+	policyDir   string
+	depth       int
+}
+
+// Option configures an Analyzer
+type Option func(*settings)
+
+// WithHTTPClient sends API requests through hc, e.g. one with a proxy or a
+// custom CA. The token is added on top of its transport.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(s *settings) {
+		s.httpClient = hc
+	}
+}
+
+// WithBaseURL sets the API URL of a GitHub Enterprise Server instance, e.g.
+// https://ghes.example.com/api/v3
+func WithBaseURL(apiURL string) Option {
+	return func(s *settings) {
+		s.baseURL = apiURL
+	}
+}
+
+// WithCacheDir keeps API responses in dir between analyses, so only new runs
+// are fetched. Call Close to write the cache back.
+func WithCacheDir(dir string) Option {
+	return func(s *settings) {
+		s.cacheDir = dir
+	}
+}
+
+// WithLogCacheDir keeps the downloaded logs of completed jobs in dir, so
+// analyzing the same runs again doesn't download them again
+func WithLogCacheDir(dir string) Option {
+	return func(s *settings) {
+		s.logCacheDir = dir
+	}
+}
+
+// WithMode sets the analysis mode, ModeDeep by default
+func WithMode(mode Mode) Option {
+	return func(s *settings) {
+		s.mode = mode
+	}
+}
+
+// WithLang sets the language of the report: en (the default), ko or ja
+func WithLang(lang string) Option {
+	return func(s *settings) {
+		s.lang = lang
+	}
+}
+
+// WithVersionChannel sets the release line of the language versions suggested
+// in examples: lts (the default) or latest
+func WithVersionChannel(channel string) Option {
+	return func(s *settings) {
+		s.channel = channel
+	}
+}
+
+// WithVersionSource sets where language versions come from: github (the
+// default) or endoflife for endoflife.date, which also has setup steps installing
+// end-of-life versions reported. endoflife.date is called through the client
+// given to WithHTTPClient.
+func WithVersionSource(source string) Option {
+	return func(s *settings) {
+		s.source = source
+	}
+}
+
+// WithSampleSize sets how many runs are analyzed in depth
+func WithSampleSize(n int) Option {
+	return func(s *settings) {
+		s.sampleSize = n
+	}
+}
+
+// WithSample selects the runs analyzed in depth: latest:N, random:N or per-branch:N
+func WithSample(sample string) Option {
+	return func(s *settings) {
+		s.sample = sample
+	}
+}
+
+// WithAnalyzeDepth sets how many levels of called reusable workflows and actions
+// are fetched and checked, 1 by default; 0 only checks the workflow
+func WithAnalyzeDepth(depth int) Option {
+	return func(s *settings) {
+		s.depth = depth
+	}
+}
+
+// WithTimeout sets the deadline for each workflow's analysis
+func WithTimeout(d time.Duration) Option {
+	return func(s *settings) {
+		s.timeout = d
+	}
+}
+
+// WithProgress prints the progress of the analysis stages to w. Progress isn't
+// printed by default.
+func WithProgress(w io.Writer) Option {
+	return func(s *settings) {
+		s.progress = w
+	}
+}
+
+// WithDebug prints debug information to stdout
+func WithDebug(enabled bool) Option {
+	return func(s *settings) {
+		s.debug = enabled
+	}
+}
+
+// WithStyleChecks adds the optional style rules
+func WithStyleChecks(enabled bool) Option {
+	return func(s *settings) {
+		s.styleChecks = enabled
+	}
+}
+
+// WithMinConfidence drops heuristic findings drawn from job logs whose
+// confidence, from 0 to 1, is below min
+func WithMinConfidence(min float64) Option {
+	return func(s *settings) {
+		s.confidence = min
+	}
+}
+
+// WithMaskedFailureRate reports continue-on-error steps failing in more than
+// rate of the successful runs, from 0 up to but not including 1, 0.2 by default
+func WithMaskedFailureRate(rate float64) Option {
+	return func(s *settings) {
+		s.maskedRate = rate
+	}
+}
+
+// WithPolicyFile enforces the YAML policy of allowed runner labels, actions and
+// permissions in file, reporting violations under the policy category
+func WithPolicyFile(file string) Option {
+	return func(s *settings) {
+		s.policyFile = file
+	}
+}
+
+// WithPolicyDir evaluates the Rego policies of package analyzer in dir
+func WithPolicyDir(dir string) Option {
+	return func(s *settings) {
+		s.policyDir = dir
+	}
+}
+
+// Analyzer analyzes the GitHub Actions workflows of repositories. It is safe to
+// analyze several workflows one after another with the same Analyzer.
+type Analyzer struct {
+	analyzer *analyzer.Analyzer
+	cache    *github.CachedClient
+}
+
+// New creates an Analyzer calling the GitHub API with token. An empty token
+// analyzes public repositories anonymously, with a lower rate limit.
+func New(token string, opts ...Option) (*Analyzer, error) {
+	s := &settings{progress: io.Discard, depth: analyzer.DefaultAnalyzeDepth, maskedRate: analyzer.DefaultMaskedFailureRate}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	lang, err := i18n.ParseLang(s.lang)
+	if err != nil {
+		return nil, err
+	}
+	sampling, err := analyzer.ParseSampling(s.sample)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := analyzer.ParseMode(string(s.mode))
+	if err != nil {
+		return nil, err
+	}
+	channel, err := analyzer.ParseVersionChannel(s.channel)
+	if err != nil {
+		return nil, err
+	}
+	source, err := analyzer.ParseVersionSource(s.source)
+	if err != nil {
+		return nil, err
+	}
+	if s.maskedRate < 0 || s.maskedRate >= 1 {
+		return nil, fmt.Errorf("masked failure rate must be from 0 up to but not including 1, got %g", s.maskedRate)
+	}
+
+	clientOpts := []github.ClientOption{github.WithBaseURL(s.baseURL), github.WithLogCache(s.logCacheDir)}
+	if s.httpClient != nil {
+		clientOpts = append(clientOpts, github.WithHTTPClient(s.httpClient))
+	}
+	ghClient := github.NewClient(token, clientOpts...)
+	a := &Analyzer{}
+	var client analyzer.GithubClient = ghClient
+	if s.cacheDir != "" {
+		if a.cache, err = github.NewCachedClient(ghClient, s.cacheDir); err != nil {
+			return nil, err
+		}
+		client = a.cache
+	}
+
+	options := []analyzer.Option{
+		analyzer.WithMode(mode),
+		analyzer.WithLang(lang),
+		analyzer.WithVersionChannel(channel),
+		analyzer.WithSampleSize(s.sampleSize),
+		analyzer.WithSampling(sampling),
+		analyzer.WithAnalyzeDepth(s.depth),
+		analyzer.WithTimeout(s.timeout),
+		analyzer.WithProgress(s.progress),
+		analyzer.WithStyleChecks(s.styleChecks),
+		analyzer.WithMinConfidence(s.confidence),
+		analyzer.WithMaskedFailureRate(s.maskedRate),
+	}
+	if source == analyzer.SourceEndOfLife {
+		options = append(options, analyzer.WithVersionChecker(analyzer.NewEndOfLifeChecker(s.httpClient, channel)))
+	} else {
+		options = append(options, analyzer.WithVersionChecker(analyzer.NewGitHubVersionChecker(client, s.httpClient, channel)))
+	}
+	if s.policyFile != "" {
+		p, err := policy.Load(s.policyFile)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, analyzer.WithPolicy(p))
+	}
+	if s.policyDir != "" {
+		r, err := policy.LoadRego(context.Background(), s.policyDir)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, analyzer.WithRegoPolicies(r))
+	}
+	a.analyzer = analyzer.NewAnalyzer(client, s.debug, options...)
+	return a, nil
+}
+
+// Analyze analyzes one workflow of owner/repo, given as its file name, e.g. ci.yml,
+// or its path under .github/workflows
+func (a *Analyzer) Analyze(ctx context.Context, owner, repo, workflowFile string) (*report.Report, error) {
+	return toReport(a.analyzer.Analyze(ctx, owner, repo, workflowFile))
+}
+
+// AnalyzeWorkflows analyzes several workflows of owner/repo and merges their reports
+func (a *Analyzer) AnalyzeWorkflows(ctx context.Context, owner, repo string, workflowFiles []string) (*report.Report, error) {
+	return toReport(a.analyzer.AnalyzeWorkflows(ctx, owner, repo, workflowFiles))
+}
+
+// toReport converts the analyzer's internal report to the stable report type
+func toReport(m *models.PerformanceReport, err error) (*report.Report, error) {
+	if m == nil {
+		return nil, err
+	}
+	r := &report.Report{}
+	models.Mirror(r, m)
+	return r, err
+}
+
+// GeneratedWorkflow is a workflow file rewritten with the analyzer's fixes
+type GeneratedWorkflow = analyzer.GeneratedWorkflow
+
+// Fixes a generated workflow can make
+const (
+	FixPermissions  = analyzer.FixPermissions
+	FixConcurrency  = analyzer.FixConcurrency
+	FixCache        = analyzer.FixCache
+	FixRunnerImages = analyzer.FixRunnerImages
+	FixPinning      = analyzer.FixPinning
+)
+
+// Generate rewrites a workflow of owner/repo with the given fixes, or all of
+// them when none are given, for review before it replaces the original
+func (a *Analyzer) Generate(ctx context.Context, owner, repo, workflowFile string, fixes ...string) (*GeneratedWorkflow, error) {
+	return a.analyzer.Generate(ctx, owner, repo, workflowFile, fixes)
+}
+
+// Close writes the API response cache back to its directory, if one was set
+func (a *Analyzer) Close() error {
+	if a.cache == nil {
+		return nil
+	}
+	return a.cache.Save()
+}
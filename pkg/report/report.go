@@ -0,0 +1,138 @@
+package report
+
+import (
+	"io"
+	"time"
+
+	"github.com/somaz94/github-action-analyzer/internal/models"
+)
+
+// Report is the result of analyzing one workflow, or several merged with Merge.
+// Its fields and their JSON names are part of the stable API: fields are only
+// added, never renamed or removed, within a major version.
+type Report struct {
+	Repository           string                `json:"repository"`
+	WorkflowFile         string                `json:"workflow_file"`
+	TotalExecutionTime   time.Duration         `json:"total_execution_time"`
+	RunStats             *RunStats             `json:"run_stats,omitempty"`
+	Trend                *RunTrend             `json:"trend,omitempty"`
+	Cost                 *CostEstimate         `json:"cost,omitempty"`
+	Sampling             string                `json:"sampling,omitempty"`
+	SlowSteps            []StepAnalysis        `json:"slow_steps"`
+	CacheRecommendations []CacheRecommendation `json:"cache_recommendations"`
+	CacheUsage           *CacheUsage           `json:"cache_usage,omitempty"`
+	WorkflowChain        *WorkflowChain        `json:"workflow_chain,omitempty"`
+	Timeline             *RunTimeline          `json:"timeline,omitempty"` // the newest run analyzed in deep mode, of each workflow when merged
+	Jobs                 []JobNode             `json:"jobs,omitempty"`
+	DORA                 *DORAMetrics          `json:"dora,omitempty"`
+	ForkExposure         *ForkExposure         `json:"fork_exposure,omitempty"`
+	RequiredChecks       *RequiredChecks       `json:"required_checks,omitempty"`
+	MergeQueue           *MergeQueue           `json:"merge_queue,omitempty"`
+	Heatmap              *RunHeatmap           `json:"heatmap,omitempty"`
+	Owners               []string              `json:"owners,omitempty"` // owners of the workflow file from CODEOWNERS
+	Teams                []TeamSummary         `json:"teams,omitempty"`  // findings and cost by owner, in merged reports
+	StarterWorkflows     []StarterWorkflow     `json:"starter_workflows,omitempty"`
+	ReleaseHardening     []ReleaseHardening    `json:"release_hardening,omitempty"`
+	Fingerprints         []WorkflowFingerprint `json:"fingerprints,omitempty"` // structure of the workflow files, to find copies across repositories
+	DockerOptimizations  []DockerOptimization  `json:"docker_optimizations"`
+	CostSavingTips       []string              `json:"cost_saving_tips"`
+	WorkflowAnalysis     *WorkflowAnalysis     `json:"workflow_analysis"`
+	Findings             []Finding             `json:"findings"`
+	Sustainability       *Sustainability       `json:"sustainability,omitempty"`
+	Migrations           []MigrationAdvice     `json:"migrations,omitempty"`
+	Secrets              *SecretsInventory     `json:"secrets,omitempty"`
+	Patches              string                `json:"patches,omitempty"` // unified diff for git apply
+	Partial              bool                  `json:"partial"`
+	CachedAt             *time.Time            `json:"cached_at,omitempty"` // reused from an analysis at this time
+	Digest               *Digest               `json:"digest,omitempty"`    // changes since the previous analysis, in digest mode
+	Adoption             *Adoption             `json:"adoption,omitempty"`  // recommendations adopted since the previous analysis
+	SkippedStages        []string              `json:"skipped_stages,omitempty"`
+	PullRequest          int                   `json:"pull_request,omitempty"`
+	HeadSHA              string                `json:"head_sha,omitempty"`
+	Lang                 string                `json:"lang,omitempty"` // en, ko or ja
+	Plain                bool                  `json:"-"`
+	Audience             string                `json:"-"` // one of the Audience constants; empty for developers
+	OutputEncoding       string                `json:"-"` // EncodingRaw or EncodingBase64 for the step outputs
+	Metrics              struct {
+		AverageStepDuration time.Duration `json:"average_step_duration"`
+		MaxStepDuration     time.Duration `json:"max_step_duration"`
+		TotalSteps          int           `json:"total_steps"`
+		FailedSteps         int           `json:"failed_steps"`
+	} `json:"metrics"`
+}
+
+// Finding severities
+const (
+	SeverityInfo     = models.SeverityInfo
+	SeverityWarning  = models.SeverityWarning
+	SeverityCritical = models.SeverityCritical
+)
+
+// Fork exposure ratings
+const (
+	ExposureNone   = models.ExposureNone
+	ExposureLow    = models.ExposureLow
+	ExposureMedium = models.ExposureMedium
+	ExposureHigh   = models.ExposureHigh
+)
+
+// Report audiences, set in Report.Audience before rendering
+const (
+	AudienceDeveloper = models.AudienceDeveloper
+	AudienceManager   = models.AudienceManager
+	AudienceSecurity  = models.AudienceSecurity
+)
+
+// Encodings of the step outputs, set in Report.OutputEncoding before rendering
+const (
+	EncodingRaw    = models.EncodingRaw
+	EncodingBase64 = models.EncodingBase64
+)
+
+// Output formats for Render
+const (
+	FormatConsole      = models.FormatConsole
+	FormatMarkdown     = models.FormatMarkdown
+	FormatJSON         = models.FormatJSON
+	FormatGitHubOutput = models.FormatGitHubOutput
+	FormatSecrets      = models.FormatSecrets
+	FormatHTML         = models.FormatHTML
+)
+
+// Formats lists the supported output formats
+func Formats() []string {
+	return models.Formats()
+}
+
+// Render writes r to w in one of the Formats
+func Render(w io.Writer, r *Report, format string) error {
+	renderer, err := models.NewRenderer(format)
+	if err != nil {
+		return err
+	}
+	return renderer.Render(w, toModel(r))
+}
+
+// Merge combines the reports of several workflows of one repository into one
+func Merge(reports ...*Report) *Report {
+	merged := make([]*models.PerformanceReport, len(reports))
+	for i, r := range reports {
+		merged[i] = toModel(r)
+	}
+	r := &Report{}
+	models.Mirror(r, models.MergeReports(merged))
+	return r
+}
+
+// Grade scores the workflow from 100 down by its findings and maps the score to a
+// letter: A from 90, B from 80, C from 70, D from 60 and F below
+func (r *Report) Grade() (letter string, score int) {
+	return toModel(r).Grade()
+}
+
+// toModel converts r to the analyzer's internal report, which renders it
+func toModel(r *Report) *models.PerformanceReport {
+	m := &models.PerformanceReport{}
+	models.Mirror(m, r)
+	return m
+}
@@ -0,0 +1,444 @@
+package report
+
+import "time"
+
+// RunStats aggregates run durations by conclusion. Runs still in progress,
+// cancelled or skipped are only counted, as their durations would skew the timings.
+type RunStats struct {
+	Successful RunDurations  `json:"successful"`
+	Failed     RunDurations  `json:"failed"` // failed or timed out
+	Cancelled  CancelledRuns `json:"cancelled"`
+	Excluded   int           `json:"excluded"` // in progress, skipped or neutral
+}
+
+// RunTrend compares the older and the newer half of the analyzed runs. Durations
+// are of successful runs only; failures are counted over successful and failed runs.
+type RunTrend struct {
+	Previous       RunDurations `json:"previous"`
+	Recent         RunDurations `json:"recent"`
+	PreviousFailed int          `json:"previous_failed"`
+	RecentFailed   int          `json:"recent_failed"`
+	PreviousRuns   int          `json:"previous_runs"` // successful and failed
+	RecentRuns     int          `json:"recent_runs"`
+}
+
+// CostEstimate prices the runner minutes of the analyzed runs in USD
+type CostEstimate struct {
+	Runs            int         `json:"runs"`
+	Entries         []CostEntry `json:"entries"`
+	Minutes         float64     `json:"minutes"`
+	BillableMinutes int         `json:"billable_minutes"`
+	Cost            float64     `json:"cost"`
+	MonthlyCost     float64     `json:"monthly_cost"`
+	Assumptions     string      `json:"assumptions"`
+
+	// Attribution ranks the branches and pull requests the runs were for by cost
+	Attribution []CostAttribution `json:"attribution,omitempty"`
+}
+
+// StepAnalysis is a step of the sampled runs and how long it took
+type StepAnalysis struct {
+	Name            string        `json:"name"`
+	ExecutionTime   time.Duration `json:"execution_time"`
+	IsSlowStep      bool          `json:"is_slow_step"`
+	Recommendations []string      `json:"recommendations"`
+}
+
+// CacheRecommendation suggests a path to cache and how
+type CacheRecommendation struct {
+	Path             string   `json:"path"`
+	Directory        string   `json:"directory,omitempty"` // subproject the recommendation is scoped to
+	Description      string   `json:"description"`
+	Impact           string   `json:"impact"`
+	Example          string   `json:"example"`
+	EstimatedSavings *Savings `json:"estimated_savings,omitempty"`
+
+	// Confidence rates recommendations for a tool only job logs mention, as Finding's does
+	Confidence float64 `json:"confidence,omitempty"`
+}
+
+// CacheUsage summarizes the repository's Actions cache against its size limit
+type CacheUsage struct {
+	Bytes            int64              `json:"bytes"`
+	Count            int                `json:"count"`
+	LimitBytes       int64              `json:"limit_bytes"`
+	StaleBytes       int64              `json:"stale_bytes"` // not accessed recently
+	StaleCount       int                `json:"stale_count"`
+	PullRequestBytes int64              `json:"pull_request_bytes"` // only usable by the pull request that saved them
+	ExcessiveKeys    []CacheKeyVariants `json:"excessive_keys,omitempty"`
+	Recommendations  []string           `json:"recommendations,omitempty"`
+}
+
+// WorkflowChain is the end-to-end latency of workflows linked by workflow_run triggers
+type WorkflowChain struct {
+	Stages      []ChainStage  `json:"stages"`
+	Runs        int           `json:"runs"`
+	AvgLeadTime time.Duration `json:"avg_lead_time"`
+	MaxLeadTime time.Duration `json:"max_lead_time"`
+}
+
+// RunTimeline is when each job and step of one run started and finished,
+// relative to the run's start, for the Gantt chart of the HTML report
+type RunTimeline struct {
+	RunID      int64         `json:"run_id"`
+	RunNumber  int           `json:"run_number"`
+	URL        string        `json:"url,omitempty"`
+	Conclusion string        `json:"conclusion"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	Jobs       []TimelineJob `json:"jobs"`
+}
+
+// JobNode is a job of the analyzed workflow with the jobs it needs and its
+// average duration over the sampled runs
+type JobNode struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name,omitempty"`
+	Needs       []string      `json:"needs,omitempty"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	Runs        int           `json:"runs"` // measured job runs, matrix expansions included
+}
+
+// DORAMetrics are the four DORA delivery metrics measured from deploy workflow runs
+type DORAMetrics struct {
+	Workflows         []string      `json:"workflows"`
+	RollbackWorkflows []string      `json:"rollback_workflows,omitempty"` // their runs count as rollbacks, not deployments
+	Period            time.Duration `json:"period"`                       // from the oldest deployment considered until now
+	Deployments       int           `json:"deployments"`
+	FailedDeployments int           `json:"failed_deployments"`
+	DeploysPerWeek    float64       `json:"deploys_per_week"`
+	LeadTime          time.Duration `json:"lead_time"` // median, from commit to successful deployment
+	ChangeFailureRate float64       `json:"change_failure_rate"`
+	Recoveries        int           `json:"recoveries"`
+	TimeToRestore     time.Duration `json:"time_to_restore,omitempty"` // median, from a failed deployment to the next successful one
+	Rollbacks         int           `json:"rollbacks"`
+	RollbackRate      float64       `json:"rollback_rate"`              // rollbacks as a share of successful deployments, which rollback workflows' runs aren't
+	TimeToRollback    time.Duration `json:"time_to_rollback,omitempty"` // mean, from a deployment to the rollback undoing it
+	FrequencyLevel    string        `json:"frequency_level"`
+	LeadTimeLevel     string        `json:"lead_time_level,omitempty"`
+	FailureRateLevel  string        `json:"failure_rate_level"`
+	RestoreLevel      string        `json:"restore_level,omitempty"`
+}
+
+// ForkExposure rates how much a malicious pull request from a fork can reach
+// through the repository's workflows. Rating is the highest of its workflows'.
+type ForkExposure struct {
+	Rating    string                `json:"rating"`
+	Workflows []ForkExposedWorkflow `json:"workflows"`
+}
+
+// RequiredChecks maps the status checks the default branch requires, through
+// branch protection or rulesets, to the workflow jobs reporting them
+type RequiredChecks struct {
+	Branch string          `json:"branch"`
+	Checks []RequiredCheck `json:"checks"`
+}
+
+// MergeQueue summarizes the runs a merge queue triggered through merge_group,
+// apart from the workflow's other runs. An entry's latency runs from the queue
+// creating its merge group to the group's run finishing.
+type MergeQueue struct {
+	Merged RunDurations    `json:"merged"` // entries whose checks passed
+	Failed RunDurations    `json:"failed"` // entries removed from the queue by a failed check
+	PerDay float64         `json:"per_day"`
+	Period time.Duration   `json:"period"` // from the oldest to the newest entry
+	Jobs   []MergeQueueJob `json:"jobs,omitempty"`
+}
+
+// RunHeatmap counts run starts by day of the week and hour in UTC, revealing the
+// windows where runs contend for runners
+type RunHeatmap struct {
+	Counts [7][24]int `json:"counts"` // by time.Weekday, Sunday first, and hour
+	Runs   int        `json:"runs"`
+}
+
+// TeamSummary is the share of several reports' findings and cost that falls to
+// one owner from CODEOWNERS. A workflow owned by several teams counts fully for
+// each of them; Team is empty for workflows and files nobody owns.
+type TeamSummary struct {
+	Team            string   `json:"team"`
+	Repositories    []string `json:"repositories"`
+	Workflows       []string `json:"workflows"`
+	Findings        int      `json:"findings"`
+	Critical        int      `json:"critical"`
+	Warnings        int      `json:"warnings"`
+	BillableMinutes int      `json:"billable_minutes"`
+	Cost            float64  `json:"cost"`
+	MonthlyCost     float64  `json:"monthly_cost"`
+}
+
+// StarterWorkflow is a workflow template of an organization's .github repository
+// with the findings every repository created from it starts out with
+type StarterWorkflow struct {
+	Path       string   `json:"path"`
+	Name       string   `json:"name"`
+	Findings   int      `json:"findings"`
+	Critical   int      `json:"critical"`
+	Warnings   int      `json:"warnings"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// ReleaseHardening is how well a workflow triggered by tags or releases guards
+// what it publishes
+type ReleaseHardening struct {
+	File          string   `json:"file"`
+	Triggers      []string `json:"triggers"`       // e.g. "push tags v*.*.*" or "release published"
+	TagFilter     bool     `json:"tag_filter"`     // the triggers only match release tags or published releases
+	Provenance    bool     `json:"provenance"`     // a build provenance attestation is generated
+	Signing       bool     `json:"signing"`        // artifacts or images are signed
+	UniqueUploads bool     `json:"unique_uploads"` // every release asset is uploaded by one step of one job
+}
+
+// WorkflowFingerprint is the structure of a workflow file: its triggers, runners
+// and steps, without job names, action versions or inputs, so copies of a
+// workflow that drifted apart a little still look alike
+type WorkflowFingerprint struct {
+	File     string   `json:"file"`
+	Features []string `json:"features"` // sorted and unique
+}
+
+// DockerOptimization suggests a faster way to build or pull a Docker image
+type DockerOptimization struct {
+	Issue            string   `json:"issue"`
+	Suggestion       string   `json:"suggestion"`
+	Improvement      string   `json:"improvement"`
+	EstimatedSavings *Savings `json:"estimated_savings,omitempty"`
+}
+
+// WorkflowAnalysis represents workflow-specific analysis
+type WorkflowAnalysis struct {
+	ParallelJobs        bool     `json:"parallel_jobs"`
+	MatrixStrategy      bool     `json:"matrix_strategy"`
+	Recommendations     []string `json:"recommendations"`
+	RunnerOptimizations []string `json:"runner_optimizations"`
+	SecurityTips        []string `json:"security_tips"`
+}
+
+// Finding is a single issue located in a workflow file
+type Finding struct {
+	Category   string `json:"category"`
+	Severity   string `json:"severity"`
+	File       string `json:"file"`
+	Line       int    `json:"line,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Example    string `json:"example,omitempty"`
+	URL        string `json:"url,omitempty"` // the evidence: the file line, or the sampled job a measurement comes from
+
+	// Owners are the CODEOWNERS owners of File
+	Owners []string `json:"owners,omitempty"`
+
+	EstimatedSavings *Savings `json:"estimated_savings,omitempty"`
+
+	// Confidence rates heuristic findings drawn from job logs, from 0 to 1; findings
+	// read from the workflow file or measured through the API have none
+	Confidence float64 `json:"confidence,omitempty"`
+
+	// Locations lists every place a deduplicated finding occurs, starting with File and Line
+	Locations []Position `json:"locations,omitempty"`
+}
+
+// Sustainability estimates the energy use and emissions of the analyzed runs
+type Sustainability struct {
+	Runs             int                   `json:"runs"`
+	Entries          []SustainabilityEntry `json:"entries"`
+	Minutes          float64               `json:"minutes"`
+	EnergyKWh        float64               `json:"energy_kwh"`
+	CO2Grams         float64               `json:"co2_grams"`
+	MonthlyEnergyKWh float64               `json:"monthly_energy_kwh"`
+	MonthlyCO2Grams  float64               `json:"monthly_co2_grams"`
+	GridCarbon       float64               `json:"grid_carbon_g_per_kwh"`
+	Assumptions      string                `json:"assumptions"`
+}
+
+// MigrationAdvice describes how a GitLab CI or CircleCI config maps to GitHub Actions
+type MigrationAdvice struct {
+	Source   string             `json:"source"`
+	File     string             `json:"file"`
+	Jobs     []MigratedJob      `json:"jobs"`
+	Mappings []ConstructMapping `json:"mappings"`
+}
+
+// SecretsInventory lists the secrets the analyzed workflows reference, by name
+// only; secret values are never read
+type SecretsInventory struct {
+	Secrets     []SecretUsage `json:"secrets"`
+	InheritedBy []string      `json:"inherited_by,omitempty"` // jobs passing every secret to a reusable workflow with secrets: inherit
+}
+
+// Digest is what changed since the previous analysis of the same workflows: the
+// findings that appeared or went away and the metrics that moved. Since is nil
+// on the first digest, which only records the baseline.
+type Digest struct {
+	Since    *time.Time    `json:"since,omitempty"`
+	New      []Finding     `json:"new_findings"`
+	Resolved []Finding     `json:"resolved_findings"`
+	Deltas   []MetricDelta `json:"metric_deltas"`
+}
+
+// Adoption is the progress on the analyzer's recommendations: the findings of
+// the previous analysis that no longer show up, and the running totals since
+// tracking started. Since is nil on the first analysis, which only records the
+// recommendations to follow.
+type Adoption struct {
+	Since        *time.Time `json:"since,omitempty"`
+	TrackedSince time.Time  `json:"tracked_since"`
+	Adopted      []Finding  `json:"adopted"`
+	TotalAdopted int        `json:"total_adopted"`
+	Open         int        `json:"open"`
+}
+
+// RunDurations summarizes the durations of a group of runs
+type RunDurations struct {
+	Runs    int           `json:"runs"`
+	Total   time.Duration `json:"total"`
+	Average time.Duration `json:"average"`
+	Max     time.Duration `json:"max"`
+}
+
+// CancelledRuns tallies cancelled runs. Superseded runs were cancelled once a newer
+// run of the same branch started, as a concurrency group with cancel-in-progress
+// does; the others were cancelled by hand.
+type CancelledRuns struct {
+	Runs       int           `json:"runs"`
+	Superseded int           `json:"superseded"`
+	Duration   time.Duration `json:"duration"` // run time spent before the cancellations
+	Saved      time.Duration `json:"saved"`    // estimated run time the superseded runs didn't spend
+}
+
+// CostEntry is the runner time and cost of one runner type
+type CostEntry struct {
+	Runner          string  `json:"runner"`
+	Minutes         float64 `json:"minutes"`
+	BillableMinutes int     `json:"billable_minutes"` // each job rounded up to a whole minute
+	RatePerMinute   float64 `json:"rate_per_minute"`
+	Cost            float64 `json:"cost"`
+}
+
+// CostAttribution is the runner time and cost of the runs of one branch, or of
+// one pull request when GitHub links the runs to it. Bot is set when bots, such
+// as Dependabot, triggered every run.
+type CostAttribution struct {
+	Branch          string  `json:"branch"`
+	PullRequest     int     `json:"pull_request,omitempty"`
+	Bot             bool    `json:"bot,omitempty"`
+	Runs            int     `json:"runs"`
+	BillableMinutes int     `json:"billable_minutes"`
+	Cost            float64 `json:"cost"`
+}
+
+// Savings estimates the time a recommendation saves, derived from measured run history
+type Savings struct {
+	PerRun   time.Duration `json:"per_run"`
+	PerMonth time.Duration `json:"per_month"`
+	Basis    string        `json:"basis"`
+}
+
+// CacheKeyVariants counts the entries saved under one key prefix
+type CacheKeyVariants struct {
+	Prefix   string `json:"prefix"`
+	Variants int    `json:"variants"`
+	Bytes    int64  `json:"bytes"`
+}
+
+// ChainStage is one workflow of a workflow_run chain
+type ChainStage struct {
+	File        string        `json:"file"`
+	Name        string        `json:"name"`
+	Depth       int           `json:"depth"`              // 0 for the workflow that starts the chain
+	Runs        int           `json:"runs"`               // runs matched to a run of the first workflow
+	AvgDuration time.Duration `json:"avg_duration"`       // from start to completion
+	AvgWait     time.Duration `json:"avg_wait,omitempty"` // from the triggering run's completion to this run's start
+}
+
+// TimelineJob is a job of the run with its steps. Start is when a runner picked
+// the job up, so the time after the run's start, or its needs, is spent queued.
+type TimelineJob struct {
+	TimelineSpan
+	Runner string         `json:"runner,omitempty"`
+	Steps  []TimelineSpan `json:"steps,omitempty"`
+}
+
+// ForkExposedWorkflow is a workflow that pull requests from forks can trigger with
+// the base repository's privileges, through pull_request_target or workflow_run
+type ForkExposedWorkflow struct {
+	File    string   `json:"file"`
+	Trigger string   `json:"trigger"`
+	Rating  string   `json:"rating"`
+	Risks   []string `json:"risks,omitempty"`
+}
+
+// RequiredCheck is one required status check and the job reporting it. File
+// and Job are empty when no workflow job reports the check.
+type RequiredCheck struct {
+	Context     string        `json:"context"`
+	File        string        `json:"file,omitempty"`
+	Job         string        `json:"job,omitempty"`
+	External    bool          `json:"external,omitempty"`     // reported by another app or CI service
+	AvgDuration time.Duration `json:"avg_duration,omitempty"` // of the analyzed workflow's runs
+	Runs        int           `json:"runs,omitempty"`
+}
+
+// MergeQueueJob is a job of the sampled merge_group runs, slowest first
+type MergeQueueJob struct {
+	Name        string        `json:"name"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	Runs        int           `json:"runs"`
+	Required    bool          `json:"required,omitempty"`
+}
+
+// Position is one place a finding occurs
+type Position struct {
+	File string `json:"file"`
+	Line int    `json:"line,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// SustainabilityEntry is the estimated footprint of one runner type
+type SustainabilityEntry struct {
+	Runner    string  `json:"runner"`
+	VCPU      int     `json:"vcpu"`
+	MemoryGB  int     `json:"memory_gb"`
+	Minutes   float64 `json:"minutes"`
+	EnergyKWh float64 `json:"energy_kwh"`
+	CO2Grams  float64 `json:"co2_grams"`
+}
+
+// MigratedJob is a job found in another CI system's config
+type MigratedJob struct {
+	Name     string   `json:"name"`
+	Line     int      `json:"line"`
+	Needs    []string `json:"needs,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// ConstructMapping maps a keyword of another CI system to its GitHub Actions equivalent
+type ConstructMapping struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Note      string `json:"note"`
+	CarryOver bool   `json:"carry_over"`
+}
+
+// SecretUsage is where one secret is used
+type SecretUsage struct {
+	Name              string   `json:"name"`
+	Jobs              []string `json:"jobs"`                          // <workflow path>:<job id>
+	Actions           []string `json:"actions,omitempty"`             // actions and reusable workflows the secret is passed to
+	ThirdPartyActions []string `json:"third_party_actions,omitempty"` // of those, the ones not from the repository owner, actions or github
+}
+
+// MetricDelta is a metric whose value changed since the previous analysis
+type MetricDelta struct {
+	Metric string `json:"metric"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// TimelineSpan is a job or step's name, outcome and offsets from the run's start
+type TimelineSpan struct {
+	Name       string        `json:"name"`
+	Conclusion string        `json:"conclusion"`
+	Start      time.Duration `json:"start"`
+	End        time.Duration `json:"end"`
+}